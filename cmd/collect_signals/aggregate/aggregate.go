@@ -0,0 +1,126 @@
+// Package aggregate combines the values of a signal for each of the packages
+// that a single repository maps to.
+//
+// A repository, such as a monorepo, may publish many packages. Signals that are
+// collected per package, like dependent or download counts, need to be
+// combined into a single value for the repository.
+package aggregate
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrInvalidStrategy = errors.New("invalid aggregation strategy")
+
+// Strategy determines how the values for each package are combined.
+type Strategy int
+
+const (
+	// Sum adds together the values for all the packages.
+	Sum Strategy = iota
+
+	// Max uses the largest value of all the packages.
+	Max
+
+	// Mean uses the average value of all the packages.
+	Mean
+
+	// Primary uses only the value of the primary package. If there is no
+	// primary package the value is undetermined.
+	Primary
+)
+
+// String implements the fmt.Stringer interface.
+func (s Strategy) String() string {
+	text, err := s.MarshalText()
+	if err != nil {
+		return ""
+	}
+	return string(text)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (s Strategy) MarshalText() ([]byte, error) {
+	switch s {
+	case Sum:
+		return []byte("sum"), nil
+	case Max:
+		return []byte("max"), nil
+	case Mean:
+		return []byte("mean"), nil
+	case Primary:
+		return []byte("primary"), nil
+	default:
+		return []byte{}, fmt.Errorf("%w: %d", ErrInvalidStrategy, s)
+	}
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (s *Strategy) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "sum":
+		*s = Sum
+	case "max":
+		*s = Max
+	case "mean":
+		*s = Mean
+	case "primary":
+		*s = Primary
+	default:
+		return fmt.Errorf("%w: %q", ErrInvalidStrategy, string(text))
+	}
+	return nil
+}
+
+// Package holds the value of a signal for a single package.
+type Package struct {
+	Name  string
+	Value float64
+
+	// Primary is true if the package is the main package published by the
+	// repository.
+	Primary bool
+}
+
+// Apply combines the values of pkgs using the strategy.
+//
+// If pkgs is empty, or the value can't be determined using the strategy, false
+// will be returned. When using Primary, a sole package is always treated as
+// the primary package.
+func (s Strategy) Apply(pkgs []Package) (float64, bool) {
+	if len(pkgs) == 0 {
+		return 0, false
+	}
+	switch s {
+	case Sum, Mean:
+		total := 0.0
+		for _, p := range pkgs {
+			total += p.Value
+		}
+		if s == Mean {
+			return total / float64(len(pkgs)), true
+		}
+		return total, true
+	case Max:
+		max := pkgs[0].Value
+		for _, p := range pkgs[1:] {
+			if p.Value > max {
+				max = p.Value
+			}
+		}
+		return max, true
+	case Primary:
+		if len(pkgs) == 1 {
+			return pkgs[0].Value, true
+		}
+		for _, p := range pkgs {
+			if p.Primary {
+				return p.Value, true
+			}
+		}
+		return 0, false
+	default:
+		panic(fmt.Sprintf("invalid aggregation strategy: %d", s))
+	}
+}
@@ -0,0 +1,84 @@
+package aggregate
+
+import (
+	"errors"
+	"testing"
+)
+
+var monorepo = []Package{
+	{Name: "@example/core", Value: 10},
+	{Name: "example", Value: 40, Primary: true},
+	{Name: "@example/cli", Value: 5},
+	{Name: "@example/plugin", Value: 1},
+}
+
+func TestStrategyApply(t *testing.T) {
+	tests := []struct {
+		strategy Strategy
+		want     float64
+	}{
+		{Sum, 56},
+		{Max, 40},
+		{Mean, 14},
+		{Primary, 40},
+	}
+	for _, test := range tests {
+		t.Run(test.strategy.String(), func(t *testing.T) {
+			got, ok := test.strategy.Apply(monorepo)
+			if !ok {
+				t.Fatal("Apply() returned false, want true")
+			}
+			if got != test.want {
+				t.Fatalf("Apply() == %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestStrategyApply_NoPackages(t *testing.T) {
+	for _, s := range []Strategy{Sum, Max, Mean, Primary} {
+		if _, ok := s.Apply(nil); ok {
+			t.Fatalf("%s Apply() returned true, want false", s)
+		}
+	}
+}
+
+func TestStrategyApply_NoPrimary(t *testing.T) {
+	pkgs := []Package{
+		{Name: "a", Value: 1},
+		{Name: "b", Value: 2},
+	}
+	if _, ok := Primary.Apply(pkgs); ok {
+		t.Fatal("Apply() returned true, want false")
+	}
+}
+
+func TestStrategyApply_SinglePackageIsPrimary(t *testing.T) {
+	got, ok := Primary.Apply([]Package{{Name: "a", Value: 3}})
+	if !ok {
+		t.Fatal("Apply() returned false, want true")
+	}
+	if got != 3 {
+		t.Fatalf("Apply() == %v, want 3", got)
+	}
+}
+
+func TestStrategyText(t *testing.T) {
+	for _, s := range []Strategy{Sum, Max, Mean, Primary} {
+		text, err := s.MarshalText()
+		if err != nil {
+			t.Fatalf("MarshalText() errored %v, want no error", err)
+		}
+		var got Strategy
+		if err := got.UnmarshalText(text); err != nil {
+			t.Fatalf("UnmarshalText(%q) errored %v, want no error", text, err)
+		}
+		if got != s {
+			t.Fatalf("UnmarshalText(%q) == %v, want %v", text, got, s)
+		}
+	}
+	var s Strategy
+	if err := s.UnmarshalText([]byte("median")); !errors.Is(err, ErrInvalidStrategy) {
+		t.Fatalf("UnmarshalText() errored %v, want %v", err, ErrInvalidStrategy)
+	}
+}
@@ -0,0 +1,108 @@
+// Package bestpractices provides a Collector that returns a Set for the
+// OpenSSF Best Practices badge level of a repository.
+package bestpractices
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/ossf/criticality_score/cmd/collect_signals/projectrepo"
+	"github.com/ossf/criticality_score/cmd/collect_signals/signal"
+	"github.com/ossf/criticality_score/internal/httpjson"
+	log "github.com/sirupsen/logrus"
+)
+
+// DefaultAPIURL is the base URL of the OpenSSF Best Practices site.
+const DefaultAPIURL = "https://bestpractices.coreinfrastructure.org"
+
+// badgeLevels maps the badge levels reported by the Best Practices site to
+// the value of the badge_level signal.
+var badgeLevels = map[string]int{
+	"in_progress": 0,
+	"passing":     1,
+	"silver":      2,
+	"gold":        3,
+}
+
+type bestPracticesSet struct {
+	// BadgeLevel is the highest badge level attained by a project for the
+	// repository: 0 for in progress, 1 for passing, 2 for silver and 3 for
+	// gold.
+	BadgeLevel signal.Field[int]
+}
+
+func (s *bestPracticesSet) Namespace() signal.Namespace {
+	return signal.Namespace("bestpractices")
+}
+
+type project struct {
+	RepoURL    string `json:"repo_url"`
+	BadgeLevel string `json:"badge_level"`
+}
+
+type Collector struct {
+	client *http.Client
+	logger *log.Logger
+	apiURL string
+}
+
+// NewCollector returns a new Collector that uses the http.Client c to query
+// the Best Practices site.
+func NewCollector(c *http.Client, logger *log.Logger) *Collector {
+	return &Collector{
+		client: c,
+		logger: logger,
+		apiURL: DefaultAPIURL,
+	}
+}
+
+func (c *Collector) EmptySet() signal.Set {
+	return &bestPracticesSet{}
+}
+
+func (c *Collector) IsSupported(r projectrepo.Repo) bool {
+	return true
+}
+
+// Collect implements the collector.Collector interface.
+//
+// If no project has been registered for the repository the signal is left
+// unset.
+func (c *Collector) Collect(ctx context.Context, r projectrepo.Repo) (signal.Set, error) {
+	s := &bestPracticesSet{}
+	c.logger.WithField("url", r.URL().String()).Debug("Fetching Best Practices projects")
+	projects, err := c.queryProjects(ctx, r.URL())
+	if err != nil {
+		return nil, err
+	}
+	level := -1
+	for _, p := range projects {
+		if l, ok := badgeLevels[p.BadgeLevel]; ok && l > level {
+			level = l
+		}
+	}
+	if level >= 0 {
+		s.BadgeLevel.Set(level)
+	}
+	return s, nil
+}
+
+// queryProjects returns the projects registered for the repository at u.
+func (c *Collector) queryProjects(ctx context.Context, u *url.URL) ([]project, error) {
+	query := url.Values{"url": {u.String()}}
+	var res []project
+	if _, err := httpjson.Get(ctx, c.client, c.apiURL+"/projects.json?"+query.Encode(), &res); err != nil {
+		return nil, err
+	}
+	// The url search also matches the project's homepage and partial URLs,
+	// so only keep the projects for this repository.
+	repo := projectrepo.NormalizeURL(u.String())
+	var projects []project
+	for _, p := range res {
+		if p.RepoURL != "" && projectrepo.NormalizeURL(p.RepoURL) == repo {
+			projects = append(projects, p)
+		}
+	}
+	return projects, nil
+}
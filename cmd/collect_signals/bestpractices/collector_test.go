@@ -0,0 +1,54 @@
+package bestpractices
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/ossf/criticality_score/internal/collectortest"
+)
+
+// testRepoURL is the repository the signals are collected for.
+const testRepoURL = "https://github.com/example/json"
+
+// newTestCollector returns a Collector where all the project searches are
+// answered with body.
+func newTestCollector(t *testing.T, body string) *Collector {
+	t.Helper()
+	s := collectortest.NewServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/projects.json" || r.URL.Query().Get("url") != "https://github.com/example/json" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	}))
+	c := NewCollector(&http.Client{}, collectortest.Logger())
+	c.apiURL = s.URL
+	return c
+}
+
+func TestCollect(t *testing.T) {
+	c := newTestCollector(t, `[
+		{"repo_url": "https://github.com/example/json-extras", "badge_level": "gold"},
+		{"repo_url": "https://github.com/Example/json.git", "badge_level": "silver"},
+		{"repo_url": "https://github.com/example/json", "badge_level": "in_progress"}
+	]`)
+	if got := collectortest.Collect[*bestPracticesSet](t, c, testRepoURL).BadgeLevel.Get(); got != 2 {
+		t.Fatalf("BadgeLevel == %d, want 2", got)
+	}
+}
+
+func TestCollect_InProgress(t *testing.T) {
+	c := newTestCollector(t, `[{"repo_url": "https://github.com/example/json", "badge_level": "in_progress"}]`)
+	s := collectortest.Collect[*bestPracticesSet](t, c, testRepoURL)
+	if !s.BadgeLevel.IsSet() || s.BadgeLevel.Get() != 0 {
+		t.Fatalf("BadgeLevel == %d, want 0", s.BadgeLevel.Get())
+	}
+}
+
+func TestCollect_NoProject(t *testing.T) {
+	c := newTestCollector(t, `[]`)
+	if collectortest.Collect[*bestPracticesSet](t, c, testRepoURL).BadgeLevel.IsSet() {
+		t.Fatal("BadgeLevel is set, want unset")
+	}
+}
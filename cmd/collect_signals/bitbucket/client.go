@@ -0,0 +1,52 @@
+package bitbucket
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/ossf/criticality_score/internal/httpjson"
+)
+
+// DefaultAPIURL is the base URL of the Bitbucket Cloud 2.0 REST API.
+const DefaultAPIURL = "https://api.bitbucket.org/2.0"
+
+// Client is used to query the REST API of Bitbucket Cloud.
+type Client struct {
+	client  *http.Client
+	token   string
+	baseURL string
+}
+
+// NewClient returns a new Client that uses the http.Client c to send
+// requests.
+//
+// If token is not empty it will be sent as a bearer token with each request.
+// A token is not required for accessing public repositories.
+func NewClient(c *http.Client, token string) *Client {
+	return &Client{
+		client:  c,
+		token:   token,
+		baseURL: DefaultAPIURL,
+	}
+}
+
+// get queries the API endpoint path and decodes the JSON response into
+// result.
+//
+// path must already be escaped.
+func (c *Client) get(ctx context.Context, path string, query url.Values, result any) (*http.Response, error) {
+	u := c.baseURL + "/" + path
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	return httpjson.Do(c.client, req, result)
+}
@@ -0,0 +1,71 @@
+package bitbucket
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ossf/criticality_score/cmd/collect_signals/github/legacy"
+	"github.com/ossf/criticality_score/cmd/collect_signals/projectrepo"
+	"github.com/ossf/criticality_score/cmd/collect_signals/signal"
+)
+
+type RepoCollector struct {
+}
+
+func (rc *RepoCollector) EmptySet() signal.Set {
+	return &signal.RepoSet{}
+}
+
+// Collect implements the collector.Collector interface.
+//
+// Bitbucket does not provide a license, archived status, stars or releases,
+// so these signals are left unset. Watchers are the closest equivalent to
+// stars, and are used for the star count.
+func (rc *RepoCollector) Collect(ctx context.Context, r projectrepo.Repo) (signal.Set, error) {
+	bbr, ok := r.(*repo)
+	if !ok {
+		return nil, errors.New("project is not a bitbucket project")
+	}
+	now := time.Now()
+
+	s := &signal.RepoSet{
+		URL:          signal.Val(r.URL().String()),
+		CreatedAt:    signal.Val(bbr.createdAt()),
+		CreatedSince: signal.Val(legacy.TimeDelta(now, bbr.createdAt(), legacy.SinceDuration)),
+		UpdatedAt:    signal.Val(bbr.updatedAt()),
+		UpdatedSince: signal.Val(legacy.TimeDelta(now, bbr.updatedAt(), legacy.SinceDuration)),
+	}
+	if lang := bbr.BasicData.Language; lang != "" {
+		s.Language.Set(lang)
+	}
+
+	bbr.logger.Debug("Fetching fork count")
+	if count, ok, err := queryCount(ctx, bbr.client, bbr.fullName(), "forks"); err != nil {
+		return nil, err
+	} else if ok {
+		s.ForkCount.Set(count)
+	}
+
+	if branch := bbr.BasicData.MainBranch.Name; branch != "" {
+		bbr.logger.Debug("Fetching contributor count")
+		count, err := queryContributorCount(ctx, bbr.client, bbr.fullName(), branch)
+		if err != nil {
+			return nil, err
+		}
+		s.ContributorCount.Set(count)
+	}
+
+	bbr.logger.Debug("Fetching watcher count")
+	if count, ok, err := queryCount(ctx, bbr.client, bbr.fullName(), "watchers"); err != nil {
+		return nil, err
+	} else if ok {
+		s.StarCount.Set(count)
+	}
+	return s, nil
+}
+
+func (rc *RepoCollector) IsSupported(p projectrepo.Repo) bool {
+	_, ok := p.(*repo)
+	return ok
+}
@@ -0,0 +1,43 @@
+package bitbucket
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/ossf/criticality_score/cmd/collect_signals/projectrepo"
+	log "github.com/sirupsen/logrus"
+)
+
+// Host is the host of Bitbucket Cloud.
+const Host = "bitbucket.org"
+
+type factory struct {
+	client *Client
+	logger *log.Logger
+}
+
+// NewRepoFactory returns a new projectrepo.Factory for repositories hosted on
+// Bitbucket Cloud.
+func NewRepoFactory(client *Client, logger *log.Logger) projectrepo.Factory {
+	return &factory{
+		client: client,
+		logger: logger,
+	}
+}
+
+func (f *factory) New(ctx context.Context, u *url.URL) (projectrepo.Repo, error) {
+	p := &repo{
+		client:  f.client,
+		origURL: u,
+		logger:  f.logger.WithField("url", u),
+	}
+	if err := p.init(ctx); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (f *factory) Match(u *url.URL) bool {
+	return strings.EqualFold(u.Hostname(), Host)
+}
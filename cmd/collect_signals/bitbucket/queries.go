@@ -0,0 +1,181 @@
+package bitbucket
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ossf/criticality_score/internal/httpjson"
+)
+
+var errInvalidRepoPath = errors.New("invalid Bitbucket repository path")
+
+type basicRepoData struct {
+	FullName   string    `json:"full_name"`
+	Language   string    `json:"language"`
+	CreatedOn  time.Time `json:"created_on"`
+	UpdatedOn  time.Time `json:"updated_on"`
+	MainBranch struct {
+		Name string `json:"name"`
+	} `json:"mainbranch"`
+	Links struct {
+		HTML struct {
+			Href string `json:"href"`
+		} `json:"html"`
+	} `json:"links"`
+}
+
+const (
+	// commitsPerPage is the maximum number of commits Bitbucket returns in a
+	// single page.
+	commitsPerPage = 100
+
+	// maxCommitPages limits the number of pages of commits read when
+	// counting contributors.
+	maxCommitPages = 10
+)
+
+type commit struct {
+	Date   time.Time `json:"date"`
+	Author struct {
+		Raw  string `json:"raw"`
+		User *struct {
+			UUID string `json:"uuid"`
+		} `json:"user"`
+	} `json:"author"`
+}
+
+// authorKey returns a key that identifies the author of the commit. The
+// Bitbucket user is used if the author is linked to an account, otherwise
+// the raw author string (e.g. "Name <email>") is used.
+func (c *commit) authorKey() string {
+	if c.Author.User != nil && c.Author.User.UUID != "" {
+		return c.Author.User.UUID
+	}
+	return strings.ToLower(c.Author.Raw)
+}
+
+// page is the paginated response returned by list endpoints. Size is the
+// total number of items, and is omitted if Bitbucket does not count them.
+type page[T any] struct {
+	Size   *int   `json:"size"`
+	Next   string `json:"next"`
+	Values []T    `json:"values"`
+}
+
+// nextQuery returns the query for the next page, or nil if this is the last
+// page.
+func (p *page[T]) nextQuery() (url.Values, error) {
+	if p.Next == "" {
+		return nil, nil
+	}
+	u, err := url.Parse(p.Next)
+	if err != nil {
+		return nil, err
+	}
+	return u.Query(), nil
+}
+
+// repoPath extracts the workspace and repository slug from the URL u, and
+// returns them as "workspace/slug".
+//
+// Paths ending in ".git" or pointing to a page inside the repository (e.g.
+// "/workspace/slug/src/main") are supported.
+func repoPath(u *url.URL) (string, error) {
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+		return "", fmt.Errorf("%w: %s", errInvalidRepoPath, u)
+	}
+	return parts[0] + "/" + strings.TrimSuffix(parts[1], ".git"), nil
+}
+
+// escapeRepoPath escapes each part of a "workspace/slug" path.
+func escapeRepoPath(p string) string {
+	workspace, slug, _ := strings.Cut(p, "/")
+	return url.PathEscape(workspace) + "/" + url.PathEscape(slug)
+}
+
+func queryBasicRepoData(ctx context.Context, c *Client, u *url.URL) (*basicRepoData, error) {
+	p, err := repoPath(u)
+	if err != nil {
+		return nil, err
+	}
+	data := &basicRepoData{}
+	if _, err := c.get(ctx, "repositories/"+escapeRepoPath(p), nil, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// queryLastCommit returns the most recent commit on the given branch.
+//
+// If there are no commits, nil will be returned.
+func queryLastCommit(ctx context.Context, c *Client, fullName, branch string) (*commit, error) {
+	query := url.Values{"pagelen": {"1"}}
+	var cs page[commit]
+	path := fmt.Sprintf("repositories/%s/commits/%s", escapeRepoPath(fullName), url.PathEscape(branch))
+	_, err := c.get(ctx, path, query, &cs)
+	if httpjson.StatusCode(err) == http.StatusNotFound {
+		// A 404 is returned if the repository is empty.
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(cs.Values) == 0 {
+		return nil, nil
+	}
+	return &cs.Values[0], nil
+}
+
+// queryCount returns the total number of items in the list endpoint under
+// the repository, such as "forks" or "watchers".
+//
+// If Bitbucket does not return the total, false will be returned.
+func queryCount(ctx context.Context, c *Client, fullName, endpoint string) (int, bool, error) {
+	query := url.Values{"pagelen": {"1"}}
+	var p page[struct{}]
+	if _, err := c.get(ctx, fmt.Sprintf("repositories/%s/%s", escapeRepoPath(fullName), endpoint), query, &p); err != nil {
+		return 0, false, err
+	}
+	if p.Size == nil {
+		return 0, false, nil
+	}
+	return *p.Size, true, nil
+}
+
+// queryContributorCount returns the number of unique authors of the most
+// recent commits on the given branch.
+//
+// Bitbucket does not provide a list of contributors, so the authors of at most
+// maxCommitPages pages of commits are counted. For repositories with a long
+// history the count will be lower than the real number of contributors.
+func queryContributorCount(ctx context.Context, c *Client, fullName, branch string) (int, error) {
+	authors := make(map[string]struct{})
+	path := fmt.Sprintf("repositories/%s/commits/%s", escapeRepoPath(fullName), url.PathEscape(branch))
+	query := url.Values{"pagelen": {fmt.Sprint(commitsPerPage)}}
+	for i := 0; i < maxCommitPages && query != nil; i++ {
+		var cs page[commit]
+		_, err := c.get(ctx, path, query, &cs)
+		if httpjson.StatusCode(err) == http.StatusNotFound {
+			// A 404 is returned if the repository is empty.
+			break
+		}
+		if err != nil {
+			return 0, err
+		}
+		for _, cm := range cs.Values {
+			if key := cm.authorKey(); key != "" {
+				authors[key] = struct{}{}
+			}
+		}
+		if query, err = cs.nextQuery(); err != nil {
+			return 0, err
+		}
+	}
+	return len(authors), nil
+}
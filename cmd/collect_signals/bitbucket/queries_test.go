@@ -0,0 +1,162 @@
+package bitbucket
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+// newTestClient returns a Client where all the requests are handled by h.
+func newTestClient(t *testing.T, h http.Handler) *Client {
+	t.Helper()
+	s := httptest.NewServer(h)
+	t.Cleanup(s.Close)
+	c := NewClient(&http.Client{}, "")
+	c.baseURL = s.URL
+	return c
+}
+
+// jsonHandler returns a handler that responds to requests for path with the
+// given status and JSON body, and 404 to all other requests.
+func jsonHandler(path string, status int, body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != path {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}
+}
+
+func TestRepoPath(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{url: "https://bitbucket.org/atlassian/python-bitbucket", want: "atlassian/python-bitbucket"},
+		{url: "https://bitbucket.org/atlassian/python-bitbucket/", want: "atlassian/python-bitbucket"},
+		{url: "https://bitbucket.org/atlassian/python-bitbucket.git", want: "atlassian/python-bitbucket"},
+		{url: "https://bitbucket.org/atlassian/python-bitbucket/src/master/", want: "atlassian/python-bitbucket"},
+	}
+	for _, test := range tests {
+		t.Run(test.url, func(t *testing.T) {
+			u, _ := url.Parse(test.url)
+			got, err := repoPath(u)
+			if err != nil {
+				t.Fatalf("repoPath() errored %v, want no error", err)
+			}
+			if got != test.want {
+				t.Fatalf("repoPath() == %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestRepoPath_Invalid(t *testing.T) {
+	for _, raw := range []string{"https://bitbucket.org", "https://bitbucket.org/", "https://bitbucket.org/atlassian"} {
+		t.Run(raw, func(t *testing.T) {
+			u, _ := url.Parse(raw)
+			if _, err := repoPath(u); !errors.Is(err, errInvalidRepoPath) {
+				t.Fatalf("repoPath() errored %v, want %v", err, errInvalidRepoPath)
+			}
+		})
+	}
+}
+
+func TestQueryLastCommit(t *testing.T) {
+	c := newTestClient(t, jsonHandler("/repositories/example/repo/commits/main", http.StatusOK, `{"values": [
+		{"hash": "abc123", "date": "2022-05-04T10:00:00+00:00"}
+	]}`))
+	got, err := queryLastCommit(context.Background(), c, "example/repo", "main")
+	if err != nil {
+		t.Fatalf("queryLastCommit() errored %v, want no error", err)
+	}
+	if want := time.Date(2022, 5, 4, 10, 0, 0, 0, time.UTC); got == nil || !got.Date.Equal(want) {
+		t.Fatalf("queryLastCommit() == %v, want %v", got, want)
+	}
+}
+
+func TestQueryLastCommit_EmptyRepository(t *testing.T) {
+	// Bitbucket responds with a 404 if the branch has no commits.
+	c := newTestClient(t, http.NotFoundHandler())
+	got, err := queryLastCommit(context.Background(), c, "example/repo", "main")
+	if err != nil {
+		t.Fatalf("queryLastCommit() errored %v, want no error", err)
+	}
+	if got != nil {
+		t.Fatalf("queryLastCommit() == %v, want nil", got)
+	}
+}
+
+func TestQueryCount(t *testing.T) {
+	c := newTestClient(t, jsonHandler("/repositories/example/repo/forks", http.StatusOK, `{"size": 12, "values": [{}]}`))
+	got, ok, err := queryCount(context.Background(), c, "example/repo", "forks")
+	if err != nil {
+		t.Fatalf("queryCount() errored %v, want no error", err)
+	}
+	if !ok || got != 12 {
+		t.Fatalf("queryCount() == %d, %v, want 12, true", got, ok)
+	}
+}
+
+func TestQueryCount_NoSize(t *testing.T) {
+	c := newTestClient(t, jsonHandler("/repositories/example/repo/watchers", http.StatusOK, `{"values": [{}], "next": "https://example.com"}`))
+	_, ok, err := queryCount(context.Background(), c, "example/repo", "watchers")
+	if err != nil {
+		t.Fatalf("queryCount() errored %v, want no error", err)
+	}
+	if ok {
+		t.Fatal("queryCount() returned true, want false")
+	}
+}
+
+func TestQueryContributorCount(t *testing.T) {
+	var s *httptest.Server
+	s = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repositories/example/repo/commits/main" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		if r.URL.Query().Get("page") == "" {
+			w.Write([]byte(`{"next": "` + s.URL + `/repositories/example/repo/commits/main?page=abc", "values": [
+				{"author": {"raw": "Alice <alice@example.com>", "user": {"uuid": "{a}"}}},
+				{"author": {"raw": "Bob <bob@example.com>"}}
+			]}`))
+			return
+		}
+		w.Write([]byte(`{"values": [
+			{"author": {"raw": "Alice <alice@work.example.com>", "user": {"uuid": "{a}"}}},
+			{"author": {"raw": "bob <BOB@example.com>"}},
+			{"author": {"raw": "Carol <carol@example.com>"}}
+		]}`))
+	}))
+	t.Cleanup(s.Close)
+	c := NewClient(&http.Client{}, "")
+	c.baseURL = s.URL
+
+	got, err := queryContributorCount(context.Background(), c, "example/repo", "main")
+	if err != nil {
+		t.Fatalf("queryContributorCount() errored %v, want no error", err)
+	}
+	if got != 3 {
+		t.Fatalf("queryContributorCount() == %d, want 3", got)
+	}
+}
+
+func TestQueryContributorCount_EmptyRepository(t *testing.T) {
+	c := newTestClient(t, http.NotFoundHandler())
+	got, err := queryContributorCount(context.Background(), c, "example/repo", "main")
+	if err != nil {
+		t.Fatalf("queryContributorCount() errored %v, want no error", err)
+	}
+	if got != 0 {
+		t.Fatalf("queryContributorCount() == %d, want 0", got)
+	}
+}
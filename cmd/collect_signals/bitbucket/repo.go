@@ -0,0 +1,66 @@
+package bitbucket
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// repo implements the projectrepo.Repo interface for a Bitbucket repository.
+type repo struct {
+	client  *Client
+	origURL *url.URL
+	logger  *log.Entry
+
+	BasicData  *basicRepoData
+	LastCommit *commit
+	realURL    *url.URL
+}
+
+// URL implements the projectrepo.Repo interface
+func (r *repo) URL() *url.URL {
+	return r.realURL
+}
+
+func (r *repo) init(ctx context.Context) error {
+	if r.BasicData != nil {
+		// Already finished. Don't init() more than once.
+		return nil
+	}
+	r.logger.Debug("Fetching basic data from Bitbucket")
+	data, err := queryBasicRepoData(ctx, r.client, r.origURL)
+	if err != nil {
+		return err
+	}
+	if branch := data.MainBranch.Name; branch != "" {
+		r.logger.Debug("Fetching last commit")
+		r.LastCommit, err = queryLastCommit(ctx, r.client, data.FullName, branch)
+		if err != nil {
+			return err
+		}
+	}
+	r.realURL, err = url.Parse(data.Links.HTML.Href)
+	if err != nil {
+		return err
+	}
+	// Set BasicData last as it is used to indicate init() has been called.
+	r.BasicData = data
+	return nil
+}
+
+func (r *repo) fullName() string {
+	return r.BasicData.FullName
+}
+
+func (r *repo) updatedAt() time.Time {
+	if r.LastCommit == nil {
+		return r.BasicData.UpdatedOn
+	}
+	return r.LastCommit.Date
+}
+
+func (r *repo) createdAt() time.Time {
+	return r.BasicData.CreatedOn
+}
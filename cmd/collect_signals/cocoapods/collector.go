@@ -0,0 +1,144 @@
+// Package cocoapods provides a Collector that returns a Set for the number of
+// downloads of the CocoaPods pod published from a repository, and the number
+// of apps that use it.
+//
+// The pod with the same name as the repository is used, if the source or
+// homepage in its latest podspec refers to the repository. Pod names are case
+// sensitive, so the name is used as it appears in the repository URL.
+package cocoapods
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/ossf/criticality_score/cmd/collect_signals/projectrepo"
+	"github.com/ossf/criticality_score/cmd/collect_signals/signal"
+	"github.com/ossf/criticality_score/internal/httpjson"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultTrunkURL is the base URL of the CocoaPods trunk API, which
+	// serves the podspecs of each pod.
+	DefaultTrunkURL = "https://trunk.cocoapods.org/api/v1"
+
+	// DefaultMetricsURL is the base URL of the CocoaPods metrics API, which
+	// serves the download and usage stats of each pod.
+	DefaultMetricsURL = "https://metrics.cocoapods.org/api/v1"
+)
+
+type cocoaPodsSet struct {
+	DownloadCount signal.Field[int]
+
+	// AppCount is the number of apps that have been built with the pod.
+	AppCount signal.Field[int]
+}
+
+func (s *cocoaPodsSet) Namespace() signal.Namespace {
+	return signal.Namespace("cocoapods")
+}
+
+type podspec struct {
+	Homepage string `json:"homepage"`
+	Source   struct {
+		Git  string `json:"git"`
+		HTTP string `json:"http"`
+	} `json:"source"`
+}
+
+type metrics struct {
+	// Stats is missing for pods that have never been downloaded.
+	Stats *struct {
+		DownloadTotal int `json:"download_total"`
+		AppTotal      int `json:"app_total"`
+	} `json:"stats"`
+}
+
+type Collector struct {
+	client     *http.Client
+	logger     *log.Logger
+	trunkURL   string
+	metricsURL string
+}
+
+// NewCollector returns a new Collector that uses the http.Client c to query
+// CocoaPods.
+func NewCollector(c *http.Client, logger *log.Logger) *Collector {
+	return &Collector{
+		client:     c,
+		logger:     logger,
+		trunkURL:   DefaultTrunkURL,
+		metricsURL: DefaultMetricsURL,
+	}
+}
+
+func (c *Collector) EmptySet() signal.Set {
+	return &cocoaPodsSet{}
+}
+
+func (c *Collector) IsSupported(r projectrepo.Repo) bool {
+	return true
+}
+
+// Collect implements the collector.Collector interface.
+//
+// If no pod is published from the repository, or CocoaPods has no stats for
+// it, the signals are left unset.
+func (c *Collector) Collect(ctx context.Context, r projectrepo.Repo) (signal.Set, error) {
+	s := &cocoaPodsSet{}
+	pod := path.Base(strings.TrimSuffix(strings.Trim(r.URL().Path, "/"), ".git"))
+	logger := c.logger.WithFields(log.Fields{
+		"url": r.URL().String(),
+		"pod": pod,
+	})
+
+	logger.Debug("Fetching latest podspec")
+	spec, err := c.queryPodspec(ctx, pod)
+	if err != nil {
+		return nil, err
+	}
+	if spec == nil || !spec.refersTo(projectrepo.NormalizeURL(r.URL().String())) {
+		return s, nil
+	}
+
+	logger.Debug("Fetching pod metrics")
+	m := &metrics{}
+	_, err = httpjson.Get(ctx, c.client, c.metricsURL+"/pods/"+url.PathEscape(pod), m)
+	if httpjson.StatusCode(err) == http.StatusNotFound {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if m.Stats == nil {
+		return s, nil
+	}
+	s.DownloadCount.Set(m.Stats.DownloadTotal)
+	s.AppCount.Set(m.Stats.AppTotal)
+	return s, nil
+}
+
+// queryPodspec returns the podspec of the latest version of the pod.
+//
+// If the pod does not exist, nil is returned.
+func (c *Collector) queryPodspec(ctx context.Context, pod string) (*podspec, error) {
+	spec := &podspec{}
+	_, err := httpjson.Get(ctx, c.client, c.trunkURL+"/pods/"+url.PathEscape(pod)+"/specs/latest", spec)
+	if httpjson.StatusCode(err) == http.StatusNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return spec, nil
+}
+
+// refersTo returns true if the podspec's source or homepage is the
+// normalized repository URL repo, or a page inside it such as a release
+// download.
+func (s *podspec) refersTo(repo string) bool {
+	return projectrepo.RefersTo(repo, s.Source.Git, s.Source.HTTP, s.Homepage)
+}
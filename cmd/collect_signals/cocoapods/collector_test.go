@@ -0,0 +1,69 @@
+package cocoapods
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/ossf/criticality_score/internal/collectortest"
+)
+
+// newTestCollector returns a Collector where all the requests are answered
+// with the bodies in responses, keyed by path.
+func newTestCollector(t *testing.T, responses map[string]string) *Collector {
+	t.Helper()
+	s := collectortest.NewServer(t, collectortest.PathHandler(responses))
+	c := NewCollector(&http.Client{}, collectortest.Logger())
+	c.trunkURL = s.URL + "/trunk"
+	c.metricsURL = s.URL + "/metrics"
+	return c
+}
+
+const testPodspec = `{
+	"name": "Alamofire",
+	"version": "5.9.1",
+	"homepage": "https://github.com/Alamofire/Alamofire",
+	"source": {"git": "https://github.com/Alamofire/Alamofire.git", "tag": "5.9.1"}
+}`
+
+func TestCollect(t *testing.T) {
+	c := newTestCollector(t, map[string]string{
+		"/trunk/pods/Alamofire/specs/latest": testPodspec,
+		"/metrics/pods/Alamofire":            `{"github": {"stargazers": 40000}, "stats": {"download_total": 120000000, "download_week": 100, "app_total": 900000, "app_week": 10}}`,
+	})
+	s := collectortest.Collect[*cocoaPodsSet](t, c, "https://github.com/Alamofire/Alamofire")
+	if got := s.DownloadCount.Get(); got != 120000000 {
+		t.Fatalf("DownloadCount == %d, want 120000000", got)
+	}
+	if got := s.AppCount.Get(); got != 900000 {
+		t.Fatalf("AppCount == %d, want 900000", got)
+	}
+}
+
+func TestCollect_NoStats(t *testing.T) {
+	c := newTestCollector(t, map[string]string{
+		"/trunk/pods/Alamofire/specs/latest": testPodspec,
+		"/metrics/pods/Alamofire":            `{"github": {"stargazers": 40000}}`,
+	})
+	s := collectortest.Collect[*cocoaPodsSet](t, c, "https://github.com/Alamofire/Alamofire")
+	if s.DownloadCount.IsSet() || s.AppCount.IsSet() {
+		t.Fatal("signals are set, want unset")
+	}
+}
+
+func TestCollect_Unrelated(t *testing.T) {
+	c := newTestCollector(t, map[string]string{
+		"/trunk/pods/Alamofire/specs/latest": testPodspec,
+	})
+	s := collectortest.Collect[*cocoaPodsSet](t, c, "https://github.com/someone/Alamofire")
+	if s.DownloadCount.IsSet() || s.AppCount.IsSet() {
+		t.Fatal("signals are set, want unset")
+	}
+}
+
+func TestCollect_NoPod(t *testing.T) {
+	c := newTestCollector(t, nil)
+	s := collectortest.Collect[*cocoaPodsSet](t, c, "https://github.com/example/example")
+	if s.DownloadCount.IsSet() || s.AppCount.IsSet() {
+		t.Fatal("signals are set, want unset")
+	}
+}
@@ -0,0 +1,46 @@
+package collector
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ossf/criticality_score/internal/githubapi"
+)
+
+// ErrRateLimited indicates that signals could not be collected because a
+// rate limit was exceeded. Use errors.As with a *RateLimitError to find out
+// when the limit resets.
+var ErrRateLimited = errors.New("rate limited")
+
+// RateLimitError is returned when collection fails because a rate limit was
+// exceeded. It matches ErrRateLimited with errors.Is.
+type RateLimitError struct {
+	// Reset is the time the rate limit resets, or zero if it is unknown.
+	Reset time.Time
+
+	// Err is the underlying error.
+	Err error
+}
+
+func (e *RateLimitError) Error() string {
+	return fmt.Sprintf("%s: %v", ErrRateLimited, e.Err)
+}
+
+func (e *RateLimitError) Unwrap() error {
+	return e.Err
+}
+
+func (e *RateLimitError) Is(target error) bool {
+	return target == ErrRateLimited
+}
+
+// CheckRateLimit returns a *RateLimitError wrapping err if err was caused by a
+// rate limit being exceeded. Otherwise err is returned unchanged.
+func CheckRateLimit(err error) error {
+	reset, ok := githubapi.RateLimitReset(err, time.Now())
+	if !ok {
+		return err
+	}
+	return &RateLimitError{Reset: reset, Err: err}
+}
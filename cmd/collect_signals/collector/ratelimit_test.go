@@ -0,0 +1,38 @@
+package collector
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v44/github"
+)
+
+func TestCheckRateLimit(t *testing.T) {
+	reset := time.Date(2022, 5, 4, 10, 0, 0, 0, time.UTC)
+	inner := &github.RateLimitError{Rate: github.Rate{Reset: github.Timestamp{Time: reset}}}
+	err := CheckRateLimit(inner)
+	if !errors.Is(err, ErrRateLimited) {
+		t.Fatalf("CheckRateLimit() == %v, want %v", err, ErrRateLimited)
+	}
+	var rlErr *RateLimitError
+	if !errors.As(err, &rlErr) {
+		t.Fatalf("CheckRateLimit() == %v, want a *RateLimitError", err)
+	}
+	if !rlErr.Reset.Equal(reset) {
+		t.Fatalf("Reset == %v, want %v", rlErr.Reset, reset)
+	}
+	if !errors.Is(err, inner) {
+		t.Fatalf("CheckRateLimit() == %v, want it to wrap %v", err, inner)
+	}
+}
+
+func TestCheckRateLimit_Other(t *testing.T) {
+	inner := errors.New("other")
+	if err := CheckRateLimit(inner); err != inner {
+		t.Fatalf("CheckRateLimit() == %v, want %v", err, inner)
+	}
+	if err := CheckRateLimit(nil); err != nil {
+		t.Fatalf("CheckRateLimit() == %v, want nil", err)
+	}
+}
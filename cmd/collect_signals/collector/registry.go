@@ -2,7 +2,9 @@ package collector
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"reflect"
 
 	"github.com/ossf/criticality_score/cmd/collect_signals/projectrepo"
 	"github.com/ossf/criticality_score/cmd/collect_signals/signal"
@@ -13,13 +15,22 @@ type empty struct{}
 
 var globalRegistry = NewRegistry()
 
+// ErrDuplicateNamespace is returned by RegisterUnique if a Collector using the
+// same Namespace has already been registered.
+var ErrDuplicateNamespace = errors.New("duplicate namespace")
+
 type Registry struct {
 	cs []Collector
+
+	// unique holds the Namespaces of Collectors added with RegisterUnique.
+	unique map[signal.Namespace]empty
 }
 
 // NewRegistry creates a new instance of Registry.
 func NewRegistry() *Registry {
-	return &Registry{}
+	return &Registry{
+		unique: make(map[signal.Namespace]empty),
+	}
 }
 
 // containsCollector returns true if c has already been registered.
@@ -40,7 +51,10 @@ func (r *Registry) containsCollector(c Collector) bool {
 //
 // The order which Collectors are added is preserved.
 func (r *Registry) Register(c Collector) {
-	validateCollector(c)
+	if ns := c.EmptySet().Namespace(); r.hasUnique(ns) {
+		panic(fmt.Sprintf("collector %s has already been registered as unique", ns))
+	}
+	r.validateCollector(c)
 	if r.containsCollector(c) {
 		panic(fmt.Sprintf("collector %s has already been registered", c.EmptySet().Namespace()))
 	}
@@ -50,6 +64,38 @@ func (r *Registry) Register(c Collector) {
 	r.cs = append(r.cs, c)
 }
 
+// RegisterUnique adds the Collector c to the registry, like Register, but
+// requires that no other Collector uses the same Namespace.
+//
+// This is intended for custom Collectors added alongside the built-in ones.
+// Unlike Register, which allows Collectors for different hosts to share a
+// Namespace, an error wrapping ErrDuplicateNamespace is returned if the
+// Namespace is already in use. Any Collector registered later with the same
+// Namespace will cause Register to panic.
+//
+// An error is also returned if the Collector's signal Set is not valid.
+func (r *Registry) RegisterUnique(c Collector) error {
+	ns := c.EmptySet().Namespace()
+	for _, regC := range r.cs {
+		if regC.EmptySet().Namespace() == ns {
+			return fmt.Errorf("%w: %s", ErrDuplicateNamespace, ns)
+		}
+	}
+	if err := signal.ValidateSet(c.EmptySet()); err != nil {
+		return err
+	}
+	r.cs = append(r.cs, c)
+	r.unique[ns] = empty{}
+	return nil
+}
+
+// hasUnique returns true if a Collector using the Namespace ns was added with
+// RegisterUnique.
+func (r *Registry) hasUnique(ns signal.Namespace) bool {
+	_, ok := r.unique[ns]
+	return ok
+}
+
 func (r *Registry) collectorsForRepository(repo projectrepo.Repo) []Collector {
 	// Check for duplicates using a map to preserve the insertion order
 	// of the collectors.
@@ -86,19 +132,23 @@ func (r *Registry) EmptySets() []signal.Set {
 		if _, ok := exists[c.EmptySet().Namespace()]; ok {
 			continue
 		}
+		exists[c.EmptySet().Namespace()] = empty{}
 		ss = append(ss, c.EmptySet())
 	}
 	return ss
 }
 
 // Collect will collect all the signals for the given repo.
+//
+// If a Collector fails because a rate limit was exceeded, the error returned
+// will be a *RateLimitError.
 func (r *Registry) Collect(ctx context.Context, repo projectrepo.Repo) ([]signal.Set, error) {
 	cs := r.collectorsForRepository(repo)
 	var ss []signal.Set
 	for _, c := range cs {
 		s, err := c.Collect(ctx, repo)
 		if err != nil {
-			return nil, err
+			return nil, CheckRateLimit(err)
 		}
 		ss = append(ss, s)
 	}
@@ -113,6 +163,14 @@ func Register(c Collector) {
 	globalRegistry.Register(c)
 }
 
+// RegisterUnique registers the collector with the global registry, requiring
+// its Namespace to be unused.
+//
+// See Registry.RegisterUnique().
+func RegisterUnique(c Collector) error {
+	return globalRegistry.RegisterUnique(c)
+}
+
 // EmptySet returns all the empty signal Sets for all the Collectors registered
 // with the global registry.
 //
@@ -129,7 +187,20 @@ func Collect(ctx context.Context, r projectrepo.Repo) ([]signal.Set, error) {
 	return globalRegistry.Collect(ctx, r)
 }
 
-func validateCollector(c Collector) {
-	// TODO - ensure a collector with the same Namespace as another use
-	// the same signal.Set
+// validateCollector ensures that if c shares a Namespace with a Collector that
+// has already been registered, then both Collectors use the same type of
+// signal Set.
+//
+// This method will panic if the signal Sets do not match.
+func (r *Registry) validateCollector(c Collector) {
+	ns := c.EmptySet().Namespace()
+	t := reflect.TypeOf(c.EmptySet())
+	for _, regC := range r.cs {
+		if regC.EmptySet().Namespace() != ns {
+			continue
+		}
+		if regT := reflect.TypeOf(regC.EmptySet()); regT != t {
+			panic(fmt.Sprintf("collector %s uses set %s, want %s", ns, t, regT))
+		}
+	}
 }
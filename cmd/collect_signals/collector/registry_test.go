@@ -0,0 +1,72 @@
+package collector
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/ossf/criticality_score/cmd/collect_signals/projectrepo"
+	"github.com/ossf/criticality_score/cmd/collect_signals/signal"
+)
+
+type customSet struct {
+	Score signal.Field[int]
+}
+
+func (s *customSet) Namespace() signal.Namespace {
+	return signal.Namespace("custom")
+}
+
+type customCollector struct{}
+
+func (c *customCollector) EmptySet() signal.Set {
+	return &customSet{}
+}
+
+func (c *customCollector) IsSupported(projectrepo.Repo) bool {
+	return true
+}
+
+func (c *customCollector) Collect(context.Context, projectrepo.Repo) (signal.Set, error) {
+	return &customSet{Score: signal.Val(1)}, nil
+}
+
+func TestRegisterUnique(t *testing.T) {
+	r := NewRegistry()
+	r.Register(&testCollector{})
+	if err := r.RegisterUnique(&customCollector{}); err != nil {
+		t.Fatalf("RegisterUnique() errored %v, want no error", err)
+	}
+	ss := r.EmptySets()
+	if len(ss) != 2 {
+		t.Fatalf("len(EmptySets()) == %d, want 2", len(ss))
+	}
+	if ns := ss[1].Namespace(); ns != "custom" {
+		t.Fatalf("EmptySets()[1].Namespace() == %s, want custom", ns)
+	}
+}
+
+func TestRegisterUnique_DuplicateNamespace(t *testing.T) {
+	r := NewRegistry()
+	r.Register(&testCollector{})
+	err := r.RegisterUnique(&testCollector{})
+	if !errors.Is(err, ErrDuplicateNamespace) {
+		t.Fatalf("RegisterUnique() errored %v, want %v", err, ErrDuplicateNamespace)
+	}
+	if l := len(r.EmptySets()); l != 1 {
+		t.Fatalf("len(EmptySets()) == %d, want 1", l)
+	}
+}
+
+func TestRegister_AfterUnique(t *testing.T) {
+	r := NewRegistry()
+	if err := r.RegisterUnique(&customCollector{}); err != nil {
+		t.Fatalf("RegisterUnique() errored %v, want no error", err)
+	}
+	defer func() {
+		if recover() == nil {
+			t.Fatal("Register() did not panic, want a panic")
+		}
+	}()
+	r.Register(&customCollector{})
+}
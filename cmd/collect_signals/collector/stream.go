@@ -0,0 +1,90 @@
+package collector
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/ossf/criticality_score/cmd/collect_signals/projectrepo"
+	"github.com/ossf/criticality_score/cmd/collect_signals/signal"
+	"github.com/ossf/criticality_score/internal/workerpool"
+)
+
+// ResolveFunc is used to turn a URL into a projectrepo.Repo that signals can
+// be collected for.
+//
+// projectrepo.Resolve is a ResolveFunc.
+type ResolveFunc func(context.Context, *url.URL) (projectrepo.Repo, error)
+
+// Result contains the outcome of collecting signals for a single URL.
+type Result struct {
+	// URL is the URL that was passed in for collection.
+	URL *url.URL
+
+	// Sets contains the signal Sets collected for the URL. Sets will be nil if
+	// Err is set.
+	Sets []signal.Set
+
+	// Err is set if the URL failed to resolve, or if collection failed.
+	Err error
+}
+
+// CollectStream resolves and collects the signals for each URL in us, returning
+// a channel that receives one Result for each URL.
+//
+// Collection is performed concurrently by the number of workers specified. As
+// a result, the order of the Results may differ from the order of us.
+//
+// The channel is closed once all the URLs have been processed, or once the
+// context is cancelled. If the context is cancelled, Results will not be
+// returned for the remaining URLs.
+func (r *Registry) CollectStream(ctx context.Context, resolve ResolveFunc, us []*url.URL, workers int) <-chan Result {
+	if workers < 1 {
+		workers = 1
+	}
+	in := make(chan *url.URL)
+	out := make(chan Result)
+
+	// Feed the urls to the workers.
+	go func() {
+		defer close(in)
+		for _, u := range us {
+			select {
+			case in <- u:
+			case <-ctx.Done():
+				return
+			}
+		}
+	}()
+
+	wait := workerpool.WorkerPool(workers, func(worker int) {
+		for u := range in {
+			res := Result{URL: u}
+			repo, err := resolve(ctx, u)
+			if err == nil {
+				res.Sets, err = r.Collect(ctx, repo)
+			}
+			res.Err = err
+			select {
+			case out <- res:
+			case <-ctx.Done():
+				return
+			}
+		}
+	})
+
+	// Close the results channel once all the workers are done.
+	go func() {
+		wait()
+		close(out)
+	}()
+
+	return out
+}
+
+// CollectStream collects the signals for each URL in us using the global
+// resolver and the Collectors registered with the global registry.
+//
+// See Registry.CollectStream().
+func CollectStream(ctx context.Context, us []*url.URL, workers int) <-chan Result {
+	return globalRegistry.CollectStream(ctx, projectrepo.Resolve, us, workers)
+}
@@ -0,0 +1,142 @@
+package collector
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"testing"
+
+	"github.com/ossf/criticality_score/cmd/collect_signals/projectrepo"
+	"github.com/ossf/criticality_score/cmd/collect_signals/signal"
+)
+
+type testRepo struct {
+	u *url.URL
+}
+
+func (r *testRepo) URL() *url.URL {
+	return r.u
+}
+
+type testSet struct {
+	Name signal.Field[string]
+}
+
+func (s *testSet) Namespace() signal.Namespace {
+	return signal.Namespace("test")
+}
+
+type testCollector struct {
+	err error
+}
+
+func (c *testCollector) EmptySet() signal.Set {
+	return &testSet{}
+}
+
+func (c *testCollector) IsSupported(projectrepo.Repo) bool {
+	return true
+}
+
+func (c *testCollector) Collect(_ context.Context, r projectrepo.Repo) (signal.Set, error) {
+	if c.err != nil {
+		return nil, c.err
+	}
+	return &testSet{Name: signal.Val(r.URL().String())}, nil
+}
+
+func testResolve(_ context.Context, u *url.URL) (projectrepo.Repo, error) {
+	if u.Hostname() != "example.com" {
+		return nil, projectrepo.ErrorNotFound
+	}
+	return &testRepo{u: u}, nil
+}
+
+func testURLs(n int) []*url.URL {
+	var us []*url.URL
+	for i := 0; i < n; i++ {
+		u, _ := url.Parse(fmt.Sprintf("https://example.com/repo/%d", i))
+		us = append(us, u)
+	}
+	return us
+}
+
+func TestCollectStream(t *testing.T) {
+	r := NewRegistry()
+	r.Register(&testCollector{})
+	us := testURLs(20)
+
+	seen := make(map[string]int)
+	for res := range r.CollectStream(context.Background(), testResolve, us, 4) {
+		if res.Err != nil {
+			t.Fatalf("Result.Err == %v, want no error", res.Err)
+		}
+		if l := len(res.Sets); l != 1 {
+			t.Fatalf("len(Result.Sets) == %d, want 1", l)
+		}
+		if name := res.Sets[0].(*testSet).Name.Get(); name != res.URL.String() {
+			t.Fatalf("Name == %s, want %s", name, res.URL)
+		}
+		seen[res.URL.String()]++
+	}
+	if l := len(seen); l != len(us) {
+		t.Fatalf("got results for %d urls, want %d", l, len(us))
+	}
+	for u, count := range seen {
+		if count != 1 {
+			t.Fatalf("got %d results for %s, want 1", count, u)
+		}
+	}
+}
+
+func TestCollectStream_Errors(t *testing.T) {
+	want := errors.New("collection failed")
+	r := NewRegistry()
+	r.Register(&testCollector{err: want})
+	us := testURLs(3)
+	unsupported, _ := url.Parse("https://unsupported.example.net/repo")
+	us = append(us, unsupported)
+
+	count := 0
+	for res := range r.CollectStream(context.Background(), testResolve, us, 2) {
+		count++
+		if res.Sets != nil {
+			t.Fatalf("Result.Sets == %v, want nil", res.Sets)
+		}
+		wantErr := want
+		if res.URL == unsupported {
+			wantErr = projectrepo.ErrorNotFound
+		}
+		if !errors.Is(res.Err, wantErr) {
+			t.Fatalf("Result.Err == %v, want %v", res.Err, wantErr)
+		}
+	}
+	if count != len(us) {
+		t.Fatalf("got %d results, want %d", count, len(us))
+	}
+}
+
+func TestCollectStream_NoURLs(t *testing.T) {
+	r := NewRegistry()
+	r.Register(&testCollector{})
+	for res := range r.CollectStream(context.Background(), testResolve, nil, 2) {
+		t.Fatalf("got result %v, want none", res)
+	}
+}
+
+func TestCollectStream_Cancelled(t *testing.T) {
+	r := NewRegistry()
+	r.Register(&testCollector{})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	count := 0
+	for range r.CollectStream(ctx, testResolve, testURLs(100), 2) {
+		count++
+	}
+	// A small number of results may be returned before the cancellation is
+	// detected, but the channel must be closed.
+	if count >= 100 {
+		t.Fatalf("got %d results, want fewer than 100", count)
+	}
+}
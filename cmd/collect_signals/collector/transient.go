@@ -0,0 +1,40 @@
+package collector
+
+import (
+	"context"
+	"errors"
+
+	"github.com/ossf/criticality_score/cmd/collect_signals/projectrepo"
+	"github.com/ossf/criticality_score/cmd/collect_signals/signal"
+	"github.com/ossf/criticality_score/internal/httpjson"
+	log "github.com/sirupsen/logrus"
+)
+
+type transientCollector struct {
+	Collector
+	logger *log.Logger
+}
+
+// TolerateTransient returns a Collector that wraps c, leaving all its signals
+// unset instead of failing when c is unable to reach a service because of a
+// transient problem, such as a 5xx status or a timeout.
+//
+// This is intended for Collectors that query third-party services, so an
+// outage of one service does not stop all the signals from being collected.
+// Rate limit errors and cancelled contexts are still returned.
+func TolerateTransient(c Collector, logger *log.Logger) Collector {
+	return &transientCollector{Collector: c, logger: logger}
+}
+
+func (c *transientCollector) Collect(ctx context.Context, r projectrepo.Repo) (signal.Set, error) {
+	s, err := c.Collector.Collect(ctx, r)
+	if err == nil || ctx.Err() != nil || !httpjson.IsTransient(err) || errors.Is(CheckRateLimit(err), ErrRateLimited) {
+		return s, err
+	}
+	c.logger.WithFields(log.Fields{
+		"url":       r.URL().String(),
+		"namespace": c.EmptySet().Namespace(),
+		"error":     err,
+	}).Warning("Leaving signals unset after a transient failure")
+	return c.EmptySet(), nil
+}
@@ -0,0 +1,62 @@
+package collector
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/ossf/criticality_score/internal/httpjson"
+	log "github.com/sirupsen/logrus"
+)
+
+func TestTolerateTransient(t *testing.T) {
+	logger := log.New()
+	logger.SetOutput(io.Discard)
+	r := &testRepo{u: &url.URL{Scheme: "https", Host: "example.com", Path: "/a"}}
+
+	tests := []struct {
+		name    string
+		err     error
+		wantErr bool
+	}{
+		{name: "no error"},
+		{name: "server error", err: &httpjson.StatusError{StatusCode: http.StatusBadGateway}},
+		{name: "not found", err: &httpjson.StatusError{StatusCode: http.StatusNotFound}, wantErr: true},
+		{name: "other", err: errors.New("other"), wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := TolerateTransient(&testCollector{err: test.err}, logger)
+			s, err := c.Collect(context.Background(), r)
+			if test.wantErr {
+				if !errors.Is(err, test.err) {
+					t.Fatalf("Collect() errored %v, want %v", err, test.err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("Collect() errored %v, want no error", err)
+			}
+			if _, ok := s.(*testSet); !ok {
+				t.Fatalf("Collect() returned %T, want *testSet", s)
+			}
+		})
+	}
+}
+
+func TestTolerateTransient_Cancelled(t *testing.T) {
+	logger := log.New()
+	logger.SetOutput(io.Discard)
+	r := &testRepo{u: &url.URL{Scheme: "https", Host: "example.com", Path: "/a"}}
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	want := &httpjson.StatusError{StatusCode: http.StatusServiceUnavailable}
+	c := TolerateTransient(&testCollector{err: want}, logger)
+	if _, err := c.Collect(ctx, r); !errors.Is(err, want) {
+		t.Fatalf("Collect() errored %v, want %v", err, want)
+	}
+}
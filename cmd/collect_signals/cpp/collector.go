@@ -0,0 +1,173 @@
+// Package cpp provides a Collector that returns a Set for whether a C or C++
+// library is packaged in ConanCenter and vcpkg, the two main C++ package
+// managers.
+//
+// Repositories are mapped to packages using Repology, or the name of the
+// repository if Repology does not know about it. A package is only used if
+// its recipe or port downloads its sources from, or links to, the
+// repository.
+//
+// Neither ConanCenter nor vcpkg publish download counts, so only whether the
+// library is packaged, and the number of versions packaged in ConanCenter,
+// are collected.
+package cpp
+
+import (
+	"context"
+	"io"
+	"net/http"
+
+	"github.com/ossf/criticality_score/cmd/collect_signals/projectrepo"
+	"github.com/ossf/criticality_score/cmd/collect_signals/repology"
+	"github.com/ossf/criticality_score/cmd/collect_signals/signal"
+	"github.com/ossf/criticality_score/internal/httpjson"
+	log "github.com/sirupsen/logrus"
+)
+
+// DefaultRawURL is the base URL the files in the ConanCenter and vcpkg
+// GitHub repositories are fetched from.
+const DefaultRawURL = "https://raw.githubusercontent.com"
+
+type cppSet struct {
+	InConanCenter signal.Field[bool]
+
+	// ConanCenterVersionCount is the number of versions of the library
+	// that ConanCenter has a recipe for.
+	ConanCenterVersionCount signal.Field[int]
+
+	InVcpkg signal.Field[bool]
+}
+
+func (s *cppSet) Namespace() signal.Namespace {
+	return signal.Namespace("cpp")
+}
+
+// projectClient is used to find the packages of a project in Repology. It is
+// implemented by repology.Client.
+type projectClient interface {
+	Project(ctx context.Context, name string) ([]repology.Package, error)
+}
+
+type Collector struct {
+	client   *http.Client
+	repology projectClient
+	logger   *log.Logger
+	rawURL   string
+}
+
+// NewCollector returns a new Collector that uses the http.Client c to fetch
+// ConanCenter recipes and vcpkg ports, and rc to query Repology.
+func NewCollector(c *http.Client, rc *repology.Client, logger *log.Logger) *Collector {
+	return newCollector(c, rc, logger)
+}
+
+func newCollector(c *http.Client, rc projectClient, logger *log.Logger) *Collector {
+	return &Collector{
+		client:   c,
+		repology: rc,
+		logger:   logger,
+		rawURL:   DefaultRawURL,
+	}
+}
+
+func (c *Collector) EmptySet() signal.Set {
+	return &cppSet{}
+}
+
+func (c *Collector) IsSupported(r projectrepo.Repo) bool {
+	return true
+}
+
+// Collect implements the collector.Collector interface.
+func (c *Collector) Collect(ctx context.Context, r projectrepo.Repo) (signal.Set, error) {
+	s := &cppSet{}
+	logger := c.logger.WithField("url", r.URL().String())
+	name := repology.ProjectName(r.URL().Path)
+
+	logger.Debug("Fetching C++ packages from Repology")
+	pkgs, err := c.repology.Project(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	repo := projectrepo.NormalizeURL(r.URL().String())
+
+	versions := 0
+	found := false
+	for _, recipe := range candidates(pkgs, repologyConanRepo, name) {
+		logger.WithField("recipe", recipe).Debug("Fetching ConanCenter recipe")
+		n, ok, err := c.queryConanRecipe(ctx, recipe, repo)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			found = true
+			versions += n
+		}
+	}
+	s.InConanCenter.Set(found)
+	if found {
+		s.ConanCenterVersionCount.Set(versions)
+	}
+
+	found = false
+	for _, port := range candidates(pkgs, repologyVcpkgRepo, name) {
+		logger.WithField("port", port).Debug("Fetching vcpkg port")
+		ok, err := c.queryVcpkgPort(ctx, port, repo)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			found = true
+			break
+		}
+	}
+	s.InVcpkg.Set(found)
+	return s, nil
+}
+
+// candidates returns the names of the packages of a project in the Repology
+// repository repoName, followed by name if it is not one of them.
+func candidates(pkgs []repology.Package, repoName, name string) []string {
+	seen := make(map[string]struct{})
+	var names []string
+	for _, p := range pkgs {
+		if p.Repo != repoName {
+			continue
+		}
+		n := p.SrcName
+		if n == "" {
+			n = p.BinName
+		}
+		if _, ok := seen[n]; ok || n == "" {
+			continue
+		}
+		seen[n] = struct{}{}
+		names = append(names, n)
+	}
+	if _, ok := seen[name]; !ok {
+		names = append(names, name)
+	}
+	return names
+}
+
+// getFile returns the contents of the file at u.
+//
+// If the file does not exist, nil is returned.
+func (c *Collector) getFile(ctx context.Context, u string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, &httpjson.StatusError{URL: u, StatusCode: resp.StatusCode}
+	}
+	return io.ReadAll(resp.Body)
+}
@@ -0,0 +1,120 @@
+package cpp
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/ossf/criticality_score/cmd/collect_signals/repology"
+	"github.com/ossf/criticality_score/internal/collectortest"
+)
+
+type fakeRepology map[string][]repology.Package
+
+func (f fakeRepology) Project(ctx context.Context, name string) ([]repology.Package, error) {
+	return f[name], nil
+}
+
+// newTestCollector returns a Collector where all the requests for files are
+// answered with the bodies in files, keyed by path, and the projects in
+// Repology are the given projects.
+func newTestCollector(t *testing.T, projects fakeRepology, files map[string]string) *Collector {
+	t.Helper()
+	s := collectortest.NewServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := files[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(body))
+	}))
+	c := newCollector(&http.Client{}, projects, collectortest.Logger())
+	c.rawURL = s.URL
+	return c
+}
+
+const (
+	testConanConfig = `versions:
+  "10.2.1":
+    folder: all
+  "10.1.1":
+    folder: all
+  "5.3.0":
+    folder: old
+`
+	testConanData = `sources:
+  "10.2.1":
+    url: "https://github.com/fmtlib/fmt/releases/download/10.2.1/fmt-10.2.1.zip"
+    sha256: "312151a2d13c8327f5c9c586ac6cf7cddc1658e8f53edae0ec56509c8fa516c9"
+  "10.1.1":
+    url:
+      - "https://github.com/fmtlib/fmt/archive/10.1.1.tar.gz"
+    sha256: "78b8c0a72b1c35e4443a7e308df52498252d1cefc2b08c9a97bc9ee6cfe61f8b"
+`
+	testVcpkgPortfile = `vcpkg_from_github(
+    OUT_SOURCE_PATH SOURCE_PATH
+    REPO fmtlib/fmt
+    REF "${VERSION}"
+    SHA512 573b7de1bd224b7b1b60d44808a843db35d4bc4634f72a9edcb52cf68e99ca66
+    HEAD_REF master
+)
+`
+)
+
+func TestCollect(t *testing.T) {
+	projects := fakeRepology{
+		"fmt": {
+			{Repo: "conancenter", SrcName: "fmt"},
+			{Repo: "vcpkg", SrcName: "fmt"},
+			{Repo: "debian_unstable", SrcName: "fmtlib"},
+		},
+	}
+	c := newTestCollector(t, projects, map[string]string{
+		"/conan-io/conan-center-index/master/recipes/fmt/config.yml":        testConanConfig,
+		"/conan-io/conan-center-index/master/recipes/fmt/all/conandata.yml": testConanData,
+		"/microsoft/vcpkg/master/ports/fmt/vcpkg.json":                      `{"name": "fmt", "homepage": "https://fmt.dev"}`,
+		"/microsoft/vcpkg/master/ports/fmt/portfile.cmake":                  testVcpkgPortfile,
+	})
+	s := collectortest.Collect[*cppSet](t, c, "https://github.com/fmtlib/fmt")
+	if !s.InConanCenter.Get() {
+		t.Fatal("InConanCenter == false, want true")
+	}
+	if got := s.ConanCenterVersionCount.Get(); got != 3 {
+		t.Fatalf("ConanCenterVersionCount == %d, want 3", got)
+	}
+	if !s.InVcpkg.Get() {
+		t.Fatal("InVcpkg == false, want true")
+	}
+}
+
+func TestCollect_Homepage(t *testing.T) {
+	c := newTestCollector(t, nil, map[string]string{
+		"/microsoft/vcpkg/master/ports/zlib/vcpkg.json": `{"name": "zlib", "homepage": "https://github.com/madler/zlib"}`,
+	})
+	s := collectortest.Collect[*cppSet](t, c, "https://github.com/madler/zlib")
+	if s.InConanCenter.Get() {
+		t.Fatal("InConanCenter == true, want false")
+	}
+	if s.ConanCenterVersionCount.IsSet() {
+		t.Fatal("ConanCenterVersionCount is set, want unset")
+	}
+	if !s.InVcpkg.Get() {
+		t.Fatal("InVcpkg == false, want true")
+	}
+}
+
+func TestCollect_Unrelated(t *testing.T) {
+	c := newTestCollector(t, nil, map[string]string{
+		"/conan-io/conan-center-index/master/recipes/fmt/config.yml":        testConanConfig,
+		"/conan-io/conan-center-index/master/recipes/fmt/all/conandata.yml": testConanData,
+		"/microsoft/vcpkg/master/ports/fmt/vcpkg.json":                      `{"name": "fmt", "homepage": "https://fmt.dev"}`,
+		"/microsoft/vcpkg/master/ports/fmt/portfile.cmake":                  testVcpkgPortfile,
+	})
+	s := collectortest.Collect[*cppSet](t, c, "https://github.com/someone/fmt")
+	if s.InConanCenter.Get() || s.InVcpkg.Get() {
+		t.Fatal("packaged signals are true, want false")
+	}
+	if s.ConanCenterVersionCount.IsSet() {
+		t.Fatal("ConanCenterVersionCount is set, want unset")
+	}
+}
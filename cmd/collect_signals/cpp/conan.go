@@ -0,0 +1,96 @@
+package cpp
+
+import (
+	"context"
+	"net/url"
+
+	"github.com/ossf/criticality_score/cmd/collect_signals/projectrepo"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// conanIndexPath is the path of the ConanCenter recipes repository.
+	conanIndexPath = "/conan-io/conan-center-index/master/recipes/"
+
+	// repologyConanRepo is the name Repology uses for ConanCenter.
+	repologyConanRepo = "conancenter"
+)
+
+// conanConfig is the config.yml file of a ConanCenter recipe, which lists
+// the versions of the library and the folder holding the recipe for each.
+type conanConfig struct {
+	Versions map[string]struct {
+		Folder string `yaml:"folder"`
+	} `yaml:"versions"`
+}
+
+// queryConanRecipe returns the number of versions in the ConanCenter recipe
+// named recipe, if it downloads its sources from the normalized repository
+// URL repo.
+//
+// If the recipe does not exist, or downloads its sources from elsewhere,
+// false is returned.
+func (c *Collector) queryConanRecipe(ctx context.Context, recipe, repo string) (int, bool, error) {
+	base := c.rawURL + conanIndexPath + url.PathEscape(recipe) + "/"
+	b, err := c.getFile(ctx, base+"config.yml")
+	if err != nil || b == nil {
+		return 0, false, err
+	}
+	var config conanConfig
+	if err := yaml.Unmarshal(b, &config); err != nil || len(config.Versions) == 0 {
+		return 0, false, nil
+	}
+
+	folders := make(map[string]struct{})
+	for _, v := range config.Versions {
+		if v.Folder != "" {
+			folders[v.Folder] = struct{}{}
+		}
+	}
+	for folder := range folders {
+		b, err := c.getFile(ctx, base+url.PathEscape(folder)+"/conandata.yml")
+		if err != nil {
+			return 0, false, err
+		}
+		if b == nil {
+			continue
+		}
+		var data any
+		if err := yaml.Unmarshal(b, &data); err != nil {
+			continue
+		}
+		if projectrepo.RefersTo(repo, yamlStrings(data)...) {
+			return len(config.Versions), true, nil
+		}
+	}
+	return 0, false, nil
+}
+
+// yamlStrings returns every string value in the decoded YAML document v,
+// such as the source URLs in a conandata.yml file.
+func yamlStrings(v any) []string {
+	switch v := v.(type) {
+	case string:
+		return []string{v}
+	case []any:
+		var ss []string
+		for _, e := range v {
+			ss = append(ss, yamlStrings(e)...)
+		}
+		return ss
+	case map[string]any:
+		var ss []string
+		for _, e := range v {
+			ss = append(ss, yamlStrings(e)...)
+		}
+		return ss
+	case map[any]any:
+		var ss []string
+		for _, e := range v {
+			ss = append(ss, yamlStrings(e)...)
+		}
+		return ss
+	default:
+		return nil
+	}
+}
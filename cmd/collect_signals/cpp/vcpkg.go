@@ -0,0 +1,56 @@
+package cpp
+
+import (
+	"context"
+	"encoding/json"
+	"net/url"
+	"regexp"
+	"strings"
+
+	"github.com/ossf/criticality_score/cmd/collect_signals/projectrepo"
+)
+
+const (
+	// vcpkgPortsPath is the path of the ports in the vcpkg repository.
+	vcpkgPortsPath = "/microsoft/vcpkg/master/ports/"
+
+	// repologyVcpkgRepo is the name Repology uses for vcpkg.
+	repologyVcpkgRepo = "vcpkg"
+)
+
+// fromGitHubRepo matches the REPO argument of vcpkg_from_github in a
+// portfile, which is the GitHub repository the sources are downloaded from.
+var fromGitHubRepo = regexp.MustCompile(`vcpkg_from_github\s*\([^)]*?\bREPO\s+"?([\w.-]+/[\w.-]+)`)
+
+// vcpkgManifest is the vcpkg.json file of a vcpkg port.
+type vcpkgManifest struct {
+	Homepage string `json:"homepage"`
+}
+
+// queryVcpkgPort returns true if the vcpkg port exists, and either downloads
+// its sources from or has a homepage at the normalized repository URL repo.
+func (c *Collector) queryVcpkgPort(ctx context.Context, port, repo string) (bool, error) {
+	base := c.rawURL + vcpkgPortsPath + url.PathEscape(port) + "/"
+	b, err := c.getFile(ctx, base+"vcpkg.json")
+	if err != nil || b == nil {
+		return false, err
+	}
+	var m vcpkgManifest
+	if err := json.Unmarshal(b, &m); err == nil && projectrepo.RefersTo(repo, m.Homepage) {
+		return true, nil
+	}
+
+	if !strings.HasPrefix(repo, "github.com/") {
+		return false, nil
+	}
+	b, err = c.getFile(ctx, base+"portfile.cmake")
+	if err != nil || b == nil {
+		return false, err
+	}
+	for _, m := range fromGitHubRepo.FindAllSubmatch(b, -1) {
+		if "github.com/"+strings.ToLower(string(m[1])) == repo {
+			return true, nil
+		}
+	}
+	return false, nil
+}
@@ -0,0 +1,214 @@
+// Package cran provides a Collector that returns a Set for the usage of the R
+// packages published on CRAN from a repository.
+//
+// The packages are read from a mapping file if the repository is listed in
+// it. Otherwise the package with the same name as the repository is used, if
+// its DESCRIPTION file refers to the repository in its URL or BugReports
+// fields.
+//
+// Downloads are reported by cranlogs, which counts downloads from the RStudio
+// CRAN mirror, so they are a lower bound.
+package cran
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/ossf/criticality_score/cmd/collect_signals/projectrepo"
+	"github.com/ossf/criticality_score/cmd/collect_signals/signal"
+	"github.com/ossf/criticality_score/internal/httpjson"
+	"github.com/ossf/criticality_score/internal/repomap"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultCranlogsURL is the base URL of the cranlogs API.
+	DefaultCranlogsURL = "https://cranlogs.r-pkg.org"
+
+	// DefaultDescriptionsURL is the base URL of the CRAN package database,
+	// which serves the DESCRIPTION file of each package as JSON.
+	DefaultDescriptionsURL = "https://crandb.r-pkg.org"
+)
+
+type cranSet struct {
+	PackageCount signal.Field[int]
+
+	// MonthlyDownloads is the total number of downloads of the packages in
+	// the last month.
+	MonthlyDownloads signal.Field[int]
+
+	// ReverseDependencyCount is the number of other CRAN packages that
+	// depend on, import or link to any of the packages.
+	ReverseDependencyCount signal.Field[int]
+}
+
+func (s *cranSet) Namespace() signal.Namespace {
+	return signal.Namespace("cran")
+}
+
+type downloads struct {
+	Downloads int    `json:"downloads"`
+	Package   string `json:"package"`
+}
+
+type description struct {
+	URL        string `json:"URL"`
+	BugReports string `json:"BugReports"`
+}
+
+type Collector struct {
+	client          *http.Client
+	logger          *log.Logger
+	packages        repomap.Map
+	dependents      map[string][]string
+	cranlogsURL     string
+	descriptionsURL string
+}
+
+// NewCollector returns a new Collector that uses the http.Client c to query
+// CRAN and cranlogs.
+//
+// packages maps repositories to the names of their CRAN packages, and may be
+// nil. The CRAN package index is downloaded when the Collector is created.
+func NewCollector(ctx context.Context, c *http.Client, packages repomap.Map, logger *log.Logger) (*Collector, error) {
+	dependents, err := loadPackages(ctx, c, DefaultPackagesURL)
+	if err != nil {
+		return nil, err
+	}
+	logger.WithField("packages", len(dependents)).Debug("Loaded CRAN package index")
+	return newCollector(c, packages, logger, dependents), nil
+}
+
+func newCollector(c *http.Client, packages repomap.Map, logger *log.Logger, dependents map[string][]string) *Collector {
+	return &Collector{
+		client:          c,
+		logger:          logger,
+		packages:        packages,
+		dependents:      dependents,
+		cranlogsURL:     DefaultCranlogsURL,
+		descriptionsURL: DefaultDescriptionsURL,
+	}
+}
+
+func (c *Collector) EmptySet() signal.Set {
+	return &cranSet{}
+}
+
+func (c *Collector) IsSupported(r projectrepo.Repo) bool {
+	return true
+}
+
+// Collect implements the collector.Collector interface.
+//
+// If no packages on CRAN are published from the repository the signals are
+// left unset.
+func (c *Collector) Collect(ctx context.Context, r projectrepo.Repo) (signal.Set, error) {
+	s := &cranSet{}
+	logger := c.logger.WithField("url", r.URL().String())
+
+	names, err := c.repoPackages(ctx, r.URL())
+	if err != nil {
+		return nil, err
+	}
+	if len(names) == 0 {
+		return s, nil
+	}
+
+	own := make(map[string]bool)
+	for _, name := range names {
+		own[name] = true
+	}
+	dependents := make(map[string]struct{})
+	total := 0
+	for _, name := range names {
+		for _, d := range c.dependents[name] {
+			if !own[d] {
+				dependents[d] = struct{}{}
+			}
+		}
+		logger.WithField("package", name).Debug("Fetching CRAN downloads")
+		n, err := c.queryDownloads(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		total += n
+	}
+	s.PackageCount.Set(len(names))
+	s.MonthlyDownloads.Set(total)
+	s.ReverseDependencyCount.Set(len(dependents))
+	return s, nil
+}
+
+// repoPackages returns the names of the packages on CRAN published from the
+// repository at u.
+func (c *Collector) repoPackages(ctx context.Context, u *url.URL) ([]string, error) {
+	if mapped, ok := c.packages.Lookup(u); ok {
+		var names []string
+		for _, name := range mapped {
+			if _, ok := c.dependents[name]; ok {
+				names = append(names, name)
+			}
+		}
+		return names, nil
+	}
+
+	name := path.Base(strings.TrimSuffix(strings.Trim(u.Path, "/"), ".git"))
+	if _, ok := c.dependents[name]; !ok {
+		return nil, nil
+	}
+	c.logger.WithFields(log.Fields{
+		"url":     u.String(),
+		"package": name,
+	}).Debug("Fetching CRAN package description")
+	desc, err := c.queryDescription(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if desc == nil || !desc.refersTo(projectrepo.NormalizeURL(u.String())) {
+		return nil, nil
+	}
+	return []string{name}, nil
+}
+
+// queryDescription returns the DESCRIPTION file of the latest version of the
+// package name.
+//
+// If the package does not exist, nil is returned.
+func (c *Collector) queryDescription(ctx context.Context, name string) (*description, error) {
+	desc := &description{}
+	_, err := httpjson.Get(ctx, c.client, c.descriptionsURL+"/"+url.PathEscape(name), desc)
+	if httpjson.StatusCode(err) == http.StatusNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return desc, nil
+}
+
+// queryDownloads returns the number of downloads of the package name in the
+// last month.
+func (c *Collector) queryDownloads(ctx context.Context, name string) (int, error) {
+	var res []downloads
+	if _, err := httpjson.Get(ctx, c.client, c.cranlogsURL+"/downloads/total/last-month/"+url.PathEscape(name), &res); err != nil {
+		return 0, err
+	}
+	total := 0
+	for _, d := range res {
+		total += d.Downloads
+	}
+	return total, nil
+}
+
+// refersTo returns true if any of the URLs in the URL or BugReports fields is
+// the normalized repository URL repo, or a path inside it such as its issue
+// tracker.
+func (d *description) refersTo(repo string) bool {
+	fields := strings.FieldsFunc(d.URL+" "+d.BugReports, func(r rune) bool {
+		return r == ',' || r == ' ' || r == '\n' || r == '\t'
+	})
+	return projectrepo.RefersTo(repo, fields...)
+}
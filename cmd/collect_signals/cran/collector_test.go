@@ -0,0 +1,82 @@
+package cran
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/ossf/criticality_score/internal/collectortest"
+	"github.com/ossf/criticality_score/internal/repomap"
+)
+
+// newTestCollector returns a Collector where all the requests are answered
+// with the bodies in responses, keyed by path.
+func newTestCollector(t *testing.T, packages repomap.Map, responses map[string]string) *Collector {
+	t.Helper()
+	s := collectortest.NewServer(t, collectortest.PathHandler(responses))
+	c := newCollector(&http.Client{}, packages, collectortest.Logger(), map[string][]string{
+		"dplyr":     {"dbplyr", "tidyr", "dtplyr"},
+		"dbplyr":    {"dtplyr"},
+		"dtplyr":    nil,
+		"tidyr":     nil,
+		"lubridate": {"tidyr"},
+	})
+	c.cranlogsURL = s.URL
+	c.descriptionsURL = s.URL
+	return c
+}
+
+func TestCollect_Guessed(t *testing.T) {
+	c := newTestCollector(t, nil, map[string]string{
+		"/dplyr":                            `{"Package": "dplyr", "URL": "https://dplyr.tidyverse.org,\nhttps://github.com/tidyverse/dplyr", "BugReports": "https://github.com/tidyverse/dplyr/issues"}`,
+		"/downloads/total/last-month/dplyr": `[{"start": "2024-01-01", "end": "2024-01-31", "downloads": 1500000, "package": "dplyr"}]`,
+	})
+	s := collectortest.Collect[*cranSet](t, c, "https://github.com/tidyverse/dplyr")
+	if got := s.PackageCount.Get(); got != 1 {
+		t.Fatalf("PackageCount == %d, want 1", got)
+	}
+	if got := s.MonthlyDownloads.Get(); got != 1500000 {
+		t.Fatalf("MonthlyDownloads == %d, want 1500000", got)
+	}
+	if got := s.ReverseDependencyCount.Get(); got != 3 {
+		t.Fatalf("ReverseDependencyCount == %d, want 3", got)
+	}
+}
+
+func TestCollect_GuessedUnrelated(t *testing.T) {
+	c := newTestCollector(t, nil, map[string]string{
+		"/dplyr": `{"Package": "dplyr", "URL": "https://github.com/tidyverse/dplyr"}`,
+	})
+	s := collectortest.Collect[*cranSet](t, c, "https://github.com/someone/dplyr")
+	if s.PackageCount.IsSet() || s.MonthlyDownloads.IsSet() || s.ReverseDependencyCount.IsSet() {
+		t.Fatal("signals are set, want unset")
+	}
+}
+
+func TestCollect_Mapped(t *testing.T) {
+	packages := repomap.Map{
+		"github.com/example/tidy": {"dplyr", "dbplyr", "notoncran"},
+	}
+	c := newTestCollector(t, packages, map[string]string{
+		"/downloads/total/last-month/dplyr":  `[{"downloads": 100, "package": "dplyr"}]`,
+		"/downloads/total/last-month/dbplyr": `[{"downloads": 20, "package": "dbplyr"}]`,
+	})
+	s := collectortest.Collect[*cranSet](t, c, "https://github.com/example/tidy")
+	if got := s.PackageCount.Get(); got != 2 {
+		t.Fatalf("PackageCount == %d, want 2", got)
+	}
+	if got := s.MonthlyDownloads.Get(); got != 120 {
+		t.Fatalf("MonthlyDownloads == %d, want 120", got)
+	}
+	// dbplyr depends on dplyr, but is published from the same repository.
+	if got := s.ReverseDependencyCount.Get(); got != 2 {
+		t.Fatalf("ReverseDependencyCount == %d, want 2", got)
+	}
+}
+
+func TestCollect_NotOnCRAN(t *testing.T) {
+	c := newTestCollector(t, nil, nil)
+	s := collectortest.Collect[*cranSet](t, c, "https://github.com/example/example")
+	if s.PackageCount.IsSet() || s.MonthlyDownloads.IsSet() || s.ReverseDependencyCount.IsSet() {
+		t.Fatal("signals are set, want unset")
+	}
+}
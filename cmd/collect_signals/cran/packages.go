@@ -0,0 +1,116 @@
+package cran
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+)
+
+// DefaultPackagesURL is the URL of the index of the source packages on CRAN.
+const DefaultPackagesURL = "https://cloud.r-project.org/src/contrib/PACKAGES"
+
+// strongDependencyFields are the fields of the index that list the packages
+// a package needs to be installed. Suggests and Enhances are not included.
+var strongDependencyFields = map[string]bool{
+	"Depends":   true,
+	"Imports":   true,
+	"LinkingTo": true,
+}
+
+// parsePackages parses the CRAN package index in r, returning the names of
+// the packages that depend on each package.
+//
+// The index is in the Debian control file format: a record for each package,
+// separated by blank lines, with continuation lines starting with
+// whitespace. Every package is in the result, even if nothing depends on it.
+func parsePackages(r io.Reader) (map[string][]string, error) {
+	dependents := make(map[string][]string)
+	var name string
+	var deps []string
+	var field, value string
+
+	endField := func() {
+		if strongDependencyFields[field] {
+			deps = append(deps, parseDependencies(value)...)
+		}
+		field, value = "", ""
+	}
+	endRecord := func() {
+		endField()
+		if name != "" {
+			if _, ok := dependents[name]; !ok {
+				dependents[name] = nil
+			}
+			for _, d := range deps {
+				if d != name {
+					dependents[d] = append(dependents[d], name)
+				}
+			}
+		}
+		name, deps = "", nil
+	}
+
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		line := s.Text()
+		if strings.TrimSpace(line) == "" {
+			endRecord()
+			continue
+		}
+		if line[0] == ' ' || line[0] == '\t' {
+			value += " " + strings.TrimSpace(line)
+			continue
+		}
+		endField()
+		k, v, ok := strings.Cut(line, ":")
+		if !ok {
+			return nil, fmt.Errorf("invalid line in package index: %q", line)
+		}
+		field, value = k, strings.TrimSpace(v)
+		if field == "Package" {
+			name = value
+		}
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	endRecord()
+	return dependents, nil
+}
+
+// parseDependencies returns the names of the packages in a dependency field
+// such as "R (>= 3.5.0), methods, Rcpp (>= 1.0.0)". R itself is not a
+// package, so it is not returned.
+func parseDependencies(v string) []string {
+	var names []string
+	for _, d := range strings.Split(v, ",") {
+		d, _, _ = strings.Cut(d, "(")
+		d = strings.TrimSpace(d)
+		if d == "" || d == "R" {
+			continue
+		}
+		names = append(names, d)
+	}
+	return names
+}
+
+// loadPackages returns the reverse dependencies of each package in the index
+// downloaded from u.
+func loadPackages(ctx context.Context, c *http.Client, u string) (map[string][]string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("request to %s failed with status %d", u, resp.StatusCode)
+	}
+	return parsePackages(resp.Body)
+}
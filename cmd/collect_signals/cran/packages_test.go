@@ -0,0 +1,65 @@
+package cran
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+	"testing"
+)
+
+const testPackages = `Package: Rcpp
+Version: 1.0.12
+Depends: R (>= 3.0.0)
+Imports: methods, utils
+Suggests: tinytest, inline
+
+Package: dplyr
+Version: 1.1.4
+Depends: R (>= 3.5.0)
+Imports: cli (>= 3.4.0), generics, glue (>= 1.3.2), lifecycle (>=
+        1.0.3), magrittr (>= 1.5), methods, R6, rlang (>= 1.1.0),
+        tibble (>= 3.2.0), tidyselect (>= 1.2.0), utils, vctrs (>= 0.6.4)
+LinkingTo: Rcpp
+Suggests: testthat
+
+Package: tidyselect
+Version: 1.2.1
+Imports: Rcpp, vctrs
+Suggests: dplyr
+`
+
+func TestParsePackages(t *testing.T) {
+	got, err := parsePackages(strings.NewReader(testPackages))
+	if err != nil {
+		t.Fatalf("parsePackages() errored %v, want no error", err)
+	}
+	for _, deps := range got {
+		sort.Strings(deps)
+	}
+	tests := map[string][]string{
+		"Rcpp":       {"dplyr", "tidyselect"},
+		"dplyr":      nil,
+		"tidyselect": {"dplyr"},
+		"lifecycle":  {"dplyr"},
+		"vctrs":      {"dplyr", "tidyselect"},
+	}
+	for name, want := range tests {
+		if deps, ok := got[name]; !ok {
+			t.Errorf("parsePackages()[%q] is missing, want %v", name, want)
+		} else if !reflect.DeepEqual(deps, want) {
+			t.Errorf("parsePackages()[%q] == %v, want %v", name, deps, want)
+		}
+	}
+	if _, ok := got["R"]; ok {
+		t.Error("parsePackages() includes R, want it excluded")
+	}
+	if _, ok := got["testthat"]; ok {
+		t.Error("parsePackages() includes a suggested package, want it excluded")
+	}
+}
+
+func TestParsePackages_Invalid(t *testing.T) {
+	if _, err := parsePackages(strings.NewReader("Package dplyr\n")); err == nil {
+		t.Fatal("parsePackages() returned no error, want an error")
+	}
+}
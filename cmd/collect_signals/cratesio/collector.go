@@ -0,0 +1,171 @@
+// Package cratesio provides a Collector that returns a Set for the usage of
+// the Rust crates published from a repository, as reported by crates.io.
+//
+// crates.io does not support looking up crates by repository, so crates are
+// found by searching for the name of the repository, and keeping the crates
+// whose repository URL refers to the repository.
+package cratesio
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/ossf/criticality_score/cmd/collect_signals/projectrepo"
+	"github.com/ossf/criticality_score/cmd/collect_signals/signal"
+	"github.com/ossf/criticality_score/internal/httpjson"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultAPIURL is the base URL of the crates.io API.
+	DefaultAPIURL = "https://crates.io/api/v1"
+
+	// userAgent identifies requests to crates.io, as required by its crawler
+	// policy.
+	userAgent = "criticality_score (https://github.com/ossf/criticality_score)"
+
+	// searchPerPage is the number of search results examined for crates
+	// published from a repository.
+	searchPerPage = 100
+)
+
+type cratesSet struct {
+	CrateCount signal.Field[int]
+
+	// ReverseDependencyCount is the number of crates that depend on any of
+	// the crates published from the repository.
+	ReverseDependencyCount signal.Field[int] `signal:"reverse_dependencies"`
+
+	// RecentDownloads is the number of downloads in the last 90 days.
+	RecentDownloads signal.Field[int]
+}
+
+func (s *cratesSet) Namespace() signal.Namespace {
+	return signal.Namespace("cratesio")
+}
+
+type crate struct {
+	Name            string `json:"name"`
+	Repository      string `json:"repository"`
+	RecentDownloads int    `json:"recent_downloads"`
+}
+
+type searchResult struct {
+	Crates []crate `json:"crates"`
+}
+
+type reverseDependencies struct {
+	Meta struct {
+		Total int `json:"total"`
+	} `json:"meta"`
+}
+
+type Collector struct {
+	client *http.Client
+	logger *log.Logger
+	apiURL string
+}
+
+// NewCollector returns a new Collector that uses the http.Client c to query
+// crates.io.
+func NewCollector(c *http.Client, logger *log.Logger) *Collector {
+	return &Collector{
+		client: c,
+		logger: logger,
+		apiURL: DefaultAPIURL,
+	}
+}
+
+func (c *Collector) EmptySet() signal.Set {
+	return &cratesSet{}
+}
+
+func (c *Collector) IsSupported(r projectrepo.Repo) bool {
+	return true
+}
+
+// Collect implements the collector.Collector interface.
+//
+// If no crates are published from the repository the signals are left unset.
+func (c *Collector) Collect(ctx context.Context, r projectrepo.Repo) (signal.Set, error) {
+	s := &cratesSet{}
+	logger := c.logger.WithField("url", r.URL().String())
+
+	logger.Debug("Searching crates.io")
+	crates, err := c.queryCrates(ctx, r.URL())
+	if err != nil {
+		return nil, err
+	}
+	if len(crates) == 0 {
+		return s, nil
+	}
+
+	deps := 0
+	downloads := 0
+	for _, cr := range crates {
+		logger.WithField("crate", cr.Name).Debug("Fetching reverse dependencies")
+		n, err := c.queryReverseDependencies(ctx, cr.Name)
+		if err != nil {
+			return nil, err
+		}
+		deps += n
+		downloads += cr.RecentDownloads
+	}
+	s.CrateCount.Set(len(crates))
+	s.ReverseDependencyCount.Set(deps)
+	s.RecentDownloads.Set(downloads)
+	return s, nil
+}
+
+// queryCrates returns the crates published from the repository at u.
+func (c *Collector) queryCrates(ctx context.Context, u *url.URL) ([]crate, error) {
+	query := url.Values{
+		"q":        {path.Base(strings.TrimSuffix(strings.Trim(u.Path, "/"), ".git"))},
+		"per_page": {fmt.Sprint(searchPerPage)},
+	}
+	var res searchResult
+	if err := c.get(ctx, "crates?"+query.Encode(), &res); err != nil {
+		return nil, err
+	}
+	repo := projectrepo.NormalizeURL(u.String())
+	var crates []crate
+	for _, cr := range res.Crates {
+		if projectrepo.RefersTo(repo, cr.Repository) {
+			crates = append(crates, cr)
+		}
+	}
+	return crates, nil
+}
+
+// queryReverseDependencies returns the number of crates that depend on the
+// crate name.
+func (c *Collector) queryReverseDependencies(ctx context.Context, name string) (int, error) {
+	var res reverseDependencies
+	err := c.get(ctx, "crates/"+url.PathEscape(name)+"/reverse_dependencies?per_page=1", &res)
+	if httpjson.StatusCode(err) == http.StatusNotFound {
+		return 0, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return res.Meta.Total, nil
+}
+
+// get queries the API endpoint path and decodes the JSON response into
+// result.
+//
+// path must already be escaped.
+func (c *Collector) get(ctx context.Context, path string, result any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.apiURL+"/"+path, nil)
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	_, err = httpjson.Do(c.client, req, result)
+	return err
+}
@@ -0,0 +1,67 @@
+package cratesio
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/ossf/criticality_score/internal/collectortest"
+)
+
+// testRepoURL is the repository the signals are collected for.
+const testRepoURL = "https://github.com/example/serde"
+
+// newTestCollector returns a Collector where all the requests are handled by
+// h.
+func newTestCollector(t *testing.T, h http.Handler) *Collector {
+	t.Helper()
+	s := collectortest.NewServer(t, h)
+	c := NewCollector(&http.Client{}, collectortest.Logger())
+	c.apiURL = s.URL
+	return c
+}
+
+func TestCollect(t *testing.T) {
+	c := newTestCollector(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("User-Agent") == "" {
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/crates":
+			w.Write([]byte(`{"crates": [
+				{"name": "serde", "repository": "https://github.com/example/serde", "recent_downloads": 100},
+				{"name": "serde_derive", "repository": "https://github.com/Example/serde/tree/main/serde_derive", "recent_downloads": 50},
+				{"name": "serde-other", "repository": "https://github.com/other/serde", "recent_downloads": 1000},
+				{"name": "serde-none", "recent_downloads": 1000}
+			]}`))
+		case "/crates/serde/reverse_dependencies":
+			w.Write([]byte(`{"dependencies": [], "meta": {"total": 30}}`))
+		case "/crates/serde_derive/reverse_dependencies":
+			w.Write([]byte(`{"dependencies": [], "meta": {"total": 5}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	s := collectortest.Collect[*cratesSet](t, c, testRepoURL)
+	if got := s.CrateCount.Get(); got != 2 {
+		t.Fatalf("CrateCount == %d, want 2", got)
+	}
+	if got := s.ReverseDependencyCount.Get(); got != 35 {
+		t.Fatalf("ReverseDependencyCount == %d, want 35", got)
+	}
+	if got := s.RecentDownloads.Get(); got != 150 {
+		t.Fatalf("RecentDownloads == %d, want 150", got)
+	}
+}
+
+func TestCollect_NoCrates(t *testing.T) {
+	c := newTestCollector(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"crates": []}`))
+	}))
+	s := collectortest.Collect[*cratesSet](t, c, testRepoURL)
+	if s.CrateCount.IsSet() || s.ReverseDependencyCount.IsSet() || s.RecentDownloads.IsSet() {
+		t.Fatal("signals are set, want unset")
+	}
+}
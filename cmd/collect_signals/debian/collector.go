@@ -0,0 +1,136 @@
+// Package debian provides a Collector that returns a Set for the number of
+// Debian systems that have the packages built from a repository installed, as
+// reported by the Debian popularity-contest.
+//
+// Repositories are mapped to Debian source packages using Repology, or the
+// name of the repository if Repology does not know about it. A source package
+// is only used if its debian/upstream/metadata or debian/control file refers
+// to the repository.
+package debian
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/ossf/criticality_score/cmd/collect_signals/projectrepo"
+	"github.com/ossf/criticality_score/cmd/collect_signals/repology"
+	"github.com/ossf/criticality_score/cmd/collect_signals/signal"
+	log "github.com/sirupsen/logrus"
+)
+
+type debianSet struct {
+	SourcePackageCount signal.Field[int]
+
+	// PopconInstalls is the number of systems with a package built from the
+	// repository installed.
+	PopconInstalls signal.Field[int]
+
+	// PopconVotes is the number of systems that regularly use a package built
+	// from the repository.
+	PopconVotes signal.Field[int]
+}
+
+func (s *debianSet) Namespace() signal.Namespace {
+	return signal.Namespace("debian")
+}
+
+// projectClient is used to find the packages of a project in Repology. It is
+// implemented by repology.Client.
+type projectClient interface {
+	Project(ctx context.Context, name string) ([]repology.Package, error)
+}
+
+type Collector struct {
+	client     *http.Client
+	repology   projectClient
+	logger     *log.Logger
+	popcon     map[string]popconEntry
+	sourcesURL string
+}
+
+// NewCollector returns a new Collector that uses the http.Client c to query
+// Debian, and rc to query Repology.
+//
+// The popularity-contest results are downloaded when the Collector is
+// created. If cacheDir is not empty, the results are cached in the directory
+// and reused for a day.
+func NewCollector(ctx context.Context, c *http.Client, rc *repology.Client, logger *log.Logger, cacheDir string) (*Collector, error) {
+	popcon, err := loadPopcon(ctx, c, DefaultPopconURL, cacheDir, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	logger.WithField("source_packages", len(popcon)).Debug("Loaded Debian popularity-contest results")
+	return newCollector(c, rc, logger, popcon), nil
+}
+
+func newCollector(c *http.Client, rc projectClient, logger *log.Logger, popcon map[string]popconEntry) *Collector {
+	return &Collector{
+		client:     c,
+		repology:   rc,
+		logger:     logger,
+		popcon:     popcon,
+		sourcesURL: DefaultSourcesURL,
+	}
+}
+
+func (c *Collector) EmptySet() signal.Set {
+	return &debianSet{}
+}
+
+func (c *Collector) IsSupported(r projectrepo.Repo) bool {
+	return true
+}
+
+// Collect implements the collector.Collector interface.
+//
+// A system may have packages from more than one of the source packages built
+// from a repository installed, so the largest counts are used rather than the
+// sum. If no source packages are built from the repository the signals are
+// left unset.
+func (c *Collector) Collect(ctx context.Context, r projectrepo.Repo) (signal.Set, error) {
+	s := &debianSet{}
+	logger := c.logger.WithField("url", r.URL().String())
+	name := repology.ProjectName(r.URL().Path)
+
+	logger.Debug("Fetching Debian source packages from Repology")
+	candidates, err := c.querySourcePackages(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if len(candidates) == 0 {
+		candidates = []string{name}
+	}
+
+	repo := projectrepo.NormalizeURL(r.URL().String())
+	count := 0
+	var best popconEntry
+	for _, pkg := range candidates {
+		e, ok := c.popcon[pkg]
+		if !ok {
+			continue
+		}
+		logger.WithField("source_package", pkg).Debug("Fetching Debian upstream metadata")
+		urls, err := c.queryUpstreamURLs(ctx, pkg)
+		if err != nil {
+			return nil, err
+		}
+		if !projectrepo.RefersTo(repo, urls...) {
+			continue
+		}
+		count++
+		if e.Installs > best.Installs {
+			best.Installs = e.Installs
+		}
+		if e.Votes > best.Votes {
+			best.Votes = e.Votes
+		}
+	}
+	if count == 0 {
+		return s, nil
+	}
+	s.SourcePackageCount.Set(count)
+	s.PopconInstalls.Set(best.Installs)
+	s.PopconVotes.Set(best.Votes)
+	return s, nil
+}
@@ -0,0 +1,99 @@
+package debian
+
+import (
+	"context"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"github.com/ossf/criticality_score/cmd/collect_signals/repology"
+	"github.com/ossf/criticality_score/internal/collectortest"
+)
+
+type fakeRepology map[string][]repology.Package
+
+func (f fakeRepology) Project(ctx context.Context, name string) ([]repology.Package, error) {
+	return f[name], nil
+}
+
+// newTestCollector returns a Collector where all the requests to Debian
+// sources are answered with the bodies in responses, keyed by path, and the
+// projects in Repology are the given projects.
+func newTestCollector(t *testing.T, projects fakeRepology, responses map[string]string) *Collector {
+	t.Helper()
+	s := collectortest.NewServer(t, collectortest.PathHandler(responses))
+	c := newCollector(&http.Client{}, projects, collectortest.Logger(), map[string]popconEntry{
+		"curl":   {Installs: 190000, Votes: 120000},
+		"other":  {Installs: 5, Votes: 1},
+		"libfoo": {Installs: 10, Votes: 2},
+	})
+	c.sourcesURL = s.URL
+	return c
+}
+
+func TestCollect(t *testing.T) {
+	projects := fakeRepology{
+		"curl": {
+			{Repo: "debian_unstable", SrcName: "curl", BinName: "curl"},
+			{Repo: "debian_unstable", SrcName: "curl", BinName: "libcurl4"},
+			{Repo: "debian_unstable", SrcName: "other", BinName: "other"},
+			{Repo: "fedora_rawhide", SrcName: "curl"},
+		},
+	}
+	c := newTestCollector(t, projects, map[string]string{
+		"/api/src/curl/": `{"package": "curl", "versions": [{"version": "7.88.1-1", "area": "main"}, {"version": "7.74.0-1", "area": "main"}]}`,
+		"/data/main/c/curl/7.88.1-1/debian/upstream/metadata": "Repository: https://github.com/curl/curl.git\n",
+		"/api/src/other/":                         `{"package": "other", "versions": [{"version": "1.0-1", "area": "main"}]}`,
+		"/data/main/o/other/1.0-1/debian/control": "Source: other\nHomepage: https://example.com/other\n",
+	})
+	s := collectortest.Collect[*debianSet](t, c, "https://github.com/curl/curl")
+	if got := s.SourcePackageCount.Get(); got != 1 {
+		t.Fatalf("SourcePackageCount == %d, want 1", got)
+	}
+	if got := s.PopconInstalls.Get(); got != 190000 {
+		t.Fatalf("PopconInstalls == %d, want 190000", got)
+	}
+	if got := s.PopconVotes.Get(); got != 120000 {
+		t.Fatalf("PopconVotes == %d, want 120000", got)
+	}
+}
+
+func TestCollect_RepositoryNameFallback(t *testing.T) {
+	c := newTestCollector(t, fakeRepology{}, map[string]string{
+		"/api/src/libfoo/": `{"package": "libfoo", "versions": [{"version": "2.0-1", "area": "main"}]}`,
+		"/data/main/libf/libfoo/2.0-1/debian/control": "Source: libfoo\nSection: libs\nHomepage: https://gitlab.com/example/libfoo\n\nPackage: libfoo2\nHomepage: https://example.com\n",
+	})
+	s := collectortest.Collect[*debianSet](t, c, "https://gitlab.com/example/LibFoo")
+	if got := s.PopconInstalls.Get(); got != 10 {
+		t.Fatalf("PopconInstalls == %d, want 10", got)
+	}
+}
+
+func TestCollect_NotPackaged(t *testing.T) {
+	c := newTestCollector(t, fakeRepology{}, map[string]string{})
+	s := collectortest.Collect[*debianSet](t, c, "https://github.com/example/unknown")
+	if s.SourcePackageCount.IsSet() || s.PopconInstalls.IsSet() || s.PopconVotes.IsSet() {
+		t.Fatal("signals are set, want unset")
+	}
+}
+
+func TestSourcePrefix(t *testing.T) {
+	tests := map[string]string{
+		"curl":   "c",
+		"libfoo": "libf",
+		"lib":    "l",
+	}
+	for pkg, want := range tests {
+		if got := sourcePrefix(pkg); got != want {
+			t.Fatalf("sourcePrefix(%q) == %q, want %q", pkg, got, want)
+		}
+	}
+}
+
+func TestParseUpstreamMetadata(t *testing.T) {
+	got := parseUpstreamMetadata([]byte("Bug-Database: https://github.com/curl/curl/issues\nRepository: https://github.com/curl/curl.git\nRepository-Browse: https://github.com/curl/curl\n"))
+	want := []string{"https://github.com/curl/curl.git", "https://github.com/curl/curl"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("parseUpstreamMetadata() == %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,161 @@
+package debian
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ossf/criticality_score/internal/httpjson"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	// DefaultSourcesURL is the base URL of the Debian sources service.
+	DefaultSourcesURL = "https://sources.debian.org"
+
+	// repologyRepo is the name Repology uses for the Debian repository.
+	repologyRepo = "debian_unstable"
+)
+
+type sourceVersions struct {
+	Versions []struct {
+		Version string `json:"version"`
+		Area    string `json:"area"`
+	} `json:"versions"`
+}
+
+// querySourcePackages returns the names of the Debian source packages that
+// Repology groups under the project name.
+//
+// If Repology does not know about the project, or it is not packaged for
+// Debian, nil is returned.
+func (c *Collector) querySourcePackages(ctx context.Context, name string) ([]string, error) {
+	pkgs, err := c.repology.Project(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[string]struct{})
+	var names []string
+	for _, p := range pkgs {
+		if p.Repo != repologyRepo || p.SrcName == "" {
+			continue
+		}
+		if _, ok := seen[p.SrcName]; ok {
+			continue
+		}
+		seen[p.SrcName] = struct{}{}
+		names = append(names, p.SrcName)
+	}
+	return names, nil
+}
+
+// queryUpstreamURLs returns the upstream URLs listed in the latest version of
+// the Debian source package pkg.
+//
+// The URLs are the Repository and Repository-Browse fields from
+// debian/upstream/metadata, and the Homepage field from debian/control. If
+// the package does not exist, nil is returned.
+func (c *Collector) queryUpstreamURLs(ctx context.Context, pkg string) ([]string, error) {
+	var versions sourceVersions
+	_, err := httpjson.Get(ctx, c.client, c.sourcesURL+"/api/src/"+url.PathEscape(pkg)+"/", &versions)
+	if httpjson.StatusCode(err) == http.StatusNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(versions.Versions) == 0 {
+		return nil, nil
+	}
+	v := versions.Versions[0]
+	base := fmt.Sprintf("%s/data/%s/%s/%s/%s/debian/", c.sourcesURL, url.PathEscape(v.Area), sourcePrefix(pkg), url.PathEscape(pkg), url.PathEscape(v.Version))
+
+	var urls []string
+	metadata, err := c.getFile(ctx, base+"upstream/metadata")
+	if err != nil {
+		return nil, err
+	}
+	if metadata != nil {
+		urls = append(urls, parseUpstreamMetadata(metadata)...)
+	}
+	control, err := c.getFile(ctx, base+"control")
+	if err != nil {
+		return nil, err
+	}
+	if control != nil {
+		if h := parseControlHomepage(control); h != "" {
+			urls = append(urls, h)
+		}
+	}
+	return urls, nil
+}
+
+// getFile returns the contents of the file at u.
+//
+// If the file does not exist, nil is returned.
+func (c *Collector) getFile(ctx context.Context, u string) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, &httpjson.StatusError{URL: u, StatusCode: resp.StatusCode}
+	}
+	return io.ReadAll(resp.Body)
+}
+
+// sourcePrefix returns the directory the Debian archive stores the source
+// package pkg under, such as "libf" for "libfoo" and "f" for "foo".
+func sourcePrefix(pkg string) string {
+	if strings.HasPrefix(pkg, "lib") && len(pkg) > 3 {
+		return pkg[:4]
+	}
+	return pkg[:1]
+}
+
+// parseUpstreamMetadata returns the Repository and Repository-Browse fields
+// in the debian/upstream/metadata file b.
+//
+// If the file is not valid, nil is returned.
+func parseUpstreamMetadata(b []byte) []string {
+	var m map[string]any
+	if err := yaml.Unmarshal(b, &m); err != nil {
+		return nil
+	}
+	var urls []string
+	for _, k := range []string{"Repository", "Repository-Browse"} {
+		if v, ok := m[k].(string); ok && v != "" {
+			urls = append(urls, v)
+		}
+	}
+	return urls
+}
+
+// parseControlHomepage returns the Homepage field from the source stanza,
+// which is the first paragraph, of the debian/control file b.
+func parseControlHomepage(b []byte) string {
+	s := bufio.NewScanner(strings.NewReader(string(b)))
+	for s.Scan() {
+		line := s.Text()
+		if strings.TrimSpace(line) == "" {
+			// The end of the source stanza.
+			break
+		}
+		if k, v, ok := strings.Cut(line, ":"); ok && strings.EqualFold(k, "Homepage") {
+			return strings.TrimSpace(v)
+		}
+	}
+	return ""
+}
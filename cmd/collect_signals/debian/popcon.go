@@ -0,0 +1,132 @@
+package debian
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	// DefaultPopconURL is the URL of the popularity-contest results for each
+	// Debian source package.
+	DefaultPopconURL = "https://popcon.debian.org/source/by_inst"
+
+	// popconCacheFile is the name of the file the results are cached in.
+	popconCacheFile = "popcon_source_by_inst"
+
+	// popconCacheTTL is how long cached results are used for. The results
+	// are updated daily.
+	popconCacheTTL = 24 * time.Hour
+)
+
+// popconEntry holds the popularity-contest results for a source package.
+type popconEntry struct {
+	// Installs is the number of systems with a binary package from the
+	// source package installed.
+	Installs int
+
+	// Votes is the number of systems that regularly use a binary package
+	// from the source package.
+	Votes int
+}
+
+// parsePopcon parses the popularity-contest results in r, returning the
+// results for each source package.
+//
+// Each line contains the rank, name, installs, votes, followed by other
+// counts. Comments starting with "#" and lines that do not start with a rank
+// (e.g. the totals at the end) are ignored.
+func parsePopcon(r io.Reader) (map[string]popconEntry, error) {
+	entries := make(map[string]popconEntry)
+	s := bufio.NewScanner(r)
+	for s.Scan() {
+		line := s.Text()
+		if strings.HasPrefix(line, "#") {
+			continue
+		}
+		fields := strings.Fields(line)
+		if len(fields) < 4 {
+			continue
+		}
+		if _, err := strconv.Atoi(fields[0]); err != nil {
+			continue
+		}
+		inst, err := strconv.Atoi(fields[2])
+		if err != nil {
+			return nil, fmt.Errorf("invalid installs for %s: %w", fields[1], err)
+		}
+		vote, err := strconv.Atoi(fields[3])
+		if err != nil {
+			return nil, fmt.Errorf("invalid votes for %s: %w", fields[1], err)
+		}
+		entries[fields[1]] = popconEntry{Installs: inst, Votes: vote}
+	}
+	if err := s.Err(); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// loadPopcon returns the popularity-contest results downloaded from u.
+//
+// If cacheDir is not empty, the results are stored in the directory and
+// reused for popconCacheTTL.
+func loadPopcon(ctx context.Context, c *http.Client, u, cacheDir string, now time.Time) (map[string]popconEntry, error) {
+	var cachePath string
+	if cacheDir != "" {
+		cachePath = filepath.Join(cacheDir, popconCacheFile)
+		if fi, err := os.Stat(cachePath); err == nil && now.Sub(fi.ModTime()) < popconCacheTTL {
+			f, err := os.Open(cachePath)
+			if err != nil {
+				return nil, err
+			}
+			defer f.Close()
+			return parsePopcon(f)
+		}
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("request to %s failed with status %d", u, resp.StatusCode)
+	}
+	if cachePath == "" {
+		return parsePopcon(resp.Body)
+	}
+
+	// Write the results to a temporary file first, so a partial download is
+	// never used.
+	if err := os.MkdirAll(cacheDir, 0o755); err != nil {
+		return nil, err
+	}
+	f, err := os.CreateTemp(cacheDir, popconCacheFile+".*")
+	if err != nil {
+		return nil, err
+	}
+	defer os.Remove(f.Name())
+	entries, err := parsePopcon(io.TeeReader(resp.Body, f))
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Rename(f.Name(), cachePath); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
@@ -0,0 +1,79 @@
+package debian
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+const testPopcon = `#Format
+#
+#<name> is the source package name;
+#<inst> is the number of people who installed this package;
+#rank name                           inst  vote   old recent no-files (maintainer)
+1     util-linux                     200000 180000 15000 5000     0 (Util-Linux Maintainers)
+2     curl                           190000 120000 60000 10000    0 (Alessandro Ghedini)
+-------------------------------------------------------------------------
+      Total                          390000 300000 75000 15000    0
+`
+
+func TestParsePopcon(t *testing.T) {
+	got, err := parsePopcon(strings.NewReader(testPopcon))
+	if err != nil {
+		t.Fatalf("parsePopcon() errored %v, want no error", err)
+	}
+	want := map[string]popconEntry{
+		"util-linux": {Installs: 200000, Votes: 180000},
+		"curl":       {Installs: 190000, Votes: 120000},
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("parsePopcon() == %v, want %v", got, want)
+	}
+}
+
+func TestParsePopcon_Invalid(t *testing.T) {
+	if _, err := parsePopcon(strings.NewReader("1 curl many 10 0 0 0\n")); err == nil {
+		t.Fatal("parsePopcon() returned no error, want an error")
+	}
+}
+
+func TestLoadPopcon_Cache(t *testing.T) {
+	requests := 0
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Write([]byte(testPopcon))
+	}))
+	defer s.Close()
+	dir := t.TempDir()
+	now := time.Now()
+
+	for i := 0; i < 2; i++ {
+		got, err := loadPopcon(context.Background(), &http.Client{}, s.URL, dir, now)
+		if err != nil {
+			t.Fatalf("loadPopcon() errored %v, want no error", err)
+		}
+		if len(got) != 2 {
+			t.Fatalf("loadPopcon() returned %d entries, want 2", len(got))
+		}
+	}
+	if requests != 1 {
+		t.Fatalf("requests == %d, want 1", requests)
+	}
+
+	// Once the cache has expired the results are downloaded again.
+	if _, err := loadPopcon(context.Background(), &http.Client{}, s.URL, dir, now.Add(2*popconCacheTTL)); err != nil {
+		t.Fatalf("loadPopcon() errored %v, want no error", err)
+	}
+	if requests != 2 {
+		t.Fatalf("requests == %d, want 2", requests)
+	}
+	if _, err := os.Stat(filepath.Join(dir, popconCacheFile)); err != nil {
+		t.Fatalf("Stat() errored %v, want no error", err)
+	}
+}
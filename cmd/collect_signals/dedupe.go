@@ -0,0 +1,50 @@
+package main
+
+import (
+	"net/url"
+	"strings"
+	"sync"
+)
+
+// empty is a convenience wrapper for the empty struct.
+type empty struct{}
+
+// repoSet is used to track the canonical URLs of repositories that have
+// already been processed during a run. It is safe for concurrent use.
+//
+// Every URL added is kept in memory until the run is complete, so memory usage
+// grows with the number of unique repositories processed.
+type repoSet struct {
+	mu   sync.Mutex
+	seen map[string]empty
+}
+
+func newRepoSet() *repoSet {
+	return &repoSet{
+		seen: make(map[string]empty),
+	}
+}
+
+// Add adds the URL u to the set. It returns true if u was added, or false if
+// u was already present.
+//
+// URLs are compared ignoring case, as hosts like GitHub treat repository URLs
+// case-insensitively.
+func (s *repoSet) Add(u *url.URL) bool {
+	key := strings.ToLower(u.String())
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.seen[key]; ok {
+		return false
+	}
+	s.seen[key] = empty{}
+	return true
+}
+
+// Remove removes the URL u from the set, so that it can be added again.
+func (s *repoSet) Remove(u *url.URL) {
+	key := strings.ToLower(u.String())
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.seen, key)
+}
@@ -0,0 +1,67 @@
+package main
+
+import (
+	"net/url"
+	"sync"
+	"sync/atomic"
+	"testing"
+)
+
+func TestRepoSetAdd(t *testing.T) {
+	s := newRepoSet()
+	u, _ := url.Parse("https://github.com/ossf/criticality_score")
+	if !s.Add(u) {
+		t.Fatal("Add() == false, want true")
+	}
+	if s.Add(u) {
+		t.Fatal("Add() == true, want false")
+	}
+	upper, _ := url.Parse("https://github.com/OSSF/Criticality_Score")
+	if s.Add(upper) {
+		t.Fatal("Add() == true, want false")
+	}
+}
+
+func TestRepoSetAdd_Shards(t *testing.T) {
+	shards := [][]string{
+		{"https://github.com/a/one", "https://github.com/shared/repo", "https://github.com/a/two"},
+		{"https://github.com/b/one", "https://github.com/shared/repo"},
+	}
+	s := newRepoSet()
+	var collected sync.Map
+	var total int32
+	var wg sync.WaitGroup
+	for _, shard := range shards {
+		wg.Add(1)
+		go func(shard []string) {
+			defer wg.Done()
+			for _, raw := range shard {
+				u, _ := url.Parse(raw)
+				if !s.Add(u) {
+					continue
+				}
+				atomic.AddInt32(&total, 1)
+				count, _ := collected.LoadOrStore(raw, new(int32))
+				atomic.AddInt32(count.(*int32), 1)
+			}
+		}(shard)
+	}
+	wg.Wait()
+	if total != 4 {
+		t.Fatalf("collected %d repos, want 4", total)
+	}
+	count, _ := collected.Load("https://github.com/shared/repo")
+	if c := atomic.LoadInt32(count.(*int32)); c != 1 {
+		t.Fatalf("shared repo collected %d times, want 1", c)
+	}
+}
+
+func TestRepoSetRemove(t *testing.T) {
+	s := newRepoSet()
+	u, _ := url.Parse("https://github.com/ossf/criticality_score")
+	s.Add(u)
+	s.Remove(u)
+	if !s.Add(u) {
+		t.Fatal("Add() == false, want true")
+	}
+}
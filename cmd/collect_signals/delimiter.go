@@ -0,0 +1,29 @@
+package main
+
+import (
+	"errors"
+	"fmt"
+	"unicode/utf8"
+)
+
+var errInvalidDelimiter = errors.New("invalid delimiter")
+
+// parseDelimiter returns the single character delimiter in s.
+//
+// As a tab is hard to type on the command line, "tab" and "\t" are also
+// accepted.
+func parseDelimiter(s string) (rune, error) {
+	switch s {
+	case "tab", `\t`:
+		return '\t', nil
+	}
+	r, size := utf8.DecodeRuneInString(s)
+	if size == 0 || size != len(s) || r == utf8.RuneError {
+		return 0, fmt.Errorf("%w: %q must be a single character", errInvalidDelimiter, s)
+	}
+	switch r {
+	case '"', '\r', '\n':
+		return 0, fmt.Errorf("%w: %q", errInvalidDelimiter, s)
+	}
+	return r, nil
+}
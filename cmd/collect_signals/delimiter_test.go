@@ -0,0 +1,42 @@
+package main
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParseDelimiter(t *testing.T) {
+	tests := []struct {
+		in   string
+		want rune
+	}{
+		{in: ",", want: ','},
+		{in: ";", want: ';'},
+		{in: "|", want: '|'},
+		{in: "tab", want: '\t'},
+		{in: `\t`, want: '\t'},
+		{in: "\t", want: '\t'},
+		{in: "§", want: '§'},
+	}
+	for _, test := range tests {
+		t.Run(test.in, func(t *testing.T) {
+			got, err := parseDelimiter(test.in)
+			if err != nil {
+				t.Fatalf("parseDelimiter() errored %v, want no error", err)
+			}
+			if got != test.want {
+				t.Fatalf("parseDelimiter() == %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestParseDelimiter_Invalid(t *testing.T) {
+	for _, in := range []string{"", ",,", "\"", "\n", "\xff"} {
+		t.Run(in, func(t *testing.T) {
+			if _, err := parseDelimiter(in); !errors.Is(err, errInvalidDelimiter) {
+				t.Fatalf("parseDelimiter() errored %v, want %v", err, errInvalidDelimiter)
+			}
+		})
+	}
+}
@@ -0,0 +1,192 @@
+// Package depfreshness provides a Collector that returns a Set for how up to
+// date the direct dependencies of the packages published from a repository
+// are, as a proxy for how well the project is maintained.
+//
+// The packages published from a repository, the dependencies of their
+// default versions, and the default version of each dependency are found
+// using the deps.dev API.
+package depfreshness
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/ossf/criticality_score/cmd/collect_signals/github/legacy"
+	"github.com/ossf/criticality_score/cmd/collect_signals/projectrepo"
+	"github.com/ossf/criticality_score/cmd/collect_signals/signal"
+	"github.com/ossf/criticality_score/internal/depsdevapi"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// maxPackages limits the number of packages published from a repository
+	// whose dependencies are examined.
+	maxPackages = 10
+
+	// maxDependencies limits the number of direct dependencies that are
+	// examined, as each needs a request to find its default version.
+	maxDependencies = 100
+
+	// outdatedMajorVersions is the number of major versions a dependency
+	// must be behind its default version by to be considered outdated.
+	outdatedMajorVersions = 2
+)
+
+type freshnessSet struct {
+	// DirectDependencyCount is the number of distinct direct dependencies of
+	// the packages whose major version could be compared.
+	DirectDependencyCount signal.Field[int]
+
+	// OutdatedDependencyRatio is the fraction of the direct dependencies
+	// that are more than one major version behind their default version.
+	OutdatedDependencyRatio signal.Field[float64]
+}
+
+func (s *freshnessSet) Namespace() signal.Namespace {
+	return signal.Namespace("dependencies")
+}
+
+// packageKey identifies a package, ignoring its version.
+type packageKey struct {
+	System string
+	Name   string
+}
+
+type Collector struct {
+	client *depsdevapi.Client
+	logger *log.Logger
+}
+
+// NewCollector returns a new Collector that uses the Client c to query
+// deps.dev.
+func NewCollector(c *depsdevapi.Client, logger *log.Logger) *Collector {
+	return &Collector{
+		client: c,
+		logger: logger,
+	}
+}
+
+func (c *Collector) EmptySet() signal.Set {
+	return &freshnessSet{}
+}
+
+func (c *Collector) IsSupported(r projectrepo.Repo) bool {
+	return true
+}
+
+// Collect implements the collector.Collector interface.
+//
+// If no packages are published from the repository, or none of their direct
+// dependencies can be compared, the signals are left unset.
+func (c *Collector) Collect(ctx context.Context, r projectrepo.Repo) (signal.Set, error) {
+	s := &freshnessSet{}
+	logger := c.logger.WithField("url", r.URL().String())
+
+	logger.Debug("Fetching packages from deps.dev")
+	pkgs, err := c.client.ProjectPackages(ctx, depsdevapi.ProjectKey(r.URL()), "")
+	if err != nil {
+		return nil, err
+	}
+	if len(pkgs) > maxPackages {
+		pkgs = pkgs[:maxPackages]
+	}
+
+	own := make(map[packageKey]bool)
+	for _, p := range pkgs {
+		own[packageKey{p.System, p.Name}] = true
+	}
+	// deps holds the lowest resolved version of each direct dependency.
+	deps := make(map[packageKey]string)
+	var order []packageKey
+	for _, p := range pkgs {
+		pkgLogger := logger.WithFields(log.Fields{
+			"system":  p.System,
+			"package": p.Name,
+		})
+		pkgLogger.Debug("Fetching default version")
+		version, err := c.client.DefaultVersion(ctx, p.System, p.Name)
+		if err != nil {
+			return nil, err
+		}
+		if version == "" {
+			continue
+		}
+		pkgLogger.Debug("Fetching dependencies")
+		d, err := c.client.Dependencies(ctx, depsdevapi.VersionKey{System: p.System, Name: p.Name, Version: version})
+		if err != nil {
+			return nil, err
+		}
+		if d == nil {
+			continue
+		}
+		for _, dep := range d.Direct() {
+			k := packageKey{dep.System, dep.Name}
+			if own[k] {
+				continue
+			}
+			prev, ok := deps[k]
+			if !ok {
+				order = append(order, k)
+			}
+			if m, ok := majorVersion(dep.Version); ok {
+				if pm, pok := majorVersion(prev); !pok || m < pm {
+					deps[k] = dep.Version
+				}
+			}
+		}
+	}
+	if len(order) > maxDependencies {
+		order = order[:maxDependencies]
+	}
+
+	compared := 0
+	outdated := 0
+	for _, k := range order {
+		used, ok := majorVersion(deps[k])
+		if !ok {
+			continue
+		}
+		logger.WithFields(log.Fields{
+			"system":     k.System,
+			"dependency": k.Name,
+		}).Debug("Fetching dependency default version")
+		latest, err := c.client.DefaultVersion(ctx, k.System, k.Name)
+		if err != nil {
+			return nil, err
+		}
+		latestMajor, ok := majorVersion(latest)
+		if !ok {
+			continue
+		}
+		compared++
+		if latestMajor-used >= outdatedMajorVersions {
+			outdated++
+		}
+	}
+	if compared == 0 {
+		return s, nil
+	}
+	s.DirectDependencyCount.Set(compared)
+	s.OutdatedDependencyRatio.Set(legacy.Round(float64(outdated)/float64(compared), 2))
+	return s, nil
+}
+
+// majorVersion returns the major version of the version v, such as 2 for
+// "2.31.0" or "v2.0.0-rc.1".
+//
+// If v does not start with a number, false is returned.
+func majorVersion(v string) (int, bool) {
+	v = strings.TrimPrefix(strings.TrimPrefix(v, "v"), "V")
+	end := strings.IndexFunc(v, func(r rune) bool {
+		return r < '0' || r > '9'
+	})
+	if end == -1 {
+		end = len(v)
+	}
+	major, err := strconv.Atoi(v[:end])
+	if err != nil {
+		return 0, false
+	}
+	return major, true
+}
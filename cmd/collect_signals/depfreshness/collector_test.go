@@ -0,0 +1,84 @@
+package depfreshness
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/ossf/criticality_score/internal/collectortest"
+	"github.com/ossf/criticality_score/internal/depsdevapi"
+)
+
+// testRepoURL is the repository the signals are collected for.
+const testRepoURL = "https://github.com/example/repo"
+
+// newTestCollector returns a Collector where all the requests are handled by
+// h.
+func newTestCollector(t *testing.T, h http.Handler) *Collector {
+	t.Helper()
+	s := collectortest.NewServer(t, h)
+	return NewCollector(depsdevapi.NewCustomClient(s.URL, &http.Client{}), collectortest.Logger())
+}
+
+func TestMajorVersion(t *testing.T) {
+	tests := []struct {
+		v    string
+		want int
+		ok   bool
+	}{
+		{"2.31.0", 2, true},
+		{"v3.0.0-rc.1", 3, true},
+		{"10", 10, true},
+		{"0.4.1", 0, true},
+		{"", 0, false},
+		{"latest", 0, false},
+	}
+	for _, test := range tests {
+		got, ok := majorVersion(test.v)
+		if got != test.want || ok != test.ok {
+			t.Errorf("majorVersion(%q) == %d, %v, want %d, %v", test.v, got, ok, test.want, test.ok)
+		}
+	}
+}
+
+func TestCollect(t *testing.T) {
+	c := newTestCollector(t, collectortest.PathHandler(map[string]string{
+		"/projects/github.com%2Fexample%2Frepo:packageversions": `{"versions": [
+			{"versionKey": {"system": "NPM", "name": "example", "version": "1.0.0"}},
+			{"versionKey": {"system": "NPM", "name": "example-cli", "version": "1.0.0"}}
+		]}`,
+		"/systems/NPM/packages/example":     `{"versions": [{"versionKey": {"version": "1.0.0"}, "isDefault": true}]}`,
+		"/systems/NPM/packages/example-cli": `{"versions": [{"versionKey": {"version": "1.0.0"}, "isDefault": true}]}`,
+		"/systems/NPM/packages/example/versions/1.0.0:dependencies": `{"nodes": [
+			{"versionKey": {"system": "NPM", "name": "example", "version": "1.0.0"}, "relation": "SELF"},
+			{"versionKey": {"system": "NPM", "name": "old", "version": "1.2.0"}, "relation": "DIRECT"},
+			{"versionKey": {"system": "NPM", "name": "current", "version": "4.0.0"}, "relation": "DIRECT"},
+			{"versionKey": {"system": "NPM", "name": "transitive", "version": "1.0.0"}, "relation": "INDIRECT"}
+		]}`,
+		"/systems/NPM/packages/example-cli/versions/1.0.0:dependencies": `{"nodes": [
+			{"versionKey": {"system": "NPM", "name": "example-cli", "version": "1.0.0"}, "relation": "SELF"},
+			{"versionKey": {"system": "NPM", "name": "example", "version": "1.0.0"}, "relation": "DIRECT"},
+			{"versionKey": {"system": "NPM", "name": "current", "version": "5.1.0"}, "relation": "DIRECT"},
+			{"versionKey": {"system": "NPM", "name": "onebehind", "version": "2.9.0"}, "relation": "DIRECT"}
+		]}`,
+		"/systems/NPM/packages/old":       `{"versions": [{"versionKey": {"version": "3.0.0"}, "isDefault": true}]}`,
+		"/systems/NPM/packages/current":   `{"versions": [{"versionKey": {"version": "5.2.0"}, "isDefault": true}]}`,
+		"/systems/NPM/packages/onebehind": `{"versions": [{"versionKey": {"version": "3.1.0"}, "isDefault": true}]}`,
+	}))
+	s := collectortest.Collect[*freshnessSet](t, c, testRepoURL)
+	if got := s.DirectDependencyCount.Get(); got != 3 {
+		t.Fatalf("DirectDependencyCount == %d, want 3", got)
+	}
+	// "old" is two major versions behind. "current" is compared at 4.0.0,
+	// the oldest version used, so is only one behind, as is "onebehind".
+	if got := s.OutdatedDependencyRatio.Get(); got != 0.33 {
+		t.Fatalf("OutdatedDependencyRatio == %v, want 0.33", got)
+	}
+}
+
+func TestCollect_NoPackages(t *testing.T) {
+	c := newTestCollector(t, collectortest.PathHandler(nil))
+	s := collectortest.Collect[*freshnessSet](t, c, testRepoURL)
+	if s.DirectDependencyCount.IsSet() || s.OutdatedDependencyRatio.IsSet() {
+		t.Fatal("signals are set, want unset")
+	}
+}
@@ -0,0 +1,86 @@
+package depsdev
+
+import (
+	"context"
+	"errors"
+	"strings"
+
+	"google.golang.org/api/iterator"
+)
+
+const ghArchiveActivityTableName = "gharchive_repo_activity"
+
+// ghArchiveDataQuery counts the events for each GitHub repository in the
+// last 90 days of GH Archive.
+//
+// Repository names are stored in lowercase, as the case of a name in GH
+// Archive depends on when the event occurred.
+const ghArchiveDataQuery = `
+CREATE TABLE ` + "`{{.ProjectID}}.{{.DatasetName}}.{{.TableName}}`" + `
+AS
+SELECT LOWER(repo.name) AS RepoName,
+       COUNTIF(type = 'IssuesEvent' AND JSON_EXTRACT_SCALAR(payload, '$.action') = 'opened') AS IssuesOpened,
+       COUNTIF(type = 'PullRequestEvent' AND JSON_EXTRACT_SCALAR(payload, '$.action') = 'opened') AS PullRequestsOpened,
+       COUNT(DISTINCT actor.login) AS UniqueActors
+FROM ` + "`githubarchive.day.20*`" + `
+WHERE _TABLE_SUFFIX BETWEEN FORMAT_DATE('%y%m%d', DATE_SUB(CURRENT_DATE(), INTERVAL 90 DAY))
+                        AND FORMAT_DATE('%y%m%d', CURRENT_DATE())
+GROUP BY RepoName;
+`
+
+const ghArchiveCountQuery = `
+SELECT IssuesOpened, PullRequestsOpened, UniqueActors
+FROM ` + "`{{.ProjectID}}.{{.DatasetName}}.{{.TableName}}`" + `
+WHERE RepoName = @reponame;
+`
+
+// repoActivity holds the number of events for a single repository.
+type repoActivity struct {
+	IssuesOpened       int
+	PullRequestsOpened int
+	UniqueActors       int
+}
+
+// ghArchiveActivity is used to query the number of events in GH Archive for
+// a GitHub repository.
+type ghArchiveActivity struct {
+	d          *dependents
+	countQuery string
+}
+
+// newGHArchiveActivity returns a new ghArchiveActivity instance, ensuring the
+// activity data exists in the same dataset as the dependent count data in d.
+//
+// The data is recreated using the same update strategy as d.
+func newGHArchiveActivity(ctx context.Context, d *dependents) (*ghArchiveActivity, error) {
+	if err := d.ensureTable(ctx, ghArchiveActivityTableName, ghArchiveDataQuery); err != nil {
+		return nil, err
+	}
+	return &ghArchiveActivity{
+		d:          d,
+		countQuery: d.generateQuery(ghArchiveCountQuery, ghArchiveActivityTableName),
+	}, nil
+}
+
+// Count returns the number of events for the GitHub repository repoName,
+// in the form "owner/name".
+//
+// If there were no events for the repository nil is returned.
+func (a *ghArchiveActivity) Count(ctx context.Context, repoName string) (*repoActivity, error) {
+	params := map[string]any{
+		"reponame": strings.ToLower(repoName),
+	}
+	it, err := a.d.b.Query(ctx, a.countQuery, params)
+	if err != nil {
+		return nil, err
+	}
+	var rec repoActivity
+	err = it.Next(&rec)
+	if errors.Is(err, iterator.Done) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &rec, nil
+}
@@ -0,0 +1,45 @@
+package depsdev
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ossf/criticality_score/internal/collectortest"
+)
+
+func TestNewGHArchiveActivity_CreatesTable(t *testing.T) {
+	b := &fakeBQ{hasDataset: true}
+	d := &dependents{b: b, logger: collectortest.Logger().WithField("test", true), dataset: &Dataset{}, strategy: UpdateNever}
+	if _, err := newGHArchiveActivity(context.Background(), d); err != nil {
+		t.Fatalf("newGHArchiveActivity() errored %v, want no error", err)
+	}
+	if !b.tableCreated {
+		t.Fatal("table was not created, want it created")
+	}
+}
+
+func TestGHArchiveActivityCount(t *testing.T) {
+	want := repoActivity{IssuesOpened: 12, PullRequestsOpened: 30, UniqueActors: 97}
+	b := &fakeBQ{
+		rows: []any{want},
+	}
+	a := &ghArchiveActivity{d: &dependents{b: b}}
+	got, err := a.Count(context.Background(), "Example/Example")
+	if err != nil {
+		t.Fatalf("Count() errored %v, want no error", err)
+	}
+	if got == nil || *got != want {
+		t.Fatalf("Count() == %v, want %v", got, want)
+	}
+}
+
+func TestGHArchiveActivityCount_NoEvents(t *testing.T) {
+	a := &ghArchiveActivity{d: &dependents{b: &fakeBQ{}}}
+	got, err := a.Count(context.Background(), "example/example")
+	if err != nil {
+		t.Fatalf("Count() errored %v, want no error", err)
+	}
+	if got != nil {
+		t.Fatalf("Count() == %v, want nil", got)
+	}
+}
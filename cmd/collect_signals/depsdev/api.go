@@ -0,0 +1,63 @@
+package depsdev
+
+import (
+	"context"
+	"strings"
+
+	"github.com/ossf/criticality_score/internal/depsdevapi"
+)
+
+// projectHosts maps deps.dev project types to the hostname used in the
+// project keys of the deps.dev API.
+var projectHosts = map[string]string{
+	"GITHUB":    "github.com",
+	"GITLAB":    "gitlab.com",
+	"BITBUCKET": "bitbucket.org",
+}
+
+// apiDependents reads dependent counts from the deps.dev REST API.
+//
+// The dependent count of a package is the number of packages that depend on
+// its default version, either directly or indirectly. This matches the
+// BigQuery dependent counts, which use the latest version of each package.
+type apiDependents struct {
+	client *depsdevapi.Client
+}
+
+// Count returns the number of dependents for each of the packages that map to
+// the project.
+//
+// If no packages map to the project an empty slice is returned.
+func (d *apiDependents) Count(ctx context.Context, projectName, projectType string) ([]packageDependents, error) {
+	// deps.dev uses lowercase project keys.
+	key := strings.ToLower(projectHosts[projectType] + "/" + projectName)
+	pkgs, err := d.client.ProjectPackages(ctx, key, "")
+	if err != nil {
+		return nil, err
+	}
+	var counts []packageDependents
+	for _, p := range pkgs {
+		version, err := d.client.DefaultVersion(ctx, p.System, p.Name)
+		if err != nil {
+			return nil, err
+		}
+		if version == "" {
+			continue
+		}
+		p.Version = version
+		deps, err := d.client.Dependents(ctx, p)
+		if err != nil {
+			return nil, err
+		}
+		if deps == nil {
+			continue
+		}
+		counts = append(counts, packageDependents{
+			System:               p.System,
+			Name:                 p.Name,
+			DependentCount:       deps.DependentCount,
+			DirectDependentCount: deps.DirectDependentCount,
+		})
+	}
+	return counts, nil
+}
@@ -0,0 +1,66 @@
+package depsdev
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/ossf/criticality_score/internal/collectortest"
+	"github.com/ossf/criticality_score/internal/depsdevapi"
+)
+
+// apiResponses holds the responses of a fake deps.dev API server, keyed by
+// the escaped request path.
+var apiResponses = map[string]string{
+	"/projects/github.com%2Fexample%2Fjson:packageversions": `{"versions": [
+		{"versionKey": {"system": "NPM", "name": "example-json", "version": "1.0.0"}},
+		{"versionKey": {"system": "NPM", "name": "example-json", "version": "2.0.0"}},
+		{"versionKey": {"system": "PYPI", "name": "example-json", "version": "2.0.0"}},
+		{"versionKey": {"system": "PYPI", "name": "example-json-unreleased", "version": "0.1.0"}}
+	]}`,
+	"/systems/NPM/packages/example-json": `{"versions": [
+		{"versionKey": {"version": "1.0.0"}},
+		{"versionKey": {"version": "2.0.0"}, "isDefault": true}
+	]}`,
+	"/systems/NPM/packages/example-json/versions/2.0.0:dependents":  `{"dependentCount": 40, "directDependentCount": 30}`,
+	"/systems/PYPI/packages/example-json":                           `{"versions": [{"versionKey": {"version": "2.0.0"}, "isDefault": true}]}`,
+	"/systems/PYPI/packages/example-json/versions/2.0.0:dependents": `{"dependentCount": 5, "directDependentCount": 5}`,
+	"/systems/PYPI/packages/example-json-unreleased":                `{"versions": [{"versionKey": {"version": "0.1.0"}}]}`,
+}
+
+func newTestAPIDependents(t *testing.T) *apiDependents {
+	t.Helper()
+	s := collectortest.NewServer(t, collectortest.PathHandler(apiResponses))
+	return &apiDependents{client: depsdevapi.NewCustomClient(s.URL, &http.Client{})}
+}
+
+func TestAPIDependentsCount(t *testing.T) {
+	d := newTestAPIDependents(t)
+	got, err := d.Count(context.Background(), "Example/json", "GITHUB")
+	if err != nil {
+		t.Fatalf("Count() errored %v, want no error", err)
+	}
+	want := []packageDependents{
+		{System: "NPM", Name: "example-json", DependentCount: 40, DirectDependentCount: 30},
+		{System: "PYPI", Name: "example-json", DependentCount: 5, DirectDependentCount: 5},
+	}
+	if len(got) != len(want) {
+		t.Fatalf("Count() == %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("Count()[%d] == %v, want %v", i, got[i], want[i])
+		}
+	}
+}
+
+func TestAPIDependentsCount_UnknownProject(t *testing.T) {
+	d := newTestAPIDependents(t)
+	got, err := d.Count(context.Background(), "example/missing", "GITLAB")
+	if err != nil {
+		t.Fatalf("Count() errored %v, want no error", err)
+	}
+	if len(got) != 0 {
+		t.Fatalf("Count() == %v, want no packages", got)
+	}
+}
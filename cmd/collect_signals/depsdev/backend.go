@@ -0,0 +1,55 @@
+package depsdev
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrInvalidBackend = errors.New("invalid backend")
+
+// Backend determines where the dependent counts are read from.
+type Backend int
+
+const (
+	// BackendBigQuery computes the dependent counts from the deps.dev public
+	// BigQuery dataset. It requires a GCP project.
+	BackendBigQuery Backend = iota
+
+	// BackendAPI reads the dependent counts from the deps.dev REST API. It
+	// does not require any credentials.
+	BackendAPI
+)
+
+// String implements the fmt.Stringer interface.
+func (b Backend) String() string {
+	text, err := b.MarshalText()
+	if err != nil {
+		return ""
+	}
+	return string(text)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (b Backend) MarshalText() ([]byte, error) {
+	switch b {
+	case BackendBigQuery:
+		return []byte("bigquery"), nil
+	case BackendAPI:
+		return []byte("api"), nil
+	default:
+		return []byte{}, fmt.Errorf("%w: %d", ErrInvalidBackend, b)
+	}
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (b *Backend) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "bigquery":
+		*b = BackendBigQuery
+	case "api":
+		*b = BackendAPI
+	default:
+		return fmt.Errorf("%w: %q", ErrInvalidBackend, string(text))
+	}
+	return nil
+}
@@ -0,0 +1,30 @@
+package depsdev
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestBackendText(t *testing.T) {
+	for _, b := range []Backend{BackendBigQuery, BackendAPI} {
+		text, err := b.MarshalText()
+		if err != nil {
+			t.Fatalf("MarshalText() errored %v, want no error", err)
+		}
+		var got Backend
+		if err := got.UnmarshalText(text); err != nil {
+			t.Fatalf("UnmarshalText(%q) errored %v, want no error", text, err)
+		}
+		if got != b {
+			t.Fatalf("UnmarshalText(%q) == %v, want %v", text, got, b)
+		}
+	}
+}
+
+func TestBackendUnmarshalText_Invalid(t *testing.T) {
+	var b Backend
+	err := b.UnmarshalText([]byte("rest"))
+	if !errors.Is(err, ErrInvalidBackend) {
+		t.Fatalf("UnmarshalText() errored %v, want %v", err, ErrInvalidBackend)
+	}
+}
@@ -3,6 +3,7 @@ package depsdev
 import (
 	"context"
 	"errors"
+	"time"
 
 	"cloud.google.com/go/bigquery"
 	"google.golang.org/api/googleapi"
@@ -19,14 +20,30 @@ type Table struct {
 	md *bigquery.TableMetadata
 }
 
+// CreationTime returns the time the table was created.
+func (t *Table) CreationTime() time.Time {
+	return t.md.CreationTime
+}
+
+// RowIterator iterates over the rows returned by a query.
+//
+// Next loads the next row into dst. iterator.Done is returned once there are
+// no more rows.
+type RowIterator interface {
+	Next(dst any) error
+}
+
 // bqAPI wraps the BigQuery Go API to make the deps.dev implementation easier to unit test.
 type bqAPI interface {
 	Project() string
 	OneResultQuery(ctx context.Context, query string, params map[string]any, result any) error
+	Query(ctx context.Context, query string, params map[string]any) (RowIterator, error)
 	NoResultQuery(ctx context.Context, query string, params map[string]any) error
 	GetDataset(ctx context.Context, id string) (*Dataset, error)
 	CreateDataset(ctx context.Context, id string) (*Dataset, error)
+	DeleteDataset(ctx context.Context, id string) error
 	GetTable(ctx context.Context, d *Dataset, id string) (*Table, error)
+	DeleteTable(ctx context.Context, d *Dataset, id string) error
 }
 
 type bq struct {
@@ -38,11 +55,7 @@ func (b *bq) Project() string {
 }
 
 func (b *bq) OneResultQuery(ctx context.Context, query string, params map[string]any, result any) error {
-	q := b.client.Query(query)
-	for k, v := range params {
-		q.Parameters = append(q.Parameters, bigquery.QueryParameter{Name: k, Value: v})
-	}
-	it, err := q.Read(ctx)
+	it, err := b.Query(ctx, query, params)
 	if err != nil {
 		return err
 	}
@@ -56,6 +69,18 @@ func (b *bq) OneResultQuery(ctx context.Context, query string, params map[string
 	return nil
 }
 
+func (b *bq) Query(ctx context.Context, query string, params map[string]any) (RowIterator, error) {
+	q := b.client.Query(query)
+	for k, v := range params {
+		q.Parameters = append(q.Parameters, bigquery.QueryParameter{Name: k, Value: v})
+	}
+	it, err := q.Read(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return it, nil
+}
+
 func (b *bq) NoResultQuery(ctx context.Context, query string, params map[string]any) error {
 	q := b.client.Query(query)
 	for k, v := range params {
@@ -99,6 +124,17 @@ func (b *bq) CreateDataset(ctx context.Context, id string) (*Dataset, error) {
 	}, nil
 }
 
+// DeleteDataset deletes the dataset, along with any tables it contains.
+//
+// If the dataset does not exist, no error is returned.
+func (b *bq) DeleteDataset(ctx context.Context, id string) error {
+	err := b.client.Dataset(id).DeleteWithContents(ctx)
+	if isNotFound(err) {
+		return nil
+	}
+	return err
+}
+
 func (b *bq) GetTable(ctx context.Context, d *Dataset, id string) (*Table, error) {
 	t := d.ds.Table(id)
 	md, err := t.Metadata(ctx)
@@ -111,6 +147,17 @@ func (b *bq) GetTable(ctx context.Context, d *Dataset, id string) (*Table, error
 	return &Table{md: md}, nil
 }
 
+// DeleteTable deletes the table from the dataset.
+//
+// If the table does not exist, no error is returned.
+func (b *bq) DeleteTable(ctx context.Context, d *Dataset, id string) error {
+	err := d.ds.Table(id).Delete(ctx)
+	if isNotFound(err) {
+		return nil
+	}
+	return err
+}
+
 func isNotFound(err error) bool {
 	if err == nil {
 		return false
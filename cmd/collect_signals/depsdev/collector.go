@@ -2,30 +2,97 @@ package depsdev
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
 	"net/url"
+	"path"
+	"sort"
 	"strings"
 
 	"cloud.google.com/go/bigquery"
+	"github.com/ossf/criticality_score/cmd/collect_signals/aggregate"
 	"github.com/ossf/criticality_score/cmd/collect_signals/collector"
 	"github.com/ossf/criticality_score/cmd/collect_signals/projectrepo"
 	"github.com/ossf/criticality_score/cmd/collect_signals/signal"
+	"github.com/ossf/criticality_score/internal/depsdevapi"
 	log "github.com/sirupsen/logrus"
 )
 
 const defaultLocation = "US"
 const DefaultDatasetName = "depsdev_analysis"
 
+// DefaultAggregation is the strategy used to combine the dependent counts of
+// each package that maps to a repository.
+const DefaultAggregation = aggregate.Sum
+
 type depsDevSet struct {
 	DependentCount signal.Field[int] `signal:"dependent_count"`
+
+	// DirectDependentCount and IndirectDependentCount split DependentCount
+	// into the packages that depend on a package directly, and those that
+	// only depend on it through other packages.
+	DirectDependentCount   signal.Field[int] `signal:"direct_dependent_count"`
+	IndirectDependentCount signal.Field[int] `signal:"indirect_dependent_count"`
+
+	// DependentCountByPackage lists the dependent count of each package that
+	// was aggregated into DependentCount.
+	DependentCountByPackage signal.Field[string] `signal:"dependent_count_by_package"`
+
+	// DependentsNPM and the fields below hold the dependent count of the
+	// packages in a single ecosystem. They are set in place of
+	// DependentCount when the ecosystem breakdown is enabled.
+	DependentsNPM      signal.Field[int] `signal:"dependents_npm"`
+	DependentsGo       signal.Field[int] `signal:"dependents_go"`
+	DependentsMaven    signal.Field[int] `signal:"dependents_maven"`
+	DependentsPyPI     signal.Field[int] `signal:"dependents_pypi"`
+	DependentsCargo    signal.Field[int] `signal:"dependents_cargo"`
+	DependentsNuGet    signal.Field[int] `signal:"dependents_nuget"`
+	DependentsRubyGems signal.Field[int] `signal:"dependents_rubygems"`
 }
 
 func (s *depsDevSet) Namespace() signal.Namespace {
 	return signal.Namespace("depsdev")
 }
 
+// ecosystemField returns the field holding the dependent count of packages
+// in the deps.dev system, such as "NPM".
+//
+// If the system has no field, nil is returned.
+func (s *depsDevSet) ecosystemField(system string) *signal.Field[int] {
+	switch strings.ToUpper(system) {
+	case "NPM":
+		return &s.DependentsNPM
+	case "GO":
+		return &s.DependentsGo
+	case "MAVEN":
+		return &s.DependentsMaven
+	case "PYPI":
+		return &s.DependentsPyPI
+	case "CARGO":
+		return &s.DependentsCargo
+	case "NUGET":
+		return &s.DependentsNuGet
+	case "RUBYGEMS":
+		return &s.DependentsRubyGems
+	default:
+		return nil
+	}
+}
+
+// dependentCounter returns the dependent counts of the packages that map to
+// a project.
+type dependentCounter interface {
+	Count(ctx context.Context, projectName, projectType string) ([]packageDependents, error)
+}
+
 type depsDevCollector struct {
-	logger     *log.Logger
-	dependents *dependents
+	logger             *log.Logger
+	dependents         dependentCounter
+	aggregation        aggregate.Strategy
+	packageDetail      bool
+	ecosystemBreakdown bool
 }
 
 func (c *depsDevCollector) EmptySet() signal.Set {
@@ -44,22 +111,157 @@ func (c *depsDevCollector) Collect(ctx context.Context, r projectrepo.Repo) (sig
 		return &s, nil
 	}
 	c.logger.WithField("url", r.URL().String()).Debug("Fetching deps.dev dependent count")
-	deps, found, err := c.dependents.Count(ctx, n, t)
+	pkgs, err := c.dependents.Count(ctx, n, t)
 	if err != nil {
 		return nil, err
 	}
-	if found {
-		s.DependentCount.Set(deps)
-	}
+	c.aggregateInto(&s, path.Base(n), pkgs)
 	return &s, nil
 }
 
-// NewCollector creates a new Collector for gathering data from deps.dev.
+// aggregateInto sets the signals in s from the dependent counts of pkgs, which
+// all map to the repository named repoName.
+func (c *depsDevCollector) aggregateInto(s *depsDevSet, repoName string, pkgs []packageDependents) {
+	var values, direct, indirect []aggregate.Package
+	var detail []string
+	bySystem := make(map[string][]aggregate.Package)
+	for _, p := range pkgs {
+		primary := isPrimaryPackage(repoName, p.Name)
+		values = append(values, aggregate.Package{Name: p.Name, Value: float64(p.DependentCount), Primary: primary})
+		bySystem[p.System] = append(bySystem[p.System], values[len(values)-1])
+		direct = append(direct, aggregate.Package{Name: p.Name, Value: float64(p.DirectDependentCount), Primary: primary})
+		indirect = append(indirect, aggregate.Package{Name: p.Name, Value: float64(p.IndirectDependentCount()), Primary: primary})
+		detail = append(detail, fmt.Sprintf("%s/%s:%d", strings.ToLower(p.System), p.Name, p.DependentCount))
+	}
+	if c.ecosystemBreakdown {
+		for system, values := range bySystem {
+			f := s.ecosystemField(system)
+			if f == nil {
+				continue
+			}
+			if deps, ok := c.aggregation.Apply(values); ok {
+				f.Set(int(math.Round(deps)))
+			}
+		}
+	} else if deps, ok := c.aggregation.Apply(values); ok {
+		s.DependentCount.Set(int(math.Round(deps)))
+	}
+	if deps, ok := c.aggregation.Apply(direct); ok {
+		s.DirectDependentCount.Set(int(math.Round(deps)))
+	}
+	if deps, ok := c.aggregation.Apply(indirect); ok {
+		s.IndirectDependentCount.Set(int(math.Round(deps)))
+	}
+	if c.packageDetail && len(detail) > 0 {
+		sort.Strings(detail)
+		s.DependentCountByPackage.Set(strings.Join(detail, ";"))
+	}
+}
+
+// isPrimaryPackage returns true if the package named pkgName appears to be the
+// main package for the repository named repoName.
 //
-// TODO add options to configure the dataset:
-//   - force dataset re-creation (-update-strategy = always,stale,weekly,monthly,never)
-//   - force dataset destruction (-depsdev-destroy-data)
-func NewCollector(ctx context.Context, logger *log.Logger, projectID, datasetName string) (collector.Collector, error) {
+// The package's name without any scope or group, such as "@scope/" for npm or
+// "group:" for Maven, must match the repository's name.
+func isPrimaryPackage(repoName, pkgName string) bool {
+	if i := strings.LastIndexAny(pkgName, "/:"); i >= 0 {
+		pkgName = pkgName[i+1:]
+	}
+	return strings.EqualFold(repoName, pkgName)
+}
+
+// Config is used to configure the deps.dev Collector.
+type Config struct {
+	// Backend determines where the dependent counts are read from. The
+	// BigQuery options below are ignored when the API backend is used.
+	Backend Backend
+
+	// APIClient is used to query deps.dev by the API backend. If nil, a
+	// client using a default http.Client is created.
+	APIClient *depsdevapi.Client
+
+	// ProjectID is the GCP project used for BigQuery. If empty, it will be
+	// detected from the environment.
+	ProjectID string
+
+	// DatasetName is the BigQuery dataset used to store the dependent counts.
+	DatasetName string
+
+	// UpdateStrategy determines when the dependent counts are recreated.
+	UpdateStrategy UpdateStrategy
+
+	// DestroyData deletes the dataset before it is recreated.
+	DestroyData bool
+
+	// Aggregation determines how dependent counts are combined for a
+	// repository that maps to many packages.
+	Aggregation aggregate.Strategy
+
+	// PackageDetail enables the dependent count of each package.
+	PackageDetail bool
+
+	// EcosystemBreakdown replaces the dependent count with a dependent
+	// count for each ecosystem. Each is combined using Aggregation.
+	EcosystemBreakdown bool
+
+	// PyPIDownloads enables the collection of download counts for PyPI
+	// packages. The download counts are combined using Aggregation.
+	PyPIDownloads bool
+
+	// Maven enables the collection of dependent counts and releases for
+	// only the Maven artifacts that map to a repository.
+	Maven bool
+
+	// GHArchive enables the collection of event counts for GitHub
+	// repositories from GH Archive.
+	GHArchive bool
+
+	// InheritedScoresFile and InheritedScoresTable enable the collection of
+	// the inherited criticality, which sums the scores from a previous run
+	// of the projects that directly depend on a repository. The scores are
+	// read from a CSV file, or from a BigQuery table in the form
+	// "project.dataset.table". Only one may be set.
+	InheritedScoresFile  string
+	InheritedScoresTable string
+
+	// InheritedScoreColumn is the column holding the scores from the
+	// previous run.
+	InheritedScoreColumn string
+}
+
+// NewCollectors creates the Collectors for gathering data from deps.dev.
+//
+// If config.PyPIDownloads is set, a Collector for the download counts of
+// PyPI packages is also returned. If config.Maven is set, a Collector for the
+// Maven artifacts is also returned, and if config.GHArchive is set, a
+// Collector for GH Archive activity is also returned. If a source of previous
+// scores is set, a Collector for the inherited criticality is also returned.
+// These all require the BigQuery backend.
+func NewCollectors(ctx context.Context, logger *log.Logger, config Config) ([]collector.Collector, error) {
+	if config.Backend == BackendAPI {
+		if config.PyPIDownloads || config.Maven || config.GHArchive || config.inherited() {
+			return nil, errors.New("pypi downloads, maven, gh archive and inherited criticality collection require the bigquery backend")
+		}
+		client := config.APIClient
+		if client == nil {
+			client = depsdevapi.NewClient(&http.Client{})
+		}
+		return []collector.Collector{
+			&depsDevCollector{
+				logger:             logger,
+				dependents:         &apiDependents{client: client},
+				aggregation:        config.Aggregation,
+				packageDetail:      config.PackageDetail,
+				ecosystemBreakdown: config.EcosystemBreakdown,
+			},
+		}, nil
+	}
+
+	if config.InheritedScoresFile != "" && config.InheritedScoresTable != "" {
+		return nil, errors.New("only one of a scores file or scores table may be used for inherited criticality")
+	}
+
+	projectID := config.ProjectID
 	if projectID == "" {
 		projectID = bigquery.DetectProjectID
 	}
@@ -70,22 +272,100 @@ func NewCollector(ctx context.Context, logger *log.Logger, projectID, datasetNam
 	// Set the location
 	gcpClient.Location = defaultLocation
 
-	dependents, err := NewDependents(ctx, gcpClient, logger, datasetName)
+	dependents, err := NewDependents(ctx, gcpClient, logger, config.DatasetName, config.UpdateStrategy, config.DestroyData)
 	if err != nil {
 		return nil, err
 	}
 
-	return &depsDevCollector{
-		logger:     logger,
-		dependents: dependents,
-	}, nil
+	cs := []collector.Collector{
+		&depsDevCollector{
+			logger:             logger,
+			dependents:         dependents,
+			aggregation:        config.Aggregation,
+			packageDetail:      config.PackageDetail,
+			ecosystemBreakdown: config.EcosystemBreakdown,
+		},
+	}
+	if config.PyPIDownloads {
+		downloads, err := newPyPIDownloads(ctx, dependents)
+		if err != nil {
+			return nil, err
+		}
+		cs = append(cs, &pypiCollector{
+			logger:      logger,
+			downloads:   downloads,
+			aggregation: config.Aggregation,
+		})
+	}
+	if config.Maven {
+		releases, err := newMavenReleases(ctx, dependents)
+		if err != nil {
+			return nil, err
+		}
+		cs = append(cs, &mavenCollector{
+			logger:      logger,
+			dependents:  dependents,
+			releases:    releases,
+			aggregation: config.Aggregation,
+		})
+	}
+	if config.GHArchive {
+		activity, err := newGHArchiveActivity(ctx, dependents)
+		if err != nil {
+			return nil, err
+		}
+		cs = append(cs, &ghArchiveCollector{
+			logger:   logger,
+			activity: activity,
+		})
+	}
+	if config.inherited() {
+		var scores previousScores
+		if config.InheritedScoresFile != "" {
+			scores, err = loadScoresFile(config.InheritedScoresFile, config.InheritedScoreColumn)
+		} else {
+			scores, err = loadScoresTable(ctx, dependents.b, config.InheritedScoresTable, config.InheritedScoreColumn)
+		}
+		if err != nil {
+			return nil, err
+		}
+		projects, err := newProjectDependents(ctx, dependents)
+		if err != nil {
+			return nil, err
+		}
+		cs = append(cs, &inheritedCollector{
+			logger:     logger,
+			dependents: projects,
+			scores:     scores,
+		})
+	}
+	return cs, nil
 }
 
+// inherited returns true if a source of previous scores is set.
+func (c Config) inherited() bool {
+	return c.InheritedScoresFile != "" || c.InheritedScoresTable != ""
+}
+
+// projectTypes maps the hostname of a repository to the deps.dev project
+// type used for repositories on that host.
+var projectTypes = map[string]string{
+	"github.com":    "GITHUB",
+	"gitlab.com":    "GITLAB",
+	"bitbucket.org": "BITBUCKET",
+}
+
+// parseRepoURL returns the deps.dev project name and type for the repository
+// at u.
+//
+// The hostname is matched ignoring case and any leading "www.". A trailing
+// ".git" is removed from the project name. If the host is not supported by
+// deps.dev, empty strings are returned.
 func parseRepoURL(u *url.URL) (projectName, projectType string) {
-	switch hn := u.Hostname(); hn {
-	case "github.com":
-		return strings.Trim(u.Path, "/"), "GITHUB"
-	default:
+	hn := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	t, ok := projectTypes[hn]
+	if !ok {
 		return "", ""
 	}
+	return strings.TrimSuffix(strings.Trim(u.Path, "/"), ".git"), t
 }
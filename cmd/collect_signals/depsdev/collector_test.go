@@ -0,0 +1,143 @@
+package depsdev
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/ossf/criticality_score/cmd/collect_signals/aggregate"
+)
+
+var monorepoPackages = []packageDependents{
+	{System: "NPM", Name: "@example/core", DependentCount: 10, DirectDependentCount: 4},
+	{System: "NPM", Name: "example", DependentCount: 40, DirectDependentCount: 12},
+	{System: "PYPI", Name: "example-extras", DependentCount: 5, DirectDependentCount: 5},
+}
+
+func TestAggregateInto(t *testing.T) {
+	tests := []struct {
+		strategy aggregate.Strategy
+		want     int
+	}{
+		{aggregate.Sum, 55},
+		{aggregate.Max, 40},
+		{aggregate.Mean, 18},
+		{aggregate.Primary, 40},
+	}
+	for _, test := range tests {
+		t.Run(test.strategy.String(), func(t *testing.T) {
+			c := &depsDevCollector{aggregation: test.strategy}
+			var s depsDevSet
+			c.aggregateInto(&s, "example", monorepoPackages)
+			if got := s.DependentCount.Get(); got != test.want {
+				t.Fatalf("DependentCount == %d, want %d", got, test.want)
+			}
+			if s.DependentCountByPackage.IsSet() {
+				t.Fatal("DependentCountByPackage is set, want unset")
+			}
+		})
+	}
+}
+
+func TestAggregateInto_DirectAndIndirect(t *testing.T) {
+	c := &depsDevCollector{aggregation: aggregate.Sum}
+	var s depsDevSet
+	c.aggregateInto(&s, "example", monorepoPackages)
+	if got := s.DirectDependentCount.Get(); got != 21 {
+		t.Fatalf("DirectDependentCount == %d, want 21", got)
+	}
+	if got := s.IndirectDependentCount.Get(); got != 34 {
+		t.Fatalf("IndirectDependentCount == %d, want 34", got)
+	}
+}
+
+func TestAggregateInto_NoPackages(t *testing.T) {
+	c := &depsDevCollector{aggregation: aggregate.Sum, packageDetail: true}
+	var s depsDevSet
+	c.aggregateInto(&s, "example", nil)
+	if s.DependentCount.IsSet() {
+		t.Fatal("DependentCount is set, want unset")
+	}
+	if s.DirectDependentCount.IsSet() || s.IndirectDependentCount.IsSet() {
+		t.Fatal("DirectDependentCount or IndirectDependentCount is set, want unset")
+	}
+	if s.DependentCountByPackage.IsSet() {
+		t.Fatal("DependentCountByPackage is set, want unset")
+	}
+}
+
+func TestAggregateInto_PackageDetail(t *testing.T) {
+	c := &depsDevCollector{aggregation: aggregate.Sum, packageDetail: true}
+	var s depsDevSet
+	c.aggregateInto(&s, "example", monorepoPackages)
+	want := "npm/@example/core:10;npm/example:40;pypi/example-extras:5"
+	if got := s.DependentCountByPackage.Get(); got != want {
+		t.Fatalf("DependentCountByPackage == %q, want %q", got, want)
+	}
+}
+
+func TestAggregateInto_EcosystemBreakdown(t *testing.T) {
+	c := &depsDevCollector{aggregation: aggregate.Sum, ecosystemBreakdown: true}
+	var s depsDevSet
+	c.aggregateInto(&s, "example", monorepoPackages)
+	if s.DependentCount.IsSet() {
+		t.Fatal("DependentCount is set, want unset")
+	}
+	if got := s.DependentsNPM.Get(); got != 50 {
+		t.Fatalf("DependentsNPM == %d, want 50", got)
+	}
+	if got := s.DependentsPyPI.Get(); got != 5 {
+		t.Fatalf("DependentsPyPI == %d, want 5", got)
+	}
+	if s.DependentsGo.IsSet() {
+		t.Fatal("DependentsGo is set, want unset")
+	}
+	if got := s.DirectDependentCount.Get(); got != 21 {
+		t.Fatalf("DirectDependentCount == %d, want 21", got)
+	}
+}
+
+func TestIsPrimaryPackage(t *testing.T) {
+	tests := []struct {
+		pkgName string
+		want    bool
+	}{
+		{"example", true},
+		{"Example", true},
+		{"@scope/example", true},
+		{"org.example:example", true},
+		{"example-extras", false},
+		{"@example/core", false},
+	}
+	for _, test := range tests {
+		if got := isPrimaryPackage("example", test.pkgName); got != test.want {
+			t.Fatalf("isPrimaryPackage(%q) == %v, want %v", test.pkgName, got, test.want)
+		}
+	}
+}
+
+func TestParseRepoURL(t *testing.T) {
+	tests := []struct {
+		url      string
+		wantName string
+		wantType string
+	}{
+		{url: "https://github.com/ossf/criticality_score", wantName: "ossf/criticality_score", wantType: "GITHUB"},
+		{url: "https://GitHub.com/ossf/criticality_score", wantName: "ossf/criticality_score", wantType: "GITHUB"},
+		{url: "https://www.github.com/ossf/criticality_score", wantName: "ossf/criticality_score", wantType: "GITHUB"},
+		{url: "https://github.com/ossf/criticality_score.git", wantName: "ossf/criticality_score", wantType: "GITHUB"},
+		{url: "https://github.com/ossf/criticality_score/", wantName: "ossf/criticality_score", wantType: "GITHUB"},
+		{url: "https://gitlab.com/gitlab-org/gitlab", wantName: "gitlab-org/gitlab", wantType: "GITLAB"},
+		{url: "https://bitbucket.org/atlassian/python-bitbucket", wantName: "atlassian/python-bitbucket", wantType: "BITBUCKET"},
+		{url: "https://example.com/ossf/criticality_score"},
+		{url: "https://wwwgithub.com/ossf/criticality_score"},
+	}
+	for _, test := range tests {
+		t.Run(test.url, func(t *testing.T) {
+			u, _ := url.Parse(test.url)
+			name, typ := parseRepoURL(u)
+			if name != test.wantName || typ != test.wantType {
+				t.Fatalf("parseRepoURL() == %q, %q, want %q, %q", name, typ, test.wantName, test.wantType)
+			}
+		})
+	}
+}
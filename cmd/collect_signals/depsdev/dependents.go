@@ -10,10 +10,13 @@ import (
 	"cloud.google.com/go/bigquery"
 	log "github.com/sirupsen/logrus"
 	_ "google.golang.org/api/bigquery/v2"
+	"google.golang.org/api/iterator"
 )
 
 const (
-	dependentCountsTableName         = "dependent_counts"
+	// dependentCountsTableName includes a version so that tables created
+	// before the direct and indirect counts were added are not reused.
+	dependentCountsTableName         = "package_dependent_counts_v2"
 	packageVersionToProjectTableName = "package_version_to_project"
 
 	snapshotQuery = "SELECT MAX(Time) AS SnapshotTime FROM `bigquery-public-data.deps_dev_v1.Snapshots`"
@@ -23,9 +26,9 @@ const (
 // TODO: count "# packages per project" to determine dependent ratio
 
 const dataQuery = `
-CREATE TEMP TABLE rawDependentCounts(Name STRING, Version STRING, System STRING, DependentCount INT)
+CREATE TEMP TABLE rawDependentCounts(Name STRING, Version STRING, System STRING, DependentCount INT, DirectDependentCount INT)
 AS
-  SELECT d.Dependency.Name as Name, d.Dependency.Version as Version, d.Dependency.System as System, COUNT(1) AS DependentCount
+  SELECT d.Dependency.Name as Name, d.Dependency.Version as Version, d.Dependency.System as System, COUNT(1) AS DependentCount, COUNTIF(d.MinimumDepth = 1) AS DirectDependentCount
   FROM ` + "`bigquery-public-data.deps_dev_v1.Dependencies`" + `AS d
   JOIN (SELECT System, Name, Version, ROW_NUMBER() OVER (PARTITION BY Name ORDER BY VersionInfo.Ordinal Desc) AS RowNumber
    FROM ` + "`bigquery-public-data.deps_dev_v1.PackageVersions`" + `
@@ -40,31 +43,50 @@ WITH pvp AS (
     FROM ` + "`bigquery-public-data.deps_dev_v1.PackageVersionToProject`" + `
     WHERE SnapshotAt = @part
 )
-SELECT pvp.ProjectName AS ProjectName, pvp.ProjectType AS ProjectType, SUM(d.DependentCount) AS DependentCount
+SELECT pvp.ProjectName AS ProjectName, pvp.ProjectType AS ProjectType, d.System AS System, d.Name AS Name, SUM(d.DependentCount) AS DependentCount, SUM(d.DirectDependentCount) AS DirectDependentCount
  FROM pvp
  JOIN rawDependentCounts AS d
       ON (pvp.System = d.System AND pvp.Name = d.Name AND pvp.Version = d.Version)
-GROUP BY ProjectName, ProjectType;
+GROUP BY ProjectName, ProjectType, System, Name;
 `
 
 const countQuery = `
-SELECT DependentCount
+SELECT System, Name, DependentCount, DirectDependentCount
 FROM ` + "`{{.ProjectID}}.{{.DatasetName}}.{{.TableName}}`" + `
 WHERE ProjectName = @projectname AND ProjectType = @projecttype;
 `
 
-func NewDependents(ctx context.Context, client *bigquery.Client, logger *log.Logger, datasetName string) (*dependents, error) {
+// NewDependents returns a new dependents instance, ensuring the dependent
+// count data exists in the dataset.
+//
+// If destroy is true the dataset will be deleted before it is recreated. The
+// strategy is then used to determine whether existing dependent count data
+// needs to be recreated.
+func NewDependents(ctx context.Context, client *bigquery.Client, logger *log.Logger, datasetName string, strategy UpdateStrategy, destroy bool) (*dependents, error) {
 	b := &bq{client: client}
+	return newDependents(ctx, b, logger, datasetName, strategy, destroy)
+}
+
+func newDependents(ctx context.Context, b bqAPI, logger *log.Logger, datasetName string, strategy UpdateStrategy, destroy bool) (*dependents, error) {
 	c := &dependents{
 		b: b,
 		logger: logger.WithFields(log.Fields{
-			"project_id": b.Project(),
-			"dataset":    datasetName,
+			"project_id":      b.Project(),
+			"dataset":         datasetName,
+			"update_strategy": strategy,
 		}),
 		datasetName: datasetName,
+		strategy:    strategy,
 	}
 	var err error
 
+	if destroy {
+		c.logger.Warn("destroying dependent count dataset")
+		if err := c.b.DeleteDataset(ctx, c.datasetName); err != nil {
+			return nil, err
+		}
+	}
+
 	// Populate the snapshot time
 	c.snapshotTime, err = c.getLatestSnapshotTime(ctx)
 	if err != nil {
@@ -72,28 +94,18 @@ func NewDependents(ctx context.Context, client *bigquery.Client, logger *log.Log
 	}
 
 	// Ensure the dataset exists
-	ds, err := c.getOrCreateDataset(ctx)
+	c.dataset, err = c.getOrCreateDataset(ctx)
 	if err != nil {
 		return nil, err
 	}
 
 	// Ensure the dependent count table exists and is populated
-	t, err := c.b.GetTable(ctx, ds, dependentCountsTableName)
-	if err != nil {
+	if err := c.ensureTable(ctx, dependentCountsTableName, dataQuery); err != nil {
 		return nil, err
 	}
-	if t != nil {
-		c.logger.Warn("dependent count table exists")
-	} else {
-		c.logger.Warn("creating dependent count table")
-		err := c.b.NoResultQuery(ctx, c.generateQuery(dataQuery), map[string]any{"part": c.snapshotTime})
-		if err != nil {
-			return nil, err
-		}
-	}
 
 	// Cache the data query to avoid re-generating it repeatedly.
-	c.countQuery = c.generateQuery(countQuery)
+	c.countQuery = c.generateQuery(countQuery, dependentCountsTableName)
 
 	return c, nil
 }
@@ -104,35 +116,88 @@ type dependents struct {
 	snapshotTime time.Time
 	countQuery   string
 	datasetName  string
+	dataset      *Dataset
+	strategy     UpdateStrategy
 }
 
-func (c *dependents) generateQuery(temp string) string {
+// ensureTable ensures the table named tableName exists in the dataset,
+// creating it with the query template temp if it is missing or needs
+// updating.
+func (c *dependents) ensureTable(ctx context.Context, tableName, temp string) error {
+	logger := c.logger.WithField("table", tableName)
+	t, err := c.b.GetTable(ctx, c.dataset, tableName)
+	if err != nil {
+		return err
+	}
+	if t != nil && c.strategy.needsUpdate(t.CreationTime(), c.snapshotTime, time.Now()) {
+		logger.WithField("created", t.CreationTime()).Warn("table needs updating")
+		if err := c.b.DeleteTable(ctx, c.dataset, tableName); err != nil {
+			return err
+		}
+		t = nil
+	}
+	if t != nil {
+		logger.Warn("table exists")
+		return nil
+	}
+	logger.Warn("creating table")
+	return c.b.NoResultQuery(ctx, c.generateQuery(temp, tableName), map[string]any{"part": c.snapshotTime})
+}
+
+func (c *dependents) generateQuery(temp, tableName string) string {
 	t := template.Must(template.New("query").Parse(temp))
 	var b bytes.Buffer
 	t.Execute(&b, struct {
 		ProjectID   string
 		DatasetName string
 		TableName   string
-	}{c.b.Project(), c.datasetName, dependentCountsTableName})
+	}{c.b.Project(), c.datasetName, tableName})
 	return b.String()
 }
 
-func (c *dependents) Count(ctx context.Context, projectName, projectType string) (int, bool, error) {
-	var rec struct {
-		DependentCount int
-	}
+// packageDependents holds the number of dependents for a single package.
+//
+// DependentCount includes both direct and indirect dependents. Direct
+// dependents depend on the package themselves, while indirect dependents
+// only depend on it through other packages.
+type packageDependents struct {
+	System               string
+	Name                 string
+	DependentCount       int
+	DirectDependentCount int
+}
+
+// IndirectDependentCount returns the number of packages that only depend on
+// the package through other packages.
+func (p packageDependents) IndirectDependentCount() int {
+	return p.DependentCount - p.DirectDependentCount
+}
+
+// Count returns the number of dependents for each of the packages that map to
+// the project.
+//
+// If no packages map to the project an empty slice is returned.
+func (c *dependents) Count(ctx context.Context, projectName, projectType string) ([]packageDependents, error) {
 	params := map[string]any{
 		"projectname": projectName,
 		"projecttype": projectType,
 	}
-	err := c.b.OneResultQuery(ctx, c.countQuery, params, &rec)
-	if err == nil {
-		return rec.DependentCount, true, nil
+	it, err := c.b.Query(ctx, c.countQuery, params)
+	if err != nil {
+		return nil, err
 	}
-	if errors.Is(err, NoResultError) {
-		return 0, false, nil
+	var pkgs []packageDependents
+	for {
+		var rec packageDependents
+		err := it.Next(&rec)
+		if errors.Is(err, iterator.Done) {
+			return pkgs, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		pkgs = append(pkgs, rec)
 	}
-	return 0, false, err
 }
 
 func (c *dependents) LatestSnapshotTime() time.Time {
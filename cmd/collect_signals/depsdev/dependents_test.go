@@ -0,0 +1,154 @@
+package depsdev
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+	"github.com/ossf/criticality_score/internal/collectortest"
+	"google.golang.org/api/iterator"
+)
+
+type fakeBQ struct {
+	snapshotTime   time.Time
+	tableCreatedAt time.Time
+	hasDataset     bool
+	hasTable       bool
+	rows           []any
+
+	datasetDeleted bool
+	tableDeleted   bool
+	tableCreated   bool
+}
+
+func (b *fakeBQ) Project() string {
+	return "test-project"
+}
+
+func (b *fakeBQ) OneResultQuery(ctx context.Context, query string, params map[string]any, result any) error {
+	reflect.ValueOf(result).Elem().FieldByName("SnapshotTime").Set(reflect.ValueOf(b.snapshotTime))
+	return nil
+}
+
+func (b *fakeBQ) Query(ctx context.Context, query string, params map[string]any) (RowIterator, error) {
+	return &fakeRowIterator{rows: b.rows}, nil
+}
+
+func (b *fakeBQ) NoResultQuery(ctx context.Context, query string, params map[string]any) error {
+	b.tableCreated = true
+	b.hasTable = true
+	return nil
+}
+
+func (b *fakeBQ) GetDataset(ctx context.Context, id string) (*Dataset, error) {
+	if !b.hasDataset {
+		return nil, nil
+	}
+	return &Dataset{}, nil
+}
+
+func (b *fakeBQ) CreateDataset(ctx context.Context, id string) (*Dataset, error) {
+	b.hasDataset = true
+	return &Dataset{}, nil
+}
+
+func (b *fakeBQ) DeleteDataset(ctx context.Context, id string) error {
+	b.datasetDeleted = true
+	b.hasDataset = false
+	b.hasTable = false
+	return nil
+}
+
+func (b *fakeBQ) GetTable(ctx context.Context, d *Dataset, id string) (*Table, error) {
+	if !b.hasTable {
+		return nil, nil
+	}
+	return &Table{md: &bigquery.TableMetadata{CreationTime: b.tableCreatedAt}}, nil
+}
+
+func (b *fakeBQ) DeleteTable(ctx context.Context, d *Dataset, id string) error {
+	b.tableDeleted = true
+	b.hasTable = false
+	return nil
+}
+
+type fakeRowIterator struct {
+	rows []any
+}
+
+func (it *fakeRowIterator) Next(dst any) error {
+	if len(it.rows) == 0 {
+		return iterator.Done
+	}
+	reflect.ValueOf(dst).Elem().Set(reflect.ValueOf(it.rows[0]))
+	it.rows = it.rows[1:]
+	return nil
+}
+
+func TestNewDependents_ReusesTable(t *testing.T) {
+	b := &fakeBQ{
+		snapshotTime:   time.Now().Add(-48 * time.Hour),
+		tableCreatedAt: time.Now().Add(-24 * time.Hour),
+		hasDataset:     true,
+		hasTable:       true,
+	}
+	if _, err := newDependents(context.Background(), b, collectortest.Logger(), "test", UpdateWeekly, false); err != nil {
+		t.Fatalf("newDependents() errored %v, want no error", err)
+	}
+	if b.tableDeleted || b.tableCreated {
+		t.Fatal("table was recreated, want it reused")
+	}
+}
+
+func TestNewDependents_RecreatesTable(t *testing.T) {
+	b := &fakeBQ{
+		snapshotTime:   time.Now().Add(-48 * time.Hour),
+		tableCreatedAt: time.Now().AddDate(0, 0, -10),
+		hasDataset:     true,
+		hasTable:       true,
+	}
+	if _, err := newDependents(context.Background(), b, collectortest.Logger(), "test", UpdateWeekly, false); err != nil {
+		t.Fatalf("newDependents() errored %v, want no error", err)
+	}
+	if !b.tableDeleted || !b.tableCreated {
+		t.Fatal("table was reused, want it recreated")
+	}
+}
+
+func TestNewDependents_Destroy(t *testing.T) {
+	b := &fakeBQ{
+		snapshotTime:   time.Now().Add(-48 * time.Hour),
+		tableCreatedAt: time.Now(),
+		hasDataset:     true,
+		hasTable:       true,
+	}
+	if _, err := newDependents(context.Background(), b, collectortest.Logger(), "test", UpdateNever, true); err != nil {
+		t.Fatalf("newDependents() errored %v, want no error", err)
+	}
+	if !b.datasetDeleted {
+		t.Fatal("dataset was not deleted, want it deleted")
+	}
+	if !b.hasDataset || !b.tableCreated {
+		t.Fatal("dataset was not recreated, want it recreated")
+	}
+}
+
+func TestDependentsCount(t *testing.T) {
+	want := []packageDependents{
+		{System: "NPM", Name: "example", DependentCount: 10},
+		{System: "NPM", Name: "@example/cli", DependentCount: 2},
+	}
+	b := &fakeBQ{
+		rows: []any{want[0], want[1]},
+	}
+	c := &dependents{b: b}
+	pkgs, err := c.Count(context.Background(), "example/example", "GITHUB")
+	if err != nil {
+		t.Fatalf("Count() errored %v, want no error", err)
+	}
+	if !reflect.DeepEqual(pkgs, want) {
+		t.Fatalf("Count() == %v, want %v", pkgs, want)
+	}
+}
@@ -0,0 +1,95 @@
+package depsdev
+
+import (
+	"context"
+	"errors"
+
+	"google.golang.org/api/iterator"
+)
+
+const pypiDownloadsTableName = "pypi_package_downloads"
+
+// pypiDataQuery counts the downloads in the last 30 days of each PyPI package
+// that maps to a project in deps.dev.
+//
+// Package names are normalized as described in PEP 503, as the names in
+// deps.dev and the PyPI downloads data may differ in case and punctuation.
+const pypiDataQuery = `
+CREATE TABLE ` + "`{{.ProjectID}}.{{.DatasetName}}.{{.TableName}}`" + `
+AS
+WITH pvp AS (
+    SELECT DISTINCT Name, ProjectName, ProjectType
+    FROM ` + "`bigquery-public-data.deps_dev_v1.PackageVersionToProject`" + `
+    WHERE SnapshotAt = @part AND System = 'PYPI'
+),
+downloads AS (
+    SELECT REGEXP_REPLACE(LOWER(file.project), r'[-_.]+', '-') AS Name, COUNT(1) AS DownloadCount
+    FROM ` + "`bigquery-public-data.pypi.file_downloads`" + `
+    WHERE timestamp >= TIMESTAMP_SUB(CURRENT_TIMESTAMP(), INTERVAL 30 DAY)
+    GROUP BY Name
+)
+SELECT pvp.ProjectName AS ProjectName, pvp.ProjectType AS ProjectType, pvp.Name AS Name, d.DownloadCount AS DownloadCount
+FROM pvp
+JOIN downloads AS d
+     ON (REGEXP_REPLACE(LOWER(pvp.Name), r'[-_.]+', '-') = d.Name);
+`
+
+const pypiCountQuery = `
+SELECT Name, DownloadCount
+FROM ` + "`{{.ProjectID}}.{{.DatasetName}}.{{.TableName}}`" + `
+WHERE ProjectName = @projectname AND ProjectType = @projecttype;
+`
+
+// packageDownloads holds the number of downloads for a single package.
+type packageDownloads struct {
+	Name          string
+	DownloadCount int
+}
+
+// pypiDownloads is used to query the number of downloads of PyPI packages
+// that map to a project.
+type pypiDownloads struct {
+	d          *dependents
+	countQuery string
+}
+
+// newPyPIDownloads returns a new pypiDownloads instance, ensuring the download
+// count data exists in the same dataset as the dependent count data in d.
+//
+// The data is recreated using the same update strategy as d.
+func newPyPIDownloads(ctx context.Context, d *dependents) (*pypiDownloads, error) {
+	if err := d.ensureTable(ctx, pypiDownloadsTableName, pypiDataQuery); err != nil {
+		return nil, err
+	}
+	return &pypiDownloads{
+		d:          d,
+		countQuery: d.generateQuery(pypiCountQuery, pypiDownloadsTableName),
+	}, nil
+}
+
+// Count returns the number of downloads for each of the PyPI packages that
+// map to the project.
+//
+// If no packages map to the project an empty slice is returned.
+func (p *pypiDownloads) Count(ctx context.Context, projectName, projectType string) ([]packageDownloads, error) {
+	params := map[string]any{
+		"projectname": projectName,
+		"projecttype": projectType,
+	}
+	it, err := p.d.b.Query(ctx, p.countQuery, params)
+	if err != nil {
+		return nil, err
+	}
+	var pkgs []packageDownloads
+	for {
+		var rec packageDownloads
+		err := it.Next(&rec)
+		if errors.Is(err, iterator.Done) {
+			return pkgs, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		pkgs = append(pkgs, rec)
+	}
+}
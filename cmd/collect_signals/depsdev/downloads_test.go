@@ -0,0 +1,83 @@
+package depsdev
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/ossf/criticality_score/cmd/collect_signals/aggregate"
+	"github.com/ossf/criticality_score/internal/collectortest"
+)
+
+func TestNewPyPIDownloads_CreatesTable(t *testing.T) {
+	b := &fakeBQ{hasDataset: true}
+	d := &dependents{b: b, logger: collectortest.Logger().WithField("test", true), dataset: &Dataset{}, strategy: UpdateNever}
+	if _, err := newPyPIDownloads(context.Background(), d); err != nil {
+		t.Fatalf("newPyPIDownloads() errored %v, want no error", err)
+	}
+	if !b.tableCreated {
+		t.Fatal("table was not created, want it created")
+	}
+}
+
+func TestNewPyPIDownloads_RecreatesTable(t *testing.T) {
+	b := &fakeBQ{
+		tableCreatedAt: time.Now().Add(-24 * time.Hour),
+		hasDataset:     true,
+		hasTable:       true,
+	}
+	d := &dependents{
+		b:            b,
+		logger:       collectortest.Logger().WithField("test", true),
+		dataset:      &Dataset{},
+		strategy:     UpdateStale,
+		snapshotTime: time.Now(),
+	}
+	if _, err := newPyPIDownloads(context.Background(), d); err != nil {
+		t.Fatalf("newPyPIDownloads() errored %v, want no error", err)
+	}
+	if !b.tableDeleted || !b.tableCreated {
+		t.Fatal("table was not recreated, want it recreated")
+	}
+}
+
+func TestPyPIDownloadsCount(t *testing.T) {
+	want := []packageDownloads{
+		{Name: "example", DownloadCount: 1000},
+		{Name: "example-extras", DownloadCount: 20},
+	}
+	b := &fakeBQ{
+		rows: []any{want[0], want[1]},
+	}
+	p := &pypiDownloads{d: &dependents{b: b}}
+	pkgs, err := p.Count(context.Background(), "example/example", "GITHUB")
+	if err != nil {
+		t.Fatalf("Count() errored %v, want no error", err)
+	}
+	if !reflect.DeepEqual(pkgs, want) {
+		t.Fatalf("Count() == %v, want %v", pkgs, want)
+	}
+}
+
+func TestPyPIAggregateInto(t *testing.T) {
+	pkgs := []packageDownloads{
+		{Name: "example", DownloadCount: 1000},
+		{Name: "example-extras", DownloadCount: 20},
+	}
+	c := &pypiCollector{aggregation: aggregate.Sum}
+	var s pypiSet
+	c.aggregateInto(&s, "example", pkgs)
+	if got := s.DownloadCount.Get(); got != 1020 {
+		t.Fatalf("DownloadCount == %d, want 1020", got)
+	}
+}
+
+func TestPyPIAggregateInto_NoPackages(t *testing.T) {
+	c := &pypiCollector{aggregation: aggregate.Sum}
+	var s pypiSet
+	c.aggregateInto(&s, "example", nil)
+	if s.DownloadCount.IsSet() {
+		t.Fatal("DownloadCount is set, want unset")
+	}
+}
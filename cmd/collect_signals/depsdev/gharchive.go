@@ -0,0 +1,63 @@
+package depsdev
+
+import (
+	"context"
+
+	"github.com/ossf/criticality_score/cmd/collect_signals/projectrepo"
+	"github.com/ossf/criticality_score/cmd/collect_signals/signal"
+	log "github.com/sirupsen/logrus"
+)
+
+// ghArchiveProjectType is the deps.dev project type of the repositories that
+// GH Archive records events for.
+const ghArchiveProjectType = "GITHUB"
+
+type ghArchiveSet struct {
+	// IssuesOpened, PullRequestsOpened and UniqueActors are counted over
+	// the last 90 days.
+	IssuesOpened       signal.Field[int]
+	PullRequestsOpened signal.Field[int]
+	UniqueActors       signal.Field[int]
+}
+
+func (s *ghArchiveSet) Namespace() signal.Namespace {
+	return signal.Namespace("gharchive")
+}
+
+type ghArchiveCollector struct {
+	logger   *log.Logger
+	activity *ghArchiveActivity
+}
+
+func (c *ghArchiveCollector) EmptySet() signal.Set {
+	return &ghArchiveSet{}
+}
+
+func (c *ghArchiveCollector) IsSupported(r projectrepo.Repo) bool {
+	_, t := parseRepoURL(r.URL())
+	return t == ghArchiveProjectType
+}
+
+// Collect implements the collector.Collector interface.
+//
+// If GH Archive has no events for the repository in the last 90 days, the
+// counts are set to zero.
+func (c *ghArchiveCollector) Collect(ctx context.Context, r projectrepo.Repo) (signal.Set, error) {
+	var s ghArchiveSet
+	n, t := parseRepoURL(r.URL())
+	if t != ghArchiveProjectType {
+		return &s, nil
+	}
+	c.logger.WithField("url", r.URL().String()).Debug("Fetching GH Archive activity")
+	a, err := c.activity.Count(ctx, n)
+	if err != nil {
+		return nil, err
+	}
+	if a == nil {
+		a = &repoActivity{}
+	}
+	s.IssuesOpened.Set(a.IssuesOpened)
+	s.PullRequestsOpened.Set(a.PullRequestsOpened)
+	s.UniqueActors.Set(a.UniqueActors)
+	return &s, nil
+}
@@ -0,0 +1,41 @@
+package depsdev
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ossf/criticality_score/internal/collectortest"
+)
+
+func TestGHArchiveCollector_NoEvents(t *testing.T) {
+	c := &ghArchiveCollector{
+		logger:   collectortest.Logger(),
+		activity: &ghArchiveActivity{d: &dependents{b: &fakeBQ{}}},
+	}
+	s, err := c.Collect(context.Background(), collectortest.NewRepo(t, "https://github.com/example/example"))
+	if err != nil {
+		t.Fatalf("Collect() errored %v, want no error", err)
+	}
+	ghs := s.(*ghArchiveSet)
+	if got := ghs.IssuesOpened.Get(); got != 0 {
+		t.Fatalf("IssuesOpened == %d, want 0", got)
+	}
+	if got := ghs.UniqueActors.Get(); got != 0 {
+		t.Fatalf("UniqueActors == %d, want 0", got)
+	}
+}
+
+func TestGHArchiveCollector_IsSupported(t *testing.T) {
+	c := &ghArchiveCollector{}
+	for _, test := range []struct {
+		url  string
+		want bool
+	}{
+		{"https://github.com/example/example", true},
+		{"https://gitlab.com/example/example", false},
+	} {
+		if got := c.IsSupported(collectortest.NewRepo(t, test.url)); got != test.want {
+			t.Fatalf("IsSupported(%s) == %v, want %v", test.url, got, test.want)
+		}
+	}
+}
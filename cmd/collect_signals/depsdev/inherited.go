@@ -0,0 +1,76 @@
+package depsdev
+
+import (
+	"context"
+
+	"github.com/ossf/criticality_score/cmd/collect_signals/github/legacy"
+	"github.com/ossf/criticality_score/cmd/collect_signals/projectrepo"
+	"github.com/ossf/criticality_score/cmd/collect_signals/signal"
+	log "github.com/sirupsen/logrus"
+)
+
+type inheritedSet struct {
+	// Criticality is the sum of the criticality scores from a previous run
+	// of the projects that directly depend on the repository.
+	Criticality signal.Field[float64]
+
+	// DependentProjectCount is the number of projects that directly depend
+	// on the repository, and ScoredDependentCount is how many of them had a
+	// score in the previous run.
+	DependentProjectCount signal.Field[int]
+	ScoredDependentCount  signal.Field[int]
+}
+
+func (s *inheritedSet) Namespace() signal.Namespace {
+	return signal.Namespace("inherited")
+}
+
+type inheritedCollector struct {
+	logger     *log.Logger
+	dependents *projectDependents
+	scores     previousScores
+}
+
+func (c *inheritedCollector) EmptySet() signal.Set {
+	return &inheritedSet{}
+}
+
+func (c *inheritedCollector) IsSupported(r projectrepo.Repo) bool {
+	_, t := parseRepoURL(r.URL())
+	return t != ""
+}
+
+// Collect implements the collector.Collector interface.
+//
+// Dependent projects without a score in the previous run add nothing to
+// Criticality. If no projects depend on the repository, the signals are set
+// to zero.
+func (c *inheritedCollector) Collect(ctx context.Context, r projectrepo.Repo) (signal.Set, error) {
+	var s inheritedSet
+	n, t := parseRepoURL(r.URL())
+	if t == "" {
+		return &s, nil
+	}
+	c.logger.WithField("url", r.URL().String()).Debug("Fetching dependent projects")
+	projects, err := c.dependents.List(ctx, n, t)
+	if err != nil {
+		return nil, err
+	}
+	c.sumInto(&s, projects)
+	return &s, nil
+}
+
+// sumInto sets the signals in s from the scores of the dependent projects.
+func (c *inheritedCollector) sumInto(s *inheritedSet, projects []dependentProject) {
+	total := 0.0
+	scored := 0
+	for _, p := range projects {
+		if score, ok := c.scores[newProjectKey(p.Name, p.Type)]; ok {
+			total += score
+			scored++
+		}
+	}
+	s.Criticality.Set(legacy.Round(total, 5))
+	s.DependentProjectCount.Set(len(projects))
+	s.ScoredDependentCount.Set(scored)
+}
@@ -0,0 +1,69 @@
+package depsdev
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ossf/criticality_score/internal/collectortest"
+)
+
+func TestInheritedCollector(t *testing.T) {
+	c := &inheritedCollector{
+		logger: collectortest.Logger(),
+		dependents: &projectDependents{d: &dependents{b: &fakeBQ{
+			rows: []any{
+				dependentProject{Name: "Example/App", Type: "GITHUB"},
+				dependentProject{Name: "example/tool", Type: "GITLAB"},
+				dependentProject{Name: "example/unscored", Type: "GITHUB"},
+			},
+		}}},
+		scores: previousScores{
+			newProjectKey("example/app", "GITHUB"):  0.5,
+			newProjectKey("example/tool", "GITLAB"): 0.25,
+		},
+	}
+	s, err := c.Collect(context.Background(), collectortest.NewRepo(t, "https://github.com/example/core"))
+	if err != nil {
+		t.Fatalf("Collect() errored %v, want no error", err)
+	}
+	is := s.(*inheritedSet)
+	if got := is.Criticality.Get(); got != 0.75 {
+		t.Fatalf("Criticality == %v, want 0.75", got)
+	}
+	if got := is.DependentProjectCount.Get(); got != 3 {
+		t.Fatalf("DependentProjectCount == %d, want 3", got)
+	}
+	if got := is.ScoredDependentCount.Get(); got != 2 {
+		t.Fatalf("ScoredDependentCount == %d, want 2", got)
+	}
+}
+
+func TestInheritedCollector_NoDependents(t *testing.T) {
+	c := &inheritedCollector{
+		logger:     collectortest.Logger(),
+		dependents: &projectDependents{d: &dependents{b: &fakeBQ{}}},
+		scores:     previousScores{},
+	}
+	s, err := c.Collect(context.Background(), collectortest.NewRepo(t, "https://github.com/example/core"))
+	if err != nil {
+		t.Fatalf("Collect() errored %v, want no error", err)
+	}
+	is := s.(*inheritedSet)
+	if got := is.Criticality.Get(); got != 0 {
+		t.Fatalf("Criticality == %v, want 0", got)
+	}
+	if got := is.DependentProjectCount.Get(); got != 0 {
+		t.Fatalf("DependentProjectCount == %d, want 0", got)
+	}
+}
+
+func TestInheritedCollector_Unsupported(t *testing.T) {
+	c := &inheritedCollector{logger: collectortest.Logger()}
+	s, err := c.Collect(context.Background(), collectortest.NewRepo(t, "https://example.org/example/core"))
+	if err != nil {
+		t.Fatalf("Collect() errored %v, want no error", err)
+	}
+	if s.(*inheritedSet).Criticality.IsSet() {
+		t.Fatal("signals are set, want unset")
+	}
+}
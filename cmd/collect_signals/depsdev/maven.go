@@ -0,0 +1,115 @@
+package depsdev
+
+import (
+	"context"
+	"math"
+	"path"
+	"time"
+
+	"github.com/ossf/criticality_score/cmd/collect_signals/aggregate"
+	"github.com/ossf/criticality_score/cmd/collect_signals/projectrepo"
+	"github.com/ossf/criticality_score/cmd/collect_signals/signal"
+	log "github.com/sirupsen/logrus"
+)
+
+// mavenSystem is the name deps.dev uses for the Maven package management
+// system.
+const mavenSystem = "MAVEN"
+
+type mavenSet struct {
+	ArtifactCount signal.Field[int]
+
+	// DependentCount combines the dependent counts of only the Maven
+	// artifacts that map to the repository.
+	DependentCount signal.Field[int]
+
+	// RecentReleaseCount is the largest number of releases of a single
+	// artifact in the year before the deps.dev snapshot. Artifacts in the
+	// same repository are often released together, so the counts are not
+	// summed.
+	RecentReleaseCount signal.Field[int]
+
+	// DaysSinceLastRelease is the number of days since the most recent
+	// release of any of the artifacts.
+	DaysSinceLastRelease signal.Field[int]
+}
+
+func (s *mavenSet) Namespace() signal.Namespace {
+	return signal.Namespace("maven")
+}
+
+type mavenCollector struct {
+	logger      *log.Logger
+	dependents  *dependents
+	releases    *mavenReleases
+	aggregation aggregate.Strategy
+}
+
+func (c *mavenCollector) EmptySet() signal.Set {
+	return &mavenSet{}
+}
+
+func (c *mavenCollector) IsSupported(r projectrepo.Repo) bool {
+	_, t := parseRepoURL(r.URL())
+	return t != ""
+}
+
+func (c *mavenCollector) Collect(ctx context.Context, r projectrepo.Repo) (signal.Set, error) {
+	var s mavenSet
+	n, t := parseRepoURL(r.URL())
+	if t == "" {
+		return &s, nil
+	}
+	logger := c.logger.WithField("url", r.URL().String())
+
+	logger.Debug("Fetching Maven dependent counts")
+	deps, err := c.dependents.Count(ctx, n, t)
+	if err != nil {
+		return nil, err
+	}
+	logger.Debug("Fetching Maven releases")
+	releases, err := c.releases.Get(ctx, n, t)
+	if err != nil {
+		return nil, err
+	}
+	c.aggregateInto(&s, path.Base(n), deps, releases, time.Now())
+	return &s, nil
+}
+
+// aggregateInto sets the signals in s from the dependent counts in deps and
+// the releases of each Maven artifact that maps to the repository named
+// repoName. Packages in deps from other systems are ignored.
+func (c *mavenCollector) aggregateInto(s *mavenSet, repoName string, deps []packageDependents, releases []packageReleases, now time.Time) {
+	var values []aggregate.Package
+	for _, p := range deps {
+		if p.System != mavenSystem {
+			continue
+		}
+		values = append(values, aggregate.Package{
+			Name:    p.Name,
+			Value:   float64(p.DependentCount),
+			Primary: isPrimaryPackage(repoName, p.Name),
+		})
+	}
+	if d, ok := c.aggregation.Apply(values); ok {
+		s.DependentCount.Set(int(math.Round(d)))
+	}
+	if len(releases) == 0 {
+		return
+	}
+	s.ArtifactCount.Set(len(releases))
+	recent := 0
+	var last time.Time
+	for _, r := range releases {
+		if r.RecentReleaseCount > recent {
+			recent = r.RecentReleaseCount
+		}
+		if r.LastReleasedAt.Valid && r.LastReleasedAt.Timestamp.After(last) {
+			last = r.LastReleasedAt.Timestamp
+		}
+	}
+	s.RecentReleaseCount.Set(recent)
+	if !last.IsZero() {
+		s.DaysSinceLastRelease.Set(int(now.Sub(last) / (24 * time.Hour)))
+	}
+}
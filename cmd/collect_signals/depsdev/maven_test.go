@@ -0,0 +1,77 @@
+package depsdev
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+	"github.com/ossf/criticality_score/cmd/collect_signals/aggregate"
+)
+
+func TestMavenReleasesGet(t *testing.T) {
+	want := []packageReleases{
+		{Name: "org.example:core", RecentReleaseCount: 4},
+	}
+	b := &fakeBQ{
+		rows: []any{want[0]},
+	}
+	m := &mavenReleases{d: &dependents{b: b}}
+	pkgs, err := m.Get(context.Background(), "example/example", "GITHUB")
+	if err != nil {
+		t.Fatalf("Get() errored %v, want no error", err)
+	}
+	if !reflect.DeepEqual(pkgs, want) {
+		t.Fatalf("Get() == %v, want %v", pkgs, want)
+	}
+}
+
+func TestMavenAggregateInto(t *testing.T) {
+	now := time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)
+	deps := []packageDependents{
+		{System: "MAVEN", Name: "org.example:core", DependentCount: 30},
+		{System: "MAVEN", Name: "org.example:example", DependentCount: 10},
+		{System: "NPM", Name: "example", DependentCount: 1000},
+	}
+	releases := []packageReleases{
+		{Name: "org.example:core", RecentReleaseCount: 4, LastReleasedAt: bigquery.NullTimestamp{Timestamp: now.AddDate(0, 0, -10), Valid: true}},
+		{Name: "org.example:example", RecentReleaseCount: 6, LastReleasedAt: bigquery.NullTimestamp{Timestamp: now.AddDate(0, 0, -40), Valid: true}},
+		{Name: "org.example:unpublished"},
+	}
+	tests := []struct {
+		strategy aggregate.Strategy
+		want     int
+	}{
+		{aggregate.Sum, 40},
+		{aggregate.Primary, 10},
+	}
+	for _, test := range tests {
+		t.Run(test.strategy.String(), func(t *testing.T) {
+			c := &mavenCollector{aggregation: test.strategy}
+			var s mavenSet
+			c.aggregateInto(&s, "example", deps, releases, now)
+			if got := s.DependentCount.Get(); got != test.want {
+				t.Fatalf("DependentCount == %d, want %d", got, test.want)
+			}
+			if got := s.ArtifactCount.Get(); got != 3 {
+				t.Fatalf("ArtifactCount == %d, want 3", got)
+			}
+			if got := s.RecentReleaseCount.Get(); got != 6 {
+				t.Fatalf("RecentReleaseCount == %d, want 6", got)
+			}
+			if got := s.DaysSinceLastRelease.Get(); got != 10 {
+				t.Fatalf("DaysSinceLastRelease == %d, want 10", got)
+			}
+		})
+	}
+}
+
+func TestMavenAggregateInto_NoArtifacts(t *testing.T) {
+	c := &mavenCollector{aggregation: aggregate.Sum}
+	var s mavenSet
+	c.aggregateInto(&s, "example", []packageDependents{{System: "NPM", Name: "example", DependentCount: 5}}, nil, time.Now())
+	if s.DependentCount.IsSet() || s.ArtifactCount.IsSet() || s.RecentReleaseCount.IsSet() || s.DaysSinceLastRelease.IsSet() {
+		t.Fatal("signals are set, want unset")
+	}
+}
@@ -0,0 +1,67 @@
+package depsdev
+
+import (
+	"context"
+	"math"
+	"path"
+
+	"github.com/ossf/criticality_score/cmd/collect_signals/aggregate"
+	"github.com/ossf/criticality_score/cmd/collect_signals/projectrepo"
+	"github.com/ossf/criticality_score/cmd/collect_signals/signal"
+	log "github.com/sirupsen/logrus"
+)
+
+type pypiSet struct {
+	// DownloadCount is the number of downloads in the last 30 days.
+	DownloadCount signal.Field[int] `signal:"download_count"`
+}
+
+func (s *pypiSet) Namespace() signal.Namespace {
+	return signal.Namespace("pypi")
+}
+
+type pypiCollector struct {
+	logger      *log.Logger
+	downloads   *pypiDownloads
+	aggregation aggregate.Strategy
+}
+
+func (c *pypiCollector) EmptySet() signal.Set {
+	return &pypiSet{}
+}
+
+func (c *pypiCollector) IsSupported(r projectrepo.Repo) bool {
+	_, t := parseRepoURL(r.URL())
+	return t != ""
+}
+
+func (c *pypiCollector) Collect(ctx context.Context, r projectrepo.Repo) (signal.Set, error) {
+	var s pypiSet
+	n, t := parseRepoURL(r.URL())
+	if t == "" {
+		return &s, nil
+	}
+	c.logger.WithField("url", r.URL().String()).Debug("Fetching PyPI download count")
+	pkgs, err := c.downloads.Count(ctx, n, t)
+	if err != nil {
+		return nil, err
+	}
+	c.aggregateInto(&s, path.Base(n), pkgs)
+	return &s, nil
+}
+
+// aggregateInto sets the download count in s from the download counts of
+// pkgs, which all map to the repository named repoName.
+func (c *pypiCollector) aggregateInto(s *pypiSet, repoName string, pkgs []packageDownloads) {
+	var values []aggregate.Package
+	for _, p := range pkgs {
+		values = append(values, aggregate.Package{
+			Name:    p.Name,
+			Value:   float64(p.DownloadCount),
+			Primary: isPrimaryPackage(repoName, p.Name),
+		})
+	}
+	if downloads, ok := c.aggregation.Apply(values); ok {
+		s.DownloadCount.Set(int(math.Round(downloads)))
+	}
+}
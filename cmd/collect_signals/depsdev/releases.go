@@ -0,0 +1,95 @@
+package depsdev
+
+import (
+	"context"
+	"errors"
+
+	"cloud.google.com/go/bigquery"
+	"google.golang.org/api/iterator"
+)
+
+const mavenReleasesTableName = "maven_package_releases"
+
+// mavenDataQuery counts the releases in the year before the snapshot, and
+// finds the time of the latest release, for each Maven artifact that maps to
+// a project in deps.dev.
+const mavenDataQuery = `
+CREATE TABLE ` + "`{{.ProjectID}}.{{.DatasetName}}.{{.TableName}}`" + `
+AS
+WITH pvp AS (
+    SELECT DISTINCT Name, ProjectName, ProjectType
+    FROM ` + "`bigquery-public-data.deps_dev_v1.PackageVersionToProject`" + `
+    WHERE SnapshotAt = @part AND System = 'MAVEN'
+),
+releases AS (
+    SELECT Name, COUNTIF(UpstreamPublishedAt >= TIMESTAMP_SUB(@part, INTERVAL 365 DAY)) AS RecentReleaseCount, MAX(UpstreamPublishedAt) AS LastReleasedAt
+    FROM ` + "`bigquery-public-data.deps_dev_v1.PackageVersions`" + `
+    WHERE SnapshotAt = @part AND System = 'MAVEN'
+    GROUP BY Name
+)
+SELECT pvp.ProjectName AS ProjectName, pvp.ProjectType AS ProjectType, pvp.Name AS Name, r.RecentReleaseCount AS RecentReleaseCount, r.LastReleasedAt AS LastReleasedAt
+FROM pvp
+JOIN releases AS r
+     ON (pvp.Name = r.Name);
+`
+
+const mavenCountQuery = `
+SELECT Name, RecentReleaseCount, LastReleasedAt
+FROM ` + "`{{.ProjectID}}.{{.DatasetName}}.{{.TableName}}`" + `
+WHERE ProjectName = @projectname AND ProjectType = @projecttype;
+`
+
+// packageReleases holds the recent releases of a single package.
+type packageReleases struct {
+	Name               string
+	RecentReleaseCount int
+	LastReleasedAt     bigquery.NullTimestamp
+}
+
+// mavenReleases is used to query the releases of Maven artifacts that map to
+// a project.
+type mavenReleases struct {
+	d          *dependents
+	countQuery string
+}
+
+// newMavenReleases returns a new mavenReleases instance, ensuring the release
+// data exists in the same dataset as the dependent count data in d.
+//
+// The data is recreated using the same update strategy as d.
+func newMavenReleases(ctx context.Context, d *dependents) (*mavenReleases, error) {
+	if err := d.ensureTable(ctx, mavenReleasesTableName, mavenDataQuery); err != nil {
+		return nil, err
+	}
+	return &mavenReleases{
+		d:          d,
+		countQuery: d.generateQuery(mavenCountQuery, mavenReleasesTableName),
+	}, nil
+}
+
+// Get returns the recent releases for each of the Maven artifacts that map to
+// the project.
+//
+// If no artifacts map to the project an empty slice is returned.
+func (m *mavenReleases) Get(ctx context.Context, projectName, projectType string) ([]packageReleases, error) {
+	params := map[string]any{
+		"projectname": projectName,
+		"projecttype": projectType,
+	}
+	it, err := m.d.b.Query(ctx, m.countQuery, params)
+	if err != nil {
+		return nil, err
+	}
+	var pkgs []packageReleases
+	for {
+		var rec packageReleases
+		err := it.Next(&rec)
+		if errors.Is(err, iterator.Done) {
+			return pkgs, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		pkgs = append(pkgs, rec)
+	}
+}
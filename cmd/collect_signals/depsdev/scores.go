@@ -0,0 +1,260 @@
+package depsdev
+
+import (
+	"context"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"google.golang.org/api/iterator"
+)
+
+const projectDependentsTableName = "project_direct_dependents"
+
+// scoreURLColumn is the column holding the repository url in the output of a
+// previous run.
+const scoreURLColumn = "repo.url"
+
+// projectDependentsDataQuery lists the projects whose packages directly
+// depend on the packages of each project, using the latest version of each
+// dependent package.
+//
+// Projects that only depend on their own packages are excluded.
+const projectDependentsDataQuery = `
+CREATE TABLE ` + "`{{.ProjectID}}.{{.DatasetName}}.{{.TableName}}`" + `
+AS
+WITH pvp AS (
+    SELECT System, Name, Version, ProjectName, ProjectType
+    FROM ` + "`bigquery-public-data.deps_dev_v1.PackageVersionToProject`" + `
+    WHERE SnapshotAt = @part
+), lv AS (
+    SELECT System, Name, Version, ROW_NUMBER() OVER (PARTITION BY System, Name ORDER BY VersionInfo.Ordinal Desc) AS RowNumber
+    FROM ` + "`bigquery-public-data.deps_dev_v1.PackageVersions`" + `
+    WHERE SnapshotAt = @part
+)
+SELECT DISTINCT dep.ProjectName AS ProjectName, dep.ProjectType AS ProjectType, dependent.ProjectName AS DependentProjectName, dependent.ProjectType AS DependentProjectType
+FROM ` + "`bigquery-public-data.deps_dev_v1.Dependencies`" + ` AS d
+JOIN lv ON (lv.RowNumber = 1 AND lv.System = d.System AND lv.Name = d.Name AND lv.Version = d.Version)
+JOIN pvp AS dependent ON (dependent.System = d.System AND dependent.Name = d.Name AND dependent.Version = d.Version)
+JOIN pvp AS dep ON (dep.System = d.Dependency.System AND dep.Name = d.Dependency.Name AND dep.Version = d.Dependency.Version)
+WHERE d.SnapshotAt = @part AND d.MinimumDepth = 1
+  AND (dep.ProjectName != dependent.ProjectName OR dep.ProjectType != dependent.ProjectType);
+`
+
+const projectDependentsQuery = `
+SELECT DependentProjectName AS Name, DependentProjectType AS Type
+FROM ` + "`{{.ProjectID}}.{{.DatasetName}}.{{.TableName}}`" + `
+WHERE ProjectName = @projectname AND ProjectType = @projecttype;
+`
+
+// scoresTableQuery reads the score of each repository from a table holding
+// the output of a previous run. The column holding the score and the table
+// are substituted in by scoresTableSQL.
+const scoresTableQuery = "SELECT CAST(repo_url AS STRING) AS URL, CAST(`%[1]s` AS FLOAT64) AS Score FROM `%[2]s` WHERE `%[1]s` IS NOT NULL"
+
+var (
+	scoreColumnPattern = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	scoreTablePattern  = regexp.MustCompile(`^[A-Za-z0-9_:-]+(\.[A-Za-z0-9_-]+){1,2}$`)
+)
+
+// projectKey identifies a project on deps.dev.
+//
+// Name is stored in lowercase, as the case of a project name may differ
+// between deps.dev and the output of a previous run.
+type projectKey struct {
+	Name string
+	Type string
+}
+
+func newProjectKey(projectName, projectType string) projectKey {
+	return projectKey{Name: strings.ToLower(projectName), Type: projectType}
+}
+
+// previousScores maps a project to its criticality score from a previous
+// run.
+type previousScores map[projectKey]float64
+
+// add records the score for the repository at the url raw.
+//
+// Repositories not supported by deps.dev are ignored.
+func (s previousScores) add(raw string, score float64) {
+	raw = strings.TrimSpace(raw)
+	if !strings.Contains(raw, "://") {
+		raw = "https://" + raw
+	}
+	u, err := url.Parse(raw)
+	if err != nil {
+		return
+	}
+	n, t := parseRepoURL(u)
+	if t == "" {
+		return
+	}
+	s[newProjectKey(n, t)] = score
+}
+
+// parseScores reads the score of each repository from r, which must be a CSV
+// file with a header row produced by a previous run of the scorer.
+//
+// The score is read from the column named column. Rows with an empty score
+// are ignored.
+func parseScores(r io.Reader, column string) (previousScores, error) {
+	cr := csv.NewReader(r)
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("scores file is empty")
+	} else if err != nil {
+		return nil, err
+	}
+	urlIdx, scoreIdx := -1, -1
+	for i, h := range header {
+		switch h {
+		case scoreURLColumn:
+			urlIdx = i
+		case column:
+			scoreIdx = i
+		}
+	}
+	if urlIdx == -1 {
+		return nil, fmt.Errorf("scores file is missing the %s column", scoreURLColumn)
+	}
+	if scoreIdx == -1 {
+		return nil, fmt.Errorf("scores file is missing the %s column", column)
+	}
+	scores := make(previousScores)
+	for {
+		row, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return scores, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if row[scoreIdx] == "" {
+			continue
+		}
+		score, err := strconv.ParseFloat(row[scoreIdx], 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid score for %s: %w", row[urlIdx], err)
+		}
+		scores.add(row[urlIdx], score)
+	}
+}
+
+// loadScoresFile reads the score of each repository from the CSV file named
+// filename.
+func loadScoresFile(filename, column string) (previousScores, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return parseScores(f, column)
+}
+
+// scoresTableSQL returns the query used to read the scores from table.
+//
+// The column and table are validated first, as they cannot be passed to
+// BigQuery as query parameters.
+func scoresTableSQL(table, column string) (string, error) {
+	if !scoreTablePattern.MatchString(table) {
+		return "", fmt.Errorf("invalid scores table: %q", table)
+	}
+	if !scoreColumnPattern.MatchString(column) {
+		return "", fmt.Errorf("invalid score column: %q", column)
+	}
+	return fmt.Sprintf(scoresTableQuery, column, table), nil
+}
+
+type scoreRow struct {
+	URL   string
+	Score float64
+}
+
+// loadScoresTable reads the score of each repository from the BigQuery table
+// in the form "project.dataset.table".
+//
+// The table must have a repo_url column, which is how the "repo.url" column
+// is named when the CSV output is loaded into BigQuery.
+func loadScoresTable(ctx context.Context, b bqAPI, table, column string) (previousScores, error) {
+	q, err := scoresTableSQL(table, column)
+	if err != nil {
+		return nil, err
+	}
+	it, err := b.Query(ctx, q, nil)
+	if err != nil {
+		return nil, err
+	}
+	scores := make(previousScores)
+	for {
+		var rec scoreRow
+		err := it.Next(&rec)
+		if errors.Is(err, iterator.Done) {
+			return scores, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		scores.add(rec.URL, rec.Score)
+	}
+}
+
+// projectDependents is used to query the projects that directly depend on a
+// project.
+type projectDependents struct {
+	d         *dependents
+	listQuery string
+}
+
+// newProjectDependents returns a new projectDependents instance, ensuring the
+// project dependents data exists in the same dataset as the dependent count
+// data in d.
+//
+// The data is recreated using the same update strategy as d.
+func newProjectDependents(ctx context.Context, d *dependents) (*projectDependents, error) {
+	if err := d.ensureTable(ctx, projectDependentsTableName, projectDependentsDataQuery); err != nil {
+		return nil, err
+	}
+	return &projectDependents{
+		d:         d,
+		listQuery: d.generateQuery(projectDependentsQuery, projectDependentsTableName),
+	}, nil
+}
+
+type dependentProject struct {
+	Name string
+	Type string
+}
+
+// List returns the projects with packages that directly depend on the
+// packages of the project.
+//
+// If no projects depend on the project an empty slice is returned.
+func (p *projectDependents) List(ctx context.Context, projectName, projectType string) ([]dependentProject, error) {
+	params := map[string]any{
+		"projectname": projectName,
+		"projecttype": projectType,
+	}
+	it, err := p.d.b.Query(ctx, p.listQuery, params)
+	if err != nil {
+		return nil, err
+	}
+	var projects []dependentProject
+	for {
+		var rec dependentProject
+		err := it.Next(&rec)
+		if errors.Is(err, iterator.Done) {
+			return projects, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		projects = append(projects, rec)
+	}
+}
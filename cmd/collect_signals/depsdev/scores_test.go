@@ -0,0 +1,90 @@
+package depsdev
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/ossf/criticality_score/internal/collectortest"
+)
+
+const previousRun = `repo.url,legacy.stars,default_score
+https://github.com/Example/Core,10,0.75
+https://gitlab.com/example/tool,5,0.25
+https://example.org/other,1,0.5
+https://github.com/example/unscored,1,
+`
+
+func TestParseScores(t *testing.T) {
+	scores, err := parseScores(strings.NewReader(previousRun), "default_score")
+	if err != nil {
+		t.Fatalf("parseScores() errored %v, want no error", err)
+	}
+	if got := len(scores); got != 2 {
+		t.Fatalf("len(scores) == %d, want 2", got)
+	}
+	if got := scores[newProjectKey("example/core", "GITHUB")]; got != 0.75 {
+		t.Fatalf("scores[example/core] == %v, want 0.75", got)
+	}
+	if got := scores[newProjectKey("example/tool", "GITLAB")]; got != 0.25 {
+		t.Fatalf("scores[example/tool] == %v, want 0.25", got)
+	}
+}
+
+func TestParseScores_MissingColumn(t *testing.T) {
+	if _, err := parseScores(strings.NewReader(previousRun), "pike_score"); err == nil {
+		t.Fatal("parseScores() returned no error, want an error")
+	}
+}
+
+func TestParseScores_InvalidScore(t *testing.T) {
+	in := "repo.url,default_score\nhttps://github.com/example/core,high\n"
+	if _, err := parseScores(strings.NewReader(in), "default_score"); err == nil {
+		t.Fatal("parseScores() returned no error, want an error")
+	}
+}
+
+func TestScoresTableSQL(t *testing.T) {
+	for _, test := range []struct {
+		table, column string
+		wantErr       bool
+	}{
+		{"project.dataset.table", "default_score", false},
+		{"dataset.table", "default_score", false},
+		{"project.dataset.table`; DROP TABLE x; --", "default_score", true},
+		{"project.dataset.table", "score` FROM x; --", true},
+		{"table", "default_score", true},
+	} {
+		_, err := scoresTableSQL(test.table, test.column)
+		if gotErr := err != nil; gotErr != test.wantErr {
+			t.Fatalf("scoresTableSQL(%q, %q) errored %v, want error %v", test.table, test.column, err, test.wantErr)
+		}
+	}
+}
+
+func TestLoadScoresTable(t *testing.T) {
+	b := &fakeBQ{
+		rows: []any{
+			scoreRow{URL: "https://github.com/example/core", Score: 0.75},
+			scoreRow{URL: "github.com/example/other", Score: 0.5},
+		},
+	}
+	scores, err := loadScoresTable(context.Background(), b, "project.dataset.table", "default_score")
+	if err != nil {
+		t.Fatalf("loadScoresTable() errored %v, want no error", err)
+	}
+	if got := scores[newProjectKey("example/other", "GITHUB")]; got != 0.5 {
+		t.Fatalf("scores[example/other] == %v, want 0.5", got)
+	}
+}
+
+func TestNewProjectDependents_CreatesTable(t *testing.T) {
+	b := &fakeBQ{hasDataset: true}
+	d := &dependents{b: b, logger: collectortest.Logger().WithField("test", true), dataset: &Dataset{}, strategy: UpdateNever}
+	if _, err := newProjectDependents(context.Background(), d); err != nil {
+		t.Fatalf("newProjectDependents() errored %v, want no error", err)
+	}
+	if !b.tableCreated {
+		t.Fatal("table was not created, want it created")
+	}
+}
@@ -0,0 +1,99 @@
+package depsdev
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+var ErrInvalidUpdateStrategy = errors.New("invalid update strategy")
+
+// UpdateStrategy determines when the dependent count data stored in BigQuery
+// is recreated.
+type UpdateStrategy int
+
+const (
+	// UpdateNever will always reuse the existing data once it has been created.
+	UpdateNever UpdateStrategy = iota
+
+	// UpdateAlways will recreate the data every time the collector is created.
+	UpdateAlways
+
+	// UpdateStale will recreate the data when a newer deps.dev snapshot is
+	// available than the one the data was created from.
+	UpdateStale
+
+	// UpdateWeekly will recreate the data once it is more than 7 days old.
+	UpdateWeekly
+
+	// UpdateMonthly will recreate the data once it is more than 30 days old.
+	UpdateMonthly
+)
+
+const (
+	weeklyUpdateAge  = 7 * 24 * time.Hour
+	monthlyUpdateAge = 30 * 24 * time.Hour
+)
+
+// String implements the fmt.Stringer interface.
+func (s UpdateStrategy) String() string {
+	text, err := s.MarshalText()
+	if err != nil {
+		return ""
+	}
+	return string(text)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (s UpdateStrategy) MarshalText() ([]byte, error) {
+	switch s {
+	case UpdateNever:
+		return []byte("never"), nil
+	case UpdateAlways:
+		return []byte("always"), nil
+	case UpdateStale:
+		return []byte("stale"), nil
+	case UpdateWeekly:
+		return []byte("weekly"), nil
+	case UpdateMonthly:
+		return []byte("monthly"), nil
+	default:
+		return []byte{}, fmt.Errorf("%w: %d", ErrInvalidUpdateStrategy, s)
+	}
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (s *UpdateStrategy) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "never":
+		*s = UpdateNever
+	case "always":
+		*s = UpdateAlways
+	case "stale":
+		*s = UpdateStale
+	case "weekly":
+		*s = UpdateWeekly
+	case "monthly":
+		*s = UpdateMonthly
+	default:
+		return fmt.Errorf("%w: %q", ErrInvalidUpdateStrategy, string(text))
+	}
+	return nil
+}
+
+// needsUpdate returns true if data created at createdAt should be recreated,
+// given the latest deps.dev snapshot time and the current time.
+func (s UpdateStrategy) needsUpdate(createdAt, snapshotTime, now time.Time) bool {
+	switch s {
+	case UpdateAlways:
+		return true
+	case UpdateStale:
+		return createdAt.Before(snapshotTime)
+	case UpdateWeekly:
+		return now.Sub(createdAt) > weeklyUpdateAge
+	case UpdateMonthly:
+		return now.Sub(createdAt) > monthlyUpdateAge
+	default:
+		return false
+	}
+}
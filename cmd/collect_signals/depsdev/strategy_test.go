@@ -0,0 +1,58 @@
+package depsdev
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestUpdateStrategyText(t *testing.T) {
+	for _, s := range []UpdateStrategy{UpdateNever, UpdateAlways, UpdateStale, UpdateWeekly, UpdateMonthly} {
+		text, err := s.MarshalText()
+		if err != nil {
+			t.Fatalf("MarshalText() errored %v, want no error", err)
+		}
+		var got UpdateStrategy
+		if err := got.UnmarshalText(text); err != nil {
+			t.Fatalf("UnmarshalText(%q) errored %v, want no error", text, err)
+		}
+		if got != s {
+			t.Fatalf("UnmarshalText(%q) == %v, want %v", text, got, s)
+		}
+	}
+}
+
+func TestUpdateStrategyUnmarshalText_Invalid(t *testing.T) {
+	var s UpdateStrategy
+	err := s.UnmarshalText([]byte("daily"))
+	if !errors.Is(err, ErrInvalidUpdateStrategy) {
+		t.Fatalf("UnmarshalText() errored %v, want %v", err, ErrInvalidUpdateStrategy)
+	}
+}
+
+func TestUpdateStrategyNeedsUpdate(t *testing.T) {
+	now := time.Date(2022, 6, 15, 0, 0, 0, 0, time.UTC)
+	snapshot := now.Add(-48 * time.Hour)
+	tests := []struct {
+		name      string
+		strategy  UpdateStrategy
+		createdAt time.Time
+		want      bool
+	}{
+		{"never", UpdateNever, now.AddDate(-1, 0, 0), false},
+		{"always", UpdateAlways, now, true},
+		{"stale/old snapshot", UpdateStale, snapshot.Add(-time.Hour), true},
+		{"stale/latest snapshot", UpdateStale, snapshot.Add(time.Hour), false},
+		{"weekly/old", UpdateWeekly, now.AddDate(0, 0, -8), true},
+		{"weekly/recent", UpdateWeekly, now.AddDate(0, 0, -6), false},
+		{"monthly/old", UpdateMonthly, now.AddDate(0, 0, -31), true},
+		{"monthly/recent", UpdateMonthly, now.AddDate(0, 0, -8), false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := test.strategy.needsUpdate(test.createdAt, snapshot, now); got != test.want {
+				t.Fatalf("needsUpdate() == %v, want %v", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+// Package dockerhub provides a Collector that returns a Set for the number of
+// pulls and stars of the Docker Hub images built from a repository.
+//
+// The images are read from a mapping file if the repository is listed in it.
+// Otherwise the image with the same owner and name as the repository is used.
+// Official images, such as "library/redis", are only found using the mapping
+// file.
+package dockerhub
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ossf/criticality_score/cmd/collect_signals/projectrepo"
+	"github.com/ossf/criticality_score/cmd/collect_signals/signal"
+	"github.com/ossf/criticality_score/internal/httpjson"
+	"github.com/ossf/criticality_score/internal/repomap"
+	log "github.com/sirupsen/logrus"
+)
+
+// DefaultAPIURL is the base URL of the Docker Hub API.
+const DefaultAPIURL = "https://hub.docker.com/v2"
+
+type dockerHubSet struct {
+	ImageCount signal.Field[int]
+	PullCount  signal.Field[int]
+	StarCount  signal.Field[int]
+}
+
+func (s *dockerHubSet) Namespace() signal.Namespace {
+	return signal.Namespace("dockerhub")
+}
+
+type repository struct {
+	PullCount int `json:"pull_count"`
+	StarCount int `json:"star_count"`
+}
+
+type Collector struct {
+	client *http.Client
+	logger *log.Logger
+	apiURL string
+	images repomap.Map
+}
+
+// NewCollector returns a new Collector that uses the http.Client c to query
+// Docker Hub.
+//
+// images maps repositories to the names of their images, such as
+// "library/redis", and may be nil.
+func NewCollector(c *http.Client, images repomap.Map, logger *log.Logger) *Collector {
+	return &Collector{
+		client: c,
+		logger: logger,
+		apiURL: DefaultAPIURL,
+		images: images,
+	}
+}
+
+func (c *Collector) EmptySet() signal.Set {
+	return &dockerHubSet{}
+}
+
+func (c *Collector) IsSupported(r projectrepo.Repo) bool {
+	return true
+}
+
+// Collect implements the collector.Collector interface.
+//
+// If none of the images exist on Docker Hub the signals are left unset.
+func (c *Collector) Collect(ctx context.Context, r projectrepo.Repo) (signal.Set, error) {
+	s := &dockerHubSet{}
+	images, ok := c.images.Lookup(r.URL())
+	if !ok {
+		images = []string{guessImage(r.URL().Path)}
+	}
+	found := 0
+	pulls := 0
+	stars := 0
+	for _, image := range images {
+		c.logger.WithFields(log.Fields{
+			"url":   r.URL().String(),
+			"image": image,
+		}).Debug("Fetching Docker Hub image")
+		repo, err := c.queryRepository(ctx, image)
+		if err != nil {
+			return nil, err
+		}
+		if repo == nil {
+			continue
+		}
+		found++
+		pulls += repo.PullCount
+		stars += repo.StarCount
+	}
+	if found == 0 {
+		return s, nil
+	}
+	s.ImageCount.Set(found)
+	s.PullCount.Set(pulls)
+	s.StarCount.Set(stars)
+	return s, nil
+}
+
+// guessImage returns the name of the Docker Hub image with the same owner and
+// name as the repository with the path p. Image names are lowercase.
+func guessImage(p string) string {
+	return strings.ToLower(strings.TrimSuffix(strings.Trim(p, "/"), ".git"))
+}
+
+// queryRepository returns the Docker Hub repository for the image.
+//
+// If the image does not exist, or is not a valid name, nil is returned.
+func (c *Collector) queryRepository(ctx context.Context, image string) (*repository, error) {
+	namespace, name, ok := strings.Cut(image, "/")
+	if !ok || namespace == "" || name == "" || strings.Contains(name, "/") {
+		return nil, nil
+	}
+	repo := &repository{}
+	u := c.apiURL + "/repositories/" + url.PathEscape(namespace) + "/" + url.PathEscape(name) + "/"
+	_, err := httpjson.Get(ctx, c.client, u, repo)
+	if httpjson.StatusCode(err) == http.StatusNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return repo, nil
+}
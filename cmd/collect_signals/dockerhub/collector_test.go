@@ -0,0 +1,55 @@
+package dockerhub
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/ossf/criticality_score/internal/collectortest"
+	"github.com/ossf/criticality_score/internal/repomap"
+)
+
+// repositories holds the responses of a fake Docker Hub API server, keyed by
+// the request path.
+var repositories = map[string]string{
+	"/repositories/example/proxy/": `{"name": "proxy", "pull_count": 5000, "star_count": 12}`,
+	"/repositories/library/proxy/": `{"name": "proxy", "pull_count": 1000000, "star_count": 900}`,
+}
+
+func newTestCollector(t *testing.T, images repomap.Map) *Collector {
+	t.Helper()
+	s := collectortest.NewServer(t, collectortest.PathHandler(repositories))
+	c := NewCollector(&http.Client{}, images, collectortest.Logger())
+	c.apiURL = s.URL
+	return c
+}
+
+func TestCollect_GuessedImage(t *testing.T) {
+	s := collectortest.Collect[*dockerHubSet](t, newTestCollector(t, nil), "https://github.com/Example/Proxy.git")
+	if got := s.ImageCount.Get(); got != 1 {
+		t.Fatalf("ImageCount == %d, want 1", got)
+	}
+	if got := s.PullCount.Get(); got != 5000 {
+		t.Fatalf("PullCount == %d, want 5000", got)
+	}
+	if got := s.StarCount.Get(); got != 12 {
+		t.Fatalf("StarCount == %d, want 12", got)
+	}
+}
+
+func TestCollect_MappedImages(t *testing.T) {
+	images := repomap.Map{"github.com/example/proxy": {"library/proxy", "example/proxy", "example/missing", "invalid"}}
+	s := collectortest.Collect[*dockerHubSet](t, newTestCollector(t, images), "https://github.com/example/proxy")
+	if got := s.ImageCount.Get(); got != 2 {
+		t.Fatalf("ImageCount == %d, want 2", got)
+	}
+	if got := s.PullCount.Get(); got != 1005000 {
+		t.Fatalf("PullCount == %d, want 1005000", got)
+	}
+}
+
+func TestCollect_NoImage(t *testing.T) {
+	s := collectortest.Collect[*dockerHubSet](t, newTestCollector(t, nil), "https://github.com/example/json")
+	if s.PullCount.IsSet() {
+		t.Fatal("PullCount is set, want unset")
+	}
+}
@@ -0,0 +1,21 @@
+package main
+
+import "sync/atomic"
+
+// countingWriter discards everything written to it, keeping a count of the
+// number of bytes written. It is used in place of the output file for a dry
+// run.
+type countingWriter struct {
+	n int64
+}
+
+// Write implements the io.Writer interface.
+func (w *countingWriter) Write(p []byte) (int, error) {
+	atomic.AddInt64(&w.n, int64(len(p)))
+	return len(p), nil
+}
+
+// Count returns the number of bytes written so far.
+func (w *countingWriter) Count() int64 {
+	return atomic.LoadInt64(&w.n)
+}
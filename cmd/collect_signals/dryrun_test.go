@@ -0,0 +1,15 @@
+package main
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestCountingWriter(t *testing.T) {
+	w := &countingWriter{}
+	fmt.Fprint(w, "hello")
+	fmt.Fprint(w, ", world")
+	if got := w.Count(); got != 12 {
+		t.Fatalf("Count() == %d, want 12", got)
+	}
+}
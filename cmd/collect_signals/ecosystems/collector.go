@@ -0,0 +1,148 @@
+// Package ecosystems provides a Collector that returns a Set for the usage of
+// the packages published from a repository, as reported by ecosyste.ms.
+//
+// ecosyste.ms indexes packages from most package registries, and supports
+// looking up packages by repository URL. It does not require any
+// credentials, so it can be used in place of the BigQuery based deps.dev
+// collector.
+package ecosystems
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	"github.com/ossf/criticality_score/cmd/collect_signals/projectrepo"
+	"github.com/ossf/criticality_score/cmd/collect_signals/signal"
+	"github.com/ossf/criticality_score/internal/httpjson"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultAPIURL is the base URL of the ecosyste.ms packages API.
+	DefaultAPIURL = "https://packages.ecosyste.ms/api/v1"
+
+	// userAgent identifies requests to ecosyste.ms, which gives identified
+	// clients a higher rate limit.
+	userAgent = "criticality_score (https://github.com/ossf/criticality_score)"
+)
+
+type ecosystemsSet struct {
+	PackageCount signal.Field[int]
+
+	// DependentPackagesCount is the total number of packages that depend on
+	// any of the packages.
+	DependentPackagesCount signal.Field[int]
+
+	// DependentReposCount is the highest number of repositories that depend
+	// on any one of the packages. The highest is used as repositories often
+	// depend on several packages published from the same repository.
+	DependentReposCount signal.Field[int]
+
+	// Downloads is the total number of downloads of the packages in the
+	// period reported by each registry, usually the last month.
+	Downloads signal.Field[int]
+
+	// Rank is the best average ranking percentile of the packages within
+	// their ecosystems. Lower is better.
+	Rank signal.Field[float64]
+}
+
+func (s *ecosystemsSet) Namespace() signal.Namespace {
+	return signal.Namespace("ecosystems")
+}
+
+type rankings struct {
+	Average *float64 `json:"average"`
+}
+
+type ecosystemsPackage struct {
+	Name                   string   `json:"name"`
+	Ecosystem              string   `json:"ecosystem"`
+	Downloads              int      `json:"downloads"`
+	DependentPackagesCount int      `json:"dependent_packages_count"`
+	DependentReposCount    int      `json:"dependent_repos_count"`
+	Rankings               rankings `json:"rankings"`
+}
+
+type Collector struct {
+	client *http.Client
+	logger *log.Logger
+	apiURL string
+}
+
+// NewCollector returns a new Collector that uses the http.Client c to query
+// ecosyste.ms.
+func NewCollector(c *http.Client, logger *log.Logger) *Collector {
+	return &Collector{
+		client: c,
+		logger: logger,
+		apiURL: DefaultAPIURL,
+	}
+}
+
+func (c *Collector) EmptySet() signal.Set {
+	return &ecosystemsSet{}
+}
+
+func (c *Collector) IsSupported(r projectrepo.Repo) bool {
+	return true
+}
+
+// Collect implements the collector.Collector interface.
+//
+// If ecosyste.ms does not know of any packages published from the repository
+// the signals are left unset.
+func (c *Collector) Collect(ctx context.Context, r projectrepo.Repo) (signal.Set, error) {
+	s := &ecosystemsSet{}
+	c.logger.WithField("url", r.URL().String()).Debug("Fetching ecosyste.ms packages")
+	pkgs, err := c.queryPackages(ctx, r.URL())
+	if err != nil {
+		return nil, err
+	}
+	if len(pkgs) == 0 {
+		return s, nil
+	}
+	dependentPkgs := 0
+	dependentRepos := 0
+	downloads := 0
+	var rank *float64
+	for _, p := range pkgs {
+		dependentPkgs += p.DependentPackagesCount
+		downloads += p.Downloads
+		if p.DependentReposCount > dependentRepos {
+			dependentRepos = p.DependentReposCount
+		}
+		if avg := p.Rankings.Average; avg != nil && (rank == nil || *avg < *rank) {
+			rank = avg
+		}
+	}
+	s.PackageCount.Set(len(pkgs))
+	s.DependentPackagesCount.Set(dependentPkgs)
+	s.DependentReposCount.Set(dependentRepos)
+	s.Downloads.Set(downloads)
+	if rank != nil {
+		s.Rank.Set(*rank)
+	}
+	return s, nil
+}
+
+// queryPackages returns the packages published from the repository at u.
+func (c *Collector) queryPackages(ctx context.Context, u *url.URL) ([]ecosystemsPackage, error) {
+	query := url.Values{"repository_url": {u.String()}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.apiURL+"/packages/lookup?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	var pkgs []ecosystemsPackage
+	_, err = httpjson.Do(c.client, req, &pkgs)
+	if httpjson.StatusCode(err) == http.StatusNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return pkgs, nil
+}
@@ -0,0 +1,80 @@
+package ecosystems
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/ossf/criticality_score/internal/collectortest"
+)
+
+// testRepoURL is the repository the signals are collected for.
+const testRepoURL = "https://github.com/example/json"
+
+// newTestCollector returns a Collector where lookups for
+// https://github.com/example/json are answered with body.
+func newTestCollector(t *testing.T, body string) *Collector {
+	t.Helper()
+	s := collectortest.NewServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/packages/lookup" || r.URL.Query().Get("repository_url") != "https://github.com/example/json" {
+			http.NotFound(w, r)
+			return
+		}
+		if r.Header.Get("User-Agent") != userAgent {
+			http.Error(w, "missing user agent", http.StatusForbidden)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	}))
+	c := NewCollector(&http.Client{}, collectortest.Logger())
+	c.apiURL = s.URL
+	return c
+}
+
+func TestCollect(t *testing.T) {
+	c := newTestCollector(t, `[
+		{"name": "example-json", "ecosystem": "npm", "downloads": 5000, "dependent_packages_count": 40,
+		 "dependent_repos_count": 900, "rankings": {"average": 1.5}},
+		{"name": "example-json", "ecosystem": "pypi", "downloads": 700, "dependent_packages_count": 10,
+		 "dependent_repos_count": 100, "rankings": {"average": 0.75}},
+		{"name": "example-json-extras", "ecosystem": "npm", "downloads": 3, "rankings": {}}
+	]`)
+	s := collectortest.Collect[*ecosystemsSet](t, c, testRepoURL)
+	if got := s.PackageCount.Get(); got != 3 {
+		t.Fatalf("PackageCount == %d, want 3", got)
+	}
+	if got := s.DependentPackagesCount.Get(); got != 50 {
+		t.Fatalf("DependentPackagesCount == %d, want 50", got)
+	}
+	if got := s.DependentReposCount.Get(); got != 900 {
+		t.Fatalf("DependentReposCount == %d, want 900", got)
+	}
+	if got := s.Downloads.Get(); got != 5703 {
+		t.Fatalf("Downloads == %d, want 5703", got)
+	}
+	if got := s.Rank.Get(); got != 0.75 {
+		t.Fatalf("Rank == %v, want 0.75", got)
+	}
+}
+
+func TestCollect_NoRankings(t *testing.T) {
+	c := newTestCollector(t, `[{"name": "example-json", "ecosystem": "npm", "downloads": 3}]`)
+	s := collectortest.Collect[*ecosystemsSet](t, c, testRepoURL)
+	if got := s.PackageCount.Get(); got != 1 {
+		t.Fatalf("PackageCount == %d, want 1", got)
+	}
+	if s.Rank.IsSet() {
+		t.Fatal("Rank is set, want unset")
+	}
+}
+
+func TestCollect_NoPackages(t *testing.T) {
+	c := newTestCollector(t, `[]`)
+	s := collectortest.Collect[*ecosystemsSet](t, c, testRepoURL)
+	if s.PackageCount.IsSet() {
+		t.Fatal("PackageCount is set, want unset")
+	}
+	if s.Downloads.IsSet() {
+		t.Fatal("Downloads is set, want unset")
+	}
+}
@@ -0,0 +1,43 @@
+package main
+
+import (
+	"os"
+	"sync"
+)
+
+var (
+	// exitHooksMu prevents concurrent access to exitHooks.
+	exitHooksMu sync.Mutex
+
+	// exitHooks holds the functions run by exit, in the order they were
+	// added.
+	exitHooks []func()
+)
+
+// onExit adds f to the functions run before the process exits.
+//
+// os.Exit does not run deferred calls, so onExit is used for cleanup that
+// must happen however the run ends, such as closing a connection.
+func onExit(f func()) {
+	exitHooksMu.Lock()
+	defer exitHooksMu.Unlock()
+	exitHooks = append(exitHooks, f)
+}
+
+// runExitHooks runs the functions added with onExit in the reverse order they
+// were added, like deferred calls. Each function is only run once.
+func runExitHooks() {
+	exitHooksMu.Lock()
+	hooks := exitHooks
+	exitHooks = nil
+	exitHooksMu.Unlock()
+	for i := len(hooks) - 1; i >= 0; i-- {
+		hooks[i]()
+	}
+}
+
+// exit runs the functions added with onExit and then exits with code.
+func exit(code int) {
+	runExitHooks()
+	os.Exit(code)
+}
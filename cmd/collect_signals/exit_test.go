@@ -0,0 +1,20 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestRunExitHooks(t *testing.T) {
+	var got []int
+	onExit(func() { got = append(got, 1) })
+	onExit(func() { got = append(got, 2) })
+
+	runExitHooks()
+	// Hooks are only run once.
+	runExitHooks()
+
+	if want := []int{2, 1}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("hooks ran in order %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,50 @@
+package gitclone
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ossf/criticality_score/cmd/collect_signals/github/legacy"
+	"github.com/ossf/criticality_score/cmd/collect_signals/projectrepo"
+	"github.com/ossf/criticality_score/cmd/collect_signals/signal"
+)
+
+type RepoCollector struct {
+}
+
+func (rc *RepoCollector) EmptySet() signal.Set {
+	return &signal.RepoSet{}
+}
+
+// Collect implements the collector.Collector interface.
+//
+// Only the signals that can be derived from the commit history are
+// collected. The creation time is the time of the first commit, and the
+// contributor count only includes the authors of commits in the last year.
+func (rc *RepoCollector) Collect(ctx context.Context, r projectrepo.Repo) (signal.Set, error) {
+	gr, ok := r.(*repo)
+	if !ok {
+		return nil, errors.New("project is not a cloned git repository")
+	}
+	now := time.Now()
+
+	s := &signal.RepoSet{
+		URL: signal.Val(r.URL().String()),
+	}
+	h := gr.History
+	if !h.LastCommitAt.IsZero() {
+		s.CreatedAt.Set(h.FirstCommitAt)
+		s.CreatedSince.Set(legacy.TimeDelta(now, h.FirstCommitAt, legacy.SinceDuration))
+		s.UpdatedAt.Set(h.LastCommitAt)
+		s.UpdatedSince.Set(legacy.TimeDelta(now, h.LastCommitAt, legacy.SinceDuration))
+	}
+	s.CommitFrequency.Set(legacy.Round(float64(h.RecentCommitCount)/52, 2))
+	s.ContributorCount.Set(h.RecentAuthorCount)
+	return s, nil
+}
+
+func (rc *RepoCollector) IsSupported(p projectrepo.Repo) bool {
+	_, ok := p.(*repo)
+	return ok
+}
@@ -0,0 +1,41 @@
+package gitclone
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/ossf/criticality_score/cmd/collect_signals/projectrepo"
+	log "github.com/sirupsen/logrus"
+)
+
+type factory struct {
+	logger *log.Logger
+}
+
+// NewRepoFactory returns a new projectrepo.Factory for any git repository
+// that can be cloned over https.
+//
+// The factory matches every https URL, so it must be registered after all
+// the other factories to act as a fallback for hosts that are not otherwise
+// supported (e.g. Gitea, SourceHut or cgit).
+func NewRepoFactory(logger *log.Logger) projectrepo.Factory {
+	return &factory{
+		logger: logger,
+	}
+}
+
+func (f *factory) New(ctx context.Context, u *url.URL) (projectrepo.Repo, error) {
+	p := &repo{
+		origURL: u,
+		logger:  f.logger.WithField("url", u),
+	}
+	if err := p.init(ctx); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (f *factory) Match(u *url.URL) bool {
+	return strings.EqualFold(u.Scheme, "https") && u.Host != ""
+}
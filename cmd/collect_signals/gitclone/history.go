@@ -0,0 +1,69 @@
+package gitclone
+
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing"
+	"github.com/go-git/go-git/v5/plumbing/object"
+)
+
+const commitLookback = time.Duration(365 * 24 * time.Hour)
+
+// history summarizes the commits reachable from HEAD.
+type history struct {
+	// FirstCommitAt is the time of the oldest commit.
+	FirstCommitAt time.Time
+
+	// LastCommitAt is the time of the most recent commit.
+	LastCommitAt time.Time
+
+	// RecentCommitCount is the number of commits since the lookback time.
+	RecentCommitCount int
+
+	// RecentAuthorCount is the number of unique authors of the commits
+	// counted in RecentCommitCount.
+	RecentAuthorCount int
+}
+
+// readHistory walks all the commits reachable from HEAD in r and returns a
+// summary of them. Commits authored after since are counted as recent.
+//
+// If r has no commits, an empty history is returned.
+func readHistory(r *git.Repository, since time.Time) (*history, error) {
+	h := &history{}
+	head, err := r.Head()
+	if errors.Is(err, plumbing.ErrReferenceNotFound) {
+		// HEAD does not point at a commit if the repository is empty.
+		return h, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	iter, err := r.Log(&git.LogOptions{From: head.Hash()})
+	if err != nil {
+		return nil, err
+	}
+	authors := make(map[string]struct{})
+	err = iter.ForEach(func(c *object.Commit) error {
+		when := c.Author.When
+		if h.FirstCommitAt.IsZero() || when.Before(h.FirstCommitAt) {
+			h.FirstCommitAt = when
+		}
+		if when.After(h.LastCommitAt) {
+			h.LastCommitAt = when
+		}
+		if when.After(since) {
+			h.RecentCommitCount++
+			authors[strings.ToLower(c.Author.Email)] = struct{}{}
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	h.RecentAuthorCount = len(authors)
+	return h, nil
+}
@@ -0,0 +1,106 @@
+package gitclone
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-billy/v5/memfs"
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	"github.com/go-git/go-git/v5/storage/memory"
+)
+
+type testCommit struct {
+	email string
+	when  time.Time
+}
+
+// newTestRepo returns an in-memory repository containing the given commits,
+// in order.
+func newTestRepo(t *testing.T, commits []testCommit) *git.Repository {
+	t.Helper()
+	fs := memfs.New()
+	r, err := git.Init(memory.NewStorage(), fs)
+	if err != nil {
+		t.Fatalf("Init() errored %v, want no error", err)
+	}
+	wt, err := r.Worktree()
+	if err != nil {
+		t.Fatalf("Worktree() errored %v, want no error", err)
+	}
+	for _, c := range commits {
+		f, err := fs.Create("file")
+		if err != nil {
+			t.Fatalf("Create() errored %v, want no error", err)
+		}
+		f.Write([]byte(c.when.String()))
+		f.Close()
+		if _, err := wt.Add("file"); err != nil {
+			t.Fatalf("Add() errored %v, want no error", err)
+		}
+		sig := &object.Signature{Name: c.email, Email: c.email, When: c.when}
+		if _, err := wt.Commit("commit", &git.CommitOptions{Author: sig, Committer: sig}); err != nil {
+			t.Fatalf("Commit() errored %v, want no error", err)
+		}
+	}
+	return r
+}
+
+func TestReadHistory(t *testing.T) {
+	since := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	first := time.Date(2019, 3, 1, 0, 0, 0, 0, time.UTC)
+	last := time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)
+	r := newTestRepo(t, []testCommit{
+		{email: "old@example.com", when: first},
+		{email: "a@example.com", when: time.Date(2022, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{email: "A@example.com", when: time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)},
+		{email: "b@example.com", when: last},
+	})
+	got, err := readHistory(r, since)
+	if err != nil {
+		t.Fatalf("readHistory() errored %v, want no error", err)
+	}
+	if !got.FirstCommitAt.Equal(first) {
+		t.Fatalf("FirstCommitAt == %v, want %v", got.FirstCommitAt, first)
+	}
+	if !got.LastCommitAt.Equal(last) {
+		t.Fatalf("LastCommitAt == %v, want %v", got.LastCommitAt, last)
+	}
+	if got.RecentCommitCount != 3 {
+		t.Fatalf("RecentCommitCount == %d, want 3", got.RecentCommitCount)
+	}
+	if got.RecentAuthorCount != 2 {
+		t.Fatalf("RecentAuthorCount == %d, want 2", got.RecentAuthorCount)
+	}
+}
+
+func TestReadHistory_Empty(t *testing.T) {
+	r := newTestRepo(t, nil)
+	got, err := readHistory(r, time.Now())
+	if err != nil {
+		t.Fatalf("readHistory() errored %v, want no error", err)
+	}
+	if *got != (history{}) {
+		t.Fatalf("readHistory() == %+v, want empty", got)
+	}
+}
+
+func TestCanonicalURL(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{url: "https://git.sr.ht/~sircmpwn/scdoc", want: "https://git.sr.ht/~sircmpwn/scdoc"},
+		{url: "https://codeberg.org/forgejo/forgejo.git", want: "https://codeberg.org/forgejo/forgejo"},
+		{url: "https://git.example.com/repo/", want: "https://git.example.com/repo"},
+	}
+	for _, test := range tests {
+		t.Run(test.url, func(t *testing.T) {
+			u, _ := url.Parse(test.url)
+			if got := canonicalURL(u).String(); got != test.want {
+				t.Fatalf("canonicalURL() == %q, want %q", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+package gitclone
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	log "github.com/sirupsen/logrus"
+)
+
+// repo implements the projectrepo.Repo interface for a git repository cloned
+// from its URL.
+type repo struct {
+	origURL *url.URL
+	logger  *log.Entry
+
+	History *history
+	realURL *url.URL
+}
+
+// URL implements the projectrepo.Repo interface
+func (r *repo) URL() *url.URL {
+	return r.realURL
+}
+
+func (r *repo) init(ctx context.Context) error {
+	if r.History != nil {
+		// Already finished. Don't init() more than once.
+		return nil
+	}
+	// The full history of the default branch is needed to find the first
+	// commit, so the repository cannot be cloned shallowly. It is cloned to a
+	// temporary directory rather than into memory, so that large repositories
+	// do not exhaust memory when there are many workers, and the directory
+	// is removed once the history has been read.
+	dir, err := os.MkdirTemp("", "collect_signals-clone-")
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := os.RemoveAll(dir); err != nil {
+			r.logger.WithFields(log.Fields{
+				"error": err,
+				"dir":   dir,
+			}).Warn("Failed to remove clone")
+		}
+	}()
+	r.logger.WithField("dir", dir).Debug("Cloning repository")
+	// Only the history of the default branch is needed, so the clone is bare
+	// and has no tags.
+	gr, err := git.PlainCloneContext(ctx, dir, true, &git.CloneOptions{
+		URL:          r.origURL.String(),
+		SingleBranch: true,
+		Tags:         git.NoTags,
+	})
+	if err != nil {
+		return err
+	}
+	r.logger.Debug("Reading commit history")
+	h, err := readHistory(gr, time.Now().Add(-commitLookback))
+	if err != nil {
+		return err
+	}
+	r.realURL = canonicalURL(r.origURL)
+	// Set History last as it is used to indicate init() has been called.
+	r.History = h
+	return nil
+}
+
+// canonicalURL returns u without a trailing "/" or ".git" suffix, so that the
+// URL is the same however the repository is referred to.
+func canonicalURL(u *url.URL) *url.URL {
+	c := *u
+	c.Path = strings.TrimSuffix(strings.TrimSuffix(c.Path, "/"), ".git")
+	c.RawPath = ""
+	c.RawQuery = ""
+	c.Fragment = ""
+	c.User = nil
+	return &c
+}
@@ -0,0 +1,71 @@
+package gitclone
+
+import (
+	"context"
+	"net/url"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/go-git/go-git/v5"
+	"github.com/go-git/go-git/v5/plumbing/object"
+	log "github.com/sirupsen/logrus"
+)
+
+func TestRepoInit(t *testing.T) {
+	// go-git runs git-upload-pack to clone from a local path.
+	if _, err := exec.LookPath("git-upload-pack"); err != nil {
+		t.Skip("git-upload-pack is not installed")
+	}
+	src := t.TempDir()
+	r, err := git.PlainInit(src, false)
+	if err != nil {
+		t.Fatalf("PlainInit() errored %v, want no error", err)
+	}
+	wt, err := r.Worktree()
+	if err != nil {
+		t.Fatalf("Worktree() errored %v, want no error", err)
+	}
+	first := time.Date(2019, 3, 1, 0, 0, 0, 0, time.UTC)
+	for i, when := range []time.Time{first, time.Now().Add(-time.Hour)} {
+		if err := os.WriteFile(filepath.Join(src, "file"), []byte{byte(i)}, 0o644); err != nil {
+			t.Fatalf("WriteFile() errored %v, want no error", err)
+		}
+		if _, err := wt.Add("file"); err != nil {
+			t.Fatalf("Add() errored %v, want no error", err)
+		}
+		sig := &object.Signature{Name: "a", Email: "a@example.com", When: when}
+		if _, err := wt.Commit("commit", &git.CommitOptions{Author: sig, Committer: sig}); err != nil {
+			t.Fatalf("Commit() errored %v, want no error", err)
+		}
+	}
+
+	// Clones are made in the temporary directory, so use an empty one to
+	// check the clone is removed.
+	tmp := t.TempDir()
+	t.Setenv("TMPDIR", tmp)
+
+	logger := log.New()
+	p := &repo{
+		origURL: &url.URL{Scheme: "file", Path: src},
+		logger:  log.NewEntry(logger),
+	}
+	if err := p.init(context.Background()); err != nil {
+		t.Fatalf("init() errored %v, want no error", err)
+	}
+	if !p.History.FirstCommitAt.Equal(first) {
+		t.Fatalf("FirstCommitAt == %v, want %v", p.History.FirstCommitAt, first)
+	}
+	if p.History.RecentCommitCount != 1 {
+		t.Fatalf("RecentCommitCount == %d, want 1", p.History.RecentCommitCount)
+	}
+	entries, err := os.ReadDir(tmp)
+	if err != nil {
+		t.Fatalf("ReadDir() errored %v, want no error", err)
+	}
+	if len(entries) != 0 {
+		t.Fatalf("temporary directory contains %d entries, want the clone to be removed", len(entries))
+	}
+}
@@ -0,0 +1,61 @@
+package github
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/go-github/v44/github"
+	"github.com/ossf/criticality_score/internal/githubapi"
+	"github.com/shurcooL/githubv4"
+)
+
+// blobObject is used to test whether a file exists.
+type blobObject struct {
+	Blob struct {
+		ByteSize int
+	} `graphql:"... on Blob"`
+}
+
+type actionMetadataQuery struct {
+	Repository struct {
+		ActionYML  *blobObject `graphql:"actionYml: object(expression: \"HEAD:action.yml\")"`
+		ActionYAML *blobObject `graphql:"actionYaml: object(expression: \"HEAD:action.yaml\")"`
+	} `graphql:"repository(owner: $repositoryOwner, name: $repositoryName)"`
+}
+
+// fetchIsAction returns true if the repository is a GitHub Action, which is
+// when the root of the default branch has an action.yml or action.yaml file.
+func fetchIsAction(ctx context.Context, c *githubapi.Client, owner, name string) (bool, error) {
+	s := &actionMetadataQuery{}
+	vars := map[string]any{
+		"repositoryOwner": githubv4.String(owner),
+		"repositoryName":  githubv4.String(name),
+	}
+	if err := c.GraphQL().Query(ctx, s, vars); err != nil {
+		return false, err
+	}
+	return s.Repository.ActionYML != nil || s.Repository.ActionYAML != nil, nil
+}
+
+// actionUsageQuery returns the code search query for workflow files that use
+// the action published from the repository.
+//
+// Code search ignores punctuation, so `uses: owner/name@` matches any
+// workflow file that contains the words "uses", owner and name in that
+// order. Workflow files in the repository itself are excluded.
+func actionUsageQuery(owner, name string) string {
+	return fmt.Sprintf("\"uses: %s/%s@\" path:.github/workflows -repo:%s/%s", owner, name, owner, name)
+}
+
+// fetchActionUsage returns an estimate of the number of workflow files in
+// public repositories that use the action published from the repository.
+func fetchActionUsage(ctx context.Context, c *githubapi.Client, owner, name string) (int, error) {
+	opts := &github.SearchOptions{
+		ListOptions: github.ListOptions{PerPage: 1}, // Only the total is needed.
+	}
+	res, _, err := c.Rest().Search.Code(ctx, actionUsageQuery(owner, name), opts)
+	if err != nil {
+		return 0, err
+	}
+	return res.GetTotal(), nil
+}
@@ -0,0 +1,46 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestFetchIsAction(t *testing.T) {
+	c := newTestClient(t, jsonHandler(http.StatusOK, `{"data": {"repository": {"actionYml": null, "actionYaml": {"byteSize": 512}}}}`))
+	got, err := fetchIsAction(context.Background(), c, "example", "example")
+	if err != nil {
+		t.Fatalf("fetchIsAction() errored %v, want no error", err)
+	}
+	if !got {
+		t.Fatal("fetchIsAction() == false, want true")
+	}
+}
+
+func TestFetchIsAction_NotAction(t *testing.T) {
+	c := newTestClient(t, jsonHandler(http.StatusOK, `{"data": {"repository": {"actionYml": null, "actionYaml": null}}}`))
+	got, err := fetchIsAction(context.Background(), c, "example", "example")
+	if err != nil {
+		t.Fatalf("fetchIsAction() errored %v, want no error", err)
+	}
+	if got {
+		t.Fatal("fetchIsAction() == true, want false")
+	}
+}
+
+func TestFetchActionUsage(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if got, want := r.URL.Query().Get("q"), actionUsageQuery("example", "setup-example"); got != want {
+			t.Errorf("q == %q, want %q", got, want)
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"total_count": 1234, "incomplete_results": false, "items": []}`))
+	}))
+	got, err := fetchActionUsage(context.Background(), c, "example", "setup-example")
+	if err != nil {
+		t.Fatalf("fetchActionUsage() errored %v, want no error", err)
+	}
+	if got != 1234 {
+		t.Fatalf("fetchActionUsage() == %d, want 1234", got)
+	}
+}
@@ -3,14 +3,36 @@ package github
 import (
 	"context"
 	"errors"
+	"net/http"
 	"time"
 
 	"github.com/ossf/criticality_score/cmd/collect_signals/github/legacy"
 	"github.com/ossf/criticality_score/cmd/collect_signals/projectrepo"
 	"github.com/ossf/criticality_score/cmd/collect_signals/signal"
+	"github.com/ossf/criticality_score/internal/githubapi"
 )
 
+// RepoCollector collects the signals in signal.RepoSet for GitHub
+// repositories.
+//
+// The signals that need extra, often paginated, requests for each repository
+// are only collected if they are enabled.
 type RepoCollector struct {
+	// CommitHistory enables the signals derived from a sample of the recent
+	// commits, such as RecentAuthorCount and Top1CommitShare.
+	CommitHistory bool
+
+	// FileTree enables HasCIConfig and TestFileRatio.
+	FileTree bool
+
+	// SecurityPolicy enables HasSecurityPolicy and SecurityContactPresent.
+	SecurityPolicy bool
+
+	// PullRequests enables the signals derived from recent pull requests.
+	PullRequests bool
+
+	// BranchProtection enables the protection signals of the default branch.
+	BranchProtection bool
 }
 
 func (rc *RepoCollector) EmptySet() signal.Set {
@@ -29,12 +51,19 @@ func (rc *RepoCollector) Collect(ctx context.Context, r projectrepo.Repo) (signa
 		Language:     signal.Val(ghr.BasicData.PrimaryLanguage.Name),
 		License:      signal.Val(ghr.BasicData.LicenseInfo.Name),
 		StarCount:    signal.Val(ghr.BasicData.StargazerCount),
+		ForkCount:    signal.Val(ghr.BasicData.ForkCount),
+		Archived:     signal.Val(ghr.BasicData.IsArchived),
 		CreatedAt:    signal.Val(ghr.createdAt()),
 		CreatedSince: signal.Val(legacy.TimeDelta(now, ghr.createdAt(), legacy.SinceDuration)),
 		UpdatedAt:    signal.Val(ghr.updatedAt()),
 		UpdatedSince: signal.Val(legacy.TimeDelta(now, ghr.updatedAt(), legacy.SinceDuration)),
 		// Note: the /stats/commit-activity REST endpoint used in the legacy Python codebase is stale.
 		CommitFrequency: signal.Val(legacy.Round(float64(ghr.BasicData.DefaultBranchRef.Target.Commit.RecentCommits.TotalCount)/52, 2)),
+		RepoSizeKB:      signal.Val(ghr.BasicData.DiskUsage),
+		LanguageCount:   signal.Val(ghr.BasicData.Languages.TotalCount),
+	}
+	if share, ok := ghr.primaryLanguageShare(); ok {
+		s.PrimaryLanguageShare.Set(share)
 	}
 	ghr.logger.Debug("Fetching contributors")
 	if contributors, err := legacy.FetchTotalContributors(ctx, ghr.client, ghr.owner(), ghr.name()); err != nil {
@@ -48,6 +77,48 @@ func (rc *RepoCollector) Collect(ctx context.Context, r projectrepo.Repo) (signa
 	} else {
 		s.OrgCount.Set(orgCount)
 	}
+	if rc.CommitHistory {
+		ghr.logger.Debug("Fetching recent commit authors")
+		authors, err := fetchRecentCommitAuthors(ctx, ghr.client, ghr.owner(), ghr.name(), recentCommitsSince(), maxRecentCommitsSampled)
+		if err != nil {
+			return nil, err
+		}
+		s.RecentAuthorCount.Set(recentAuthorCount(authors, now.Add(-recentAuthorLookback)))
+		s.DistinctOrgCount.Set(distinctOrgCount(authors))
+		if len(authors) > 0 {
+			s.Top1CommitShare.Set(topCommitShare(authors, 1))
+			s.Top3CommitShare.Set(topCommitShare(authors, 3))
+			s.SignedCommitRatio.Set(signedCommitRatio(authors))
+		}
+	}
+	if rc.FileTree {
+		ghr.logger.Debug("Fetching file tree")
+		tree, err := fetchTreeSummary(ctx, ghr.client, ghr.owner(), ghr.name())
+		if err != nil {
+			return nil, err
+		}
+		s.HasCIConfig.Set(tree.HasCIConfig)
+		if ratio, ok := tree.TestFileRatio(); ok {
+			s.TestFileRatio.Set(ratio)
+		}
+	}
+	if rc.SecurityPolicy {
+		ghr.logger.Debug("Fetching security policy")
+		policy, err := fetchSecurityPolicy(ctx, ghr.client, ghr.owner(), ghr.name())
+		if err != nil {
+			return nil, err
+		}
+		s.HasSecurityPolicy.Set(policy.Enabled)
+		s.SecurityContactPresent.Set(policy.HasContact)
+	}
+	if rc.PullRequests {
+		ghr.logger.Debug("Fetching recent pull requests")
+		pulls, err := fetchRecentPulls(ctx, ghr.client, ghr.owner(), ghr.name(), now.Add(-recentPullLookback), maxRecentPullsSampled)
+		if err != nil {
+			return nil, err
+		}
+		summarizePulls(s, pulls)
+	}
 	ghr.logger.Debug("Fetching releases")
 	if releaseCount, err := legacy.FetchReleaseCount(ctx, ghr.client, ghr.owner(), ghr.name(), legacyReleaseLookback); err != nil {
 		return nil, err
@@ -64,6 +135,31 @@ func (rc *RepoCollector) Collect(ctx context.Context, r projectrepo.Repo) (signa
 			}
 		}
 	}
+	// Projects that tag releases without creating GitHub Releases fall back
+	// to using their tags.
+	if count := ghr.BasicData.Releases.TotalCount; count > 0 {
+		s.ReleaseCount.Set(count)
+	} else {
+		s.ReleaseCount.Set(ghr.BasicData.Tags.TotalCount)
+	}
+	if published := ghr.BasicData.LatestRelease.PublishedAt; !published.IsZero() {
+		s.LastReleasedAt.Set(published)
+	} else if tagged := ghr.latestTagAt(); !tagged.IsZero() {
+		s.LastReleasedAt.Set(tagged)
+	}
+	s.SetReleaseLag(now)
+	s.SetDaysSinceLastRelease(now)
+	if branch := ghr.BasicData.DefaultBranchRef.Name; rc.BranchProtection && branch != "" {
+		ghr.logger.Debug("Fetching default branch protection")
+		if bp, err := fetchBranchProtection(ctx, ghr.client, ghr.owner(), ghr.name(), branch); err != nil {
+			return nil, err
+		} else if bp != nil {
+			s.DefaultBranchProtected.Set(bp.Protected)
+			s.DefaultBranchRequiredReviews.Set(bp.RequiredReviewCount)
+			s.DefaultBranchRequiresLinearHistory.Set(bp.RequiresLinearHistory)
+			s.DefaultBranchAllowsForcePush.Set(bp.AllowsForcePush)
+		}
+	}
 	return s, nil
 }
 
@@ -86,6 +182,27 @@ func (ic *IssuesCollector) Collect(ctx context.Context, r projectrepo.Repo) (sig
 	}
 	s := &signal.IssuesSet{}
 
+	if ghr.BasicData.HasIssuesEnabled {
+		s.OpenCount.Set(ghr.BasicData.OpenIssues.TotalCount)
+
+		ghr.logger.Debug("Fetching open issue assignment")
+		assigned, unassigned, err := fetchOpenIssueAssignment(ctx, ghr.client, ghr.owner(), ghr.name(), maxOpenIssuesSampled)
+		if err != nil {
+			return nil, err
+		}
+		s.AssignedOpenCount.Set(assigned)
+		s.UnassignedOpenCount.Set(unassigned)
+
+		ghr.logger.Debug("Fetching issue first response time")
+		wait, ok, err := fetchIssueFirstResponse(ctx, ghr.client, ghr.owner(), ghr.name())
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			s.FirstResponseHours.Set(int(wait.Hours()))
+		}
+	}
+
 	ghr.logger.Debug("Fetching closed issues")
 	closed, err := legacy.FetchIssueCount(ctx, ghr.client, ghr.owner(), ghr.name(), legacy.IssueStateClosed, legacy.IssueLookback)
 	if err != nil {
@@ -126,3 +243,474 @@ func (ic *IssuesCollector) IsSupported(r projectrepo.Repo) bool {
 	_, ok := r.(*repo)
 	return ok
 }
+
+type workflowSet struct {
+	WorkflowCount            signal.Field[int]
+	DaysSinceLastWorkflowRun signal.Field[int]
+}
+
+func (s *workflowSet) Namespace() signal.Namespace {
+	return signal.Namespace("github_actions")
+}
+
+// WorkflowCollector collects signals about the GitHub Actions workflows used
+// by a repository.
+type WorkflowCollector struct {
+	// IncludeRuns enables fetching the recency of workflow runs. This requires
+	// the token to have access to the Actions API.
+	IncludeRuns bool
+}
+
+func (wc *WorkflowCollector) EmptySet() signal.Set {
+	return &workflowSet{}
+}
+
+func (wc *WorkflowCollector) Collect(ctx context.Context, r projectrepo.Repo) (signal.Set, error) {
+	ghr, ok := r.(*repo)
+	if !ok {
+		return nil, errors.New("project is not a github project")
+	}
+	s := &workflowSet{}
+
+	ghr.logger.Debug("Fetching workflows")
+	count, err := fetchWorkflowCount(ctx, ghr.client, ghr.owner(), ghr.name())
+	if err != nil {
+		return nil, err
+	}
+	s.WorkflowCount.Set(count)
+
+	if !wc.IncludeRuns || count == 0 {
+		return s, nil
+	}
+	ghr.logger.Debug("Fetching last workflow run")
+	last, ok, err := fetchLastWorkflowRun(ctx, ghr.client, ghr.owner(), ghr.name())
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		s.DaysSinceLastWorkflowRun.Set(int(time.Since(last).Hours()) / 24)
+	}
+	return s, nil
+}
+
+func (wc *WorkflowCollector) IsSupported(r projectrepo.Repo) bool {
+	_, ok := r.(*repo)
+	return ok
+}
+
+type provenanceSet struct {
+	HasSLSAProvenance signal.Field[bool] `signal:"has_slsa_provenance"`
+}
+
+func (s *provenanceSet) Namespace() signal.Namespace {
+	return signal.Namespace("provenance")
+}
+
+// ProvenanceCollector collects signals about whether a repository publishes
+// build provenance, such as SLSA attestations, alongside its releases.
+type ProvenanceCollector struct {
+}
+
+func (pc *ProvenanceCollector) EmptySet() signal.Set {
+	return &provenanceSet{}
+}
+
+func (pc *ProvenanceCollector) Collect(ctx context.Context, r projectrepo.Repo) (signal.Set, error) {
+	ghr, ok := r.(*repo)
+	if !ok {
+		return nil, errors.New("project is not a github project")
+	}
+	s := &provenanceSet{}
+
+	ghr.logger.Debug("Fetching latest release provenance")
+	has, ok, err := fetchLatestReleaseProvenance(ctx, ghr.client, ghr.owner(), ghr.name())
+	if err != nil {
+		return nil, err
+	}
+	if ok {
+		s.HasSLSAProvenance.Set(has)
+	}
+	return s, nil
+}
+
+func (pc *ProvenanceCollector) IsSupported(r projectrepo.Repo) bool {
+	_, ok := r.(*repo)
+	return ok
+}
+
+type packagesSet struct {
+	PackageCount  signal.Field[int]
+	DownloadCount signal.Field[int]
+}
+
+func (s *packagesSet) Namespace() signal.Namespace {
+	return signal.Namespace("ghpackages")
+}
+
+// PackagesCollector collects signals about the packages a repository
+// publishes to GitHub Packages.
+type PackagesCollector struct {
+}
+
+func (pc *PackagesCollector) EmptySet() signal.Set {
+	return &packagesSet{}
+}
+
+func (pc *PackagesCollector) Collect(ctx context.Context, r projectrepo.Repo) (signal.Set, error) {
+	ghr, ok := r.(*repo)
+	if !ok {
+		return nil, errors.New("project is not a github project")
+	}
+	s := &packagesSet{}
+
+	ghr.logger.Debug("Fetching GitHub Packages downloads")
+	count, downloads, err := fetchPackageDownloads(ctx, ghr.client, ghr.owner(), ghr.name())
+	if err != nil {
+		return nil, err
+	}
+	s.PackageCount.Set(count)
+	if count > 0 {
+		s.DownloadCount.Set(downloads)
+	}
+	return s, nil
+}
+
+func (pc *PackagesCollector) IsSupported(r projectrepo.Repo) bool {
+	_, ok := r.(*repo)
+	return ok
+}
+
+type fundingSet struct {
+	HasFundingFile    signal.Field[bool]
+	HasOpenCollective signal.Field[bool]
+	HasGithubSponsors signal.Field[bool]
+
+	// SponsorCount is the number of GitHub Sponsors of the repository's
+	// owner. It is only set if the owner has a GitHub Sponsors listing.
+	SponsorCount signal.Field[int]
+}
+
+func (s *fundingSet) Namespace() signal.Namespace {
+	return signal.Namespace("funding")
+}
+
+// FundingCollector collects signals about whether a repository, or its
+// owner, asks for and receives funding.
+type FundingCollector struct {
+}
+
+func (fc *FundingCollector) EmptySet() signal.Set {
+	return &fundingSet{}
+}
+
+func (fc *FundingCollector) Collect(ctx context.Context, r projectrepo.Repo) (signal.Set, error) {
+	ghr, ok := r.(*repo)
+	if !ok {
+		return nil, errors.New("project is not a github project")
+	}
+	s := &fundingSet{}
+
+	ghr.logger.Debug("Fetching funding")
+	f, err := fetchFunding(ctx, ghr.client, ghr.owner(), ghr.name())
+	if err != nil {
+		return nil, err
+	}
+	s.HasFundingFile.Set(f.LinkCount > 0)
+	s.HasOpenCollective.Set(f.HasOpenCollective)
+	s.HasGithubSponsors.Set(f.HasSponsorsListing)
+	if f.HasSponsorsListing {
+		s.SponsorCount.Set(f.SponsorCount)
+	}
+	return s, nil
+}
+
+func (fc *FundingCollector) IsSupported(r projectrepo.Repo) bool {
+	_, ok := r.(*repo)
+	return ok
+}
+
+type discussionsSet struct {
+	DiscussionCount signal.Field[int]
+
+	// RecentDiscussionCount is the number of discussions created in the
+	// last 90 days, and ActiveDiscussionCount is the number updated in the
+	// last 90 days.
+	RecentDiscussionCount signal.Field[int]
+	ActiveDiscussionCount signal.Field[int]
+}
+
+func (s *discussionsSet) Namespace() signal.Namespace {
+	return signal.Namespace("discussions")
+}
+
+// DiscussionsCollector collects signals about the activity in a repository's
+// GitHub Discussions.
+type DiscussionsCollector struct {
+}
+
+func (dc *DiscussionsCollector) EmptySet() signal.Set {
+	return &discussionsSet{}
+}
+
+// Collect implements the collector.Collector interface.
+//
+// If the repository does not have Discussions enabled, the signals are left
+// unset.
+func (dc *DiscussionsCollector) Collect(ctx context.Context, r projectrepo.Repo) (signal.Set, error) {
+	ghr, ok := r.(*repo)
+	if !ok {
+		return nil, errors.New("project is not a github project")
+	}
+	s := &discussionsSet{}
+
+	ghr.logger.Debug("Fetching discussions")
+	d, err := fetchDiscussions(ctx, ghr.client, ghr.owner(), ghr.name(), githubapi.Today().Add(-discussionLookback), maxRecentDiscussionsSampled)
+	if err != nil {
+		return nil, err
+	}
+	if !d.Enabled {
+		return s, nil
+	}
+	s.DiscussionCount.Set(d.TotalCount)
+	s.RecentDiscussionCount.Set(d.CreatedCount)
+	s.ActiveDiscussionCount.Set(d.UpdatedCount)
+	return s, nil
+}
+
+func (dc *DiscussionsCollector) IsSupported(r projectrepo.Repo) bool {
+	_, ok := r.(*repo)
+	return ok
+}
+
+type actionSet struct {
+	IsAction signal.Field[bool]
+
+	// WorkflowReferenceCount is an estimate of the number of workflow files
+	// in other public repositories that use the action. It is only set if the
+	// repository is an action.
+	WorkflowReferenceCount signal.Field[int]
+}
+
+func (s *actionSet) Namespace() signal.Namespace {
+	return signal.Namespace("action")
+}
+
+// ActionUsageCollector collects signals about how widely the GitHub Action
+// published from a repository is used.
+//
+// The usage is found using code search, which has a low rate limit.
+type ActionUsageCollector struct {
+}
+
+func (ac *ActionUsageCollector) EmptySet() signal.Set {
+	return &actionSet{}
+}
+
+func (ac *ActionUsageCollector) Collect(ctx context.Context, r projectrepo.Repo) (signal.Set, error) {
+	ghr, ok := r.(*repo)
+	if !ok {
+		return nil, errors.New("project is not a github project")
+	}
+	s := &actionSet{}
+
+	ghr.logger.Debug("Fetching action metadata")
+	isAction, err := fetchIsAction(ctx, ghr.client, ghr.owner(), ghr.name())
+	if err != nil {
+		return nil, err
+	}
+	s.IsAction.Set(isAction)
+	if !isAction {
+		return s, nil
+	}
+	ghr.logger.Debug("Searching for workflows that use the action")
+	count, err := fetchActionUsage(ctx, ghr.client, ghr.owner(), ghr.name())
+	if err != nil {
+		return nil, err
+	}
+	s.WorkflowReferenceCount.Set(count)
+	return s, nil
+}
+
+func (ac *ActionUsageCollector) IsSupported(r projectrepo.Repo) bool {
+	_, ok := r.(*repo)
+	return ok
+}
+
+type sigstoreSet struct {
+	// SignedReleaseCount is the number of recent releases with an asset that
+	// has an entry in the Rekor transparency log.
+	SignedReleaseCount signal.Field[int]
+}
+
+func (s *sigstoreSet) Namespace() signal.Namespace {
+	return signal.Namespace("sigstore")
+}
+
+// SigstoreCollector collects signals about whether a repository signs its
+// release assets with Sigstore, using the Rekor transparency log.
+type SigstoreCollector struct {
+	// Client is used to query Rekor. If Client is nil, http.DefaultClient is
+	// used.
+	Client *http.Client
+
+	// rekorURL overrides DefaultRekorURL in tests.
+	rekorURL string
+}
+
+func (sc *SigstoreCollector) EmptySet() signal.Set {
+	return &sigstoreSet{}
+}
+
+func (sc *SigstoreCollector) Collect(ctx context.Context, r projectrepo.Repo) (signal.Set, error) {
+	ghr, ok := r.(*repo)
+	if !ok {
+		return nil, errors.New("project is not a github project")
+	}
+	s := &sigstoreSet{}
+
+	ghr.logger.Debug("Fetching recent release assets")
+	releases, err := fetchRecentReleaseAssets(ctx, ghr.client, ghr.owner(), ghr.name(), signedReleasesSampled)
+	if err != nil {
+		return nil, err
+	}
+	if len(releases) == 0 {
+		return s, nil
+	}
+	hc := sc.Client
+	if hc == nil {
+		hc = http.DefaultClient
+	}
+	rekorURL := sc.rekorURL
+	if rekorURL == "" {
+		rekorURL = DefaultRekorURL
+	}
+	ghr.logger.Debug("Looking up release assets in Rekor")
+	signed, err := countSignedReleases(ctx, hc, rekorURL, releases)
+	if err != nil {
+		return nil, err
+	}
+	s.SignedReleaseCount.Set(signed)
+	return s, nil
+}
+
+func (sc *SigstoreCollector) IsSupported(r projectrepo.Repo) bool {
+	_, ok := r.(*repo)
+	return ok
+}
+
+type trafficSet struct {
+	ViewCount          signal.Field[int]
+	UniqueVisitorCount signal.Field[int]
+	CloneCount         signal.Field[int]
+	UniqueClonerCount  signal.Field[int]
+}
+
+func (s *trafficSet) Namespace() signal.Namespace {
+	return signal.Namespace("traffic")
+}
+
+// TrafficCollector collects the views and clones of a repository over the
+// last 14 days.
+//
+// Traffic is only available to tokens with push access to the repository, so
+// this is intended for organizations scoring their own repositories. The
+// signals are left unset for other repositories.
+type TrafficCollector struct {
+}
+
+func (tc *TrafficCollector) EmptySet() signal.Set {
+	return &trafficSet{}
+}
+
+func (tc *TrafficCollector) Collect(ctx context.Context, r projectrepo.Repo) (signal.Set, error) {
+	ghr, ok := r.(*repo)
+	if !ok {
+		return nil, errors.New("project is not a github project")
+	}
+	s := &trafficSet{}
+
+	ghr.logger.Debug("Fetching traffic")
+	t, err := fetchTraffic(ctx, ghr.client, ghr.owner(), ghr.name())
+	if err != nil {
+		return nil, err
+	}
+	if t == nil {
+		ghr.logger.Debug("No push access, skipping traffic")
+		return s, nil
+	}
+	s.ViewCount.Set(t.Views)
+	s.UniqueVisitorCount.Set(t.UniqueVisitors)
+	s.CloneCount.Set(t.Clones)
+	s.UniqueClonerCount.Set(t.UniqueCloners)
+	return s, nil
+}
+
+func (tc *TrafficCollector) IsSupported(r projectrepo.Repo) bool {
+	_, ok := r.(*repo)
+	return ok
+}
+
+type triageSet struct {
+	// OpenIssueLabeledRatio and OpenPullRequestLabeledRatio are the share of
+	// the sampled open issues and pull requests that have a label.
+	OpenIssueLabeledRatio       signal.Field[float64]
+	OpenPullRequestLabeledRatio signal.Field[float64]
+
+	// StaleIssueRatio and StalePullRequestRatio are the share of open issues
+	// and pull requests that are over a year old without any response.
+	StaleIssueRatio       signal.Field[float64]
+	StalePullRequestRatio signal.Field[float64]
+}
+
+func (s *triageSet) Namespace() signal.Namespace {
+	return signal.Namespace("triage")
+}
+
+// TriageCollector collects how well the open issues and pull requests of a
+// repository are triaged, as an indicator of maintenance neglect.
+//
+// The signals for issues are left unset if issues are disabled or there are
+// no open issues, and likewise for pull requests.
+type TriageCollector struct {
+}
+
+func (tc *TriageCollector) EmptySet() signal.Set {
+	return &triageSet{}
+}
+
+func (tc *TriageCollector) Collect(ctx context.Context, r projectrepo.Repo) (signal.Set, error) {
+	ghr, ok := r.(*repo)
+	if !ok {
+		return nil, errors.New("project is not a github project")
+	}
+	s := &triageSet{}
+	now := time.Now()
+
+	if ghr.BasicData.HasIssuesEnabled {
+		ghr.logger.Debug("Fetching open issue triage")
+		h, err := fetchIssueTriage(ctx, ghr.client, ghr.owner(), ghr.name(), now)
+		if err != nil {
+			return nil, err
+		}
+		if h != nil {
+			s.OpenIssueLabeledRatio.Set(h.LabeledRatio)
+			s.StaleIssueRatio.Set(h.StaleRatio)
+		}
+	}
+
+	ghr.logger.Debug("Fetching open pull request triage")
+	h, err := fetchPullRequestTriage(ctx, ghr.client, ghr.owner(), ghr.name(), now)
+	if err != nil {
+		return nil, err
+	}
+	if h != nil {
+		s.OpenPullRequestLabeledRatio.Set(h.LabeledRatio)
+		s.StalePullRequestRatio.Set(h.StaleRatio)
+	}
+	return s, nil
+}
+
+func (tc *TriageCollector) IsSupported(r projectrepo.Repo) bool {
+	_, ok := r.(*repo)
+	return ok
+}
@@ -0,0 +1,197 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/ossf/criticality_score/cmd/collect_signals/github/legacy"
+	"github.com/ossf/criticality_score/internal/githubapi"
+	"github.com/ossf/criticality_score/internal/githubapi/pagination"
+	"github.com/shurcooL/githubv4"
+)
+
+const (
+	recentCommitsPerPage = 100
+
+	// recentCommitLookback is how far back commits are examined for their
+	// authors.
+	recentCommitLookback = 365 * 24 * time.Hour
+
+	// recentAuthorLookback is how far back commits are examined when
+	// counting recent authors.
+	recentAuthorLookback = 90 * 24 * time.Hour
+
+	// maxRecentCommitsSampled limits the number of recent commits that are
+	// examined for their authors, to bound the number of pages fetched.
+	maxRecentCommitsSampled = 1000
+)
+
+// commitAuthor is the author of a single commit, along with the details of
+// the commit needed by the signals derived from its author.
+type commitAuthor struct {
+	// Login is the GitHub login of the author, if the commit's email
+	// address is associated with a GitHub user.
+	Login string
+
+	// Email is the email address recorded in the commit.
+	Email string
+
+	// Company is the company on the author's GitHub profile.
+	Company string
+
+	// CommittedAt is when the commit was made.
+	CommittedAt time.Time
+
+	// Signed is true if the commit has a GPG, S/MIME or SSH signature,
+	// whether or not GitHub was able to verify it.
+	Signed bool
+}
+
+// key returns a string that identifies the author across commits.
+func (a commitAuthor) key() string {
+	if a.Login != "" {
+		return "login:" + a.Login
+	}
+	return "email:" + a.Email
+}
+
+type recentCommitsQuery struct {
+	Repository struct {
+		DefaultBranchRef struct {
+			Target struct {
+				Commit struct {
+					History struct {
+						TotalCount int
+						Nodes      []struct {
+							CommittedDate time.Time
+							Signature     *struct {
+								IsValid bool
+							}
+							Author struct {
+								Email string
+								User  *struct {
+									Login   string
+									Company string
+								}
+							}
+						}
+						PageInfo struct {
+							EndCursor   string
+							HasNextPage bool
+						}
+					} `graphql:"history(first: $perPage, after: $endCursor, since: $since)"`
+				} `graphql:"... on Commit"`
+			}
+		}
+	} `graphql:"repository(owner: $repositoryOwner, name: $repositoryName)"`
+}
+
+// Total implements the pagination.PagedQuery interface
+func (q *recentCommitsQuery) Total() int {
+	return q.Repository.DefaultBranchRef.Target.Commit.History.TotalCount
+}
+
+// Length implements the pagination.PagedQuery interface
+func (q *recentCommitsQuery) Length() int {
+	return len(q.Repository.DefaultBranchRef.Target.Commit.History.Nodes)
+}
+
+// Get implements the pagination.PagedQuery interface
+func (q *recentCommitsQuery) Get(i int) any {
+	n := q.Repository.DefaultBranchRef.Target.Commit.History.Nodes[i]
+	author := commitAuthor{
+		Email:       n.Author.Email,
+		CommittedAt: n.CommittedDate,
+		Signed:      n.Signature != nil,
+	}
+	if n.Author.User != nil {
+		author.Login = n.Author.User.Login
+		author.Company = n.Author.User.Company
+	}
+	return author
+}
+
+// HasNextPage implements the pagination.PagedQuery interface
+func (q *recentCommitsQuery) HasNextPage() bool {
+	return q.Repository.DefaultBranchRef.Target.Commit.History.PageInfo.HasNextPage
+}
+
+// NextPageVars implements the pagination.PagedQuery interface
+func (q *recentCommitsQuery) NextPageVars() map[string]any {
+	cursor := q.Repository.DefaultBranchRef.Target.Commit.History.PageInfo.EndCursor
+	if cursor == "" {
+		return map[string]any{
+			"endCursor": (*githubv4.String)(nil),
+		}
+	} else {
+		return map[string]any{
+			"endCursor": githubv4.String(cursor),
+		}
+	}
+}
+
+// recentCommitsSince returns the time commits are examined from.
+//
+// It is relative to the start of the day, so the history query is the same
+// throughout a day and can be served from the cache.
+func recentCommitsSince() time.Time {
+	return githubapi.Today().Add(-recentCommitLookback)
+}
+
+// fetchRecentCommitAuthors returns the author of each commit made to the
+// default branch since the given time, most recent first.
+//
+// At most maxSampled commits are returned.
+func fetchRecentCommitAuthors(ctx context.Context, c *githubapi.Client, owner, name string, since time.Time, maxSampled int) ([]commitAuthor, error) {
+	s := &recentCommitsQuery{}
+	vars := map[string]any{
+		"perPage":         githubv4.Int(recentCommitsPerPage),
+		"repositoryOwner": githubv4.String(owner),
+		"repositoryName":  githubv4.String(name),
+		"since":           githubv4.GitTimestamp{Time: since},
+	}
+	cursor, err := pagination.Query(ctx, c.GraphQL(), s, vars)
+	if err != nil {
+		return nil, err
+	}
+	var authors []commitAuthor
+	for len(authors) < maxSampled {
+		obj, err := cursor.Next()
+		if obj == nil && errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		authors = append(authors, obj.(commitAuthor))
+	}
+	return authors, nil
+}
+
+// recentAuthorCount returns the number of distinct authors of the commits
+// made since the given time.
+func recentAuthorCount(authors []commitAuthor, since time.Time) int {
+	seen := make(map[string]struct{})
+	for _, a := range authors {
+		if a.CommittedAt.Before(since) {
+			continue
+		}
+		seen[a.key()] = struct{}{}
+	}
+	return len(seen)
+}
+
+// signedCommitRatio returns the fraction of the commits by authors that are
+// signed, rounded to two decimal places.
+//
+// authors must not be empty.
+func signedCommitRatio(authors []commitAuthor) float64 {
+	signed := 0
+	for _, a := range authors {
+		if a.Signed {
+			signed++
+		}
+	}
+	return legacy.Round(float64(signed)/float64(len(authors)), 2)
+}
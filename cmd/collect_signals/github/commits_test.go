@@ -0,0 +1,98 @@
+package github
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestFetchRecentCommitAuthors(t *testing.T) {
+	c := newTestClient(t, jsonHandler(http.StatusOK, `{"data": {"repository": {"defaultBranchRef": {"target": {"history": {
+		"totalCount": 3,
+		"nodes": [
+			{"author": {"email": "a@example.com", "user": {"login": "a", "company": "Example Inc."}}, "signature": {"isValid": true}},
+			{"author": {"email": "b@example.org", "user": null}, "signature": null},
+			{"author": {"email": "a@example.com", "user": {"login": "a", "company": "Example Inc."}}}
+		],
+		"pageInfo": {"endCursor": "abc", "hasNextPage": false}
+	}}}}}}`))
+	authors, err := fetchRecentCommitAuthors(context.Background(), c, "example", "example", time.Now(), 2)
+	if err != nil {
+		t.Fatalf("fetchRecentCommitAuthors() errored %v, want no error", err)
+	}
+	want := []commitAuthor{
+		{Login: "a", Email: "a@example.com", Company: "Example Inc.", Signed: true},
+		{Email: "b@example.org"},
+	}
+	if len(authors) != len(want) {
+		t.Fatalf("fetchRecentCommitAuthors() == %v, want %v", authors, want)
+	}
+	for i := range want {
+		if authors[i] != want[i] {
+			t.Fatalf("fetchRecentCommitAuthors()[%d] == %+v, want %+v", i, authors[i], want[i])
+		}
+	}
+}
+
+func TestFetchRecentCommitAuthors_EmptyRepo(t *testing.T) {
+	c := newTestClient(t, jsonHandler(http.StatusOK, `{"data": {"repository": {"defaultBranchRef": null}}}`))
+	authors, err := fetchRecentCommitAuthors(context.Background(), c, "example", "example", time.Now(), maxRecentCommitsSampled)
+	if err != nil {
+		t.Fatalf("fetchRecentCommitAuthors() errored %v, want no error", err)
+	}
+	if len(authors) != 0 {
+		t.Fatalf("fetchRecentCommitAuthors() == %v, want no authors", authors)
+	}
+}
+
+func TestRecentAuthorCount(t *testing.T) {
+	now := time.Date(2022, time.June, 1, 0, 0, 0, 0, time.UTC)
+	authors := []commitAuthor{
+		{Login: "a", CommittedAt: now.Add(-time.Hour)},
+		{Login: "a", CommittedAt: now.Add(-2 * time.Hour)},
+		{Email: "b@example.com", CommittedAt: now.Add(-24 * time.Hour)},
+		{Login: "c", CommittedAt: now.Add(-100 * 24 * time.Hour)},
+	}
+	if got := recentAuthorCount(authors, now.Add(-recentAuthorLookback)); got != 2 {
+		t.Fatalf("recentAuthorCount() == %d, want 2", got)
+	}
+}
+
+func TestSignedCommitRatio(t *testing.T) {
+	authors := []commitAuthor{
+		{Login: "a", Signed: true},
+		{Login: "a"},
+		{Login: "b", Signed: true},
+	}
+	if got := signedCommitRatio(authors); got != 0.67 {
+		t.Fatalf("signedCommitRatio() == %v, want 0.67", got)
+	}
+}
+
+func TestFetchRecentCommitAuthors_SameRequestInOneDay(t *testing.T) {
+	var bodies []string
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := io.ReadAll(r.Body)
+		if err != nil {
+			t.Fatalf("ReadAll() errored %v, want no error", err)
+		}
+		bodies = append(bodies, string(b))
+		jsonHandler(http.StatusOK, `{"data": {"repository": {"defaultBranchRef": null}}}`)(w, r)
+	}))
+	for i := 0; i < 2; i++ {
+		if _, err := fetchRecentCommitAuthors(context.Background(), c, "example", "example", recentCommitsSince(), maxRecentCommitsSampled); err != nil {
+			t.Fatalf("fetchRecentCommitAuthors() errored %v, want no error", err)
+		}
+		time.Sleep(time.Millisecond)
+	}
+	if len(bodies) != 2 {
+		t.Fatalf("len(requests) == %d, want 2", len(bodies))
+	}
+	// The cache keys requests by their body, so it must not change within a
+	// day for the history to be served from the cache.
+	if bodies[0] != bodies[1] {
+		t.Fatalf("request bodies differ: %q != %q", bodies[0], bodies[1])
+	}
+}
@@ -0,0 +1,31 @@
+package github
+
+import (
+	"sort"
+
+	"github.com/ossf/criticality_score/cmd/collect_signals/github/legacy"
+)
+
+// topCommitShare returns the fraction of the commits by authors that were
+// made by the n authors with the most commits, rounded to two decimal places.
+//
+// authors must not be empty.
+func topCommitShare(authors []commitAuthor, n int) float64 {
+	counts := make(map[string]int)
+	for _, a := range authors {
+		counts[a.key()]++
+	}
+	sorted := make([]int, 0, len(counts))
+	for _, c := range counts {
+		sorted = append(sorted, c)
+	}
+	sort.Sort(sort.Reverse(sort.IntSlice(sorted)))
+	if n > len(sorted) {
+		n = len(sorted)
+	}
+	top := 0
+	for _, c := range sorted[:n] {
+		top += c
+	}
+	return legacy.Round(float64(top)/float64(len(authors)), 2)
+}
@@ -0,0 +1,26 @@
+package github
+
+import "testing"
+
+func TestTopCommitShare(t *testing.T) {
+	a := commitAuthor{Login: "a", Email: "a@example.com"}
+	b := commitAuthor{Email: "b@example.com"}
+	c := commitAuthor{Login: "c"}
+	d := commitAuthor{Login: "d"}
+	e := commitAuthor{Login: "e"}
+	authors := []commitAuthor{a, a, a, a, b, b, c, d, e, a}
+
+	if got := topCommitShare(authors, 1); got != 0.5 {
+		t.Fatalf("topCommitShare(1) == %v, want 0.5", got)
+	}
+	if got := topCommitShare(authors, 3); got != 0.8 {
+		t.Fatalf("topCommitShare(3) == %v, want 0.8", got)
+	}
+}
+
+func TestTopCommitShare_FewAuthors(t *testing.T) {
+	authors := []commitAuthor{{Login: "a"}, {Login: "b"}}
+	if got := topCommitShare(authors, 3); got != 1 {
+		t.Fatalf("topCommitShare(3) == %v, want 1", got)
+	}
+}
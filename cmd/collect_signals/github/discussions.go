@@ -0,0 +1,131 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/ossf/criticality_score/internal/githubapi"
+	"github.com/ossf/criticality_score/internal/githubapi/pagination"
+	"github.com/shurcooL/githubv4"
+)
+
+const (
+	discussionsPerPage = 100
+
+	// discussionLookback is how far back discussions are counted as recent.
+	discussionLookback = 90 * 24 * time.Hour
+
+	// maxRecentDiscussionsSampled limits the number of recently updated
+	// discussions that are examined, to bound the number of pages fetched.
+	maxRecentDiscussionsSampled = 1000
+)
+
+type discussionDates struct {
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+type discussionsQuery struct {
+	Repository struct {
+		HasDiscussionsEnabled bool
+		Discussions           struct {
+			TotalCount int
+			Nodes      []discussionDates
+			PageInfo   struct {
+				EndCursor   string
+				HasNextPage bool
+			}
+		} `graphql:"discussions(orderBy: {field: UPDATED_AT, direction: DESC}, first: $perPage, after: $endCursor)"`
+	} `graphql:"repository(owner: $repositoryOwner, name: $repositoryName)"`
+}
+
+// Total implements the pagination.PagedQuery interface
+func (q *discussionsQuery) Total() int {
+	return q.Repository.Discussions.TotalCount
+}
+
+// Length implements the pagination.PagedQuery interface
+func (q *discussionsQuery) Length() int {
+	return len(q.Repository.Discussions.Nodes)
+}
+
+// Get implements the pagination.PagedQuery interface
+func (q *discussionsQuery) Get(i int) any {
+	return q.Repository.Discussions.Nodes[i]
+}
+
+// HasNextPage implements the pagination.PagedQuery interface
+func (q *discussionsQuery) HasNextPage() bool {
+	return q.Repository.Discussions.PageInfo.HasNextPage
+}
+
+// NextPageVars implements the pagination.PagedQuery interface
+func (q *discussionsQuery) NextPageVars() map[string]any {
+	if q.Repository.Discussions.PageInfo.EndCursor == "" {
+		return map[string]any{
+			"endCursor": (*githubv4.String)(nil),
+		}
+	} else {
+		return map[string]any{
+			"endCursor": githubv4.String(q.Repository.Discussions.PageInfo.EndCursor),
+		}
+	}
+}
+
+// discussions describes the activity in a repository's GitHub Discussions.
+type discussions struct {
+	// Enabled is true if the repository has Discussions enabled.
+	Enabled bool
+
+	// TotalCount is the total number of discussions.
+	TotalCount int
+
+	// CreatedCount is the number of discussions created since the lookback.
+	CreatedCount int
+
+	// UpdatedCount is the number of discussions updated since the lookback,
+	// including those created since then.
+	UpdatedCount int
+}
+
+// fetchDiscussions returns the activity in the repository's Discussions
+// since the given time.
+//
+// At most maxSampled of the most recently updated discussions are examined,
+// so CreatedCount and UpdatedCount are at most maxSampled.
+func fetchDiscussions(ctx context.Context, c *githubapi.Client, owner, name string, since time.Time, maxSampled int) (*discussions, error) {
+	s := &discussionsQuery{}
+	vars := map[string]any{
+		"perPage":         githubv4.Int(discussionsPerPage),
+		"repositoryOwner": githubv4.String(owner),
+		"repositoryName":  githubv4.String(name),
+	}
+	cursor, err := pagination.Query(ctx, c.GraphQL(), s, vars)
+	if err != nil {
+		return nil, err
+	}
+	d := &discussions{
+		Enabled:    s.Repository.HasDiscussionsEnabled,
+		TotalCount: cursor.Total(),
+	}
+	for d.UpdatedCount < maxSampled {
+		obj, err := cursor.Next()
+		if obj == nil && errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		dates := obj.(discussionDates)
+		if dates.UpdatedAt.Before(since) {
+			// Discussions are ordered by update, so the rest are older.
+			break
+		}
+		d.UpdatedCount++
+		if !dates.CreatedAt.Before(since) {
+			d.CreatedCount++
+		}
+	}
+	return d, nil
+}
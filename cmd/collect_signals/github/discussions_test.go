@@ -0,0 +1,44 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestFetchDiscussions(t *testing.T) {
+	c := newTestClient(t, jsonHandler(http.StatusOK, `{"data": {"repository": {"hasDiscussionsEnabled": true, "discussions": {
+		"totalCount": 40,
+		"nodes": [
+			{"createdAt": "2022-03-01T00:00:00Z", "updatedAt": "2022-03-05T00:00:00Z"},
+			{"createdAt": "2021-06-01T00:00:00Z", "updatedAt": "2022-02-01T00:00:00Z"},
+			{"createdAt": "2021-05-01T00:00:00Z", "updatedAt": "2021-12-01T00:00:00Z"}
+		],
+		"pageInfo": {"endCursor": "abc", "hasNextPage": true}
+	}}}}`))
+	since := time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC)
+	d, err := fetchDiscussions(context.Background(), c, "example", "example", since, maxRecentDiscussionsSampled)
+	if err != nil {
+		t.Fatalf("fetchDiscussions() errored %v, want no error", err)
+	}
+	want := discussions{Enabled: true, TotalCount: 40, CreatedCount: 1, UpdatedCount: 2}
+	if *d != want {
+		t.Fatalf("fetchDiscussions() == %+v, want %+v", *d, want)
+	}
+}
+
+func TestFetchDiscussions_Disabled(t *testing.T) {
+	c := newTestClient(t, jsonHandler(http.StatusOK, `{"data": {"repository": {"hasDiscussionsEnabled": false, "discussions": {
+		"totalCount": 0,
+		"nodes": [],
+		"pageInfo": {"endCursor": "", "hasNextPage": false}
+	}}}}`))
+	d, err := fetchDiscussions(context.Background(), c, "example", "example", time.Now(), maxRecentDiscussionsSampled)
+	if err != nil {
+		t.Fatalf("fetchDiscussions() errored %v, want no error", err)
+	}
+	if d.Enabled {
+		t.Fatal("fetchDiscussions() Enabled == true, want false")
+	}
+}
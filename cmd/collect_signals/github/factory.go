@@ -3,6 +3,7 @@ package github
 import (
 	"context"
 	"net/url"
+	"strings"
 
 	"github.com/ossf/criticality_score/cmd/collect_signals/projectrepo"
 	"github.com/ossf/criticality_score/internal/githubapi"
@@ -33,6 +34,10 @@ func (f *factory) New(ctx context.Context, u *url.URL) (projectrepo.Repo, error)
 	return p, nil
 }
 
+// Match implements the projectrepo.Factory interface.
+//
+// URLs must have the same host as the client, which is github.com unless a
+// GitHub Enterprise Server client is used.
 func (f *factory) Match(u *url.URL) bool {
-	return u.Hostname() == "github.com"
+	return strings.EqualFold(u.Hostname(), f.client.Host())
 }
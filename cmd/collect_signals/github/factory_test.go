@@ -0,0 +1,40 @@
+package github
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/ossf/criticality_score/internal/githubapi"
+	log "github.com/sirupsen/logrus"
+)
+
+func TestFactoryMatch(t *testing.T) {
+	f := NewRepoFactory(githubapi.NewClient(&http.Client{}), log.New())
+	for _, raw := range []string{"https://github.com/ossf/criticality_score", "https://GitHub.com/ossf/criticality_score"} {
+		u, _ := url.Parse(raw)
+		if !f.Match(u) {
+			t.Fatalf("Match(%q) == false, want true", raw)
+		}
+	}
+	u, _ := url.Parse("https://github.example.com/ossf/criticality_score")
+	if f.Match(u) {
+		t.Fatalf("Match(%q) == true, want false", u)
+	}
+}
+
+func TestFactoryMatch_Enterprise(t *testing.T) {
+	c, err := githubapi.NewEnterpriseClient("https://github.example.com", &http.Client{})
+	if err != nil {
+		t.Fatalf("NewEnterpriseClient() errored %v, want no error", err)
+	}
+	f := NewRepoFactory(c, log.New())
+	u, _ := url.Parse("https://github.example.com/ossf/criticality_score")
+	if !f.Match(u) {
+		t.Fatalf("Match(%q) == false, want true", u)
+	}
+	u, _ = url.Parse("https://github.com/ossf/criticality_score")
+	if f.Match(u) {
+		t.Fatalf("Match(%q) == true, want false", u)
+	}
+}
@@ -0,0 +1,78 @@
+package github
+
+import (
+	"context"
+
+	"github.com/ossf/criticality_score/internal/githubapi"
+	"github.com/shurcooL/githubv4"
+)
+
+// sponsorable holds the GitHub Sponsors fields of a user or organization.
+type sponsorable struct {
+	HasSponsorsListing bool
+	Sponsors           struct {
+		TotalCount int
+	}
+}
+
+type fundingQuery struct {
+	Repository struct {
+		// FundingLinks holds the links from the repository's FUNDING.yml
+		// file, or the owner's default FUNDING.yml.
+		FundingLinks []struct {
+			Platform string
+			URL      string
+		}
+		Owner struct {
+			User         sponsorable `graphql:"... on User"`
+			Organization sponsorable `graphql:"... on Organization"`
+		}
+	} `graphql:"repository(owner: $repositoryOwner, name: $repositoryName)"`
+}
+
+// funding describes how a repository is funded.
+type funding struct {
+	// LinkCount is the number of funding links in FUNDING.yml.
+	LinkCount int
+
+	// HasOpenCollective is true if FUNDING.yml links to Open Collective.
+	HasOpenCollective bool
+
+	// HasSponsorsListing is true if the owner can be sponsored through
+	// GitHub Sponsors.
+	HasSponsorsListing bool
+
+	// SponsorCount is the number of sponsors of the owner.
+	SponsorCount int
+}
+
+// fetchFunding returns how the repository, and its owner, are funded.
+//
+// Sponsors are those of the owner, as GitHub Sponsors does not support
+// sponsoring a single repository.
+func fetchFunding(ctx context.Context, c *githubapi.Client, owner, name string) (*funding, error) {
+	q := &fundingQuery{}
+	vars := map[string]any{
+		"repositoryOwner": githubv4.String(owner),
+		"repositoryName":  githubv4.String(name),
+	}
+	if err := c.GraphQL().Query(ctx, q, vars); err != nil {
+		return nil, err
+	}
+	f := &funding{LinkCount: len(q.Repository.FundingLinks)}
+	for _, l := range q.Repository.FundingLinks {
+		if l.Platform == "OPEN_COLLECTIVE" {
+			f.HasOpenCollective = true
+		}
+	}
+	// Only the fragment matching the owner's type is populated.
+	for _, s := range []sponsorable{q.Repository.Owner.User, q.Repository.Owner.Organization} {
+		if s.HasSponsorsListing {
+			f.HasSponsorsListing = true
+		}
+		if s.Sponsors.TotalCount > f.SponsorCount {
+			f.SponsorCount = s.Sponsors.TotalCount
+		}
+	}
+	return f, nil
+}
@@ -0,0 +1,39 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestFetchFunding(t *testing.T) {
+	c := newTestClient(t, jsonHandler(http.StatusOK, `{"data": {"repository": {
+		"fundingLinks": [
+			{"platform": "GITHUB", "url": "https://github.com/example"},
+			{"platform": "OPEN_COLLECTIVE", "url": "https://opencollective.com/example"}
+		],
+		"owner": {"hasSponsorsListing": true, "sponsors": {"totalCount": 42}}
+	}}}`))
+	f, err := fetchFunding(context.Background(), c, "example", "example")
+	if err != nil {
+		t.Fatalf("fetchFunding() errored %v, want no error", err)
+	}
+	want := funding{LinkCount: 2, HasOpenCollective: true, HasSponsorsListing: true, SponsorCount: 42}
+	if *f != want {
+		t.Fatalf("fetchFunding() == %+v, want %+v", *f, want)
+	}
+}
+
+func TestFetchFunding_Unfunded(t *testing.T) {
+	c := newTestClient(t, jsonHandler(http.StatusOK, `{"data": {"repository": {
+		"fundingLinks": [],
+		"owner": {"hasSponsorsListing": false, "sponsors": {"totalCount": 0}}
+	}}}`))
+	f, err := fetchFunding(context.Background(), c, "example", "example")
+	if err != nil {
+		t.Fatalf("fetchFunding() errored %v, want no error", err)
+	}
+	if *f != (funding{}) {
+		t.Fatalf("fetchFunding() == %+v, want no funding", *f)
+	}
+}
@@ -0,0 +1,49 @@
+package github
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/ossf/criticality_score/internal/githubapi"
+)
+
+// redirectTransport sends every request to the host serving target, rather
+// than to GitHub.
+type redirectTransport struct {
+	target *url.URL
+}
+
+// RoundTrip implements the http.RoundTripper interface
+func (t *redirectTransport) RoundTrip(r *http.Request) (*http.Response, error) {
+	r = r.Clone(r.Context())
+	r.URL.Scheme = t.target.Scheme
+	r.URL.Host = t.target.Host
+	return http.DefaultTransport.RoundTrip(r)
+}
+
+// newTestClient returns a githubapi.Client where all the requests are handled
+// by h.
+func newTestClient(t *testing.T, h http.Handler) *githubapi.Client {
+	t.Helper()
+	s := httptest.NewServer(h)
+	t.Cleanup(s.Close)
+	u, err := url.Parse(s.URL)
+	if err != nil {
+		t.Fatalf("url.Parse() errored %v, want no error", err)
+	}
+	return githubapi.NewClient(&http.Client{
+		Transport: &redirectTransport{target: u},
+	})
+}
+
+// jsonHandler returns a handler that responds to all requests with the given
+// status and JSON body.
+func jsonHandler(status int, body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}
+}
@@ -0,0 +1,195 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"io"
+	"math"
+	"time"
+
+	"github.com/ossf/criticality_score/internal/githubapi"
+	"github.com/ossf/criticality_score/internal/githubapi/pagination"
+	"github.com/shurcooL/githubv4"
+)
+
+const (
+	openIssuesPerPage = 100
+
+	// maxOpenIssuesSampled limits the number of open issues that are
+	// examined for assignees, to bound the number of pages fetched.
+	maxOpenIssuesSampled = 1000
+)
+
+type openIssuesQuery struct {
+	Repository struct {
+		Issues struct {
+			TotalCount int
+			Nodes      []struct {
+				Assignees struct {
+					TotalCount int
+				}
+			}
+			PageInfo struct {
+				EndCursor   string
+				HasNextPage bool
+			}
+		} `graphql:"issues(states: OPEN, orderBy: {field: UPDATED_AT, direction: DESC}, first: $perPage, after: $endCursor)"`
+	} `graphql:"repository(owner: $repositoryOwner, name: $repositoryName)"`
+}
+
+// Total implements the pagination.PagedQuery interface
+func (q *openIssuesQuery) Total() int {
+	return q.Repository.Issues.TotalCount
+}
+
+// Length implements the pagination.PagedQuery interface
+func (q *openIssuesQuery) Length() int {
+	return len(q.Repository.Issues.Nodes)
+}
+
+// Get implements the pagination.PagedQuery interface
+func (q *openIssuesQuery) Get(i int) any {
+	return q.Repository.Issues.Nodes[i].Assignees.TotalCount
+}
+
+// HasNextPage implements the pagination.PagedQuery interface
+func (q *openIssuesQuery) HasNextPage() bool {
+	return q.Repository.Issues.PageInfo.HasNextPage
+}
+
+// NextPageVars implements the pagination.PagedQuery interface
+func (q *openIssuesQuery) NextPageVars() map[string]any {
+	if q.Repository.Issues.PageInfo.EndCursor == "" {
+		return map[string]any{
+			"endCursor": (*githubv4.String)(nil),
+		}
+	} else {
+		return map[string]any{
+			"endCursor": githubv4.String(q.Repository.Issues.PageInfo.EndCursor),
+		}
+	}
+}
+
+// fetchOpenIssueAssignment returns the number of open issues that have at
+// least one assignee, and the number of open issues without an assignee.
+//
+// At most maxSampled of the most recently updated open issues are examined.
+// If there are more open issues than this, the counts are an approximation
+// that assumes the remaining issues are assigned in the same proportion as
+// those examined. The sum of the counts is always the total number of open
+// issues.
+//
+// Pull requests are not included in the counts.
+func fetchOpenIssueAssignment(ctx context.Context, c *githubapi.Client, owner, name string, maxSampled int) (assigned, unassigned int, err error) {
+	s := &openIssuesQuery{}
+	vars := map[string]any{
+		"perPage":         githubv4.Int(openIssuesPerPage),
+		"repositoryOwner": githubv4.String(owner),
+		"repositoryName":  githubv4.String(name),
+	}
+	cursor, err := pagination.Query(ctx, c.GraphQL(), s, vars)
+	if err != nil {
+		return 0, 0, err
+	}
+	sampled := 0
+	for sampled < maxSampled {
+		obj, err := cursor.Next()
+		if obj == nil && errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return 0, 0, err
+		}
+		sampled++
+		if obj.(int) > 0 {
+			assigned++
+		}
+	}
+	total := cursor.Total()
+	if sampled == 0 || sampled >= total {
+		return assigned, sampled - assigned, nil
+	}
+	assigned = int(math.Round(float64(assigned) * float64(total) / float64(sampled)))
+	return assigned, total - assigned, nil
+}
+
+const (
+	// firstResponseIssuesSampled is the number of the most recently created
+	// issues examined for the time to first response.
+	firstResponseIssuesSampled = 100
+
+	// firstResponseCommentsSampled is the number of the earliest comments on
+	// each issue that are searched for a response from a maintainer.
+	firstResponseCommentsSampled = 20
+)
+
+// maintainerAssociations holds the author associations of people who can
+// respond to an issue on behalf of the project.
+var maintainerAssociations = map[string]bool{
+	"OWNER":        true,
+	"MEMBER":       true,
+	"COLLABORATOR": true,
+}
+
+type issueComment struct {
+	CreatedAt         time.Time
+	AuthorAssociation string
+}
+
+type recentIssuesQuery struct {
+	Repository struct {
+		Issues struct {
+			Nodes []struct {
+				CreatedAt         time.Time
+				AuthorAssociation string
+				Comments          struct {
+					Nodes []issueComment
+				} `graphql:"comments(first: $commentsPerIssue)"`
+			}
+		} `graphql:"issues(orderBy: {field: CREATED_AT, direction: DESC}, first: $perPage)"`
+	} `graphql:"repository(owner: $repositoryOwner, name: $repositoryName)"`
+}
+
+// firstResponse returns the earliest of the comments made by a maintainer.
+// If no maintainer commented, false is returned.
+func firstResponse(comments []issueComment) (time.Time, bool) {
+	for _, c := range comments {
+		if maintainerAssociations[c.AuthorAssociation] {
+			return c.CreatedAt, true
+		}
+	}
+	return time.Time{}, false
+}
+
+// fetchIssueFirstResponse returns the median time between an issue being
+// opened and a maintainer first commenting on it.
+//
+// The firstResponseIssuesSampled most recent issues are examined. Issues
+// opened by maintainers, and issues no maintainer has commented on, are not
+// included. If none of the issues are included, false is returned.
+func fetchIssueFirstResponse(ctx context.Context, c *githubapi.Client, owner, name string) (time.Duration, bool, error) {
+	q := &recentIssuesQuery{}
+	vars := map[string]any{
+		"perPage":          githubv4.Int(firstResponseIssuesSampled),
+		"commentsPerIssue": githubv4.Int(firstResponseCommentsSampled),
+		"repositoryOwner":  githubv4.String(owner),
+		"repositoryName":   githubv4.String(name),
+	}
+	if err := c.GraphQL().Query(ctx, q, vars); err != nil {
+		return 0, false, err
+	}
+	var waits []time.Duration
+	for _, issue := range q.Repository.Issues.Nodes {
+		if maintainerAssociations[issue.AuthorAssociation] {
+			continue
+		}
+		responded, ok := firstResponse(issue.Comments.Nodes)
+		if !ok {
+			continue
+		}
+		waits = append(waits, responded.Sub(issue.CreatedAt))
+	}
+	if len(waits) == 0 {
+		return 0, false, nil
+	}
+	return medianDuration(waits), true, nil
+}
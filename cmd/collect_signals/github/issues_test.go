@@ -0,0 +1,119 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// pagedHandler returns a handler that responds with each page in turn.
+func pagedHandler(t *testing.T, pages ...string) http.HandlerFunc {
+	next := 0
+	return func(w http.ResponseWriter, r *http.Request) {
+		if next >= len(pages) {
+			t.Errorf("unexpected request for page %d", next+1)
+			http.NotFound(w, r)
+			return
+		}
+		jsonHandler(http.StatusOK, pages[next])(w, r)
+		next++
+	}
+}
+
+func TestFetchOpenIssueAssignment(t *testing.T) {
+	c := newTestClient(t, pagedHandler(t,
+		`{"data": {"repository": {"issues": {"totalCount": 5, "nodes": [
+			{"assignees": {"totalCount": 1}},
+			{"assignees": {"totalCount": 0}},
+			{"assignees": {"totalCount": 2}}
+		], "pageInfo": {"endCursor": "c1", "hasNextPage": true}}}}}`,
+		`{"data": {"repository": {"issues": {"totalCount": 5, "nodes": [
+			{"assignees": {"totalCount": 0}},
+			{"assignees": {"totalCount": 0}}
+		], "pageInfo": {"endCursor": "c2", "hasNextPage": false}}}}}`,
+	))
+	assigned, unassigned, err := fetchOpenIssueAssignment(context.Background(), c, "example", "example", maxOpenIssuesSampled)
+	if err != nil {
+		t.Fatalf("fetchOpenIssueAssignment() errored %v, want no error", err)
+	}
+	if assigned != 2 || unassigned != 3 {
+		t.Fatalf("fetchOpenIssueAssignment() == %d, %d; want 2, 3", assigned, unassigned)
+	}
+}
+
+func TestFetchOpenIssueAssignment_NoIssues(t *testing.T) {
+	c := newTestClient(t, pagedHandler(t,
+		`{"data": {"repository": {"issues": {"totalCount": 0, "nodes": [], "pageInfo": {"endCursor": "", "hasNextPage": false}}}}}`,
+	))
+	assigned, unassigned, err := fetchOpenIssueAssignment(context.Background(), c, "example", "example", maxOpenIssuesSampled)
+	if err != nil {
+		t.Fatalf("fetchOpenIssueAssignment() errored %v, want no error", err)
+	}
+	if assigned != 0 || unassigned != 0 {
+		t.Fatalf("fetchOpenIssueAssignment() == %d, %d; want 0, 0", assigned, unassigned)
+	}
+}
+
+func TestFetchOpenIssueAssignment_Approximated(t *testing.T) {
+	// Only the first page is requested, as the sample limit is reached.
+	c := newTestClient(t, pagedHandler(t,
+		`{"data": {"repository": {"issues": {"totalCount": 40, "nodes": [
+			{"assignees": {"totalCount": 1}},
+			{"assignees": {"totalCount": 0}},
+			{"assignees": {"totalCount": 0}},
+			{"assignees": {"totalCount": 0}}
+		], "pageInfo": {"endCursor": "c1", "hasNextPage": true}}}}}`,
+	))
+	assigned, unassigned, err := fetchOpenIssueAssignment(context.Background(), c, "example", "example", 4)
+	if err != nil {
+		t.Fatalf("fetchOpenIssueAssignment() errored %v, want no error", err)
+	}
+	if assigned != 10 || unassigned != 30 {
+		t.Fatalf("fetchOpenIssueAssignment() == %d, %d; want 10, 30", assigned, unassigned)
+	}
+}
+
+func TestFetchIssueFirstResponse(t *testing.T) {
+	c := newTestClient(t, jsonHandler(http.StatusOK, `{"data": {"repository": {"issues": {"nodes": [
+		{"createdAt": "2022-01-01T00:00:00Z", "authorAssociation": "NONE", "comments": {"nodes": [
+			{"createdAt": "2022-01-01T01:00:00Z", "authorAssociation": "NONE"},
+			{"createdAt": "2022-01-01T02:00:00Z", "authorAssociation": "MEMBER"}
+		]}},
+		{"createdAt": "2022-01-02T00:00:00Z", "authorAssociation": "CONTRIBUTOR", "comments": {"nodes": [
+			{"createdAt": "2022-01-02T10:00:00Z", "authorAssociation": "OWNER"}
+		]}},
+		{"createdAt": "2022-01-03T00:00:00Z", "authorAssociation": "NONE", "comments": {"nodes": [
+			{"createdAt": "2022-01-04T00:00:00Z", "authorAssociation": "COLLABORATOR"}
+		]}},
+		{"createdAt": "2022-01-04T00:00:00Z", "authorAssociation": "NONE", "comments": {"nodes": []}},
+		{"createdAt": "2022-01-05T00:00:00Z", "authorAssociation": "MEMBER", "comments": {"nodes": [
+			{"createdAt": "2022-01-09T00:00:00Z", "authorAssociation": "MEMBER"}
+		]}}
+	]}}}}`))
+	wait, ok, err := fetchIssueFirstResponse(context.Background(), c, "example", "example")
+	if err != nil {
+		t.Fatalf("fetchIssueFirstResponse() errored %v, want no error", err)
+	}
+	if !ok {
+		t.Fatalf("fetchIssueFirstResponse() ok == false, want true")
+	}
+	if want := 10 * time.Hour; wait != want {
+		t.Fatalf("fetchIssueFirstResponse() == %v, want %v", wait, want)
+	}
+}
+
+func TestFetchIssueFirstResponse_NoResponses(t *testing.T) {
+	c := newTestClient(t, jsonHandler(http.StatusOK, `{"data": {"repository": {"issues": {"nodes": [
+		{"createdAt": "2022-01-01T00:00:00Z", "authorAssociation": "NONE", "comments": {"nodes": [
+			{"createdAt": "2022-01-01T01:00:00Z", "authorAssociation": "NONE"}
+		]}}
+	]}}}}`))
+	_, ok, err := fetchIssueFirstResponse(context.Background(), c, "example", "example")
+	if err != nil {
+		t.Fatalf("fetchIssueFirstResponse() errored %v, want no error", err)
+	}
+	if ok {
+		t.Fatalf("fetchIssueFirstResponse() ok == true, want false")
+	}
+}
@@ -22,7 +22,7 @@ const (
 // This count includes both issues and pull requests.
 func FetchIssueCount(ctx context.Context, c *githubapi.Client, owner, name string, state IssueState, lookback time.Duration) (int, error) {
 	opts := &github.IssueListByRepoOptions{
-		Since:       time.Now().UTC().Add(-lookback),
+		Since:       githubapi.Today().Add(-lookback),
 		State:       string(state),
 		ListOptions: github.ListOptions{PerPage: 1}, // 1 result per page means LastPage is total number of records.
 	}
@@ -46,7 +46,7 @@ func FetchIssueCount(ctx context.Context, c *githubapi.Client, owner, name strin
 // If the exact number if unable to be returned because there are too many
 // results, a TooManyResultsError will be returned.
 func FetchIssueCommentCount(ctx context.Context, c *githubapi.Client, owner, name string, lookback time.Duration) (int, error) {
-	since := time.Now().UTC().Add(-lookback)
+	since := githubapi.Today().Add(-lookback)
 	opts := &github.IssueListCommentsOptions{
 		Since:       &since,
 		ListOptions: github.ListOptions{PerPage: 1}, // 1 result per page means LastPage is total number of records.
@@ -0,0 +1,88 @@
+package github
+
+import (
+	"strings"
+)
+
+// orgFilter removes the parts of a company name that vary between people
+// working for the same company.
+var orgFilter = strings.NewReplacer(
+	"inc.", "",
+	"llc", "",
+	"@", "",
+	" ", "",
+)
+
+// personalEmailDomains holds the domains of email providers used by people
+// for themselves, rather than through their employer.
+var personalEmailDomains = map[string]bool{
+	"163.com":                  true,
+	"gmail.com":                true,
+	"gmx.de":                   true,
+	"gmx.net":                  true,
+	"googlemail.com":           true,
+	"hotmail.com":              true,
+	"icloud.com":               true,
+	"live.com":                 true,
+	"mail.ru":                  true,
+	"me.com":                   true,
+	"outlook.com":              true,
+	"proton.me":                true,
+	"protonmail.com":           true,
+	"qq.com":                   true,
+	"users.noreply.github.com": true,
+	"noreply.github.com":       true,
+	"yahoo.com":                true,
+	"yandex.ru":                true,
+}
+
+// authorOrg returns the organization the author appears to work for, or an
+// empty string if it is unknown.
+//
+// The company on the author's GitHub profile is used if it is set, otherwise
+// the domain of their email address is used unless it is a personal email
+// provider.
+func authorOrg(a commitAuthor) string {
+	if org := strings.TrimRight(orgFilter.Replace(strings.ToLower(a.Company)), ","); org != "" {
+		return org
+	}
+	_, domain, ok := strings.Cut(strings.ToLower(a.Email), "@")
+	if !ok || personalEmailDomains[domain] {
+		return ""
+	}
+	return domainOrg(domain)
+}
+
+// domainOrg returns the name of the organization that owns the email domain,
+// such as "redhat" for "us.redhat.com", so that it matches the company names
+// on GitHub profiles. Short second level labels, such as "co" in "co.uk", are
+// skipped.
+func domainOrg(domain string) string {
+	labels := strings.Split(domain, ".")
+	if len(labels) < 2 {
+		return ""
+	}
+	org := labels[len(labels)-2]
+	if len(org) <= 3 && len(labels) >= 3 {
+		org = labels[len(labels)-3]
+	}
+	return org
+}
+
+// distinctOrgCount returns the number of distinct organizations that the
+// authors work for. Authors whose organization is unknown are ignored.
+func distinctOrgCount(authors []commitAuthor) int {
+	orgs := make(map[string]struct{})
+	seen := make(map[string]struct{})
+	for _, a := range authors {
+		k := a.key()
+		if _, ok := seen[k]; ok {
+			continue
+		}
+		seen[k] = struct{}{}
+		if org := authorOrg(a); org != "" {
+			orgs[org] = struct{}{}
+		}
+	}
+	return len(orgs)
+}
@@ -0,0 +1,39 @@
+package github
+
+import (
+	"testing"
+)
+
+func TestAuthorOrg(t *testing.T) {
+	tests := []struct {
+		author commitAuthor
+		want   string
+	}{
+		{commitAuthor{Company: "@RedHat"}, "redhat"},
+		{commitAuthor{Company: "Red Hat, Inc.", Email: "x@gmail.com"}, "redhat"},
+		{commitAuthor{Email: "x@us.redhat.com"}, "redhat"},
+		{commitAuthor{Email: "x@example.co.uk"}, "example"},
+		{commitAuthor{Email: "x@gmail.com"}, ""},
+		{commitAuthor{Email: "1234+x@users.noreply.github.com"}, ""},
+		{commitAuthor{Email: "root@localhost"}, ""},
+		{commitAuthor{}, ""},
+	}
+	for _, test := range tests {
+		if got := authorOrg(test.author); got != test.want {
+			t.Fatalf("authorOrg(%+v) == %q, want %q", test.author, got, test.want)
+		}
+	}
+}
+
+func TestDistinctOrgCount(t *testing.T) {
+	authors := []commitAuthor{
+		{Login: "a", Company: "Red Hat"},
+		{Login: "b", Email: "b@redhat.com"},
+		{Login: "a", Company: "Red Hat"},
+		{Email: "c@example.org"},
+		{Email: "d@gmail.com"},
+	}
+	if got := distinctOrgCount(authors); got != 2 {
+		t.Fatalf("distinctOrgCount() == %d, want 2", got)
+	}
+}
@@ -0,0 +1,51 @@
+package github
+
+import (
+	"context"
+
+	"github.com/ossf/criticality_score/internal/githubapi"
+	"github.com/shurcooL/githubv4"
+)
+
+// packagesPerPage is the number of packages fetched for a repository. Few
+// repositories publish more packages than this.
+const packagesPerPage = 100
+
+type packagesQuery struct {
+	Repository struct {
+		Packages struct {
+			TotalCount int
+			Nodes      []struct {
+				Name       string
+				Statistics *struct {
+					DownloadsTotalCount int
+				}
+			}
+		} `graphql:"packages(first: $perPage)"`
+	} `graphql:"repository(owner: $repositoryOwner, name: $repositoryName)"`
+}
+
+// fetchPackageDownloads returns the number of GitHub Packages published from
+// the repository, and the total number of times they have been downloaded.
+//
+// The GraphQL API only reports downloads for the npm, Maven, RubyGems, NuGet
+// and Docker registries. Packages in the GitHub Container Registry are
+// counted, but their downloads are not available. Only the downloads of the
+// first packagesPerPage packages are included.
+func fetchPackageDownloads(ctx context.Context, c *githubapi.Client, owner, name string) (count, downloads int, err error) {
+	q := &packagesQuery{}
+	vars := map[string]any{
+		"repositoryOwner": githubv4.String(owner),
+		"repositoryName":  githubv4.String(name),
+		"perPage":         githubv4.Int(packagesPerPage),
+	}
+	if err := c.GraphQL().Query(ctx, q, vars); err != nil {
+		return 0, 0, err
+	}
+	for _, p := range q.Repository.Packages.Nodes {
+		if p.Statistics != nil {
+			downloads += p.Statistics.DownloadsTotalCount
+		}
+	}
+	return q.Repository.Packages.TotalCount, downloads, nil
+}
@@ -0,0 +1,36 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestFetchPackageDownloads(t *testing.T) {
+	c := newTestClient(t, jsonHandler(http.StatusOK, `{"data": {"repository": {"packages": {"totalCount": 3, "nodes": [
+		{"name": "example", "statistics": {"downloadsTotalCount": 1200}},
+		{"name": "example-cli", "statistics": {"downloadsTotalCount": 34}},
+		{"name": "example-image", "statistics": null}
+	]}}}}`))
+	count, downloads, err := fetchPackageDownloads(context.Background(), c, "example", "example")
+	if err != nil {
+		t.Fatalf("fetchPackageDownloads() errored %v, want no error", err)
+	}
+	if count != 3 {
+		t.Fatalf("fetchPackageDownloads() count == %d, want 3", count)
+	}
+	if downloads != 1234 {
+		t.Fatalf("fetchPackageDownloads() downloads == %d, want 1234", downloads)
+	}
+}
+
+func TestFetchPackageDownloads_NoPackages(t *testing.T) {
+	c := newTestClient(t, jsonHandler(http.StatusOK, `{"data": {"repository": {"packages": {"totalCount": 0, "nodes": []}}}}`))
+	count, downloads, err := fetchPackageDownloads(context.Background(), c, "example", "example")
+	if err != nil {
+		t.Fatalf("fetchPackageDownloads() errored %v, want no error", err)
+	}
+	if count != 0 || downloads != 0 {
+		t.Fatalf("fetchPackageDownloads() == %d, %d, want 0, 0", count, downloads)
+	}
+}
@@ -0,0 +1,67 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/google/go-github/v44/github"
+	"github.com/ossf/criticality_score/internal/githubapi"
+)
+
+// branchProtection contains the subset of a branch's protection rule that is
+// used to generate signals.
+type branchProtection struct {
+	// Protected is true if the branch has a protection rule.
+	Protected bool
+
+	// RequiredReviewCount is the number of approving reviews a pull request
+	// needs before it can be merged into the branch.
+	RequiredReviewCount int
+
+	RequiresLinearHistory bool
+	AllowsForcePush       bool
+}
+
+// unprotectedBranch is the protection that applies to a branch if it has no
+// protection rule configured.
+var unprotectedBranch = branchProtection{
+	Protected:             false,
+	RequiredReviewCount:   0,
+	RequiresLinearHistory: false,
+	AllowsForcePush:       true,
+}
+
+// fetchBranchProtection returns the protection rule for the given branch.
+//
+// Reading a branch's protection rule requires admin access to the repository.
+// If the token used does not have access, nil will be returned along with a
+// nil error.
+func fetchBranchProtection(ctx context.Context, c *githubapi.Client, owner, name, branch string) (*branchProtection, error) {
+	p, _, err := c.Rest().Repositories.GetBranchProtection(ctx, owner, name, branch)
+	if errors.Is(err, github.ErrBranchNotProtected) {
+		bp := unprotectedBranch
+		return &bp, nil
+	}
+	switch githubapi.ErrorResponseStatusCode(err) {
+	case http.StatusForbidden, http.StatusNotFound:
+		// A 403 is returned if the token lacks admin access, while a 404
+		// may be returned for private repositories the token can't see.
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	// Settings that are missing from a protection rule are disabled.
+	bp := &branchProtection{Protected: true}
+	if rr := p.GetRequiredPullRequestReviews(); rr != nil {
+		bp.RequiredReviewCount = rr.RequiredApprovingReviewCount
+	}
+	if lh := p.GetRequireLinearHistory(); lh != nil {
+		bp.RequiresLinearHistory = lh.Enabled
+	}
+	if fp := p.GetAllowForcePushes(); fp != nil {
+		bp.AllowsForcePush = fp.Enabled
+	}
+	return bp, nil
+}
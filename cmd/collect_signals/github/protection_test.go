@@ -0,0 +1,97 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+const testProtectionPath = "/repos/example/example/branches/main/protection"
+
+func protectionHandler(status int, body string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != testProtectionPath {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	})
+}
+
+func TestFetchBranchProtection_Configured(t *testing.T) {
+	c := newTestClient(t, protectionHandler(http.StatusOK, `{
+		"required_linear_history": {"enabled": true},
+		"allow_force_pushes": {"enabled": false},
+		"required_pull_request_reviews": {"required_approving_review_count": 2}
+	}`))
+	bp, err := fetchBranchProtection(context.Background(), c, "example", "example", "main")
+	if err != nil {
+		t.Fatalf("fetchBranchProtection() errored %v, want no error", err)
+	}
+	if bp == nil {
+		t.Fatal("fetchBranchProtection() == nil, want a protection rule")
+	}
+	if !bp.Protected {
+		t.Errorf("Protected == false, want true")
+	}
+	if bp.RequiredReviewCount != 2 {
+		t.Errorf("RequiredReviewCount == %d, want 2", bp.RequiredReviewCount)
+	}
+	if !bp.RequiresLinearHistory {
+		t.Errorf("RequiresLinearHistory == false, want true")
+	}
+	if bp.AllowsForcePush {
+		t.Errorf("AllowsForcePush == true, want false")
+	}
+}
+
+func TestFetchBranchProtection_MissingSettings(t *testing.T) {
+	c := newTestClient(t, protectionHandler(http.StatusOK, `{}`))
+	bp, err := fetchBranchProtection(context.Background(), c, "example", "example", "main")
+	if err != nil {
+		t.Fatalf("fetchBranchProtection() errored %v, want no error", err)
+	}
+	if bp == nil {
+		t.Fatal("fetchBranchProtection() == nil, want a protection rule")
+	}
+	if !bp.Protected {
+		t.Errorf("Protected == false, want true")
+	}
+	if bp.RequiredReviewCount != 0 || bp.RequiresLinearHistory || bp.AllowsForcePush {
+		t.Errorf("fetchBranchProtection() == %+v, want all settings disabled", *bp)
+	}
+}
+
+func TestFetchBranchProtection_Unconfigured(t *testing.T) {
+	c := newTestClient(t, protectionHandler(http.StatusNotFound, `{"message": "Branch not protected"}`))
+	bp, err := fetchBranchProtection(context.Background(), c, "example", "example", "main")
+	if err != nil {
+		t.Fatalf("fetchBranchProtection() errored %v, want no error", err)
+	}
+	if bp == nil {
+		t.Fatal("fetchBranchProtection() == nil, want a protection rule")
+	}
+	if *bp != unprotectedBranch {
+		t.Errorf("fetchBranchProtection() == %+v, want %+v", *bp, unprotectedBranch)
+	}
+}
+
+func TestFetchBranchProtection_AccessDenied(t *testing.T) {
+	c := newTestClient(t, protectionHandler(http.StatusForbidden, `{"message": "Must have admin rights to Repository."}`))
+	bp, err := fetchBranchProtection(context.Background(), c, "example", "example", "main")
+	if err != nil {
+		t.Fatalf("fetchBranchProtection() errored %v, want no error", err)
+	}
+	if bp != nil {
+		t.Fatalf("fetchBranchProtection() == %+v, want nil", *bp)
+	}
+}
+
+func TestFetchBranchProtection_ServerError(t *testing.T) {
+	c := newTestClient(t, protectionHandler(http.StatusInternalServerError, `{"message": "Server Error"}`))
+	if _, err := fetchBranchProtection(context.Background(), c, "example", "example", "main"); err == nil {
+		t.Fatal("fetchBranchProtection() returned no error, want an error")
+	}
+}
@@ -0,0 +1,52 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"strings"
+
+	"github.com/ossf/criticality_score/internal/githubapi"
+)
+
+// provenanceSuffixes holds the file name suffixes used by release assets that
+// contain SLSA provenance or other build attestations.
+var provenanceSuffixes = []string{
+	".intoto.jsonl",
+	".intoto.json",
+	".slsa",
+	".att",
+	".sigstore",
+}
+
+// isProvenanceAsset returns true if the release asset named name appears to
+// contain build provenance.
+func isProvenanceAsset(name string) bool {
+	name = strings.ToLower(name)
+	for _, suffix := range provenanceSuffixes {
+		if strings.HasSuffix(name, suffix) {
+			return true
+		}
+	}
+	return strings.Contains(name, "provenance")
+}
+
+// fetchLatestReleaseProvenance returns true if the latest release of the
+// repository has an asset containing build provenance.
+//
+// If the repository has no releases, whether provenance is published can't be
+// determined and false is returned for ok.
+func fetchLatestReleaseProvenance(ctx context.Context, c *githubapi.Client, owner, name string) (hasProvenance, ok bool, err error) {
+	release, _, err := c.Rest().Repositories.GetLatestRelease(ctx, owner, name)
+	if githubapi.ErrorResponseStatusCode(err) == http.StatusNotFound {
+		return false, false, nil
+	}
+	if err != nil {
+		return false, false, err
+	}
+	for _, a := range release.Assets {
+		if isProvenanceAsset(a.GetName()) {
+			return true, true, nil
+		}
+	}
+	return false, true, nil
+}
@@ -0,0 +1,71 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestIsProvenanceAsset(t *testing.T) {
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{"example_linux_amd64.tar.gz", false},
+		{"checksums.txt", false},
+		{"example.intoto.jsonl", true},
+		{"multiple.INTOTO.JSONL", true},
+		{"example.sigstore", true},
+		{"provenance.json", true},
+		{"attestation.slsa", true},
+	}
+	for _, test := range tests {
+		if got := isProvenanceAsset(test.name); got != test.want {
+			t.Fatalf("isProvenanceAsset(%q) == %v, want %v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestFetchLatestReleaseProvenance(t *testing.T) {
+	c := newTestClient(t, jsonHandler(http.StatusOK, `{"tag_name": "v1.0.0", "assets": [
+		{"name": "example_linux_amd64.tar.gz"},
+		{"name": "example.intoto.jsonl"}
+	]}`))
+	has, ok, err := fetchLatestReleaseProvenance(context.Background(), c, "example", "example")
+	if err != nil {
+		t.Fatalf("fetchLatestReleaseProvenance() errored %v, want no error", err)
+	}
+	if !ok {
+		t.Fatal("fetchLatestReleaseProvenance() returned false for ok, want true")
+	}
+	if !has {
+		t.Fatal("fetchLatestReleaseProvenance() == false, want true")
+	}
+}
+
+func TestFetchLatestReleaseProvenance_NoProvenance(t *testing.T) {
+	c := newTestClient(t, jsonHandler(http.StatusOK, `{"tag_name": "v1.0.0", "assets": [
+		{"name": "example_linux_amd64.tar.gz"}
+	]}`))
+	has, ok, err := fetchLatestReleaseProvenance(context.Background(), c, "example", "example")
+	if err != nil {
+		t.Fatalf("fetchLatestReleaseProvenance() errored %v, want no error", err)
+	}
+	if !ok {
+		t.Fatal("fetchLatestReleaseProvenance() returned false for ok, want true")
+	}
+	if has {
+		t.Fatal("fetchLatestReleaseProvenance() == true, want false")
+	}
+}
+
+func TestFetchLatestReleaseProvenance_NoRelease(t *testing.T) {
+	c := newTestClient(t, jsonHandler(http.StatusNotFound, `{"message": "Not Found"}`))
+	_, ok, err := fetchLatestReleaseProvenance(context.Background(), c, "example", "example")
+	if err != nil {
+		t.Fatalf("fetchLatestReleaseProvenance() errored %v, want no error", err)
+	}
+	if ok {
+		t.Fatal("fetchLatestReleaseProvenance() returned true for ok, want false")
+	}
+}
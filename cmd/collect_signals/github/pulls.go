@@ -0,0 +1,178 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/ossf/criticality_score/cmd/collect_signals/signal"
+	"github.com/ossf/criticality_score/internal/githubapi"
+	"github.com/ossf/criticality_score/internal/githubapi/pagination"
+	"github.com/shurcooL/githubv4"
+)
+
+const (
+	recentPullsPerPage = 50
+
+	// recentPullLookback is how far back pull requests are examined.
+	recentPullLookback = 90 * 24 * time.Hour
+
+	// maxRecentPullsSampled limits the number of recent pull requests that
+	// are examined, to bound the number of pages fetched.
+	maxRecentPullsSampled = 500
+)
+
+// pullRequest is a single pull request.
+type pullRequest struct {
+	CreatedAt time.Time
+
+	// MergedAt is the time the pull request was merged, or the zero time if
+	// it has not been merged.
+	MergedAt time.Time
+
+	// Closed is true if the pull request has been merged or closed.
+	Closed bool
+
+	// External is true if the author is not a maintainer of the project.
+	External bool
+
+	// Reviewed is true if the pull request has at least one review.
+	Reviewed bool
+}
+
+func (p pullRequest) merged() bool {
+	return !p.MergedAt.IsZero()
+}
+
+type recentPullsQuery struct {
+	Repository struct {
+		PullRequests struct {
+			TotalCount int
+			Nodes      []struct {
+				CreatedAt         time.Time
+				MergedAt          *time.Time
+				Closed            bool
+				AuthorAssociation string
+				Reviews           struct {
+					TotalCount int
+				}
+			}
+			PageInfo struct {
+				EndCursor   string
+				HasNextPage bool
+			}
+		} `graphql:"pullRequests(orderBy: {field: CREATED_AT, direction: DESC}, first: $perPage, after: $endCursor)"`
+	} `graphql:"repository(owner: $repositoryOwner, name: $repositoryName)"`
+}
+
+// Total implements the pagination.PagedQuery interface
+func (q *recentPullsQuery) Total() int {
+	return q.Repository.PullRequests.TotalCount
+}
+
+// Length implements the pagination.PagedQuery interface
+func (q *recentPullsQuery) Length() int {
+	return len(q.Repository.PullRequests.Nodes)
+}
+
+// Get implements the pagination.PagedQuery interface
+func (q *recentPullsQuery) Get(i int) any {
+	n := q.Repository.PullRequests.Nodes[i]
+	p := pullRequest{
+		CreatedAt: n.CreatedAt,
+		Closed:    n.Closed,
+		External:  !maintainerAssociations[n.AuthorAssociation],
+		Reviewed:  n.Reviews.TotalCount > 0,
+	}
+	if n.MergedAt != nil {
+		p.MergedAt = *n.MergedAt
+	}
+	return p
+}
+
+// HasNextPage implements the pagination.PagedQuery interface
+func (q *recentPullsQuery) HasNextPage() bool {
+	return q.Repository.PullRequests.PageInfo.HasNextPage
+}
+
+// NextPageVars implements the pagination.PagedQuery interface
+func (q *recentPullsQuery) NextPageVars() map[string]any {
+	if q.Repository.PullRequests.PageInfo.EndCursor == "" {
+		return map[string]any{
+			"endCursor": (*githubv4.String)(nil),
+		}
+	} else {
+		return map[string]any{
+			"endCursor": githubv4.String(q.Repository.PullRequests.PageInfo.EndCursor),
+		}
+	}
+}
+
+// fetchRecentPulls returns the pull requests created since the given time,
+// most recent first.
+//
+// At most maxSampled pull requests are returned.
+func fetchRecentPulls(ctx context.Context, c *githubapi.Client, owner, name string, since time.Time, maxSampled int) ([]pullRequest, error) {
+	s := &recentPullsQuery{}
+	vars := map[string]any{
+		"perPage":         githubv4.Int(recentPullsPerPage),
+		"repositoryOwner": githubv4.String(owner),
+		"repositoryName":  githubv4.String(name),
+	}
+	cursor, err := pagination.Query(ctx, c.GraphQL(), s, vars)
+	if err != nil {
+		return nil, err
+	}
+	var pulls []pullRequest
+	for len(pulls) < maxSampled {
+		obj, err := cursor.Next()
+		if obj == nil && errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		p := obj.(pullRequest)
+		if p.CreatedAt.Before(since) {
+			// Pull requests are ordered by creation, so the rest are older.
+			break
+		}
+		pulls = append(pulls, p)
+	}
+	return pulls, nil
+}
+
+// summarizePulls sets the pull request signals in s from pulls.
+//
+// Each signal is left unset if none of the pull requests can be used to
+// calculate it. PullMergeHours only considers merged pull requests, and
+// ExternalPullAcceptancePercent only considers closed pull requests from
+// authors outside the project.
+func summarizePulls(s *signal.RepoSet, pulls []pullRequest) {
+	if len(pulls) == 0 {
+		return
+	}
+	var mergeTimes []time.Duration
+	reviewed, externalClosed, externalMerged := 0, 0, 0
+	for _, p := range pulls {
+		if p.merged() {
+			mergeTimes = append(mergeTimes, p.MergedAt.Sub(p.CreatedAt))
+		}
+		if p.Reviewed {
+			reviewed++
+		}
+		if p.External && p.Closed {
+			externalClosed++
+			if p.merged() {
+				externalMerged++
+			}
+		}
+	}
+	if len(mergeTimes) > 0 {
+		s.PullMergeHours.Set(int(medianDuration(mergeTimes).Hours()))
+	}
+	s.ReviewedPullPercent.Set(percent(reviewed, len(pulls)))
+	if externalClosed > 0 {
+		s.ExternalPullAcceptancePercent.Set(percent(externalMerged, externalClosed))
+	}
+}
@@ -0,0 +1,97 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ossf/criticality_score/cmd/collect_signals/signal"
+)
+
+func TestFetchRecentPulls(t *testing.T) {
+	c := newTestClient(t, jsonHandler(http.StatusOK, `{"data": {"repository": {"pullRequests": {
+		"totalCount": 3,
+		"nodes": [
+			{"createdAt": "2022-03-02T00:00:00Z", "mergedAt": "2022-03-03T00:00:00Z", "closed": true, "authorAssociation": "CONTRIBUTOR", "reviews": {"totalCount": 2}},
+			{"createdAt": "2022-03-01T00:00:00Z", "mergedAt": null, "closed": false, "authorAssociation": "MEMBER", "reviews": {"totalCount": 0}},
+			{"createdAt": "2021-12-01T00:00:00Z", "mergedAt": null, "closed": true, "authorAssociation": "NONE", "reviews": {"totalCount": 0}}
+		],
+		"pageInfo": {"endCursor": "abc", "hasNextPage": false}
+	}}}}`))
+	since := time.Date(2022, time.January, 1, 0, 0, 0, 0, time.UTC)
+	pulls, err := fetchRecentPulls(context.Background(), c, "example", "example", since, maxRecentPullsSampled)
+	if err != nil {
+		t.Fatalf("fetchRecentPulls() errored %v, want no error", err)
+	}
+	want := []pullRequest{
+		{
+			CreatedAt: time.Date(2022, time.March, 2, 0, 0, 0, 0, time.UTC),
+			MergedAt:  time.Date(2022, time.March, 3, 0, 0, 0, 0, time.UTC),
+			Closed:    true,
+			External:  true,
+			Reviewed:  true,
+		},
+		{CreatedAt: time.Date(2022, time.March, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	if len(pulls) != len(want) {
+		t.Fatalf("fetchRecentPulls() == %+v, want %+v", pulls, want)
+	}
+	for i := range want {
+		if !pulls[i].CreatedAt.Equal(want[i].CreatedAt) || !pulls[i].MergedAt.Equal(want[i].MergedAt) ||
+			pulls[i].Closed != want[i].Closed || pulls[i].External != want[i].External || pulls[i].Reviewed != want[i].Reviewed {
+			t.Fatalf("fetchRecentPulls()[%d] == %+v, want %+v", i, pulls[i], want[i])
+		}
+	}
+}
+
+func TestSummarizePulls(t *testing.T) {
+	created := time.Date(2022, time.March, 1, 0, 0, 0, 0, time.UTC)
+	pulls := []pullRequest{
+		{CreatedAt: created, MergedAt: created.Add(2 * time.Hour), Closed: true, External: true, Reviewed: true},
+		{CreatedAt: created, MergedAt: created.Add(30 * time.Hour), Closed: true, Reviewed: true},
+		{CreatedAt: created, MergedAt: created.Add(5 * time.Hour), Closed: true},
+		{CreatedAt: created, Closed: true, External: true},
+		{CreatedAt: created, External: true},
+	}
+	s := &signal.RepoSet{}
+	summarizePulls(s, pulls)
+	if got := s.PullMergeHours.Get(); got != 5 {
+		t.Fatalf("PullMergeHours == %d, want 5", got)
+	}
+	if got := s.ReviewedPullPercent.Get(); got != 40 {
+		t.Fatalf("ReviewedPullPercent == %v, want 40", got)
+	}
+	if got := s.ExternalPullAcceptancePercent.Get(); got != 50 {
+		t.Fatalf("ExternalPullAcceptancePercent == %v, want 50", got)
+	}
+}
+
+func TestSummarizePulls_NoPulls(t *testing.T) {
+	s := &signal.RepoSet{}
+	summarizePulls(s, nil)
+	if s.PullMergeHours.IsSet() {
+		t.Fatalf("PullMergeHours is set, want unset")
+	}
+	if s.ReviewedPullPercent.IsSet() {
+		t.Fatalf("ReviewedPullPercent is set, want unset")
+	}
+	if s.ExternalPullAcceptancePercent.IsSet() {
+		t.Fatalf("ExternalPullAcceptancePercent is set, want unset")
+	}
+}
+
+func TestSummarizePulls_NoExternalClosed(t *testing.T) {
+	created := time.Date(2022, time.March, 1, 0, 0, 0, 0, time.UTC)
+	s := &signal.RepoSet{}
+	summarizePulls(s, []pullRequest{{CreatedAt: created, External: true}})
+	if s.PullMergeHours.IsSet() {
+		t.Fatalf("PullMergeHours is set, want unset")
+	}
+	if got := s.ReviewedPullPercent.Get(); got != 0 {
+		t.Fatalf("ReviewedPullPercent == %v, want 0", got)
+	}
+	if s.ExternalPullAcceptancePercent.IsSet() {
+		t.Fatalf("ExternalPullAcceptancePercent is set, want unset")
+	}
+}
@@ -2,10 +2,13 @@ package github
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/url"
 	"strings"
 	"time"
 
+	"github.com/ossf/criticality_score/internal/githubapi"
 	"github.com/shurcooL/githubv4"
 )
 
@@ -15,11 +18,14 @@ const (
 	legacyCommitLookback      = time.Duration(365 * 24 * time.Hour)
 )
 
+var errInvalidRepoPath = errors.New("invalid GitHub repository path")
+
 type basicRepoData struct {
 	Name            string
 	Owner           struct{ Login string }
 	LicenseInfo     struct{ Name string }
 	StargazerCount  int
+	ForkCount       int
 	URL             string
 	MirrorURL       string
 	CreatedAt       time.Time
@@ -27,9 +33,25 @@ type basicRepoData struct {
 	PrimaryLanguage struct {
 		Name string
 	}
+
+	// DiskUsage is the size of the repository in kilobytes.
+	DiskUsage int
+
+	// Languages only includes the largest language, which is usually the
+	// primary language.
+	Languages struct {
+		TotalCount int
+		TotalSize  int
+		Edges      []struct {
+			Size int
+		}
+	} `graphql:"languages(first: 1, orderBy: {field: SIZE, direction: DESC})"`
 	Watchers struct {
 		TotalCount int
 	}
+	OpenIssues struct {
+		TotalCount int
+	} `graphql:"openissues:issues(states: OPEN)"`
 	HasIssuesEnabled bool
 	IsArchived       bool
 	IsDisabled       bool
@@ -37,6 +59,7 @@ type basicRepoData struct {
 	IsMirror         bool
 
 	DefaultBranchRef struct {
+		Name   string
 		Target struct {
 			Commit struct { // this is the last commit
 				AuthoredDate  time.Time
@@ -50,20 +73,61 @@ type basicRepoData struct {
 	Tags struct {
 		TotalCount int
 	} `graphql:"refs(refPrefix:\"refs/tags/\")"`
+
+	LatestTag struct {
+		Nodes []struct {
+			Target struct {
+				Commit struct {
+					CommittedDate time.Time
+				} `graphql:"... on Commit"`
+				Tag struct {
+					Tagger struct {
+						Date time.Time
+					}
+				} `graphql:"... on Tag"`
+			}
+		}
+	} `graphql:"latesttag:refs(refPrefix:\"refs/tags/\", first: 1, orderBy: {field: TAG_COMMIT_DATE, direction: DESC})"`
+
+	Releases struct {
+		TotalCount int
+	}
+
+	LatestRelease struct {
+		PublishedAt time.Time
+	}
+}
+
+// parseRepoPath returns the owner and name of the repository from the path of
+// u.
+//
+// A trailing ".git" is removed from the name. If the path does not contain
+// exactly an owner and a name an error will be returned.
+func parseRepoPath(u *url.URL) (owner, name string, err error) {
+	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
+	if len(parts) != 2 {
+		return "", "", fmt.Errorf("%w: %s", errInvalidRepoPath, u)
+	}
+	owner = parts[0]
+	name = strings.TrimSuffix(parts[1], ".git")
+	if owner == "" || name == "" {
+		return "", "", fmt.Errorf("%w: %s", errInvalidRepoPath, u)
+	}
+	return owner, name, nil
 }
 
 func queryBasicRepoData(ctx context.Context, client *githubv4.Client, u *url.URL) (*basicRepoData, error) {
 	// Search based on owner and repo name becaues the `repository` query
 	// better handles changes in ownership and repository name than the
 	// `resource` query.
-	// TODO - consider improving support for scp style urls and urls ending in .git
-	parts := strings.Split(strings.Trim(u.Path, "/"), "/")
-	owner := parts[0]
-	name := parts[1]
+	owner, name, err := parseRepoPath(u)
+	if err != nil {
+		return nil, err
+	}
 	s := &struct {
 		Repository basicRepoData `graphql:"repository(owner: $repositoryOwner, name: $repositoryName)"`
 	}{}
-	now := time.Now().UTC()
+	now := githubapi.Today()
 	vars := map[string]any{
 		"repositoryOwner":      githubv4.String(owner),
 		"repositoryName":       githubv4.String(name),
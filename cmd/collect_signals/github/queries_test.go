@@ -0,0 +1,63 @@
+package github
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/ossf/criticality_score/cmd/collect_signals/projectrepo"
+)
+
+func TestParseRepoPath(t *testing.T) {
+	tests := []struct {
+		raw       string
+		wantOwner string
+		wantName  string
+	}{
+		{raw: "https://github.com/owner/repo", wantOwner: "owner", wantName: "repo"},
+		{raw: "https://github.com/owner/repo/", wantOwner: "owner", wantName: "repo"},
+		{raw: "https://github.com/owner/repo.git", wantOwner: "owner", wantName: "repo"},
+		{raw: "https://github.com/owner/repo.js", wantOwner: "owner", wantName: "repo.js"},
+		{raw: "ssh://git@github.com/owner/repo.git", wantOwner: "owner", wantName: "repo"},
+		{raw: "git@github.com:owner/repo.git", wantOwner: "owner", wantName: "repo"},
+		{raw: "git@github.com:owner/repo", wantOwner: "owner", wantName: "repo"},
+	}
+	for _, test := range tests {
+		t.Run(test.raw, func(t *testing.T) {
+			u, err := projectrepo.ParseURL(test.raw)
+			if err != nil {
+				t.Fatalf("ParseURL() errored %v, want no error", err)
+			}
+			owner, name, err := parseRepoPath(u)
+			if err != nil {
+				t.Fatalf("parseRepoPath() errored %v, want no error", err)
+			}
+			if owner != test.wantOwner || name != test.wantName {
+				t.Fatalf("parseRepoPath() == %q, %q; want %q, %q", owner, name, test.wantOwner, test.wantName)
+			}
+		})
+	}
+}
+
+func TestParseRepoPath_Invalid(t *testing.T) {
+	tests := []string{
+		"https://github.com",
+		"https://github.com/",
+		"https://github.com/owner",
+		"https://github.com/owner/",
+		"https://github.com/owner/repo/tree/main",
+		"https://github.com//repo",
+		"https://github.com/owner/.git",
+		"git@github.com:owner",
+	}
+	for _, raw := range tests {
+		t.Run(raw, func(t *testing.T) {
+			u, err := projectrepo.ParseURL(raw)
+			if err != nil {
+				t.Fatalf("ParseURL() errored %v, want no error", err)
+			}
+			if _, _, err := parseRepoPath(u); !errors.Is(err, errInvalidRepoPath) {
+				t.Fatalf("parseRepoPath() errored %v, want %v", err, errInvalidRepoPath)
+			}
+		})
+	}
+}
@@ -51,6 +51,26 @@ func (r *repo) init(ctx context.Context) error {
 	return nil
 }
 
+// UpstreamURL implements the projectrepo.Mirror interface.
+//
+// The upstream repository is read from the mirror URL GitHub records for the
+// repository. "git" and "http" URLs are changed to "https", so a factory can
+// resolve them.
+func (r *repo) UpstreamURL() *url.URL {
+	if !r.BasicData.IsMirror && r.BasicData.MirrorURL == "" {
+		return nil
+	}
+	u, err := url.Parse(r.BasicData.MirrorURL)
+	if err != nil || u.Host == "" {
+		return nil
+	}
+	switch u.Scheme {
+	case "git", "http":
+		u.Scheme = "https"
+	}
+	return u
+}
+
 func (r *repo) owner() string {
 	return r.BasicData.Owner.Login
 }
@@ -66,3 +86,31 @@ func (r *repo) updatedAt() time.Time {
 func (r *repo) createdAt() time.Time {
 	return r.created
 }
+
+// latestTagAt returns the time of the most recent tag, or the zero time if
+// there are no tags.
+//
+// Annotated tags use the time they were tagged, while lightweight tags use the
+// time of the commit they point to.
+func (r *repo) latestTagAt() time.Time {
+	if len(r.BasicData.LatestTag.Nodes) == 0 {
+		return time.Time{}
+	}
+	target := r.BasicData.LatestTag.Nodes[0].Target
+	if d := target.Tag.Tagger.Date; !d.IsZero() {
+		return d
+	}
+	return target.Commit.CommittedDate
+}
+
+// primaryLanguageShare returns the fraction of the code in the repository
+// that is written in its largest language, rounded to two decimal places.
+//
+// If GitHub did not detect any languages, false is returned.
+func (r *repo) primaryLanguageShare() (float64, bool) {
+	l := r.BasicData.Languages
+	if l.TotalSize == 0 || len(l.Edges) == 0 {
+		return 0, false
+	}
+	return legacy.Round(float64(l.Edges[0].Size)/float64(l.TotalSize), 2), true
+}
@@ -0,0 +1,103 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+)
+
+func TestRepoLatestTagAt(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want time.Time
+	}{
+		{
+			name: "annotated tag",
+			body: `{"data": {"repository": {"latesttag": {"nodes": [
+				{"target": {"tagger": {"date": "2022-05-04T10:00:00Z"}}}
+			]}}}}`,
+			want: time.Date(2022, 5, 4, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "lightweight tag",
+			body: `{"data": {"repository": {"latesttag": {"nodes": [
+				{"target": {"committedDate": "2022-03-02T08:00:00Z"}}
+			]}}}}`,
+			want: time.Date(2022, 3, 2, 8, 0, 0, 0, time.UTC),
+		},
+		{
+			name: "no tags",
+			body: `{"data": {"repository": {"latesttag": {"nodes": []}}}}`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := newTestClient(t, jsonHandler(http.StatusOK, test.body))
+			u, _ := url.Parse("https://github.com/example/example")
+			data, err := queryBasicRepoData(context.Background(), c.GraphQL(), u)
+			if err != nil {
+				t.Fatalf("queryBasicRepoData() errored %v, want no error", err)
+			}
+			r := &repo{BasicData: data}
+			if got := r.latestTagAt(); !got.Equal(test.want) {
+				t.Fatalf("latestTagAt() == %v, want %v", got, test.want)
+			}
+		})
+	}
+}
+
+func TestRepoPrimaryLanguageShare(t *testing.T) {
+	c := newTestClient(t, jsonHandler(http.StatusOK, `{"data": {"repository": {"languages": {
+		"totalCount": 3, "totalSize": 3000, "edges": [{"size": 2000}]
+	}}}}`))
+	u, _ := url.Parse("https://github.com/example/example")
+	data, err := queryBasicRepoData(context.Background(), c.GraphQL(), u)
+	if err != nil {
+		t.Fatalf("queryBasicRepoData() errored %v, want no error", err)
+	}
+	r := &repo{BasicData: data}
+	share, ok := r.primaryLanguageShare()
+	if !ok {
+		t.Fatal("primaryLanguageShare() ok == false, want true")
+	}
+	if share != 0.67 {
+		t.Fatalf("primaryLanguageShare() == %v, want 0.67", share)
+	}
+}
+
+func TestRepoPrimaryLanguageShare_NoLanguages(t *testing.T) {
+	r := &repo{BasicData: &basicRepoData{}}
+	if _, ok := r.primaryLanguageShare(); ok {
+		t.Fatal("primaryLanguageShare() ok == true, want false")
+	}
+}
+
+func TestRepoUpstreamURL(t *testing.T) {
+	tests := []struct {
+		name      string
+		isMirror  bool
+		mirrorURL string
+		want      string
+	}{
+		{name: "not a mirror"},
+		{name: "mirror", isMirror: true, mirrorURL: "https://git.example.org/example.git", want: "https://git.example.org/example.git"},
+		{name: "git url", isMirror: true, mirrorURL: "git://git.example.org/example.git", want: "https://git.example.org/example.git"},
+		{name: "mirror url only", mirrorURL: "https://git.example.org/example", want: "https://git.example.org/example"},
+		{name: "unknown upstream", isMirror: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			r := &repo{BasicData: &basicRepoData{IsMirror: test.isMirror, MirrorURL: test.mirrorURL}}
+			got := ""
+			if u := r.UpstreamURL(); u != nil {
+				got = u.String()
+			}
+			if got != test.want {
+				t.Fatalf("UpstreamURL() == %q, want %q", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+package github
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/ossf/criticality_score/internal/githubapi"
+	"github.com/shurcooL/githubv4"
+)
+
+// textBlob holds the contents of a file. It is empty if the file does not
+// exist or is binary.
+type textBlob struct {
+	Blob struct {
+		Text string
+	} `graphql:"... on Blob"`
+}
+
+type securityPolicyQuery struct {
+	Repository struct {
+		IsSecurityPolicyEnabled bool
+
+		// A security policy can be in any of these locations in the
+		// repository, or in the owner's .github repository.
+		Root   textBlob `graphql:"root: object(expression: \"HEAD:SECURITY.md\")"`
+		GitHub textBlob `graphql:"github: object(expression: \"HEAD:.github/SECURITY.md\")"`
+		Docs   textBlob `graphql:"docs: object(expression: \"HEAD:docs/SECURITY.md\")"`
+		Owner  struct {
+			Repository struct {
+				Root   textBlob `graphql:"root: object(expression: \"HEAD:SECURITY.md\")"`
+				GitHub textBlob `graphql:"github: object(expression: \"HEAD:.github/SECURITY.md\")"`
+			} `graphql:"repository(name: \".github\")"`
+		}
+	} `graphql:"repository(owner: $repositoryOwner, name: $repositoryName)"`
+}
+
+// contactPattern matches the common ways a security policy says how to
+// report a vulnerability: an email address, or a link to a web page such as
+// the repository's GitHub Security Advisories.
+var contactPattern = regexp.MustCompile(`(?i)[a-z0-9._%+-]+@[a-z0-9.-]+\.[a-z]{2,}|https?://\S+`)
+
+// securityPolicy describes a repository's security policy.
+type securityPolicy struct {
+	// Enabled is true if GitHub found a security policy for the repository.
+	Enabled bool
+
+	// HasContact is true if the policy includes an email address or a link
+	// for reporting vulnerabilities.
+	HasContact bool
+}
+
+// fetchSecurityPolicy returns whether the repository has a security policy,
+// and whether it says how to report a vulnerability.
+//
+// The policy is looked for in the SECURITY.md files that GitHub recognizes,
+// including the default policy in the owner's .github repository.
+func fetchSecurityPolicy(ctx context.Context, c *githubapi.Client, owner, name string) (*securityPolicy, error) {
+	q := &securityPolicyQuery{}
+	vars := map[string]any{
+		"repositoryOwner": githubv4.String(owner),
+		"repositoryName":  githubv4.String(name),
+	}
+	if err := c.GraphQL().Query(ctx, q, vars); err != nil {
+		return nil, err
+	}
+	r := q.Repository
+	p := &securityPolicy{Enabled: r.IsSecurityPolicyEnabled}
+	if !p.Enabled {
+		return p, nil
+	}
+	// Use the first policy found, in the order GitHub uses.
+	for _, b := range []textBlob{r.GitHub, r.Root, r.Docs, r.Owner.Repository.GitHub, r.Owner.Repository.Root} {
+		if b.Blob.Text != "" {
+			p.HasContact = contactPattern.MatchString(b.Blob.Text)
+			break
+		}
+	}
+	return p, nil
+}
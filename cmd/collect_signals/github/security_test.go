@@ -0,0 +1,50 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestFetchSecurityPolicy(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want securityPolicy
+	}{
+		{
+			name: "email contact",
+			body: `{"data": {"repository": {"isSecurityPolicyEnabled": true,
+				"github": {"text": "Please email security@example.com to report a vulnerability."}}}}`,
+			want: securityPolicy{Enabled: true, HasContact: true},
+		},
+		{
+			name: "advisory link in owner policy",
+			body: `{"data": {"repository": {"isSecurityPolicyEnabled": true, "root": null,
+				"owner": {"repository": {"root": {"text": "Report at https://github.com/example/example/security/advisories/new"}}}}}}`,
+			want: securityPolicy{Enabled: true, HasContact: true},
+		},
+		{
+			name: "no contact",
+			body: `{"data": {"repository": {"isSecurityPolicyEnabled": true,
+				"root": {"text": "We take security seriously."}}}}`,
+			want: securityPolicy{Enabled: true},
+		},
+		{
+			name: "no policy",
+			body: `{"data": {"repository": {"isSecurityPolicyEnabled": false}}}`,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := newTestClient(t, jsonHandler(http.StatusOK, test.body))
+			p, err := fetchSecurityPolicy(context.Background(), c, "example", "example")
+			if err != nil {
+				t.Fatalf("fetchSecurityPolicy() errored %v, want no error", err)
+			}
+			if *p != test.want {
+				t.Fatalf("fetchSecurityPolicy() == %+v, want %+v", *p, test.want)
+			}
+		})
+	}
+}
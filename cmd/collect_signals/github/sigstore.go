@@ -0,0 +1,129 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/ossf/criticality_score/internal/githubapi"
+	"github.com/ossf/criticality_score/internal/httpjson"
+)
+
+const (
+	// DefaultRekorURL is the base URL of the public Sigstore Rekor
+	// transparency log.
+	DefaultRekorURL = "https://rekor.sigstore.dev"
+
+	// signedReleasesSampled is the number of the most recent releases that
+	// are checked for signed assets.
+	signedReleasesSampled = 10
+
+	// maxAssetsChecked limits the number of assets of each release that are
+	// looked up in Rekor, to bound the number of requests.
+	maxAssetsChecked = 5
+)
+
+// signatureSuffixes holds the file name suffixes used by release assets that
+// contain a signature or certificate for another asset, rather than an
+// artifact.
+var signatureSuffixes = []string{
+	".sig",
+	".asc",
+	".pem",
+	".crt",
+	".cert",
+	".bundle",
+	".sigstore.json",
+}
+
+// releaseAsset is a release asset, along with the SHA-256 digest GitHub
+// records for it. The digest is not part of the go-github types, and is empty
+// for assets uploaded before GitHub started recording digests.
+type releaseAsset struct {
+	Name   string `json:"name"`
+	Digest string `json:"digest"`
+}
+
+type releaseAssets struct {
+	TagName string         `json:"tag_name"`
+	Assets  []releaseAsset `json:"assets"`
+}
+
+// isSignatureAsset returns true if the release asset named name appears to
+// hold a signature, certificate or attestation for another asset.
+func isSignatureAsset(name string) bool {
+	lower := strings.ToLower(name)
+	for _, suffix := range signatureSuffixes {
+		if strings.HasSuffix(lower, suffix) {
+			return true
+		}
+	}
+	return isProvenanceAsset(name)
+}
+
+// fetchRecentReleaseAssets returns the assets of the n most recent releases
+// of the repository, most recent first.
+func fetchRecentReleaseAssets(ctx context.Context, c *githubapi.Client, owner, name string, n int) ([]releaseAssets, error) {
+	req, err := c.Rest().NewRequest(http.MethodGet, fmt.Sprintf("repos/%s/%s/releases?per_page=%d", owner, name, n), nil)
+	if err != nil {
+		return nil, err
+	}
+	var releases []releaseAssets
+	if _, err := c.Rest().Do(ctx, req, &releases); err != nil {
+		return nil, err
+	}
+	return releases, nil
+}
+
+// rekorHasEntry returns true if the Rekor log at rekorURL has an entry for
+// an artifact with the given digest, such as "sha256:abc...".
+func rekorHasEntry(ctx context.Context, hc *http.Client, rekorURL, digest string) (bool, error) {
+	body, err := json.Marshal(map[string]string{"hash": digest})
+	if err != nil {
+		return false, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, rekorURL+"/api/v1/index/retrieve", bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	var uuids []string
+	if _, err := httpjson.Do(hc, req, &uuids); err != nil {
+		return false, err
+	}
+	return len(uuids) > 0, nil
+}
+
+// countSignedReleases returns the number of releases with at least one asset
+// that has an entry in the Rekor log at rekorURL.
+//
+// Signatures and attestations are not looked up, nor are assets without a
+// digest. At most maxAssetsChecked assets of each release are looked up.
+func countSignedReleases(ctx context.Context, hc *http.Client, rekorURL string, releases []releaseAssets) (int, error) {
+	signed := 0
+	for _, r := range releases {
+		checked := 0
+		for _, a := range r.Assets {
+			if checked >= maxAssetsChecked {
+				break
+			}
+			if a.Digest == "" || isSignatureAsset(a.Name) {
+				continue
+			}
+			checked++
+			ok, err := rekorHasEntry(ctx, hc, rekorURL, a.Digest)
+			if err != nil {
+				return 0, err
+			}
+			if ok {
+				signed++
+				break
+			}
+		}
+	}
+	return signed, nil
+}
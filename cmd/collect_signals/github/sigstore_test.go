@@ -0,0 +1,122 @@
+package github
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestIsSignatureAsset(t *testing.T) {
+	tests := []struct {
+		name string
+		want bool
+	}{
+		{"example_linux_amd64.tar.gz", false},
+		{"checksums.txt", false},
+		{"checksums.txt.sig", true},
+		{"example.tar.gz.asc", true},
+		{"example.PEM", true},
+		{"example.sigstore.json", true},
+		{"example.intoto.jsonl", true},
+	}
+	for _, test := range tests {
+		if got := isSignatureAsset(test.name); got != test.want {
+			t.Fatalf("isSignatureAsset(%q) == %v, want %v", test.name, got, test.want)
+		}
+	}
+}
+
+func TestFetchRecentReleaseAssets(t *testing.T) {
+	c := newTestClient(t, jsonHandler(http.StatusOK, `[
+		{"tag_name": "v1.1.0", "assets": [{"name": "example.tar.gz", "digest": "sha256:aa"}]},
+		{"tag_name": "v1.0.0", "assets": []}
+	]`))
+	releases, err := fetchRecentReleaseAssets(context.Background(), c, "example", "example", signedReleasesSampled)
+	if err != nil {
+		t.Fatalf("fetchRecentReleaseAssets() errored %v, want no error", err)
+	}
+	if len(releases) != 2 {
+		t.Fatalf("len(releases) == %d, want 2", len(releases))
+	}
+	if got := releases[0].Assets[0].Digest; got != "sha256:aa" {
+		t.Fatalf("Digest == %q, want %q", got, "sha256:aa")
+	}
+}
+
+// newTestRekor returns the URL of a Rekor server that has entries for the
+// given digests, and a pointer to the number of lookups it has answered.
+func newTestRekor(t *testing.T, digests ...string) (string, *int) {
+	t.Helper()
+	logged := make(map[string]bool)
+	for _, d := range digests {
+		logged[d] = true
+	}
+	lookups := 0
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/api/v1/index/retrieve" {
+			http.NotFound(w, r)
+			return
+		}
+		var q struct {
+			Hash string `json:"hash"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&q); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		lookups++
+		w.Header().Set("Content-Type", "application/json")
+		if logged[q.Hash] {
+			w.Write([]byte(`["24296fb24b8ad77a"]`))
+		} else {
+			w.Write([]byte(`[]`))
+		}
+	}))
+	t.Cleanup(s.Close)
+	return s.URL, &lookups
+}
+
+func TestCountSignedReleases(t *testing.T) {
+	rekorURL, lookups := newTestRekor(t, "sha256:signed")
+	releases := []releaseAssets{
+		{TagName: "v3", Assets: []releaseAsset{
+			{Name: "example.tar.gz.sig", Digest: "sha256:sig"},
+			{Name: "example.zip", Digest: "sha256:unsigned"},
+			{Name: "example.tar.gz", Digest: "sha256:signed"},
+			{Name: "example.deb", Digest: "sha256:signed"},
+		}},
+		{TagName: "v2", Assets: []releaseAsset{
+			{Name: "example.tar.gz", Digest: "sha256:unsigned"},
+		}},
+		{TagName: "v1", Assets: []releaseAsset{
+			{Name: "example.tar.gz"},
+		}},
+	}
+	got, err := countSignedReleases(context.Background(), &http.Client{}, rekorURL, releases)
+	if err != nil {
+		t.Fatalf("countSignedReleases() errored %v, want no error", err)
+	}
+	if got != 1 {
+		t.Fatalf("countSignedReleases() == %d, want 1", got)
+	}
+	// The signature and the assets after the first signed asset are
+	// skipped, as is the asset without a digest.
+	if *lookups != 3 {
+		t.Fatalf("lookups == %d, want 3", *lookups)
+	}
+}
+
+func TestCountSignedReleases_Error(t *testing.T) {
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	t.Cleanup(s.Close)
+	releases := []releaseAssets{
+		{TagName: "v1", Assets: []releaseAsset{{Name: "example.tar.gz", Digest: "sha256:aa"}}},
+	}
+	if _, err := countSignedReleases(context.Background(), &http.Client{}, s.URL, releases); err == nil {
+		t.Fatal("countSignedReleases() returned no error, want an error")
+	}
+}
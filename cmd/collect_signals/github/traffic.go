@@ -0,0 +1,47 @@
+package github
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/go-github/v44/github"
+	"github.com/ossf/criticality_score/internal/githubapi"
+)
+
+// traffic holds the views and clones of a repository over the last 14 days,
+// which is the period GitHub keeps traffic for.
+type traffic struct {
+	Views          int
+	UniqueVisitors int
+	Clones         int
+	UniqueCloners  int
+}
+
+// fetchTraffic returns the traffic for the repository.
+//
+// Reading a repository's traffic requires push access to the repository. If
+// the token used does not have access, nil will be returned along with a nil
+// error.
+func fetchTraffic(ctx context.Context, c *githubapi.Client, owner, name string) (*traffic, error) {
+	opts := &github.TrafficBreakdownOptions{Per: "day"}
+	views, _, err := c.Rest().Repositories.ListTrafficViews(ctx, owner, name, opts)
+	switch githubapi.ErrorResponseStatusCode(err) {
+	case http.StatusForbidden, http.StatusNotFound:
+		// A 403 is returned if the token lacks push access, while a 404
+		// may be returned for private repositories the token can't see.
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	clones, _, err := c.Rest().Repositories.ListTrafficClones(ctx, owner, name, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &traffic{
+		Views:          views.GetCount(),
+		UniqueVisitors: views.GetUniques(),
+		Clones:         clones.GetCount(),
+		UniqueCloners:  clones.GetUniques(),
+	}, nil
+}
@@ -0,0 +1,40 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestFetchTraffic(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/repos/example/example/traffic/views":
+			w.Write([]byte(`{"count": 1400, "uniques": 300, "views": [{"timestamp": "2024-01-01T00:00:00Z", "count": 100, "uniques": 20}]}`))
+		case "/repos/example/example/traffic/clones":
+			w.Write([]byte(`{"count": 250, "uniques": 40, "clones": []}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	got, err := fetchTraffic(context.Background(), c, "example", "example")
+	if err != nil {
+		t.Fatalf("fetchTraffic() errored %v, want no error", err)
+	}
+	want := traffic{Views: 1400, UniqueVisitors: 300, Clones: 250, UniqueCloners: 40}
+	if got == nil || *got != want {
+		t.Fatalf("fetchTraffic() == %v, want %v", got, want)
+	}
+}
+
+func TestFetchTraffic_NoPushAccess(t *testing.T) {
+	c := newTestClient(t, jsonHandler(http.StatusForbidden, `{"message": "Must have push access to repository"}`))
+	got, err := fetchTraffic(context.Background(), c, "example", "example")
+	if err != nil {
+		t.Fatalf("fetchTraffic() errored %v, want no error", err)
+	}
+	if got != nil {
+		t.Fatalf("fetchTraffic() == %v, want nil", got)
+	}
+}
@@ -0,0 +1,170 @@
+package github
+
+import (
+	"context"
+	"path"
+	"strings"
+
+	"github.com/ossf/criticality_score/cmd/collect_signals/github/legacy"
+	"github.com/ossf/criticality_score/internal/githubapi"
+	"github.com/shurcooL/githubv4"
+)
+
+// ciConfigFiles holds the names of the files and directories in the root of
+// a repository that configure a CI system.
+var ciConfigFiles = map[string]bool{
+	".travis.yml":             true,
+	".circleci":               true,
+	".gitlab-ci.yml":          true,
+	"appveyor.yml":            true,
+	".appveyor.yml":           true,
+	"azure-pipelines.yml":     true,
+	"Jenkinsfile":             true,
+	".drone.yml":              true,
+	".buildkite":              true,
+	"bitbucket-pipelines.yml": true,
+	".cirrus.yml":             true,
+}
+
+// testDirs holds the names of directories that usually only contain tests.
+var testDirs = map[string]bool{
+	"test":      true,
+	"tests":     true,
+	"__tests__": true,
+	"spec":      true,
+	"testing":   true,
+}
+
+type leafEntry struct {
+	Name string
+	Type string
+}
+
+type subtreeEntry struct {
+	Name   string
+	Type   string
+	Object struct {
+		Tree struct {
+			Entries []leafEntry
+		} `graphql:"... on Tree"`
+	}
+}
+
+type rootEntry struct {
+	Name   string
+	Type   string
+	Object struct {
+		Tree struct {
+			Entries []subtreeEntry
+		} `graphql:"... on Tree"`
+	}
+}
+
+type treeQuery struct {
+	Repository struct {
+		Object struct {
+			Tree struct {
+				Entries []rootEntry
+			} `graphql:"... on Tree"`
+		} `graphql:"object(expression: \"HEAD:\")"`
+	} `graphql:"repository(owner: $repositoryOwner, name: $repositoryName)"`
+}
+
+// treeSummary describes the files near the root of a repository.
+type treeSummary struct {
+	// HasCIConfig is true if the repository configures a CI system.
+	HasCIConfig bool
+
+	// FileCount is the number of files examined, and TestFileCount is the
+	// number of them that look like tests.
+	FileCount     int
+	TestFileCount int
+}
+
+// TestFileRatio returns the fraction of the files that look like tests,
+// rounded to two decimal places, or false if there are no files.
+func (s *treeSummary) TestFileRatio() (float64, bool) {
+	if s.FileCount == 0 {
+		return 0, false
+	}
+	return legacy.Round(float64(s.TestFileCount)/float64(s.FileCount), 2), true
+}
+
+// fetchTreeSummary returns a summary of the files in the default branch of
+// the repository, using a single query.
+//
+// Only the files in the root directory, and up to two directories below it,
+// are examined, so the counts are a rough sample of large repositories.
+func fetchTreeSummary(ctx context.Context, c *githubapi.Client, owner, name string) (*treeSummary, error) {
+	q := &treeQuery{}
+	vars := map[string]any{
+		"repositoryOwner": githubv4.String(owner),
+		"repositoryName":  githubv4.String(name),
+	}
+	if err := c.GraphQL().Query(ctx, q, vars); err != nil {
+		return nil, err
+	}
+	s := &treeSummary{}
+	for _, e := range q.Repository.Object.Tree.Entries {
+		if ciConfigFiles[e.Name] {
+			s.HasCIConfig = true
+		}
+		if e.Type == "blob" {
+			s.addFile(e.Name)
+			continue
+		}
+		for _, sub := range e.Object.Tree.Entries {
+			p := path.Join(e.Name, sub.Name)
+			if p == ".github/workflows" && hasWorkflowFile(sub.Object.Tree.Entries) {
+				s.HasCIConfig = true
+			}
+			if sub.Type == "blob" {
+				s.addFile(p)
+				continue
+			}
+			for _, leaf := range sub.Object.Tree.Entries {
+				if leaf.Type == "blob" {
+					s.addFile(path.Join(p, leaf.Name))
+				}
+			}
+		}
+	}
+	return s, nil
+}
+
+func (s *treeSummary) addFile(p string) {
+	s.FileCount++
+	if isTestFile(p) {
+		s.TestFileCount++
+	}
+}
+
+func hasWorkflowFile(entries []leafEntry) bool {
+	for _, e := range entries {
+		if ext := path.Ext(e.Name); e.Type == "blob" && (ext == ".yml" || ext == ".yaml") {
+			return true
+		}
+	}
+	return false
+}
+
+// isTestFile returns true if the file at path p looks like a test, either
+// because it is in a test directory or because of its name, such as
+// "foo_test.go", "test_foo.py", "foo.spec.js" or "FooTest.java".
+func isTestFile(p string) bool {
+	dir, file := path.Split(p)
+	for _, d := range strings.Split(strings.Trim(dir, "/"), "/") {
+		if testDirs[strings.ToLower(d)] {
+			return true
+		}
+	}
+	base := strings.TrimSuffix(file, path.Ext(file))
+	if strings.HasSuffix(base, "Test") || strings.HasSuffix(base, "Tests") {
+		return base != "Test" && base != "Tests"
+	}
+	base = strings.ToLower(base)
+	return strings.HasPrefix(base, "test_") ||
+		strings.HasSuffix(base, "_test") ||
+		strings.HasSuffix(base, ".test") ||
+		strings.HasSuffix(base, ".spec")
+}
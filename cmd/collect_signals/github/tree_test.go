@@ -0,0 +1,74 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"testing"
+)
+
+func TestFetchTreeSummary(t *testing.T) {
+	c := newTestClient(t, jsonHandler(http.StatusOK, `{"data": {"repository": {"object": {"entries": [
+		{"name": "README.md", "type": "blob"},
+		{"name": ".github", "type": "tree", "object": {"entries": [
+			{"name": "workflows", "type": "tree", "object": {"entries": [
+				{"name": "ci.yml", "type": "blob"}
+			]}}
+		]}},
+		{"name": "pkg", "type": "tree", "object": {"entries": [
+			{"name": "foo.go", "type": "blob"},
+			{"name": "foo_test.go", "type": "blob"},
+			{"name": "bar", "type": "tree", "object": {"entries": [
+				{"name": "bar.go", "type": "blob"}
+			]}}
+		]}},
+		{"name": "tests", "type": "tree", "object": {"entries": [
+			{"name": "smoke.sh", "type": "blob"}
+		]}}
+	]}}}}`))
+	s, err := fetchTreeSummary(context.Background(), c, "example", "example")
+	if err != nil {
+		t.Fatalf("fetchTreeSummary() errored %v, want no error", err)
+	}
+	want := treeSummary{HasCIConfig: true, FileCount: 6, TestFileCount: 2}
+	if *s != want {
+		t.Fatalf("fetchTreeSummary() == %+v, want %+v", *s, want)
+	}
+	if got, _ := s.TestFileRatio(); got != 0.33 {
+		t.Fatalf("TestFileRatio() == %v, want 0.33", got)
+	}
+}
+
+func TestFetchTreeSummary_EmptyRepo(t *testing.T) {
+	c := newTestClient(t, jsonHandler(http.StatusOK, `{"data": {"repository": {"object": null}}}`))
+	s, err := fetchTreeSummary(context.Background(), c, "example", "example")
+	if err != nil {
+		t.Fatalf("fetchTreeSummary() errored %v, want no error", err)
+	}
+	if s.HasCIConfig {
+		t.Fatal("HasCIConfig == true, want false")
+	}
+	if _, ok := s.TestFileRatio(); ok {
+		t.Fatal("TestFileRatio() ok == true, want false")
+	}
+}
+
+func TestIsTestFile(t *testing.T) {
+	tests := map[string]bool{
+		"foo_test.go":            true,
+		"src/test_foo.py":        true,
+		"lib/foo.spec.ts":        true,
+		"lib/foo.test.js":        true,
+		"src/FooTest.java":       true,
+		"test/helpers.rb":        true,
+		"a/__tests__/b.js":       true,
+		"src/contest.go":         false,
+		"Test.java":              false,
+		"latest.go":              false,
+		"docs/testing-guide.txt": false,
+	}
+	for p, want := range tests {
+		if got := isTestFile(p); got != want {
+			t.Errorf("isTestFile(%q) == %v, want %v", p, got, want)
+		}
+	}
+}
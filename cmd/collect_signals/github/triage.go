@@ -0,0 +1,233 @@
+package github
+
+import (
+	"context"
+	"errors"
+	"io"
+	"time"
+
+	"github.com/ossf/criticality_score/cmd/collect_signals/github/legacy"
+	"github.com/ossf/criticality_score/internal/githubapi"
+	"github.com/ossf/criticality_score/internal/githubapi/pagination"
+	"github.com/shurcooL/githubv4"
+)
+
+const (
+	triageItemsPerPage = 100
+
+	// maxTriageItemsSampled limits the number of open issues, and the
+	// number of open pull requests, that are examined for labels and
+	// responses.
+	maxTriageItemsSampled = 500
+
+	// staleTriageAge is how old an open issue or pull request without a
+	// response must be to count as stale.
+	staleTriageAge = 365 * 24 * time.Hour
+)
+
+// triageItem is a single open issue or pull request.
+type triageItem struct {
+	CreatedAt time.Time
+	Labeled   bool
+
+	// Responded is true if anyone commented on the item or, for a pull
+	// request, reviewed it.
+	Responded bool
+}
+
+type openIssuesTriageQuery struct {
+	Repository struct {
+		Issues struct {
+			TotalCount int
+			Nodes      []struct {
+				CreatedAt time.Time
+				Labels    struct {
+					TotalCount int
+				}
+				Comments struct {
+					TotalCount int
+				}
+			}
+			PageInfo struct {
+				EndCursor   string
+				HasNextPage bool
+			}
+		} `graphql:"issues(states: OPEN, orderBy: {field: UPDATED_AT, direction: ASC}, first: $perPage, after: $endCursor)"`
+	} `graphql:"repository(owner: $repositoryOwner, name: $repositoryName)"`
+}
+
+// Total implements the pagination.PagedQuery interface
+func (q *openIssuesTriageQuery) Total() int {
+	return q.Repository.Issues.TotalCount
+}
+
+// Length implements the pagination.PagedQuery interface
+func (q *openIssuesTriageQuery) Length() int {
+	return len(q.Repository.Issues.Nodes)
+}
+
+// Get implements the pagination.PagedQuery interface
+func (q *openIssuesTriageQuery) Get(i int) any {
+	n := q.Repository.Issues.Nodes[i]
+	return triageItem{
+		CreatedAt: n.CreatedAt,
+		Labeled:   n.Labels.TotalCount > 0,
+		Responded: n.Comments.TotalCount > 0,
+	}
+}
+
+// HasNextPage implements the pagination.PagedQuery interface
+func (q *openIssuesTriageQuery) HasNextPage() bool {
+	return q.Repository.Issues.PageInfo.HasNextPage
+}
+
+// NextPageVars implements the pagination.PagedQuery interface
+func (q *openIssuesTriageQuery) NextPageVars() map[string]any {
+	if q.Repository.Issues.PageInfo.EndCursor == "" {
+		return map[string]any{
+			"endCursor": (*githubv4.String)(nil),
+		}
+	} else {
+		return map[string]any{
+			"endCursor": githubv4.String(q.Repository.Issues.PageInfo.EndCursor),
+		}
+	}
+}
+
+type openPullsTriageQuery struct {
+	Repository struct {
+		PullRequests struct {
+			TotalCount int
+			Nodes      []struct {
+				CreatedAt time.Time
+				Labels    struct {
+					TotalCount int
+				}
+				Comments struct {
+					TotalCount int
+				}
+				Reviews struct {
+					TotalCount int
+				}
+			}
+			PageInfo struct {
+				EndCursor   string
+				HasNextPage bool
+			}
+		} `graphql:"pullRequests(states: OPEN, orderBy: {field: UPDATED_AT, direction: ASC}, first: $perPage, after: $endCursor)"`
+	} `graphql:"repository(owner: $repositoryOwner, name: $repositoryName)"`
+}
+
+// Total implements the pagination.PagedQuery interface
+func (q *openPullsTriageQuery) Total() int {
+	return q.Repository.PullRequests.TotalCount
+}
+
+// Length implements the pagination.PagedQuery interface
+func (q *openPullsTriageQuery) Length() int {
+	return len(q.Repository.PullRequests.Nodes)
+}
+
+// Get implements the pagination.PagedQuery interface
+func (q *openPullsTriageQuery) Get(i int) any {
+	n := q.Repository.PullRequests.Nodes[i]
+	return triageItem{
+		CreatedAt: n.CreatedAt,
+		Labeled:   n.Labels.TotalCount > 0,
+		Responded: n.Comments.TotalCount > 0 || n.Reviews.TotalCount > 0,
+	}
+}
+
+// HasNextPage implements the pagination.PagedQuery interface
+func (q *openPullsTriageQuery) HasNextPage() bool {
+	return q.Repository.PullRequests.PageInfo.HasNextPage
+}
+
+// NextPageVars implements the pagination.PagedQuery interface
+func (q *openPullsTriageQuery) NextPageVars() map[string]any {
+	cursor := q.Repository.PullRequests.PageInfo.EndCursor
+	if cursor == "" {
+		return map[string]any{
+			"endCursor": (*githubv4.String)(nil),
+		}
+	} else {
+		return map[string]any{
+			"endCursor": githubv4.String(cursor),
+		}
+	}
+}
+
+// triageHygiene holds the share of open items that are labeled, and the
+// share that are stale.
+type triageHygiene struct {
+	LabeledRatio float64
+	StaleRatio   float64
+}
+
+// sampleTriage examines up to maxSampled of the items returned by the paged
+// query q, and returns their triage hygiene as of now.
+//
+// The items are examined least recently updated first, as an item without a
+// response has usually not been updated since it was opened. So the stale
+// items are all examined unless there are more than maxSampled of them, and
+// StaleRatio is the number found divided by the total number of open items.
+// LabeledRatio is the share of the examined items that are labeled, which
+// may be lower than across all open items if not all are examined.
+//
+// If there are no open items, nil is returned.
+func sampleTriage(ctx context.Context, c *githubapi.Client, q pagination.PagedQuery, owner, name string, now time.Time, maxSampled int) (*triageHygiene, error) {
+	vars := map[string]any{
+		"perPage":         githubv4.Int(triageItemsPerPage),
+		"repositoryOwner": githubv4.String(owner),
+		"repositoryName":  githubv4.String(name),
+	}
+	cursor, err := pagination.Query(ctx, c.GraphQL(), q, vars)
+	if err != nil {
+		return nil, err
+	}
+	staleBefore := now.Add(-staleTriageAge)
+	sampled, labeled, stale := 0, 0, 0
+	for sampled < maxSampled {
+		obj, err := cursor.Next()
+		if obj == nil && errors.Is(err, io.EOF) {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		item := obj.(triageItem)
+		sampled++
+		if item.Labeled {
+			labeled++
+		}
+		if !item.Responded && item.CreatedAt.Before(staleBefore) {
+			stale++
+		}
+	}
+	total := cursor.Total()
+	if sampled == 0 || total == 0 {
+		return nil, nil
+	}
+	if total < sampled {
+		total = sampled
+	}
+	return &triageHygiene{
+		LabeledRatio: legacy.Round(float64(labeled)/float64(sampled), 2),
+		StaleRatio:   legacy.Round(float64(stale)/float64(total), 2),
+	}, nil
+}
+
+// fetchIssueTriage returns the triage hygiene of the open issues.
+//
+// Pull requests are not included. If there are no open issues, nil is
+// returned.
+func fetchIssueTriage(ctx context.Context, c *githubapi.Client, owner, name string, now time.Time) (*triageHygiene, error) {
+	return sampleTriage(ctx, c, &openIssuesTriageQuery{}, owner, name, now, maxTriageItemsSampled)
+}
+
+// fetchPullRequestTriage returns the triage hygiene of the open pull
+// requests.
+//
+// If there are no open pull requests, nil is returned.
+func fetchPullRequestTriage(ctx context.Context, c *githubapi.Client, owner, name string, now time.Time) (*triageHygiene, error) {
+	return sampleTriage(ctx, c, &openPullsTriageQuery{}, owner, name, now, maxTriageItemsSampled)
+}
@@ -0,0 +1,62 @@
+package github
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+var triageNow = time.Date(2024, 6, 1, 0, 0, 0, 0, time.UTC)
+
+func TestFetchIssueTriage(t *testing.T) {
+	c := newTestClient(t, pagedHandler(t,
+		`{"data": {"repository": {"issues": {"totalCount": 4, "nodes": [
+			{"createdAt": "2021-01-01T00:00:00Z", "labels": {"totalCount": 0}, "comments": {"totalCount": 0}},
+			{"createdAt": "2022-01-01T00:00:00Z", "labels": {"totalCount": 1}, "comments": {"totalCount": 3}}
+		], "pageInfo": {"endCursor": "c1", "hasNextPage": true}}}}}`,
+		`{"data": {"repository": {"issues": {"totalCount": 4, "nodes": [
+			{"createdAt": "2024-05-01T00:00:00Z", "labels": {"totalCount": 2}, "comments": {"totalCount": 0}},
+			{"createdAt": "2024-05-20T00:00:00Z", "labels": {"totalCount": 1}, "comments": {"totalCount": 1}}
+		], "pageInfo": {"endCursor": "c2", "hasNextPage": false}}}}}`,
+	))
+	got, err := fetchIssueTriage(context.Background(), c, "example", "example", triageNow)
+	if err != nil {
+		t.Fatalf("fetchIssueTriage() errored %v, want no error", err)
+	}
+	want := triageHygiene{LabeledRatio: 0.75, StaleRatio: 0.25}
+	if got == nil || *got != want {
+		t.Fatalf("fetchIssueTriage() == %v, want %v", got, want)
+	}
+}
+
+func TestFetchIssueTriage_NoIssues(t *testing.T) {
+	c := newTestClient(t, pagedHandler(t,
+		`{"data": {"repository": {"issues": {"totalCount": 0, "nodes": [], "pageInfo": {"endCursor": "", "hasNextPage": false}}}}}`,
+	))
+	got, err := fetchIssueTriage(context.Background(), c, "example", "example", triageNow)
+	if err != nil {
+		t.Fatalf("fetchIssueTriage() errored %v, want no error", err)
+	}
+	if got != nil {
+		t.Fatalf("fetchIssueTriage() == %v, want nil", got)
+	}
+}
+
+func TestFetchPullRequestTriage_Sampled(t *testing.T) {
+	// Only the first page is requested, as the sample limit is reached. The
+	// stale ratio is over all 10 open pull requests.
+	c := newTestClient(t, pagedHandler(t,
+		`{"data": {"repository": {"pullRequests": {"totalCount": 10, "nodes": [
+			{"createdAt": "2021-01-01T00:00:00Z", "labels": {"totalCount": 0}, "comments": {"totalCount": 0}, "reviews": {"totalCount": 0}},
+			{"createdAt": "2021-02-01T00:00:00Z", "labels": {"totalCount": 0}, "comments": {"totalCount": 0}, "reviews": {"totalCount": 2}}
+		], "pageInfo": {"endCursor": "c1", "hasNextPage": true}}}}}`,
+	))
+	got, err := sampleTriage(context.Background(), c, &openPullsTriageQuery{}, "example", "example", triageNow, 2)
+	if err != nil {
+		t.Fatalf("sampleTriage() errored %v, want no error", err)
+	}
+	want := triageHygiene{LabeledRatio: 0, StaleRatio: 0.1}
+	if got == nil || *got != want {
+		t.Fatalf("sampleTriage() == %v, want %v", got, want)
+	}
+}
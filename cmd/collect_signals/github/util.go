@@ -0,0 +1,24 @@
+package github
+
+import (
+	"sort"
+	"time"
+
+	"github.com/ossf/criticality_score/cmd/collect_signals/github/legacy"
+)
+
+// medianDuration returns the median of ds, which must not be empty. ds is
+// sorted in place.
+func medianDuration(ds []time.Duration) time.Duration {
+	sort.Slice(ds, func(i, j int) bool { return ds[i] < ds[j] })
+	mid := len(ds) / 2
+	if len(ds)%2 == 0 {
+		return (ds[mid-1] + ds[mid]) / 2
+	}
+	return ds[mid]
+}
+
+// percent returns n as a percentage of total, rounded to two decimal places.
+func percent(n, total int) float64 {
+	return legacy.Round(100*float64(n)/float64(total), 2)
+}
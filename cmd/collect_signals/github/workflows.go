@@ -0,0 +1,73 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"path"
+	"time"
+
+	"github.com/google/go-github/v44/github"
+	"github.com/ossf/criticality_score/internal/githubapi"
+	"github.com/shurcooL/githubv4"
+)
+
+type workflowsQuery struct {
+	Repository struct {
+		Object struct {
+			Tree struct {
+				Entries []struct {
+					Name string
+					Type string
+				}
+			} `graphql:"... on Tree"`
+		} `graphql:"object(expression: \"HEAD:.github/workflows\")"`
+	} `graphql:"repository(owner: $repositoryOwner, name: $repositoryName)"`
+}
+
+// fetchWorkflowCount returns the number of GitHub Actions workflow files
+// present in the default branch of the repository.
+//
+// Repositories without a .github/workflows directory have no workflows.
+func fetchWorkflowCount(ctx context.Context, c *githubapi.Client, owner, name string) (int, error) {
+	s := &workflowsQuery{}
+	vars := map[string]any{
+		"repositoryOwner": githubv4.String(owner),
+		"repositoryName":  githubv4.String(name),
+	}
+	if err := c.GraphQL().Query(ctx, s, vars); err != nil {
+		return 0, err
+	}
+	total := 0
+	for _, e := range s.Repository.Object.Tree.Entries {
+		if e.Type != "blob" {
+			continue
+		}
+		if ext := path.Ext(e.Name); ext == ".yml" || ext == ".yaml" {
+			total++
+		}
+	}
+	return total, nil
+}
+
+// fetchLastWorkflowRun returns the time the most recent workflow run was
+// created for the repository.
+//
+// If the repository has no workflow runs, or the token does not have access
+// to the Actions API, false will be returned.
+func fetchLastWorkflowRun(ctx context.Context, c *githubapi.Client, owner, name string) (time.Time, bool, error) {
+	opts := &github.ListWorkflowRunsOptions{
+		ListOptions: github.ListOptions{PerPage: 1}, // Runs are returned newest first.
+	}
+	runs, _, err := c.Rest().Actions.ListRepositoryWorkflowRuns(ctx, owner, name, opts)
+	switch githubapi.ErrorResponseStatusCode(err) {
+	case http.StatusForbidden, http.StatusNotFound:
+		return time.Time{}, false, nil
+	}
+	if err != nil {
+		return time.Time{}, false, err
+	}
+	if len(runs.WorkflowRuns) == 0 {
+		return time.Time{}, false, nil
+	}
+	return runs.WorkflowRuns[0].GetCreatedAt().Time, true, nil
+}
@@ -0,0 +1,73 @@
+package github
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestFetchWorkflowCount(t *testing.T) {
+	c := newTestClient(t, jsonHandler(http.StatusOK, `{"data": {"repository": {"object": {"entries": [
+		{"name": "ci.yml", "type": "blob"},
+		{"name": "release.yaml", "type": "blob"},
+		{"name": "README.md", "type": "blob"},
+		{"name": "templates", "type": "tree"}
+	]}}}}`))
+	count, err := fetchWorkflowCount(context.Background(), c, "example", "example")
+	if err != nil {
+		t.Fatalf("fetchWorkflowCount() errored %v, want no error", err)
+	}
+	if count != 2 {
+		t.Fatalf("fetchWorkflowCount() == %d, want 2", count)
+	}
+}
+
+func TestFetchWorkflowCount_NoWorkflowsDir(t *testing.T) {
+	c := newTestClient(t, jsonHandler(http.StatusOK, `{"data": {"repository": {"object": null}}}`))
+	count, err := fetchWorkflowCount(context.Background(), c, "example", "example")
+	if err != nil {
+		t.Fatalf("fetchWorkflowCount() errored %v, want no error", err)
+	}
+	if count != 0 {
+		t.Fatalf("fetchWorkflowCount() == %d, want 0", count)
+	}
+}
+
+func TestFetchLastWorkflowRun(t *testing.T) {
+	c := newTestClient(t, jsonHandler(http.StatusOK, `{"total_count": 2, "workflow_runs": [
+		{"id": 2, "created_at": "2022-05-04T10:00:00Z"}
+	]}`))
+	last, ok, err := fetchLastWorkflowRun(context.Background(), c, "example", "example")
+	if err != nil {
+		t.Fatalf("fetchLastWorkflowRun() errored %v, want no error", err)
+	}
+	if !ok {
+		t.Fatal("fetchLastWorkflowRun() returned false, want true")
+	}
+	if want := time.Date(2022, 5, 4, 10, 0, 0, 0, time.UTC); !last.Equal(want) {
+		t.Fatalf("fetchLastWorkflowRun() == %v, want %v", last, want)
+	}
+}
+
+func TestFetchLastWorkflowRun_NoRuns(t *testing.T) {
+	c := newTestClient(t, jsonHandler(http.StatusOK, `{"total_count": 0, "workflow_runs": []}`))
+	_, ok, err := fetchLastWorkflowRun(context.Background(), c, "example", "example")
+	if err != nil {
+		t.Fatalf("fetchLastWorkflowRun() errored %v, want no error", err)
+	}
+	if ok {
+		t.Fatal("fetchLastWorkflowRun() returned true, want false")
+	}
+}
+
+func TestFetchLastWorkflowRun_Inaccessible(t *testing.T) {
+	c := newTestClient(t, jsonHandler(http.StatusForbidden, `{"message": "Resource not accessible by integration"}`))
+	_, ok, err := fetchLastWorkflowRun(context.Background(), c, "example", "example")
+	if err != nil {
+		t.Fatalf("fetchLastWorkflowRun() errored %v, want no error", err)
+	}
+	if ok {
+		t.Fatal("fetchLastWorkflowRun() returned true, want false")
+	}
+}
@@ -0,0 +1,138 @@
+// Package githubdependents provides a Collector that returns a Set for the
+// number of repositories and packages that GitHub's dependency graph reports
+// as depending on a repository, shown as "Used by" on github.com.
+//
+// Neither the REST nor the GraphQL API expose dependents, as
+// dependencyGraphManifests only lists a repository's own dependencies. So
+// the counts are read from the repository's dependents page instead. The page
+// is not an API and its markup may change, in which case the signals are left
+// unset.
+package githubdependents
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/url"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/ossf/criticality_score/cmd/collect_signals/projectrepo"
+	"github.com/ossf/criticality_score/cmd/collect_signals/signal"
+	"github.com/ossf/criticality_score/internal/httpjson"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultBaseURL is the base URL of the GitHub website.
+	DefaultBaseURL = "https://github.com"
+
+	// maxPageSize bounds how much of the dependents page is read.
+	maxPageSize = 4 << 20
+)
+
+// countPattern matches the links on the dependents page that select between
+// dependent repositories and packages, capturing the type and the count.
+var countPattern = regexp.MustCompile(`(?s)dependent_type=(REPOSITORY|PACKAGE)"[^>]*>.*?([0-9][0-9,]*)\s*(?:Repositor|Package)`)
+
+type dependentsSet struct {
+	RepositoryCount signal.Field[int]
+	PackageCount    signal.Field[int]
+}
+
+func (s *dependentsSet) Namespace() signal.Namespace {
+	return signal.Namespace("github_dependents")
+}
+
+type Collector struct {
+	client  *http.Client
+	logger  *log.Logger
+	baseURL string
+}
+
+// NewCollector returns a new Collector that uses the http.Client c to fetch
+// the dependents page from github.com.
+func NewCollector(c *http.Client, logger *log.Logger) *Collector {
+	return &Collector{
+		client:  c,
+		logger:  logger,
+		baseURL: DefaultBaseURL,
+	}
+}
+
+func (c *Collector) EmptySet() signal.Set {
+	return &dependentsSet{}
+}
+
+func (c *Collector) IsSupported(r projectrepo.Repo) bool {
+	return strings.EqualFold(r.URL().Hostname(), "github.com")
+}
+
+// Collect implements the collector.Collector interface.
+//
+// If the repository does not have a dependency graph, or the counts cannot be
+// found on the page, the signals are left unset.
+func (c *Collector) Collect(ctx context.Context, r projectrepo.Repo) (signal.Set, error) {
+	s := &dependentsSet{}
+	logger := c.logger.WithField("url", r.URL().String())
+	logger.Debug("Fetching GitHub dependents page")
+	page, err := c.getPage(ctx, r.URL())
+	if err != nil {
+		return nil, err
+	}
+	repos, pkgs, ok := parseCounts(page)
+	if !ok {
+		if page != nil {
+			logger.Debug("No dependent counts found on GitHub dependents page")
+		}
+		return s, nil
+	}
+	s.RepositoryCount.Set(repos)
+	s.PackageCount.Set(pkgs)
+	return s, nil
+}
+
+// getPage returns the dependents page for the repository at u.
+//
+// If the page does not exist, nil is returned.
+func (c *Collector) getPage(ctx context.Context, u *url.URL) ([]byte, error) {
+	p := strings.TrimSuffix(strings.Trim(u.Path, "/"), ".git")
+	pageURL := c.baseURL + "/" + p + "/network/dependents"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, pageURL, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "text/html")
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, &httpjson.StatusError{URL: pageURL, StatusCode: resp.StatusCode}
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxPageSize))
+}
+
+// parseCounts returns the number of dependent repositories and packages shown
+// on the dependents page. If neither count is found, ok is false.
+func parseCounts(page []byte) (repos, pkgs int, ok bool) {
+	for _, m := range countPattern.FindAllSubmatch(page, -1) {
+		n, err := strconv.Atoi(strings.ReplaceAll(string(m[2]), ",", ""))
+		if err != nil {
+			continue
+		}
+		switch string(m[1]) {
+		case "REPOSITORY":
+			repos = n
+		case "PACKAGE":
+			pkgs = n
+		}
+		ok = true
+	}
+	return repos, pkgs, ok
+}
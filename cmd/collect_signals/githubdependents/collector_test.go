@@ -0,0 +1,74 @@
+package githubdependents
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/ossf/criticality_score/internal/collectortest"
+)
+
+const dependentsPage = `<div class="table-list-header-toggle states flex-auto pl-0">
+  <a class="btn-link selected" href="/example/json/network/dependents?dependent_type=REPOSITORY">
+    <svg aria-hidden="true" height="16" viewBox="0 0 16 16" width="16"><path d="M2 2.5A2.5 2.5 0 014.5 0h8.75"></path></svg>
+    1,234,567
+    Repositories
+  </a>
+  <a class="btn-link " href="/example/json/network/dependents?dependent_type=PACKAGE">
+    <svg aria-hidden="true" height="16" viewBox="0 0 16 16" width="16"><path d="M8.878.392a1.75 1.75 0 00-1.756 0"></path></svg>
+    89
+    Packages
+  </a>
+</div>`
+
+func newTestCollector(t *testing.T, body string) *Collector {
+	t.Helper()
+	s := collectortest.NewServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/example/json/network/dependents" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html")
+		w.Write([]byte(body))
+	}))
+	c := NewCollector(&http.Client{}, collectortest.Logger())
+	c.baseURL = s.URL
+	return c
+}
+
+func TestCollect(t *testing.T) {
+	s := collectortest.Collect[*dependentsSet](t, newTestCollector(t, dependentsPage), "https://github.com/example/json.git")
+	if got := s.RepositoryCount.Get(); got != 1234567 {
+		t.Fatalf("RepositoryCount == %d, want 1234567", got)
+	}
+	if got := s.PackageCount.Get(); got != 89 {
+		t.Fatalf("PackageCount == %d, want 89", got)
+	}
+}
+
+func TestCollect_NoDependencyGraph(t *testing.T) {
+	s := collectortest.Collect[*dependentsSet](t, newTestCollector(t, `<p>Dependency graph is not enabled.</p>`), "https://github.com/example/json")
+	if s.RepositoryCount.IsSet() {
+		t.Fatal("RepositoryCount is set, want unset")
+	}
+}
+
+func TestCollect_NotFound(t *testing.T) {
+	s := collectortest.Collect[*dependentsSet](t, newTestCollector(t, dependentsPage), "https://github.com/example/missing")
+	if s.RepositoryCount.IsSet() {
+		t.Fatal("RepositoryCount is set, want unset")
+	}
+}
+
+func TestParseCounts_Singular(t *testing.T) {
+	page := `<a href="/a/b/network/dependents?dependent_type=REPOSITORY">
+		1
+		Repository
+	</a>`
+	repos, pkgs, ok := parseCounts([]byte(page))
+	if !ok {
+		t.Fatal("parseCounts() ok == false, want true")
+	}
+	if repos != 1 || pkgs != 0 {
+		t.Fatalf("parseCounts() == %d, %d, want 1, 0", repos, pkgs)
+	}
+}
@@ -0,0 +1,62 @@
+package githubmentions
+
+import (
+	"container/list"
+	"sync"
+)
+
+// cacheEntry is the value stored in each element of the cache's list.
+type cacheEntry struct {
+	key   string
+	count int
+}
+
+// cache is a fixed size, least recently used cache of mention counts keyed by
+// repository path. It is safe for concurrent use.
+type cache struct {
+	mu      sync.Mutex
+	size    int
+	order   *list.List
+	entries map[string]*list.Element
+}
+
+func newCache(size int) *cache {
+	return &cache{
+		size:    size,
+		order:   list.New(),
+		entries: make(map[string]*list.Element),
+	}
+}
+
+// Get returns the count stored for key, and true if it was present.
+func (c *cache) Get(key string) (int, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	e, ok := c.entries[key]
+	if !ok {
+		return 0, false
+	}
+	c.order.MoveToFront(e)
+	return e.Value.(*cacheEntry).count, true
+}
+
+// Add stores count for key, evicting the least recently used entry if the
+// cache is full.
+func (c *cache) Add(key string, count int) {
+	if c.size <= 0 {
+		return
+	}
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if e, ok := c.entries[key]; ok {
+		e.Value.(*cacheEntry).count = count
+		c.order.MoveToFront(e)
+		return
+	}
+	c.entries[key] = c.order.PushFront(&cacheEntry{key: key, count: count})
+	if c.order.Len() > c.size {
+		oldest := c.order.Back()
+		c.order.Remove(oldest)
+		delete(c.entries, oldest.Value.(*cacheEntry).key)
+	}
+}
@@ -0,0 +1,44 @@
+package githubmentions
+
+import "testing"
+
+func TestCacheGet_Missing(t *testing.T) {
+	c := newCache(2)
+	if _, ok := c.Get("a/b"); ok {
+		t.Fatal("Get() returned true, want false")
+	}
+}
+
+func TestCacheAdd(t *testing.T) {
+	c := newCache(2)
+	c.Add("a/b", 1)
+	c.Add("a/b", 2)
+	if got, ok := c.Get("a/b"); !ok || got != 2 {
+		t.Fatalf("Get() == %d, %v, want 2, true", got, ok)
+	}
+}
+
+func TestCacheAdd_EvictsLeastRecentlyUsed(t *testing.T) {
+	c := newCache(2)
+	c.Add("a/a", 1)
+	c.Add("b/b", 2)
+	c.Get("a/a")
+	c.Add("c/c", 3)
+	if _, ok := c.Get("b/b"); ok {
+		t.Fatal("Get(\"b/b\") returned true, want false")
+	}
+	if _, ok := c.Get("a/a"); !ok {
+		t.Fatal("Get(\"a/a\") returned false, want true")
+	}
+	if _, ok := c.Get("c/c"); !ok {
+		t.Fatal("Get(\"c/c\") returned false, want true")
+	}
+}
+
+func TestCacheAdd_ZeroSize(t *testing.T) {
+	c := newCache(0)
+	c.Add("a/b", 1)
+	if _, ok := c.Get("a/b"); ok {
+		t.Fatal("Get() returned true, want false")
+	}
+}
@@ -11,11 +11,30 @@ import (
 	"fmt"
 	"net/url"
 	"strings"
+	"time"
 
 	"github.com/google/go-github/v44/github"
 	"github.com/ossf/criticality_score/cmd/collect_signals/projectrepo"
 	"github.com/ossf/criticality_score/cmd/collect_signals/signal"
 	"github.com/ossf/criticality_score/internal/githubapi"
+	"github.com/ossf/criticality_score/internal/retry"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// cacheSize is the number of repositories to remember mention counts for.
+	cacheSize = 10000
+
+	// maxAttempts is the number of times the search is attempted before
+	// giving up when rate limited.
+	maxAttempts = 3
+
+	// defaultRateLimitWait is used when GitHub does not say how long to wait
+	// after hitting a rate limit.
+	defaultRateLimitWait = time.Minute
+
+	// maxRateLimitWait bounds how long a single rate limit will be waited for.
+	maxRateLimitWait = 10 * time.Minute
 )
 
 type mentionSet struct {
@@ -28,11 +47,20 @@ func (s *mentionSet) Namespace() signal.Namespace {
 
 type Collector struct {
 	client *githubapi.Client
+	logger *log.Logger
+	cache  *cache
+
+	// sleep waits for the given duration, or until the context is done. It
+	// is replaced for testing.
+	sleep func(context.Context, time.Duration) error
 }
 
-func NewCollector(c *githubapi.Client) *Collector {
+func NewCollector(c *githubapi.Client, logger *log.Logger) *Collector {
 	return &Collector{
 		client: c,
+		logger: logger,
+		cache:  newCache(cacheSize),
+		sleep:  retry.ContextSleep,
 	}
 }
 
@@ -44,24 +72,81 @@ func (c *Collector) IsSupported(r projectrepo.Repo) bool {
 	return true
 }
 
+// Collect implements the collector.Collector interface.
+//
+// If the search is still being rate limited after several attempts the
+// mention count is left unset, rather than failing the repository.
 func (c *Collector) Collect(ctx context.Context, r projectrepo.Repo) (signal.Set, error) {
 	s := &mentionSet{}
-	if c, err := c.githubSearchTotalCommitMentions(ctx, r.URL()); err != nil {
+	repoName := strings.ToLower(strings.Trim(r.URL().Path, "/"))
+	if n, ok := c.cache.Get(repoName); ok {
+		s.MentionCount.Set(n)
+		return s, nil
+	}
+	n, err := c.githubSearchTotalCommitMentions(ctx, r.URL())
+	if _, ok := githubapi.RateLimitReset(err, time.Now()); ok {
+		c.logger.WithFields(log.Fields{
+			"url":   r.URL().String(),
+			"error": err,
+		}).Warn("Rate limited searching for commit mentions; skipping")
+		return s, nil
+	}
+	if err != nil {
 		return nil, err
-	} else {
-		s.MentionCount.Set(c)
 	}
+	c.cache.Add(repoName, n)
+	s.MentionCount.Set(n)
 	return s, nil
 }
 
+// githubSearchTotalCommitMentions searches for the repository in commit
+// messages, waiting and trying again up to maxAttempts times if GitHub's
+// rate limits are hit.
 func (c *Collector) githubSearchTotalCommitMentions(ctx context.Context, u *url.URL) (int, error) {
 	repoName := strings.Trim(u.Path, "/")
 	opts := &github.SearchOptions{
 		ListOptions: github.ListOptions{PerPage: 1},
 	}
-	commits, _, err := c.client.Rest().Search.Commits(ctx, fmt.Sprintf("\"%s\"", repoName), opts)
-	if err != nil {
-		return 0, err
+	var err error
+	for attempt := 1; attempt <= maxAttempts; attempt++ {
+		var commits *github.CommitsSearchResult
+		commits, _, err = c.client.Rest().Search.Commits(ctx, fmt.Sprintf("\"%s\"", repoName), opts)
+		if err == nil {
+			return commits.GetTotal(), nil
+		}
+		wait, ok := rateLimitWait(err, time.Now())
+		if !ok {
+			return 0, err
+		}
+		if attempt == maxAttempts {
+			break
+		}
+		c.logger.WithFields(log.Fields{
+			"url":     u.String(),
+			"wait":    wait,
+			"attempt": attempt,
+		}).Warn("Rate limited searching for commit mentions; waiting")
+		if sleepErr := c.sleep(ctx, wait); sleepErr != nil {
+			return 0, sleepErr
+		}
+	}
+	return 0, err
+}
+
+// rateLimitWait returns how long to wait before trying again if err was
+// caused by hitting one of GitHub's rate limits, as detected by
+// githubapi.RateLimitReset. If err is not a rate limit error false is returned.
+func rateLimitWait(err error, now time.Time) (time.Duration, bool) {
+	reset, ok := githubapi.RateLimitReset(err, now)
+	if !ok {
+		return 0, false
+	}
+	wait := reset.Sub(now)
+	if reset.IsZero() || wait <= 0 {
+		wait = defaultRateLimitWait
+	}
+	if wait > maxRateLimitWait {
+		wait = maxRateLimitWait
 	}
-	return commits.GetTotal(), nil
+	return wait, true
 }
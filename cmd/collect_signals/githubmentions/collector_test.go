@@ -0,0 +1,154 @@
+package githubmentions
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v44/github"
+	"github.com/ossf/criticality_score/internal/collectortest"
+	"github.com/ossf/criticality_score/internal/githubapi"
+)
+
+// newTestCollector returns a Collector where all the requests are handled by
+// h and sleeping returns immediately.
+func newTestCollector(t *testing.T, h http.Handler) *Collector {
+	t.Helper()
+	s := collectortest.NewServer(t, h)
+	client, err := githubapi.NewEnterpriseClient(s.URL, &http.Client{})
+	if err != nil {
+		t.Fatalf("NewEnterpriseClient() errored %v, want no error", err)
+	}
+	c := NewCollector(client, collectortest.Logger())
+	c.sleep = func(context.Context, time.Duration) error { return nil }
+	return c
+}
+
+func collectCount(t *testing.T, c *Collector) (int, bool) {
+	t.Helper()
+	s, err := c.Collect(context.Background(), collectortest.NewRepo(t, "https://github.com/example/example"))
+	if err != nil {
+		t.Fatalf("Collect() errored %v, want no error", err)
+	}
+	f := s.(*mentionSet).MentionCount
+	return f.Get(), f.IsSet()
+}
+
+func TestCollect_Cached(t *testing.T) {
+	requests := 0
+	c := newTestCollector(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"total_count": 42, "items": []}`))
+	}))
+	for i := 0; i < 2; i++ {
+		if got, ok := collectCount(t, c); !ok || got != 42 {
+			t.Fatalf("Collect() == %d, %v, want 42, true", got, ok)
+		}
+	}
+	if requests != 1 {
+		t.Fatalf("requests == %d, want 1", requests)
+	}
+}
+
+func TestCollect_SecondaryRateLimitRecovers(t *testing.T) {
+	requests := 0
+	c := newTestCollector(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("Content-Type", "application/json")
+		if requests == 1 {
+			w.Header().Set("Retry-After", "30")
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"message": "slow down", "documentation_url": "https://docs.github.com/rest/overview/resources-in-the-rest-api#secondary-rate-limits"}`))
+			return
+		}
+		w.Write([]byte(`{"total_count": 7, "items": []}`))
+	}))
+	if got, ok := collectCount(t, c); !ok || got != 7 {
+		t.Fatalf("Collect() == %d, %v, want 7, true", got, ok)
+	}
+}
+
+func TestCollect_RateLimitedSkips(t *testing.T) {
+	c := newTestCollector(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-RateLimit-Limit", "30")
+		w.Header().Set("X-RateLimit-Remaining", "0")
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(time.Now().Add(time.Minute).Unix(), 10))
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"message": "API rate limit exceeded"}`))
+	}))
+	if _, ok := collectCount(t, c); ok {
+		t.Fatal("Collect() set the mention count, want unset")
+	}
+}
+
+func TestCollect_OtherError(t *testing.T) {
+	c := newTestCollector(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnprocessableEntity)
+		w.Write([]byte(`{"message": "Validation Failed"}`))
+	}))
+	if _, err := c.Collect(context.Background(), collectortest.NewRepo(t, "https://github.com/example/example")); err == nil {
+		t.Fatal("Collect() returned no error, want an error")
+	}
+}
+
+func TestRateLimitWait(t *testing.T) {
+	now := time.Date(2022, 5, 4, 10, 0, 0, 0, time.UTC)
+	retryAfter := 30 * time.Second
+	tests := []struct {
+		name   string
+		err    error
+		want   time.Duration
+		wantOk bool
+	}{
+		{
+			name:   "rate limit",
+			err:    &github.RateLimitError{Rate: github.Rate{Reset: github.Timestamp{Time: now.Add(2 * time.Minute)}}},
+			want:   2 * time.Minute,
+			wantOk: true,
+		},
+		{
+			name:   "rate limit too long",
+			err:    &github.RateLimitError{Rate: github.Rate{Reset: github.Timestamp{Time: now.Add(time.Hour)}}},
+			want:   maxRateLimitWait,
+			wantOk: true,
+		},
+		{
+			name:   "abuse with retry after",
+			err:    &github.AbuseRateLimitError{RetryAfter: &retryAfter},
+			want:   retryAfter,
+			wantOk: true,
+		},
+		{
+			name:   "abuse without retry after",
+			err:    &github.AbuseRateLimitError{},
+			want:   defaultRateLimitWait,
+			wantOk: true,
+		},
+		{
+			name:   "graphql rate limit",
+			err:    errors.New("API rate limit exceeded for user ID 1."),
+			want:   defaultRateLimitWait,
+			wantOk: true,
+		},
+		{
+			name:   "other error",
+			err:    io.EOF,
+			wantOk: false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, ok := rateLimitWait(test.err, now)
+			if ok != test.wantOk || got != test.want {
+				t.Fatalf("rateLimitWait() == %v, %v, want %v, %v", got, ok, test.want, test.wantOk)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package gitlab
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/ossf/criticality_score/internal/httpjson"
+)
+
+// Client is used to query the REST API of gitlab.com and self-hosted GitLab
+// instances.
+type Client struct {
+	client *http.Client
+	token  string
+}
+
+// NewClient returns a new Client that uses the http.Client c to send
+// requests.
+//
+// If token is not empty it will be sent as a Personal Access Token with each
+// request. A token is not required for accessing public projects.
+func NewClient(c *http.Client, token string) *Client {
+	return &Client{
+		client: c,
+		token:  token,
+	}
+}
+
+// get queries the API endpoint path on the GitLab instance at host, and
+// decodes the JSON response into result.
+//
+// path must already be escaped.
+func (c *Client) get(ctx context.Context, host, path string, query url.Values, result any) (*http.Response, error) {
+	u := fmt.Sprintf("https://%s/api/v4/%s", host, path)
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if c.token != "" {
+		req.Header.Set("PRIVATE-TOKEN", c.token)
+	}
+	return httpjson.Do(c.client, req, result)
+}
@@ -0,0 +1,86 @@
+package gitlab
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/ossf/criticality_score/cmd/collect_signals/github/legacy"
+	"github.com/ossf/criticality_score/cmd/collect_signals/projectrepo"
+	"github.com/ossf/criticality_score/cmd/collect_signals/signal"
+)
+
+type RepoCollector struct {
+}
+
+func (rc *RepoCollector) EmptySet() signal.Set {
+	return &signal.RepoSet{}
+}
+
+func (rc *RepoCollector) Collect(ctx context.Context, r projectrepo.Repo) (signal.Set, error) {
+	glr, ok := r.(*repo)
+	if !ok {
+		return nil, errors.New("project is not a gitlab project")
+	}
+	now := time.Now()
+
+	s := &signal.RepoSet{
+		URL:          signal.Val(r.URL().String()),
+		StarCount:    signal.Val(glr.BasicData.StarCount),
+		ForkCount:    signal.Val(glr.BasicData.ForksCount),
+		Archived:     signal.Val(glr.BasicData.Archived),
+		CreatedAt:    signal.Val(glr.createdAt()),
+		CreatedSince: signal.Val(legacy.TimeDelta(now, glr.createdAt(), legacy.SinceDuration)),
+		UpdatedAt:    signal.Val(glr.updatedAt()),
+		UpdatedSince: signal.Val(legacy.TimeDelta(now, glr.updatedAt(), legacy.SinceDuration)),
+	}
+	if glr.BasicData.License.Name != "" {
+		s.License.Set(glr.BasicData.License.Name)
+	}
+
+	glr.logger.Debug("Fetching languages")
+	if lang, err := queryPrimaryLanguage(ctx, glr.client, glr.host(), glr.id()); err != nil {
+		return nil, err
+	} else if lang != "" {
+		s.Language.Set(lang)
+	}
+
+	glr.logger.Debug("Fetching contributor count")
+	if count, ok, err := queryContributorCount(ctx, glr.client, glr.host(), glr.id()); err != nil {
+		return nil, err
+	} else if ok {
+		s.ContributorCount.Set(count)
+	}
+
+	glr.logger.Debug("Fetching releases")
+	if rs, err := queryReleases(ctx, glr.client, glr.host(), glr.id(), now.Add(-legacyReleaseLookback)); err != nil {
+		return nil, err
+	} else if rs != nil {
+		s.RecentReleaseCount.Set(rs.Recent)
+		if rs.TotalOK {
+			s.ReleaseCount.Set(rs.Total)
+		}
+		if rs.Latest != nil {
+			s.LastReleasedAt.Set(rs.Latest.ReleasedAt)
+		}
+	}
+	s.SetReleaseLag(now)
+	s.SetDaysSinceLastRelease(now)
+
+	if branch := glr.BasicData.DefaultBranch; branch != "" {
+		glr.logger.Debug("Fetching recent commit count")
+		count, ok, err := queryCommitCount(ctx, glr.client, glr.host(), glr.id(), branch, now.Add(-legacyCommitLookback))
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			s.CommitFrequency.Set(legacy.Round(float64(count)/52, 2))
+		}
+	}
+	return s, nil
+}
+
+func (rc *RepoCollector) IsSupported(p projectrepo.Repo) bool {
+	_, ok := p.(*repo)
+	return ok
+}
@@ -0,0 +1,59 @@
+package gitlab
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/ossf/criticality_score/cmd/collect_signals/projectrepo"
+	log "github.com/sirupsen/logrus"
+)
+
+// DefaultHost is the host of the public GitLab instance.
+const DefaultHost = "gitlab.com"
+
+// empty is a convenience wrapper for the empty struct.
+type empty struct{}
+
+type factory struct {
+	client *Client
+	logger *log.Logger
+	hosts  map[string]empty
+}
+
+// NewRepoFactory returns a new projectrepo.Factory for repositories hosted on
+// the GitLab instances in hosts.
+//
+// Hosts are matched against the hostname of repository URLs, ignoring case.
+// This allows self-hosted GitLab instances to be supported along with
+// gitlab.com.
+func NewRepoFactory(client *Client, logger *log.Logger, hosts []string) projectrepo.Factory {
+	f := &factory{
+		client: client,
+		logger: logger,
+		hosts:  make(map[string]empty),
+	}
+	for _, h := range hosts {
+		if h = strings.ToLower(strings.TrimSpace(h)); h != "" {
+			f.hosts[h] = empty{}
+		}
+	}
+	return f
+}
+
+func (f *factory) New(ctx context.Context, u *url.URL) (projectrepo.Repo, error) {
+	p := &repo{
+		client:  f.client,
+		origURL: u,
+		logger:  f.logger.WithField("url", u),
+	}
+	if err := p.init(ctx); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+func (f *factory) Match(u *url.URL) bool {
+	_, ok := f.hosts[strings.ToLower(u.Hostname())]
+	return ok
+}
@@ -0,0 +1,235 @@
+package gitlab
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ossf/criticality_score/internal/httpjson"
+)
+
+const (
+	legacyCommitLookback  = time.Duration(365 * 24 * time.Hour)
+	legacyReleaseLookback = time.Duration(365 * 24 * time.Hour)
+
+	// releasesPerPage is the number of releases requested at a time, and
+	// maxReleasePages limits how many pages are examined when counting
+	// recent releases.
+	releasesPerPage = 100
+	maxReleasePages = 10
+
+	// totalHeader is the header GitLab uses to return the number of items in
+	// a paginated result. For performance reasons GitLab omits the header if
+	// there are more than 10,000 items.
+	totalHeader = "X-Total"
+)
+
+var errInvalidProjectPath = errors.New("invalid GitLab project path")
+
+type basicRepoData struct {
+	ID                int       `json:"id"`
+	PathWithNamespace string    `json:"path_with_namespace"`
+	WebURL            string    `json:"web_url"`
+	DefaultBranch     string    `json:"default_branch"`
+	StarCount         int       `json:"star_count"`
+	ForksCount        int       `json:"forks_count"`
+	CreatedAt         time.Time `json:"created_at"`
+	LastActivityAt    time.Time `json:"last_activity_at"`
+	Archived          bool      `json:"archived"`
+	License           struct {
+		Name string `json:"name"`
+	} `json:"license"`
+}
+
+type commit struct {
+	AuthoredDate time.Time `json:"authored_date"`
+}
+
+type release struct {
+	ReleasedAt time.Time `json:"released_at"`
+}
+
+// projectPath extracts the full path of a project, including the namespace,
+// from the URL u.
+//
+// Paths ending in ".git" or pointing to a page inside the project (e.g.
+// "/group/project/-/issues") are supported.
+func projectPath(u *url.URL) (string, error) {
+	p := strings.Trim(u.Path, "/")
+	if i := strings.Index(p, "/-/"); i >= 0 {
+		p = p[:i]
+	}
+	p = strings.TrimSuffix(p, ".git")
+	// A project must be inside a namespace, either a user or a group.
+	if !strings.Contains(p, "/") {
+		return "", fmt.Errorf("%w: %s", errInvalidProjectPath, u)
+	}
+	return p, nil
+}
+
+func queryBasicRepoData(ctx context.Context, c *Client, u *url.URL) (*basicRepoData, error) {
+	p, err := projectPath(u)
+	if err != nil {
+		return nil, err
+	}
+	query := url.Values{"license": {"true"}}
+	data := &basicRepoData{}
+	if _, err := c.get(ctx, u.Host, "projects/"+url.PathEscape(p), query, data); err != nil {
+		return nil, err
+	}
+	return data, nil
+}
+
+// queryLastCommit returns the most recent commit on the given branch.
+//
+// If there are no commits, nil will be returned.
+func queryLastCommit(ctx context.Context, c *Client, host string, id int, branch string) (*commit, error) {
+	query := url.Values{
+		"ref_name": {branch},
+		"per_page": {"1"},
+	}
+	var cs []commit
+	_, err := c.get(ctx, host, fmt.Sprintf("projects/%d/repository/commits", id), query, &cs)
+	if httpjson.StatusCode(err) == http.StatusNotFound {
+		// A 404 is returned if the repository is empty.
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(cs) == 0 {
+		return nil, nil
+	}
+	return &cs[0], nil
+}
+
+// releaseStats summarizes the releases of a project.
+type releaseStats struct {
+	// Latest is the most recent release, or nil if there are none.
+	Latest *release
+
+	// Total is the total number of releases. It is only valid if TotalOK is
+	// true, as GitLab may not return the total.
+	Total   int
+	TotalOK bool
+
+	// Recent is the number of releases since the time passed to
+	// queryReleases.
+	Recent int
+}
+
+// queryReleases returns the latest release of the project, the total number
+// of releases and the number of releases since the supplied time.
+//
+// If releases are not available for the project, nil will be returned.
+func queryReleases(ctx context.Context, c *Client, host string, id int, since time.Time) (*releaseStats, error) {
+	stats := &releaseStats{}
+	for page := 1; page <= maxReleasePages; page++ {
+		query := url.Values{
+			"order_by": {"released_at"},
+			"sort":     {"desc"},
+			"per_page": {strconv.Itoa(releasesPerPage)},
+			"page":     {strconv.Itoa(page)},
+		}
+		var rs []release
+		resp, err := c.get(ctx, host, fmt.Sprintf("projects/%d/releases", id), query, &rs)
+		switch httpjson.StatusCode(err) {
+		case http.StatusForbidden, http.StatusNotFound:
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		if page == 1 {
+			if len(rs) > 0 {
+				stats.Latest = &rs[0]
+			}
+			if stats.Total, stats.TotalOK, err = parseTotal(resp); err != nil {
+				return nil, err
+			}
+		}
+		for _, r := range rs {
+			if r.ReleasedAt.Before(since) {
+				return stats, nil
+			}
+			stats.Recent++
+		}
+		if len(rs) < releasesPerPage {
+			break
+		}
+	}
+	return stats, nil
+}
+
+// queryContributorCount returns the number of contributors to the project's
+// repository.
+//
+// If GitLab does not return the total, false will be returned.
+func queryContributorCount(ctx context.Context, c *Client, host string, id int) (int, bool, error) {
+	query := url.Values{"per_page": {"1"}}
+	resp, err := c.get(ctx, host, fmt.Sprintf("projects/%d/repository/contributors", id), query, nil)
+	if httpjson.StatusCode(err) == http.StatusNotFound {
+		// A 404 is returned if the repository is empty.
+		return 0, true, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return parseTotal(resp)
+}
+
+// queryCommitCount returns the number of commits on the given branch since
+// the supplied time.
+//
+// If GitLab does not return the total, false will be returned.
+func queryCommitCount(ctx context.Context, c *Client, host string, id int, branch string, since time.Time) (int, bool, error) {
+	query := url.Values{
+		"ref_name": {branch},
+		"since":    {since.UTC().Format(time.RFC3339)},
+		"per_page": {"1"},
+	}
+	resp, err := c.get(ctx, host, fmt.Sprintf("projects/%d/repository/commits", id), query, nil)
+	if httpjson.StatusCode(err) == http.StatusNotFound {
+		return 0, true, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return parseTotal(resp)
+}
+
+// parseTotal returns the value of the totalHeader in resp.
+//
+// If the header is missing false is returned.
+func parseTotal(resp *http.Response) (int, bool, error) {
+	v := resp.Header.Get(totalHeader)
+	if v == "" {
+		return 0, false, nil
+	}
+	total, err := strconv.Atoi(v)
+	if err != nil {
+		return 0, false, fmt.Errorf("failed to parse %s header: %w", totalHeader, err)
+	}
+	return total, true, nil
+}
+
+// queryPrimaryLanguage returns the language that makes up the largest
+// percentage of the project's repository.
+func queryPrimaryLanguage(ctx context.Context, c *Client, host string, id int) (string, error) {
+	langs := map[string]float64{}
+	if _, err := c.get(ctx, host, fmt.Sprintf("projects/%d/languages", id), nil, &langs); err != nil {
+		return "", err
+	}
+	primary := ""
+	for lang, pct := range langs {
+		if primary == "" || pct > langs[primary] || (pct == langs[primary] && lang < primary) {
+			primary = lang
+		}
+	}
+	return primary, nil
+}
@@ -0,0 +1,116 @@
+package gitlab
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestProjectPath(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{url: "https://gitlab.com/gitlab-org/gitlab", want: "gitlab-org/gitlab"},
+		{url: "https://gitlab.com/gitlab-org/gitlab/", want: "gitlab-org/gitlab"},
+		{url: "https://gitlab.com/gitlab-org/gitlab.git", want: "gitlab-org/gitlab"},
+		{url: "https://gitlab.com/gitlab-org/security/gitlab", want: "gitlab-org/security/gitlab"},
+		{url: "https://gitlab.com/gitlab-org/gitlab/-/issues", want: "gitlab-org/gitlab"},
+		{url: "https://gitlab.example.com:8443/group/project", want: "group/project"},
+	}
+	for _, test := range tests {
+		t.Run(test.url, func(t *testing.T) {
+			u, _ := url.Parse(test.url)
+			got, err := projectPath(u)
+			if err != nil {
+				t.Fatalf("projectPath() errored %v, want no error", err)
+			}
+			if got != test.want {
+				t.Fatalf("projectPath() == %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestProjectPath_Invalid(t *testing.T) {
+	for _, raw := range []string{"https://gitlab.com", "https://gitlab.com/", "https://gitlab.com/project"} {
+		t.Run(raw, func(t *testing.T) {
+			u, _ := url.Parse(raw)
+			if _, err := projectPath(u); !errors.Is(err, errInvalidProjectPath) {
+				t.Fatalf("projectPath() errored %v, want %v", err, errInvalidProjectPath)
+			}
+		})
+	}
+}
+
+// newTestClient returns a Client and host where all the requests are handled
+// by h.
+func newTestClient(t *testing.T, h http.Handler) (*Client, string) {
+	t.Helper()
+	s := httptest.NewTLSServer(h)
+	t.Cleanup(s.Close)
+	return NewClient(s.Client(), ""), strings.TrimPrefix(s.URL, "https://")
+}
+
+func TestQueryReleases(t *testing.T) {
+	now := time.Date(2022, 6, 1, 0, 0, 0, 0, time.UTC)
+	c, host := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set(totalHeader, "3")
+		w.Write([]byte(`[
+			{"released_at": "2022-05-01T00:00:00Z"},
+			{"released_at": "2022-01-01T00:00:00Z"},
+			{"released_at": "2020-01-01T00:00:00Z"}
+		]`))
+	}))
+	got, err := queryReleases(context.Background(), c, host, 1, now.Add(-legacyReleaseLookback))
+	if err != nil {
+		t.Fatalf("queryReleases() errored %v, want no error", err)
+	}
+	if got.Recent != 2 {
+		t.Fatalf("Recent == %d, want 2", got.Recent)
+	}
+	if !got.TotalOK || got.Total != 3 {
+		t.Fatalf("Total == %d, %v, want 3, true", got.Total, got.TotalOK)
+	}
+	if want := time.Date(2022, 5, 1, 0, 0, 0, 0, time.UTC); got.Latest == nil || !got.Latest.ReleasedAt.Equal(want) {
+		t.Fatalf("Latest == %v, want %v", got.Latest, want)
+	}
+}
+
+func TestQueryReleases_Unavailable(t *testing.T) {
+	c, host := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusForbidden)
+	}))
+	got, err := queryReleases(context.Background(), c, host, 1, time.Now())
+	if err != nil {
+		t.Fatalf("queryReleases() errored %v, want no error", err)
+	}
+	if got != nil {
+		t.Fatalf("queryReleases() == %v, want nil", got)
+	}
+}
+
+func TestQueryContributorCount(t *testing.T) {
+	c, host := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v4/projects/1/repository/contributors" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set(totalHeader, "42")
+		w.Write([]byte(`[{"name": "someone"}]`))
+	}))
+	got, ok, err := queryContributorCount(context.Background(), c, host, 1)
+	if err != nil {
+		t.Fatalf("queryContributorCount() errored %v, want no error", err)
+	}
+	if !ok || got != 42 {
+		t.Fatalf("queryContributorCount() == %d, %v, want 42, true", got, ok)
+	}
+}
@@ -0,0 +1,72 @@
+package gitlab
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+)
+
+// repo implements the projectrepo.Repo interface for a GitLab repository.
+type repo struct {
+	client  *Client
+	origURL *url.URL
+	logger  *log.Entry
+
+	BasicData  *basicRepoData
+	LastCommit *commit
+	realURL    *url.URL
+}
+
+// URL implements the projectrepo.Repo interface
+func (r *repo) URL() *url.URL {
+	return r.realURL
+}
+
+func (r *repo) init(ctx context.Context) error {
+	if r.BasicData != nil {
+		// Already finished. Don't init() more than once.
+		return nil
+	}
+	r.logger.Debug("Fetching basic data from GitLab")
+	data, err := queryBasicRepoData(ctx, r.client, r.origURL)
+	if err != nil {
+		return err
+	}
+	if data.DefaultBranch != "" {
+		r.logger.Debug("Fetching last commit")
+		r.LastCommit, err = queryLastCommit(ctx, r.client, r.host(), data.ID, data.DefaultBranch)
+		if err != nil {
+			return err
+		}
+	}
+	r.realURL, err = url.Parse(data.WebURL)
+	if err != nil {
+		return err
+	}
+	// Set BasicData last as it is used to indicate init() has been called.
+	r.BasicData = data
+	return nil
+}
+
+// host returns the host, including any port, of the GitLab instance hosting
+// the repo.
+func (r *repo) host() string {
+	return r.origURL.Host
+}
+
+func (r *repo) id() int {
+	return r.BasicData.ID
+}
+
+func (r *repo) updatedAt() time.Time {
+	if r.LastCommit == nil {
+		return r.BasicData.LastActivityAt
+	}
+	return r.LastCommit.AuthoredDate
+}
+
+func (r *repo) createdAt() time.Time {
+	return r.BasicData.CreatedAt
+}
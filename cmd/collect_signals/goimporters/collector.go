@@ -0,0 +1,107 @@
+// Package goimporters provides a Collector that returns a Set for the number
+// of packages that import the Go modules published from a repository.
+//
+// The modules rooted in a repository, including each module in a multi-module
+// repository, are found using the deps.dev API. The number of importers of a
+// module is the number of packages that directly depend on its default
+// version.
+package goimporters
+
+import (
+	"context"
+
+	"github.com/ossf/criticality_score/cmd/collect_signals/projectrepo"
+	"github.com/ossf/criticality_score/cmd/collect_signals/signal"
+	"github.com/ossf/criticality_score/internal/depsdevapi"
+	log "github.com/sirupsen/logrus"
+)
+
+// goSystem is the name deps.dev uses for the Go package management system.
+const goSystem = "GO"
+
+type goSet struct {
+	ModuleCount signal.Field[int]
+
+	// Importers is the total number of packages that directly depend on any
+	// of the modules published from the repository.
+	Importers signal.Field[int]
+}
+
+func (s *goSet) Namespace() signal.Namespace {
+	return signal.Namespace("go")
+}
+
+type Collector struct {
+	client *depsdevapi.Client
+	logger *log.Logger
+}
+
+// NewCollector returns a new Collector that uses the Client c to query
+// deps.dev.
+func NewCollector(c *depsdevapi.Client, logger *log.Logger) *Collector {
+	return &Collector{
+		client: c,
+		logger: logger,
+	}
+}
+
+func (c *Collector) EmptySet() signal.Set {
+	return &goSet{}
+}
+
+func (c *Collector) IsSupported(r projectrepo.Repo) bool {
+	return true
+}
+
+// Collect implements the collector.Collector interface.
+//
+// If the repository is not known to deps.dev, or no Go modules are published
+// from it, the signals are left unset.
+func (c *Collector) Collect(ctx context.Context, r projectrepo.Repo) (signal.Set, error) {
+	s := &goSet{}
+	logger := c.logger.WithField("url", r.URL().String())
+
+	logger.Debug("Fetching Go modules from deps.dev")
+	modules, err := c.client.ProjectPackages(ctx, depsdevapi.ProjectKey(r.URL()), goSystem)
+	if err != nil {
+		return nil, err
+	}
+	if len(modules) == 0 {
+		return s, nil
+	}
+
+	importers := 0
+	for _, m := range modules {
+		logger.WithField("module", m.Name).Debug("Fetching Go module importers")
+		n, err := c.queryImporters(ctx, m.Name)
+		if err != nil {
+			return nil, err
+		}
+		importers += n
+	}
+	s.ModuleCount.Set(len(modules))
+	s.Importers.Set(importers)
+	return s, nil
+}
+
+// queryImporters returns the number of packages that directly depend on the
+// default version of the Go module.
+//
+// If the module does not have a default version, 0 is returned.
+func (c *Collector) queryImporters(ctx context.Context, module string) (int, error) {
+	version, err := c.client.DefaultVersion(ctx, goSystem, module)
+	if err != nil {
+		return 0, err
+	}
+	if version == "" {
+		return 0, nil
+	}
+	d, err := c.client.Dependents(ctx, depsdevapi.VersionKey{System: goSystem, Name: module, Version: version})
+	if err != nil {
+		return 0, err
+	}
+	if d == nil {
+		return 0, nil
+	}
+	return d.DirectDependentCount, nil
+}
@@ -0,0 +1,55 @@
+package goimporters
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/ossf/criticality_score/internal/collectortest"
+	"github.com/ossf/criticality_score/internal/depsdevapi"
+)
+
+// testRepoURL is the repository the signals are collected for.
+const testRepoURL = "https://github.com/Example/Repo"
+
+// newTestCollector returns a Collector where all the requests are handled by
+// h.
+func newTestCollector(t *testing.T, h http.Handler) *Collector {
+	t.Helper()
+	s := collectortest.NewServer(t, h)
+	return NewCollector(depsdevapi.NewCustomClient(s.URL, &http.Client{}), collectortest.Logger())
+}
+
+func TestCollect(t *testing.T) {
+	c := newTestCollector(t, collectortest.PathHandler(map[string]string{
+		"/projects/github.com%2Fexample%2Frepo:packageversions": `{"versions": [
+			{"versionKey": {"system": "GO", "name": "github.com/example/repo", "version": "v1.0.0"}},
+			{"versionKey": {"system": "GO", "name": "github.com/example/repo", "version": "v1.1.0"}},
+			{"versionKey": {"system": "GO", "name": "github.com/example/repo/sub", "version": "v0.1.0"}},
+			{"versionKey": {"system": "NPM", "name": "repo", "version": "1.0.0"}}
+		]}`,
+		"/systems/GO/packages/github.com%2Fexample%2Frepo": `{"versions": [
+			{"versionKey": {"version": "v1.0.0"}},
+			{"versionKey": {"version": "v1.1.0"}, "isDefault": true}
+		]}`,
+		"/systems/GO/packages/github.com%2Fexample%2Frepo/versions/v1.1.0:dependents": `{"dependentCount": 100, "directDependentCount": 40}`,
+		"/systems/GO/packages/github.com%2Fexample%2Frepo%2Fsub": `{"versions": [
+			{"versionKey": {"version": "v0.1.0"}, "isDefault": true}
+		]}`,
+		"/systems/GO/packages/github.com%2Fexample%2Frepo%2Fsub/versions/v0.1.0:dependents": `{"dependentCount": 3, "directDependentCount": 2}`,
+	}))
+	s := collectortest.Collect[*goSet](t, c, testRepoURL)
+	if got := s.ModuleCount.Get(); got != 2 {
+		t.Fatalf("ModuleCount == %d, want 2", got)
+	}
+	if got := s.Importers.Get(); got != 42 {
+		t.Fatalf("Importers == %d, want 42", got)
+	}
+}
+
+func TestCollect_UnknownProject(t *testing.T) {
+	c := newTestCollector(t, http.NotFoundHandler())
+	s := collectortest.Collect[*goSet](t, c, testRepoURL)
+	if s.ModuleCount.IsSet() || s.Importers.IsSet() {
+		t.Fatal("signals are set, want unset")
+	}
+}
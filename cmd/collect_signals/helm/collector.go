@@ -0,0 +1,163 @@
+// Package helm provides a Collector that returns a Set for the usage of the
+// Helm charts built from a repository, as reported by Artifact Hub.
+//
+// Charts are found by searching Artifact Hub for the name of the repository,
+// and keeping the charts with a link or home page that refers to the
+// repository.
+//
+// Artifact Hub does not publish the number of times a chart is pulled, so
+// only stars are collected.
+package helm
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/ossf/criticality_score/cmd/collect_signals/projectrepo"
+	"github.com/ossf/criticality_score/cmd/collect_signals/signal"
+	"github.com/ossf/criticality_score/internal/httpjson"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultAPIURL is the base URL of the Artifact Hub API.
+	DefaultAPIURL = "https://artifacthub.io/api/v1"
+
+	// searchLimit is the number of search results examined for charts
+	// built from a repository. The details of each chart are fetched, so it
+	// is kept small.
+	searchLimit = 20
+
+	// helmKind is the kind Artifact Hub uses for Helm charts.
+	helmKind = 0
+)
+
+type helmSet struct {
+	ChartCount signal.Field[int]
+
+	// StarCount is the total number of stars given to the charts on
+	// Artifact Hub.
+	StarCount signal.Field[int]
+}
+
+func (s *helmSet) Namespace() signal.Namespace {
+	return signal.Namespace("helm")
+}
+
+type searchResult struct {
+	Packages []struct {
+		Name       string `json:"name"`
+		Repository struct {
+			Name string `json:"name"`
+		} `json:"repository"`
+	} `json:"packages"`
+}
+
+type chart struct {
+	HomeURL string `json:"home_url"`
+	Links   []struct {
+		URL string `json:"url"`
+	} `json:"links"`
+	Stars int `json:"stars"`
+}
+
+type Collector struct {
+	client *http.Client
+	logger *log.Logger
+	apiURL string
+}
+
+// NewCollector returns a new Collector that uses the http.Client c to query
+// Artifact Hub.
+func NewCollector(c *http.Client, logger *log.Logger) *Collector {
+	return &Collector{
+		client: c,
+		logger: logger,
+		apiURL: DefaultAPIURL,
+	}
+}
+
+func (c *Collector) EmptySet() signal.Set {
+	return &helmSet{}
+}
+
+func (c *Collector) IsSupported(r projectrepo.Repo) bool {
+	return true
+}
+
+// Collect implements the collector.Collector interface.
+//
+// If no charts are built from the repository the signals are left unset.
+func (c *Collector) Collect(ctx context.Context, r projectrepo.Repo) (signal.Set, error) {
+	s := &helmSet{}
+	logger := c.logger.WithField("url", r.URL().String())
+
+	logger.Debug("Searching Artifact Hub")
+	query := url.Values{
+		"kind":         {fmt.Sprint(helmKind)},
+		"ts_query_web": {path.Base(strings.TrimSuffix(strings.Trim(r.URL().Path, "/"), ".git"))},
+		"limit":        {fmt.Sprint(searchLimit)},
+		"facets":       {"false"},
+		"deprecated":   {"false"},
+	}
+	var res searchResult
+	if _, err := httpjson.Get(ctx, c.client, c.apiURL+"/packages/search?"+query.Encode(), &res); err != nil {
+		return nil, err
+	}
+
+	repo := projectrepo.NormalizeURL(r.URL().String())
+	charts := 0
+	stars := 0
+	for _, p := range res.Packages {
+		logger.WithFields(log.Fields{
+			"chart_repo": p.Repository.Name,
+			"chart":      p.Name,
+		}).Debug("Fetching chart")
+		ch, err := c.queryChart(ctx, p.Repository.Name, p.Name)
+		if err != nil {
+			return nil, err
+		}
+		if ch == nil || !ch.refersTo(repo) {
+			continue
+		}
+		charts++
+		stars += ch.Stars
+	}
+	if charts == 0 {
+		return s, nil
+	}
+	s.ChartCount.Set(charts)
+	s.StarCount.Set(stars)
+	return s, nil
+}
+
+// queryChart returns the details of the latest version of the chart name in
+// the chart repository repoName.
+//
+// If the chart does not exist, nil is returned.
+func (c *Collector) queryChart(ctx context.Context, repoName, name string) (*chart, error) {
+	ch := &chart{}
+	_, err := httpjson.Get(ctx, c.client, c.apiURL+"/packages/helm/"+url.PathEscape(repoName)+"/"+url.PathEscape(name), ch)
+	if httpjson.StatusCode(err) == http.StatusNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return ch, nil
+}
+
+// refersTo returns true if the chart's home page or any of its links is the
+// normalized repository URL repo, or a page inside it such as the chart's
+// directory.
+func (ch *chart) refersTo(repo string) bool {
+	urls := []string{ch.HomeURL}
+	for _, l := range ch.Links {
+		urls = append(urls, l.URL)
+	}
+	return projectrepo.RefersTo(repo, urls...)
+}
@@ -0,0 +1,54 @@
+package helm
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/ossf/criticality_score/internal/collectortest"
+)
+
+// testRepoURL is the repository the signals are collected for.
+const testRepoURL = "https://github.com/prometheus-community/helm-charts"
+
+// newTestCollector returns a Collector where all the requests are answered
+// with the bodies in responses, keyed by path.
+func newTestCollector(t *testing.T, responses map[string]string) *Collector {
+	t.Helper()
+	s := collectortest.NewServer(t, collectortest.PathHandler(responses))
+	c := NewCollector(&http.Client{}, collectortest.Logger())
+	c.apiURL = s.URL
+	return c
+}
+
+func TestCollect(t *testing.T) {
+	c := newTestCollector(t, map[string]string{
+		"/packages/search": `{"packages": [
+			{"name": "kube-prometheus-stack", "repository": {"name": "prometheus-community"}},
+			{"name": "prometheus", "repository": {"name": "prometheus-community"}},
+			{"name": "prometheus", "repository": {"name": "bitnami"}},
+			{"name": "gone", "repository": {"name": "prometheus-community"}}
+		]}`,
+		"/packages/helm/prometheus-community/kube-prometheus-stack": `{"name": "kube-prometheus-stack", "stars": 300, "links": [
+			{"name": "Chart Source", "url": "https://github.com/prometheus-community/helm-charts/tree/main/charts/kube-prometheus-stack"}
+		]}`,
+		"/packages/helm/prometheus-community/prometheus": `{"name": "prometheus", "stars": 200, "home_url": "https://github.com/prometheus-community/helm-charts"}`,
+		"/packages/helm/bitnami/prometheus":              `{"name": "prometheus", "stars": 50, "home_url": "https://github.com/prometheus/prometheus", "links": [{"name": "source", "url": "https://github.com/bitnami/charts"}]}`,
+	})
+	s := collectortest.Collect[*helmSet](t, c, testRepoURL)
+	if got := s.ChartCount.Get(); got != 2 {
+		t.Fatalf("ChartCount == %d, want 2", got)
+	}
+	if got := s.StarCount.Get(); got != 500 {
+		t.Fatalf("StarCount == %d, want 500", got)
+	}
+}
+
+func TestCollect_NoCharts(t *testing.T) {
+	c := newTestCollector(t, map[string]string{
+		"/packages/search": `{"packages": []}`,
+	})
+	s := collectortest.Collect[*helmSet](t, c, testRepoURL)
+	if s.ChartCount.IsSet() || s.StarCount.IsSet() {
+		t.Fatal("signals are set, want unset")
+	}
+}
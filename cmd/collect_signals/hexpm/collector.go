@@ -0,0 +1,181 @@
+// Package hexpm provides a Collector that returns a Set for the usage of the
+// Elixir and Erlang packages published from a repository, as reported by
+// hex.pm.
+//
+// Packages are found by searching hex.pm for the name of the repository, and
+// keeping the packages with a link that refers to the repository.
+package hexpm
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/ossf/criticality_score/cmd/collect_signals/projectrepo"
+	"github.com/ossf/criticality_score/cmd/collect_signals/signal"
+	"github.com/ossf/criticality_score/internal/httpjson"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultAPIURL is the base URL of the hex.pm API.
+	DefaultAPIURL = "https://hex.pm/api"
+
+	// perPage is the number of packages hex.pm returns in each page of
+	// results.
+	perPage = 100
+
+	// maxDependentPages limits the number of pages of dependents fetched for
+	// each package, as hex.pm does not report the total.
+	maxDependentPages = 10
+)
+
+type hexSet struct {
+	PackageCount signal.Field[int]
+
+	// RecentDownloads is the total number of downloads of the packages in
+	// the last 90 days.
+	RecentDownloads signal.Field[int]
+
+	// DependentCount is the number of other packages that depend on any of
+	// the packages. It is at most 1000 for each package.
+	DependentCount signal.Field[int]
+}
+
+func (s *hexSet) Namespace() signal.Namespace {
+	return signal.Namespace("hexpm")
+}
+
+type hexPackage struct {
+	Name string `json:"name"`
+	Meta struct {
+		Links map[string]string `json:"links"`
+	} `json:"meta"`
+	Downloads struct {
+		Recent int `json:"recent"`
+	} `json:"downloads"`
+}
+
+type Collector struct {
+	client *http.Client
+	logger *log.Logger
+	apiURL string
+}
+
+// NewCollector returns a new Collector that uses the http.Client c to query
+// hex.pm.
+func NewCollector(c *http.Client, logger *log.Logger) *Collector {
+	return &Collector{
+		client: c,
+		logger: logger,
+		apiURL: DefaultAPIURL,
+	}
+}
+
+func (c *Collector) EmptySet() signal.Set {
+	return &hexSet{}
+}
+
+func (c *Collector) IsSupported(r projectrepo.Repo) bool {
+	return true
+}
+
+// Collect implements the collector.Collector interface.
+//
+// If no packages are published from the repository the signals are left
+// unset.
+func (c *Collector) Collect(ctx context.Context, r projectrepo.Repo) (signal.Set, error) {
+	s := &hexSet{}
+	logger := c.logger.WithField("url", r.URL().String())
+
+	logger.Debug("Searching hex.pm")
+	pkgs, err := c.queryPackages(ctx, r.URL())
+	if err != nil {
+		return nil, err
+	}
+	if len(pkgs) == 0 {
+		return s, nil
+	}
+
+	own := make(map[string]bool)
+	for _, p := range pkgs {
+		own[p.Name] = true
+	}
+	dependents := make(map[string]struct{})
+	downloads := 0
+	for _, p := range pkgs {
+		downloads += p.Downloads.Recent
+		logger.WithField("package", p.Name).Debug("Fetching hex.pm dependents")
+		names, err := c.queryDependents(ctx, p.Name)
+		if err != nil {
+			return nil, err
+		}
+		for _, name := range names {
+			if !own[name] {
+				dependents[name] = struct{}{}
+			}
+		}
+	}
+	s.PackageCount.Set(len(pkgs))
+	s.RecentDownloads.Set(downloads)
+	s.DependentCount.Set(len(dependents))
+	return s, nil
+}
+
+// search returns a page of the packages matching the hex.pm search query q.
+func (c *Collector) search(ctx context.Context, q string, page int) ([]hexPackage, error) {
+	query := url.Values{
+		"search": {q},
+		"page":   {fmt.Sprint(page)},
+	}
+	var res []hexPackage
+	if _, err := httpjson.Get(ctx, c.client, c.apiURL+"/packages?"+query.Encode(), &res); err != nil {
+		return nil, err
+	}
+	return res, nil
+}
+
+// queryPackages returns the packages published from the repository at u.
+//
+// Only the first page of search results is examined.
+func (c *Collector) queryPackages(ctx context.Context, u *url.URL) ([]hexPackage, error) {
+	res, err := c.search(ctx, path.Base(strings.TrimSuffix(strings.Trim(u.Path, "/"), ".git")), 1)
+	if err != nil {
+		return nil, err
+	}
+	repo := projectrepo.NormalizeURL(u.String())
+	var pkgs []hexPackage
+	for _, p := range res {
+		for _, link := range p.Meta.Links {
+			if projectrepo.RefersTo(repo, link) {
+				pkgs = append(pkgs, p)
+				break
+			}
+		}
+	}
+	return pkgs, nil
+}
+
+// queryDependents returns the names of the packages that depend on the
+// package name.
+//
+// At most maxDependentPages pages of dependents are fetched.
+func (c *Collector) queryDependents(ctx context.Context, name string) ([]string, error) {
+	var names []string
+	for page := 1; page <= maxDependentPages; page++ {
+		res, err := c.search(ctx, "depends:hexpm:"+name, page)
+		if err != nil {
+			return nil, err
+		}
+		for _, p := range res {
+			names = append(names, p.Name)
+		}
+		if len(res) < perPage {
+			break
+		}
+	}
+	return names, nil
+}
@@ -0,0 +1,77 @@
+package hexpm
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/ossf/criticality_score/internal/collectortest"
+)
+
+// testRepoURL is the repository the signals are collected for.
+const testRepoURL = "https://github.com/elixir-plug/plug"
+
+// newTestCollector returns a Collector where all the requests are handled by
+// h.
+func newTestCollector(t *testing.T, h http.Handler) *Collector {
+	t.Helper()
+	s := collectortest.NewServer(t, h)
+	c := NewCollector(&http.Client{}, collectortest.Logger())
+	c.apiURL = s.URL
+	return c
+}
+
+// packagesJSON returns a JSON list of packages with the given names.
+func packagesJSON(names []string) string {
+	var items []string
+	for _, n := range names {
+		items = append(items, fmt.Sprintf(`{"name": %q}`, n))
+	}
+	return "[" + strings.Join(items, ",") + "]"
+}
+
+func TestCollect(t *testing.T) {
+	var manyDependents []string
+	for i := 0; i < perPage; i++ {
+		manyDependents = append(manyDependents, fmt.Sprintf("dep%d", i))
+	}
+	c := newTestCollector(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		q := r.URL.Query()
+		switch q.Get("search") + "#" + q.Get("page") {
+		case "plug#1":
+			w.Write([]byte(`[
+				{"name": "plug", "meta": {"links": {"GitHub": "https://github.com/elixir-plug/plug"}}, "downloads": {"all": 100000000, "recent": 5000000}},
+				{"name": "plug_crypto", "meta": {"links": {"GitHub": "https://github.com/elixir-plug/plug_crypto"}}, "downloads": {"recent": 4000000}}
+			]`))
+		case "depends:hexpm:plug#1":
+			w.Write([]byte(packagesJSON(manyDependents)))
+		case "depends:hexpm:plug#2":
+			w.Write([]byte(packagesJSON([]string{"phoenix", "dep0"})))
+		default:
+			w.Write([]byte(`[]`))
+		}
+	}))
+	s := collectortest.Collect[*hexSet](t, c, testRepoURL)
+	if got := s.PackageCount.Get(); got != 1 {
+		t.Fatalf("PackageCount == %d, want 1", got)
+	}
+	if got := s.RecentDownloads.Get(); got != 5000000 {
+		t.Fatalf("RecentDownloads == %d, want 5000000", got)
+	}
+	if got := s.DependentCount.Get(); got != perPage+1 {
+		t.Fatalf("DependentCount == %d, want %d", got, perPage+1)
+	}
+}
+
+func TestCollect_NoPackages(t *testing.T) {
+	c := newTestCollector(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[]`))
+	}))
+	s := collectortest.Collect[*hexSet](t, c, testRepoURL)
+	if s.PackageCount.IsSet() || s.RecentDownloads.IsSet() || s.DependentCount.IsSet() {
+		t.Fatal("signals are set, want unset")
+	}
+}
@@ -0,0 +1,171 @@
+// Package librariesio provides a Collector that returns a Set for the
+// SourceRank and dependent repository count of the packages published from a
+// repository, as reported by libraries.io.
+//
+// libraries.io allows 60 requests per minute for each API key, so requests
+// are sent at most once per second.
+package librariesio
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/ossf/criticality_score/cmd/collect_signals/projectrepo"
+	"github.com/ossf/criticality_score/cmd/collect_signals/signal"
+	"github.com/ossf/criticality_score/internal/httpjson"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultAPIURL is the base URL of the libraries.io API.
+	DefaultAPIURL = "https://libraries.io/api"
+
+	// requestInterval is the minimum time between requests to libraries.io,
+	// to stay within its rate limit.
+	requestInterval = time.Second
+)
+
+// hosts maps the hostnames of supported repositories to the name of the host
+// used by libraries.io.
+var hosts = map[string]string{
+	"github.com":    "github",
+	"gitlab.com":    "gitlab",
+	"bitbucket.org": "bitbucket",
+}
+
+type librariesIOSet struct {
+	ProjectCount signal.Field[int]
+
+	// SourceRank is the highest SourceRank of the packages.
+	SourceRank signal.Field[int]
+
+	// DependentReposCount is the highest number of repositories that depend
+	// on any one of the packages. The highest is used as repositories often
+	// depend on several packages published from the same repository.
+	DependentReposCount signal.Field[int]
+}
+
+func (s *librariesIOSet) Namespace() signal.Namespace {
+	return signal.Namespace("librariesio")
+}
+
+type project struct {
+	Name                string `json:"name"`
+	Platform            string `json:"platform"`
+	Rank                int    `json:"rank"`
+	DependentReposCount int    `json:"dependent_repos_count"`
+}
+
+type Collector struct {
+	client *http.Client
+	logger *log.Logger
+	apiURL string
+	apiKey string
+
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+// NewCollector returns a new Collector that uses the http.Client c to query
+// libraries.io. If apiKey is not empty it is sent with each request.
+func NewCollector(c *http.Client, apiKey string, logger *log.Logger) *Collector {
+	return &Collector{
+		client:   c,
+		logger:   logger,
+		apiURL:   DefaultAPIURL,
+		apiKey:   apiKey,
+		interval: requestInterval,
+	}
+}
+
+func (c *Collector) EmptySet() signal.Set {
+	return &librariesIOSet{}
+}
+
+func (c *Collector) IsSupported(r projectrepo.Repo) bool {
+	_, ok := hosts[strings.ToLower(r.URL().Hostname())]
+	return ok
+}
+
+// Collect implements the collector.Collector interface.
+//
+// If libraries.io does not know of any packages published from the
+// repository the signals are left unset.
+func (c *Collector) Collect(ctx context.Context, r projectrepo.Repo) (signal.Set, error) {
+	s := &librariesIOSet{}
+	c.logger.WithField("url", r.URL().String()).Debug("Fetching libraries.io projects")
+	projects, err := c.queryProjects(ctx, r.URL())
+	if err != nil {
+		return nil, err
+	}
+	if len(projects) == 0 {
+		return s, nil
+	}
+	rank := 0
+	dependents := 0
+	for _, p := range projects {
+		if p.Rank > rank {
+			rank = p.Rank
+		}
+		if p.DependentReposCount > dependents {
+			dependents = p.DependentReposCount
+		}
+	}
+	s.ProjectCount.Set(len(projects))
+	s.SourceRank.Set(rank)
+	s.DependentReposCount.Set(dependents)
+	return s, nil
+}
+
+// queryProjects returns the packages that libraries.io knows are published
+// from the repository at u.
+//
+// If libraries.io does not know about the repository, nil is returned.
+func (c *Collector) queryProjects(ctx context.Context, u *url.URL) ([]project, error) {
+	host := hosts[strings.ToLower(u.Hostname())]
+	p := strings.TrimSuffix(strings.Trim(u.Path, "/"), ".git")
+	reqURL := c.apiURL + "/" + host + "/" + p + "/projects"
+	if c.apiKey != "" {
+		reqURL += "?" + url.Values{"api_key": {c.apiKey}}.Encode()
+	}
+	if err := c.wait(ctx); err != nil {
+		return nil, err
+	}
+	var projects []project
+	_, err := httpjson.Get(ctx, c.client, reqURL, &projects)
+	if httpjson.StatusCode(err) == http.StatusNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return projects, nil
+}
+
+// wait blocks until the next request can be sent, or ctx is done.
+func (c *Collector) wait(ctx context.Context) error {
+	c.mu.Lock()
+	now := time.Now()
+	wait := c.next.Sub(now)
+	if wait < 0 {
+		wait = 0
+	}
+	c.next = now.Add(wait + c.interval)
+	c.mu.Unlock()
+	if wait == 0 {
+		return nil
+	}
+	t := time.NewTimer(wait)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
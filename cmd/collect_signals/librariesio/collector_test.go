@@ -0,0 +1,73 @@
+package librariesio
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/ossf/criticality_score/internal/collectortest"
+	log "github.com/sirupsen/logrus"
+)
+
+func newTestCollector(t *testing.T, apiKey string) *Collector {
+	t.Helper()
+	s := collectortest.NewServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/github/example/json/projects" || r.URL.Query().Get("api_key") != apiKey {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[
+			{"name": "example-json", "platform": "NPM", "rank": 18, "dependent_repos_count": 1200},
+			{"name": "example-json", "platform": "Pypi", "rank": 22, "dependent_repos_count": 300}
+		]`))
+	}))
+	c := NewCollector(&http.Client{}, apiKey, collectortest.Logger())
+	c.apiURL = s.URL
+	c.interval = 0
+	return c
+}
+
+func TestCollect(t *testing.T) {
+	s := collectortest.Collect[*librariesIOSet](t, newTestCollector(t, "secret"), "https://github.com/example/json.git")
+	if got := s.ProjectCount.Get(); got != 2 {
+		t.Fatalf("ProjectCount == %d, want 2", got)
+	}
+	if got := s.SourceRank.Get(); got != 22 {
+		t.Fatalf("SourceRank == %d, want 22", got)
+	}
+	if got := s.DependentReposCount.Get(); got != 1200 {
+		t.Fatalf("DependentReposCount == %d, want 1200", got)
+	}
+}
+
+func TestCollect_NoAPIKey(t *testing.T) {
+	s := collectortest.Collect[*librariesIOSet](t, newTestCollector(t, ""), "https://github.com/example/json")
+	if got := s.ProjectCount.Get(); got != 2 {
+		t.Fatalf("ProjectCount == %d, want 2", got)
+	}
+}
+
+func TestCollect_UnknownRepo(t *testing.T) {
+	s := collectortest.Collect[*librariesIOSet](t, newTestCollector(t, ""), "https://github.com/example/missing")
+	if s.SourceRank.IsSet() {
+		t.Fatal("SourceRank is set, want unset")
+	}
+}
+
+func TestIsSupported(t *testing.T) {
+	c := NewCollector(&http.Client{}, "", log.New())
+	tests := []struct {
+		url  string
+		want bool
+	}{
+		{"https://github.com/example/json", true},
+		{"https://GitLab.com/example/json", true},
+		{"https://bitbucket.org/example/json", true},
+		{"https://example.com/example/json", false},
+	}
+	for _, test := range tests {
+		if got := c.IsSupported(collectortest.NewRepo(t, test.url)); got != test.want {
+			t.Fatalf("IsSupported(%q) == %v, want %v", test.url, got, test.want)
+		}
+	}
+}
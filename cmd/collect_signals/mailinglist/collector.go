@@ -0,0 +1,131 @@
+// Package mailinglist provides a Collector that returns a Set for the
+// activity on the mailing lists a project uses.
+//
+// Mailing lists cannot be found from a repository, so they are read from a
+// mapping file. Archives served by public-inbox, such as lore.kernel.org, and
+// by Mailman 2's pipermail are supported.
+package mailinglist
+
+import (
+	"context"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ossf/criticality_score/cmd/collect_signals/projectrepo"
+	"github.com/ossf/criticality_score/cmd/collect_signals/signal"
+	"github.com/ossf/criticality_score/internal/repomap"
+	log "github.com/sirupsen/logrus"
+)
+
+// messageLookback is how far back messages are counted.
+const messageLookback = 90 * 24 * time.Hour
+
+type mailingListSet struct {
+	ListCount signal.Field[int]
+
+	// RecentMessageCount is the number of messages sent to any of the lists
+	// in the last 90 days.
+	RecentMessageCount signal.Field[int]
+}
+
+func (s *mailingListSet) Namespace() signal.Namespace {
+	return signal.Namespace("mailing_list")
+}
+
+type Collector struct {
+	client *http.Client
+	logger *log.Logger
+	lists  repomap.Map
+}
+
+// NewCollector returns a new Collector that uses the http.Client c to read
+// mailing list archives.
+//
+// lists maps repositories to the archive URLs of their mailing lists, such
+// as "https://lore.kernel.org/git/".
+func NewCollector(c *http.Client, lists repomap.Map, logger *log.Logger) *Collector {
+	return &Collector{
+		client: c,
+		logger: logger,
+		lists:  lists,
+	}
+}
+
+func (c *Collector) EmptySet() signal.Set {
+	return &mailingListSet{}
+}
+
+// IsSupported returns true if the repository has mailing lists in the
+// mapping file.
+func (c *Collector) IsSupported(r projectrepo.Repo) bool {
+	_, ok := c.lists.Lookup(r.URL())
+	return ok
+}
+
+// Collect implements the collector.Collector interface.
+//
+// Lists in an unsupported archive, such as Google Groups, are skipped. If
+// none of the lists are supported the signals are left unset.
+func (c *Collector) Collect(ctx context.Context, r projectrepo.Repo) (signal.Set, error) {
+	s := &mailingListSet{}
+	lists, _ := c.lists.Lookup(r.URL())
+	now := time.Now()
+	since := now.Add(-messageLookback)
+	found := 0
+	messages := 0
+	for _, l := range lists {
+		logger := c.logger.WithFields(log.Fields{
+			"url":  r.URL().String(),
+			"list": l,
+		})
+		var n int
+		var err error
+		switch archiveKind(l) {
+		case pipermail:
+			logger.Debug("Counting pipermail messages")
+			n, err = c.countPipermail(ctx, l, since, now)
+		case publicInbox:
+			logger.Debug("Counting public-inbox messages")
+			n, err = c.countPublicInbox(ctx, l, since)
+		default:
+			logger.Warn("Mailing list archive is not supported")
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		found++
+		messages += n
+	}
+	if found == 0 {
+		return s, nil
+	}
+	s.ListCount.Set(found)
+	s.RecentMessageCount.Set(messages)
+	return s, nil
+}
+
+type kind int
+
+const (
+	unsupported kind = iota
+	publicInbox
+	pipermail
+)
+
+// archiveKind returns the kind of archive at the URL u.
+//
+// Archives with "/pipermail/" in their path are served by Mailman 2. Google
+// Groups archives are not supported. All other archives are assumed to be
+// served by public-inbox.
+func archiveKind(u string) kind {
+	switch {
+	case strings.Contains(u, "groups.google.com"):
+		return unsupported
+	case strings.Contains(u, "/pipermail/"):
+		return pipermail
+	default:
+		return publicInbox
+	}
+}
@@ -0,0 +1,141 @@
+package mailinglist
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/ossf/criticality_score/internal/collectortest"
+	"github.com/ossf/criticality_score/internal/repomap"
+)
+
+func newTestCollector(t *testing.T, lists repomap.Map) *Collector {
+	t.Helper()
+	return NewCollector(&http.Client{}, lists, collectortest.Logger())
+}
+
+// testRepoURL is the repository the signals are collected for.
+const testRepoURL = "https://github.com/example/example"
+
+// atomPage returns an Atom feed with n entries.
+func atomPage(n int) string {
+	var b strings.Builder
+	b.WriteString(`<?xml version="1.0"?><feed xmlns="http://www.w3.org/2005/Atom">`)
+	for i := 0; i < n; i++ {
+		fmt.Fprintf(&b, "<entry><id>urn:uuid:%d</id></entry>", i)
+	}
+	b.WriteString("</feed>")
+	return b.String()
+}
+
+func TestCollect(t *testing.T) {
+	pages := map[string]string{
+		"":    atomPage(200),
+		"200": atomPage(35),
+	}
+	s := collectortest.NewServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("x") != "A" || !strings.HasPrefix(r.URL.Query().Get("q"), "d:") {
+			t.Errorf("unexpected query %q", r.URL.RawQuery)
+		}
+		body, ok := pages[r.URL.Query().Get("o")]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(body))
+	}))
+	c := newTestCollector(t, repomap.Map{
+		"github.com/example/example": {s.URL + "/list/", "https://groups.google.com/g/example"},
+	})
+	r := collectortest.NewRepo(t, testRepoURL)
+	if !c.IsSupported(r) {
+		t.Fatal("IsSupported() == false, want true")
+	}
+	set, err := c.Collect(context.Background(), r)
+	if err != nil {
+		t.Fatalf("Collect() errored %v, want no error", err)
+	}
+	ms := set.(*mailingListSet)
+	if got := ms.ListCount.Get(); got != 1 {
+		t.Fatalf("ListCount == %d, want 1", got)
+	}
+	if got := ms.RecentMessageCount.Get(); got != 235 {
+		t.Fatalf("RecentMessageCount == %d, want 235", got)
+	}
+}
+
+func TestCollect_Unsupported(t *testing.T) {
+	c := newTestCollector(t, repomap.Map{
+		"github.com/example/example": {"https://groups.google.com/g/example"},
+	})
+	set, err := c.Collect(context.Background(), collectortest.NewRepo(t, testRepoURL))
+	if err != nil {
+		t.Fatalf("Collect() errored %v, want no error", err)
+	}
+	ms := set.(*mailingListSet)
+	if ms.ListCount.IsSet() || ms.RecentMessageCount.IsSet() {
+		t.Fatal("signals are set, want unset")
+	}
+}
+
+func TestIsSupported_NotMapped(t *testing.T) {
+	c := newTestCollector(t, repomap.Map{})
+	if c.IsSupported(collectortest.NewRepo(t, testRepoURL)) {
+		t.Fatal("IsSupported() == true, want false")
+	}
+}
+
+func TestCountPipermail(t *testing.T) {
+	months := map[string]string{
+		"/pipermail/example/2022-January/date.html":  `<p><b>Messages:</b> 310<p>`,
+		"/pipermail/example/2022-February/date.html": `<p><b>Messages:</b> 50<p>`,
+	}
+	s := collectortest.NewServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := months[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(body))
+	}))
+	c := newTestCollector(t, nil)
+	since := time.Date(2022, time.January, 22, 0, 0, 0, 0, time.UTC)
+	now := time.Date(2022, time.March, 3, 0, 0, 0, 0, time.UTC)
+	// 10 of January's 31 days are counted, along with all of February. March
+	// has no archive yet.
+	n, err := c.countPipermail(context.Background(), s.URL+"/pipermail/example/", since, now)
+	if err != nil {
+		t.Fatalf("countPipermail() errored %v, want no error", err)
+	}
+	if n != 150 {
+		t.Fatalf("countPipermail() == %d, want 150", n)
+	}
+}
+
+func TestCountPipermail_ServerError(t *testing.T) {
+	s := collectortest.NewServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	c := newTestCollector(t, nil)
+	now := time.Now()
+	if _, err := c.countPipermail(context.Background(), s.URL, now.Add(-messageLookback), now); err == nil {
+		t.Fatal("countPipermail() returned no error, want an error")
+	}
+}
+
+func TestArchiveKind(t *testing.T) {
+	tests := map[string]kind{
+		"https://lore.kernel.org/git/":                  publicInbox,
+		"https://mail.python.org/pipermail/python-dev/": pipermail,
+		"https://groups.google.com/g/golang-nuts":       unsupported,
+		"https://public-inbox.org/meta/":                publicInbox,
+	}
+	for u, want := range tests {
+		if got := archiveKind(u); got != want {
+			t.Errorf("archiveKind(%q) == %v, want %v", u, got, want)
+		}
+	}
+}
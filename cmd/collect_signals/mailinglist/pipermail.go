@@ -0,0 +1,73 @@
+package mailinglist
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ossf/criticality_score/internal/httpjson"
+)
+
+// messagesPattern matches the message count at the top of a pipermail
+// archive page.
+var messagesPattern = regexp.MustCompile(`(?i)<b>Messages:</b>\s*([0-9]+)`)
+
+// countPipermail returns an estimate of the number of messages sent to the
+// pipermail list at base between since and now.
+//
+// pipermail archives messages by month, so the count for the month that
+// contains since is scaled by the fraction of that month after since.
+func (c *Collector) countPipermail(ctx context.Context, base string, since, now time.Time) (int, error) {
+	since = since.UTC()
+	total := 0.0
+	for m := monthStart(since); !m.After(now); m = m.AddDate(0, 1, 0) {
+		n, err := c.monthMessages(ctx, base, m)
+		if err != nil {
+			return 0, err
+		}
+		if next := m.AddDate(0, 1, 0); since.After(m) {
+			total += float64(n) * next.Sub(since).Hours() / next.Sub(m).Hours()
+		} else {
+			total += float64(n)
+		}
+	}
+	return int(total + 0.5), nil
+}
+
+func monthStart(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), 1, 0, 0, 0, 0, time.UTC)
+}
+
+// monthMessages returns the number of messages in the archive for the month
+// starting at m. If the month has no archive, 0 is returned.
+func (c *Collector) monthMessages(ctx context.Context, base string, m time.Time) (int, error) {
+	u := strings.TrimSuffix(base, "/") + "/" + m.Format("2006-January") + "/date.html"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return 0, err
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return 0, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return 0, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return 0, &httpjson.StatusError{URL: u, StatusCode: resp.StatusCode}
+	}
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return 0, err
+	}
+	match := messagesPattern.FindSubmatch(b)
+	if match == nil {
+		return 0, nil
+	}
+	return strconv.Atoi(string(match[1]))
+}
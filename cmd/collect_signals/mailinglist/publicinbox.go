@@ -0,0 +1,77 @@
+package mailinglist
+
+import (
+	"context"
+	"encoding/xml"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ossf/criticality_score/internal/httpjson"
+)
+
+// maxPublicInboxPages limits the number of pages of search results fetched
+// for a single list. The count stops at the messages on these pages.
+const maxPublicInboxPages = 25
+
+type atomFeed struct {
+	Entries []struct {
+		ID string `xml:"id"`
+	} `xml:"entry"`
+}
+
+// countPublicInbox returns the number of messages sent to the public-inbox
+// list at base since the given time.
+//
+// Messages are counted by paging through the Atom feed of a date search.
+func (c *Collector) countPublicInbox(ctx context.Context, base string, since time.Time) (int, error) {
+	total := 0
+	for page := 0; page < maxPublicInboxPages; page++ {
+		feed, err := c.searchPublicInbox(ctx, base, since, total)
+		if err != nil {
+			return 0, err
+		}
+		if feed == nil || len(feed.Entries) == 0 {
+			break
+		}
+		total += len(feed.Entries)
+	}
+	return total, nil
+}
+
+// searchPublicInbox returns the page of messages sent since the given time,
+// starting at offset.
+//
+// public-inbox responds with 404 when there are no more messages, so nil is
+// returned.
+func (c *Collector) searchPublicInbox(ctx context.Context, base string, since time.Time, offset int) (*atomFeed, error) {
+	q := url.Values{}
+	q.Set("q", "d:"+since.UTC().Format("20060102")+"..")
+	q.Set("x", "A")
+	if offset > 0 {
+		q.Set("o", strconv.Itoa(offset))
+	}
+	u := strings.TrimSuffix(base, "/") + "/?" + q.Encode()
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, &httpjson.StatusError{URL: u, StatusCode: resp.StatusCode}
+	}
+	feed := &atomFeed{}
+	if err := xml.NewDecoder(resp.Body).Decode(feed); err != nil {
+		return nil, err
+	}
+	return feed, nil
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"bufio"
 	"context"
+	"errors"
 	"flag"
 	"fmt"
 	"io"
@@ -11,15 +12,54 @@ import (
 	"os"
 	"path"
 	"strings"
+	"time"
 
+	"github.com/ossf/criticality_score/cmd/collect_signals/aggregate"
+	"github.com/ossf/criticality_score/cmd/collect_signals/bestpractices"
+	"github.com/ossf/criticality_score/cmd/collect_signals/bitbucket"
+	"github.com/ossf/criticality_score/cmd/collect_signals/cocoapods"
 	"github.com/ossf/criticality_score/cmd/collect_signals/collector"
+	"github.com/ossf/criticality_score/cmd/collect_signals/cpp"
+	"github.com/ossf/criticality_score/cmd/collect_signals/cran"
+	"github.com/ossf/criticality_score/cmd/collect_signals/cratesio"
+	"github.com/ossf/criticality_score/cmd/collect_signals/debian"
+	"github.com/ossf/criticality_score/cmd/collect_signals/depfreshness"
 	"github.com/ossf/criticality_score/cmd/collect_signals/depsdev"
+	"github.com/ossf/criticality_score/cmd/collect_signals/dockerhub"
+	"github.com/ossf/criticality_score/cmd/collect_signals/ecosystems"
+	"github.com/ossf/criticality_score/cmd/collect_signals/gitclone"
 	"github.com/ossf/criticality_score/cmd/collect_signals/github"
+	"github.com/ossf/criticality_score/cmd/collect_signals/githubdependents"
 	"github.com/ossf/criticality_score/cmd/collect_signals/githubmentions"
+	"github.com/ossf/criticality_score/cmd/collect_signals/gitlab"
+	"github.com/ossf/criticality_score/cmd/collect_signals/goimporters"
+	"github.com/ossf/criticality_score/cmd/collect_signals/helm"
+	"github.com/ossf/criticality_score/cmd/collect_signals/hexpm"
+	"github.com/ossf/criticality_score/cmd/collect_signals/librariesio"
+	"github.com/ossf/criticality_score/cmd/collect_signals/mailinglist"
+	"github.com/ossf/criticality_score/cmd/collect_signals/npm"
+	"github.com/ossf/criticality_score/cmd/collect_signals/nuget"
+	"github.com/ossf/criticality_score/cmd/collect_signals/openhub"
+	"github.com/ossf/criticality_score/cmd/collect_signals/osv"
+	"github.com/ossf/criticality_score/cmd/collect_signals/packagist"
 	"github.com/ossf/criticality_score/cmd/collect_signals/projectrepo"
+	"github.com/ossf/criticality_score/cmd/collect_signals/pubdev"
+	"github.com/ossf/criticality_score/cmd/collect_signals/registryreleases"
+	"github.com/ossf/criticality_score/cmd/collect_signals/repology"
 	"github.com/ossf/criticality_score/cmd/collect_signals/result"
+	"github.com/ossf/criticality_score/cmd/collect_signals/result/bq"
+	"github.com/ossf/criticality_score/cmd/collect_signals/rubygems"
+	"github.com/ossf/criticality_score/cmd/collect_signals/scorecard"
+	"github.com/ossf/criticality_score/cmd/collect_signals/signal"
+	"github.com/ossf/criticality_score/cmd/collect_signals/stackoverflow"
+	"github.com/ossf/criticality_score/cmd/collect_signals/swiftpm"
+	"github.com/ossf/criticality_score/cmd/collect_signals/terraform"
+	"github.com/ossf/criticality_score/cmd/collect_signals/wikidata"
+	"github.com/ossf/criticality_score/internal/depsdevapi"
 	"github.com/ossf/criticality_score/internal/githubapi"
+	"github.com/ossf/criticality_score/internal/httpjson"
 	"github.com/ossf/criticality_score/internal/outfile"
+	"github.com/ossf/criticality_score/internal/repomap"
 	"github.com/ossf/criticality_score/internal/textvarflag"
 	"github.com/ossf/criticality_score/internal/workerpool"
 	"github.com/ossf/scorecard/v4/clients/githubrepo/roundtripper"
@@ -30,14 +70,92 @@ import (
 const defaultLogLevel = log.InfoLevel
 
 var (
-	gcpProjectFlag     = flag.String("gcp-project-id", "", "the Google Cloud Project ID to use. Auto-detects by default.")
-	depsdevDisableFlag = flag.Bool("depsdev-disable", false, "disables the collection of signals from deps.dev.")
-	depsdevDatasetFlag = flag.String("depsdev-dataset", depsdev.DefaultDatasetName, "the BigQuery dataset name to use.")
-	workersFlag        = flag.Int("workers", 1, "the total number of concurrent workers to use.")
-	logLevel           log.Level
+	gcpProjectFlag           = flag.String("gcp-project-id", "", "the Google Cloud Project ID to use. Auto-detects by default.")
+	depsdevDisableFlag       = flag.Bool("depsdev-disable", false, "disables the collection of signals from deps.dev.")
+	npmDisableFlag           = flag.Bool("npm-disable", false, "disables the collection of npm download counts.")
+	cratesioDisableFlag      = flag.Bool("cratesio-disable", false, "disables the collection of signals from crates.io.")
+	goImportersDisableFlag   = flag.Bool("go-importers-disable", false, "disables the collection of Go module importer counts.")
+	nugetDisableFlag         = flag.Bool("nuget-disable", false, "disables the collection of NuGet download counts.")
+	rubygemsDisableFlag      = flag.Bool("rubygems-disable", false, "disables the collection of signals from rubygems.org.")
+	packagistDisableFlag     = flag.Bool("packagist-disable", false, "disables the collection of signals from Packagist.")
+	pubdevDisableFlag        = flag.Bool("pubdev-disable", false, "disables the collection of signals from pub.dev.")
+	hexpmDisableFlag         = flag.Bool("hexpm-disable", false, "disables the collection of signals from hex.pm.")
+	cocoapodsDisableFlag     = flag.Bool("cocoapods-disable", false, "disables the collection of signals from CocoaPods.")
+	terraformDisableFlag     = flag.Bool("terraform-disable", false, "disables the collection of Terraform Registry download counts.")
+	osvDisableFlag           = flag.Bool("osv-disable", false, "disables the collection of OSV vulnerability counts.")
+	registryDisableFlag      = flag.Bool("registry-releases-disable", false, "disables the collection of package registry release cadence.")
+	depFreshnessFlag         = flag.Bool("dependency-freshness", false, "collects the share of direct dependencies more than one major version behind, using deps.dev.")
+	scorecardDisableFlag     = flag.Bool("scorecard-disable", false, "disables the collection of OpenSSF Scorecard results.")
+	bestPracticesDisableFlag = flag.Bool("bestpractices-disable", false, "disables the collection of OpenSSF Best Practices badge levels.")
+	ecosystemsFlag           = flag.Bool("ecosystems", false, "collects dependent counts and downloads from ecosyste.ms instead of deps.dev. Does not require GCP.")
+	librariesIOFlag          = flag.Bool("librariesio", false, "collects SourceRank and dependent repository counts from libraries.io.")
+	openHubFlag              = flag.Bool("openhub", false, "collects contributor, commit and code line counts from Open Hub. Requires OPENHUB_API_KEY.")
+	stackOverflowFlag        = flag.Bool("stackoverflow", false, "collects Stack Overflow question counts for the tags of each repository.")
+	stackOverflowTagsFlag    = flag.String("stackoverflow-tags", "", "a CSV `file` mapping repository urls to Stack Overflow tags.")
+	dockerHubFlag            = flag.Bool("dockerhub", false, "collects pull and star counts for the Docker Hub images of each repository.")
+	dockerHubImagesFlag      = flag.String("dockerhub-images", "", "a CSV `file` mapping repository urls to Docker Hub images.")
+	helmFlag                 = flag.Bool("helm", false, "collects star counts for the Helm charts on Artifact Hub built from each repository.")
+	cranFlag                 = flag.Bool("cran", false, "collects downloads and reverse dependency counts for the CRAN packages of each repository.")
+	cranPackagesFlag         = flag.String("cran-packages", "", "a CSV `file` mapping repository urls to CRAN packages.")
+	wikidataFlag             = flag.Bool("wikidata", false, "collects whether each repository's project has a Wikidata entity and Wikipedia articles.")
+	swiftPMFlag              = flag.Bool("swiftpm", false, "collects the platform and Swift version compatibility of Swift packages from the Swift Package Index.")
+	mailingListsFlag         = flag.String("mailing-lists", "", "a CSV `file` mapping repository urls to mailing list archives to count recent messages in.")
+	repologyFlag             = flag.Bool("repology", false, "collects the number of distribution repositories a project is packaged in from Repology.")
+	debianPopconFlag         = flag.Bool("debian-popcon", false, "collects Debian popularity-contest signals for the packages built from a repository.")
+	debianCacheDirFlag       = flag.String("debian-popcon-cache-dir", "", "caches the Debian popularity-contest results in `dir` for a day.")
+	cppFlag                  = flag.Bool("cpp", false, "collects whether each repository is packaged in ConanCenter and vcpkg.")
+	depsdevDatasetFlag       = flag.String("depsdev-dataset", depsdev.DefaultDatasetName, "the BigQuery dataset name to use.")
+	depsdevDestroyFlag       = flag.Bool("depsdev-destroy-data", false, "deletes the BigQuery dataset before it is recreated.")
+	depsdevDetailFlag        = flag.Bool("depsdev-package-detail", false, "outputs the dependent count of each package mapped to a repository.")
+	depsdevEcosystemFlag     = flag.Bool("depsdev-ecosystem-breakdown", false, "outputs a dependent count for each ecosystem instead of a single dependent count.")
+	depsdevPyPIFlag          = flag.Bool("depsdev-pypi-downloads", false, "collects PyPI download counts for the packages that map to a repository.")
+	depsdevMavenFlag         = flag.Bool("depsdev-maven", false, "collects dependent counts and releases for the Maven artifacts that map to a repository.")
+	depsdevGHArchiveFlag     = flag.Bool("depsdev-gharchive", false, "collects event counts for the last 90 days of GitHub activity from GH Archive.")
+	depsdevScoresFileFlag    = flag.String("depsdev-inherited-scores", "", "the `file` containing scores from a previous run, used to sum the scores of a repository's direct dependents.")
+	depsdevScoresTableFlag   = flag.String("depsdev-inherited-scores-table", "", "the BigQuery `table` containing scores from a previous run, used to sum the scores of a repository's direct dependents.")
+	depsdevScoreColumnFlag   = flag.String("depsdev-inherited-score-column", "default_score", "the `column` containing the scores from a previous run.")
+	workersFlag              = flag.Int("workers", 1, "the total number of concurrent workers to use.")
+	mirrorDisableFlag        = flag.Bool("mirror-resolution-disable", false, "collects read-only mirrors as they are, instead of the upstream repository they mirror.")
+	repoTimeoutFlag          = flag.Duration("repo-timeout", 0, "the maximum `duration` to spend collecting a single repository. Zero means no limit.")
+	csvDelimiterFlag         = flag.String("csv-delimiter", ",", "the `character` used to separate fields in csv output. Use \"tab\" for tab separated output.")
+	csvAlwaysQuoteFlag       = flag.Bool("csv-always-quote", false, "quotes every field in csv output.")
+	passthroughFlag          = flag.String("passthrough", "", "a CSV `file` of extra columns for each repository to include in the output.")
+	bigqueryTableFlag        = flag.String("bigquery-table", "", "also streams each record into the BigQuery `table`, in the form project.dataset.table. The table is created if it does not exist.")
+	dryRunFlag               = flag.Bool("dry-run", false, "collects all the signals but does not write OUT_FILE.")
+	statusFileFlag           = flag.String("status-file", "", "writes the outcome of collecting each repository to `file` as JSON lines.")
+	rateLimitWaitFlag        = flag.Bool("rate-limit-wait", false, "waits for rate limits to reset and retries, instead of stopping the run.")
+	dedupeFlag               = flag.Bool("dedupe", false, "skips repositories that have already been collected during the run.")
+	githubEnterpriseFlag     = flag.String("github-enterprise-url", "", "the base `url` of a GitHub Enterprise Server instance to use instead of github.com.")
+	githubCacheDirFlag       = flag.String("github-cache-dir", "", "caches responses from GitHub in `dir`, to avoid repeating requests across runs.")
+	githubCacheTTLFlag       = flag.Duration("github-cache-ttl", 24*time.Hour, "the `duration` responses in -github-cache-dir are used for.")
+	githubDependentsFlag     = flag.Bool("github-dependents", false, "collects GitHub's \"Used by\" dependent counts from the dependents page of each repository.")
+	githubPackagesFlag       = flag.Bool("github-packages", false, "collects the number of GitHub Packages published from each repository and their downloads.")
+	githubFundingFlag        = flag.Bool("github-funding", false, "collects whether each repository has FUNDING.yml, GitHub Sponsors or Open Collective links.")
+	githubDiscussionsFlag    = flag.Bool("github-discussions", false, "collects the number of GitHub Discussions in each repository and their recent activity.")
+	githubActionUsageFlag    = flag.Bool("github-action-usage", false, "estimates how many workflows use each repository that is a GitHub Action. Uses code search.")
+	githubSigstoreFlag       = flag.Bool("github-sigstore", false, "collects the number of recent releases with assets recorded in the Sigstore Rekor transparency log.")
+	githubTrafficFlag        = flag.Bool("github-traffic", false, "collects the views and clones of each repository the token has push access to.")
+	githubTriageFlag         = flag.Bool("github-triage", false, "collects the share of open issues and pull requests that are labeled, and that are stale.")
+	githubCommitHistoryFlag  = flag.Bool("github-commit-history", false, "collects author, concentration and signing signals from a sample of each repository's recent commits.")
+	githubFileTreeFlag       = flag.Bool("github-file-tree", false, "collects whether each repository configures CI, and its share of test files.")
+	githubSecurityFlag       = flag.Bool("github-security-policy", false, "collects whether each repository has a security policy with a contact.")
+	githubPullsFlag          = flag.Bool("github-pull-requests", false, "collects merge time, review and acceptance signals from each repository's recent pull requests.")
+	githubProtectionFlag     = flag.Bool("github-branch-protection", false, "collects the protection rules of each repository's default branch.")
+	workflowRunsDisableFlag  = flag.Bool("github-workflow-runs-disable", false, "disables fetching GitHub Actions workflow runs.")
+	gitlabHostsFlag          = flag.String("gitlab-hosts", gitlab.DefaultHost, "a comma separated list of GitLab hosts to collect signals from.")
+	gitCloneFallbackFlag     = flag.Bool("git-clone-fallback", false, "clones repositories on unsupported hosts to collect signals from the git history.")
+	depsdevBackend           depsdev.Backend
+	depsdevUpdateStrategy    depsdev.UpdateStrategy
+	depsdevAggregation       aggregate.Strategy
+	formatType               result.WriterType
+	logLevel                 log.Level
 )
 
 func init() {
+	textvarflag.TextVar(flag.CommandLine, &depsdevBackend, "depsdev-backend", depsdev.BackendBigQuery, "sets the `backend` used to read deps.dev dependent counts. Can be bigquery or api.")
+	textvarflag.TextVar(flag.CommandLine, &depsdevUpdateStrategy, "depsdev-update-strategy", depsdev.UpdateNever, "sets the `strategy` for recreating deps.dev data. Can be always, stale, weekly, monthly or never.")
+	textvarflag.TextVar(flag.CommandLine, &depsdevAggregation, "depsdev-aggregation", depsdev.DefaultAggregation, "sets the `strategy` for combining dependent counts of many packages. Can be sum, max, mean or primary.")
+	textvarflag.TextVar(flag.CommandLine, &formatType, "format", result.WriterTypeCSV, "set the output `format`. Can be csv, text or proto.")
 	textvarflag.TextVar(flag.CommandLine, &logLevel, "log", defaultLogLevel, "set the `level` of logging.")
 	outfile.DefineFlags(flag.CommandLine, "force", "append", "OUT_FILE")
 	flag.Usage = func() {
@@ -52,44 +170,176 @@ func init() {
 	}
 }
 
-func handleRepo(ctx context.Context, logger *log.Entry, u *url.URL, out result.Writer) {
-	r, err := projectrepo.Resolve(ctx, u)
+// pendingPerWorker limits how many repositories may be waiting to be written
+// for each worker, while a slower repository ahead of them is still being
+// collected.
+const pendingPerWorker = 10
+
+// defaultRateLimitWait is how long to wait before retrying a repository when
+// the time the rate limit resets is unknown.
+const defaultRateLimitWait = time.Minute
+
+// exitRateLimited is the exit status used when the run stops because a rate
+// limit was exceeded, indicating that it can be retried later.
+const exitRateLimited = 3
+
+// repoJob is a repository url to collect signals for, along with its position
+// in the input.
+type repoJob struct {
+	index int
+	input string
+	u     *url.URL
+}
+
+// repoResult holds the outcome of collecting the signals for a repoJob.
+type repoResult struct {
+	index  int
+	input  string
+	logger *log.Entry
+
+	// sets is nil if the repository was skipped, and skip holds the reason.
+	sets []signal.Set
+	skip string
+	err  error
+}
+
+// collectRepo collects the signals for the repository at u.
+//
+// If the repository can't be found, or if seen is not nil and the repository's
+// canonical URL is already in seen, the repository is skipped. nil is returned
+// for the signal sets, along with the reason it was skipped.
+//
+// If timeout is greater than zero, the repository is also skipped if it takes
+// longer than timeout to collect.
+//
+// If resolveMirrors is true and the repository is a read-only mirror, the
+// upstream repository is collected instead, and the mirror's URL is recorded
+// in repo.mirror_url.
+//
+// The logger returned includes the canonical URL of the repository once it has
+// been resolved.
+func collectRepo(ctx context.Context, logger *log.Entry, u *url.URL, seen *repoSet, timeout time.Duration, resolveMirrors bool) (*log.Entry, []signal.Set, string, error) {
+	repoCtx := ctx
+	if timeout > 0 {
+		var cancel context.CancelFunc
+		repoCtx, cancel = context.WithTimeout(ctx, timeout)
+		defer cancel()
+	}
+	// timedOut returns true if repoCtx has timed out, rather than ctx being
+	// cancelled.
+	timedOut := func() bool {
+		return errors.Is(repoCtx.Err(), context.DeadlineExceeded) && ctx.Err() == nil
+	}
+
+	r, err := projectrepo.Resolve(repoCtx, u)
+	if err := collector.CheckRateLimit(err); errors.Is(err, collector.ErrRateLimited) {
+		// A rate limited repository may exist, so it must not be skipped.
+		return logger, nil, "", err
+	}
 	if err != nil {
+		reason := skipReasonUncollectable
+		if timedOut() {
+			reason = skipReasonTimeout
+		}
 		logger.WithFields(log.Fields{
-			"error": err,
+			"error":  err,
+			"reason": reason,
 		}).Warning("Failed to create project")
+		recordSkipped(ctx, reason)
 		// TODO: we should have an error that indicates that the URL/Project
 		// should be skipped/ignored.
-		return // TODO: add a flag to continue or abort on failure
+		return logger, nil, reason, nil // TODO: add a flag to continue or abort on failure
+	}
+	var mirror *url.URL
+	if resolveMirrors {
+		r, mirror, err = resolveUpstream(repoCtx, logger, r, projectrepo.Resolve)
+		if err != nil {
+			return logger, nil, "", err
+		}
 	}
 	logger = logger.WithField("canonical_url", r.URL().String())
+	if mirror != nil {
+		logger = logger.WithField("mirror_url", mirror.String())
+	}
 
-	// TODO: p.URL() should be checked to see if it has already been processed.
+	if seen != nil && !seen.Add(r.URL()) {
+		logger.Info("Skipping already collected repository")
+		recordSkipped(ctx, skipReasonDuplicate)
+		return logger, nil, skipReasonDuplicate, nil
+	}
 
 	// Collect the signals for the given project
 	logger.Info("Collecting")
-	ss, err := collector.Collect(ctx, r)
-	if err != nil {
+	ss, err := collector.Collect(repoCtx, r)
+	if err != nil && timedOut() {
 		logger.WithFields(log.Fields{
+			"error":   err,
+			"timeout": timeout,
+		}).Warning("Timed out collecting signals for project")
+		recordSkipped(ctx, skipReasonTimeout)
+		return logger, nil, skipReasonTimeout, nil
+	}
+	if seen != nil && errors.Is(err, collector.ErrRateLimited) {
+		// Allow the repository to be collected again once the rate limit
+		// has reset.
+		seen.Remove(r.URL())
+	}
+	if mirror != nil && err == nil {
+		setMirrorURL(ss, mirror)
+	}
+	return logger, ss, "", err
+}
+
+// collectRepoWithRetry calls collectRepo, and if wait is true, tries again
+// each time collection fails because a rate limit was exceeded, after waiting
+// for the limit to reset.
+func collectRepoWithRetry(ctx context.Context, logger *log.Entry, u *url.URL, seen *repoSet, timeout time.Duration, resolveMirrors, wait bool) (*log.Entry, []signal.Set, string, error) {
+	for {
+		l, ss, skip, err := collectRepo(ctx, logger, u, seen, timeout, resolveMirrors)
+		var rlErr *collector.RateLimitError
+		if !wait || !errors.As(err, &rlErr) {
+			return l, ss, skip, err
+		}
+		d := time.Until(rlErr.Reset)
+		if rlErr.Reset.IsZero() || d <= 0 {
+			d = defaultRateLimitWait
+		}
+		l.WithFields(log.Fields{
 			"error": err,
-		}).Error("Failed to collect signals for project")
-		os.Exit(1) // TODO: add a flag to continue or abort on failure
+			"wait":  d,
+		}).Warning("Rate limited; waiting before retrying")
+		time.Sleep(d)
 	}
+}
 
+// writeRepo writes the signal sets collected for a repository to out as a
+// single record.
+//
+// The extras are written to the extra columns, in the same order. If extras
+// is empty the extra columns are left empty.
+func writeRepo(logger *log.Entry, out result.Writer, ss []signal.Set, columns, extras []string) {
 	rec := out.Record()
 	for _, s := range ss {
 		if err := rec.WriteSignalSet(s); err != nil {
 			logger.WithFields(log.Fields{
 				"error": err,
 			}).Error("Failed to write signal set")
-			os.Exit(1) // TODO: add a flag to continue or abort on failure
+			exit(1) // TODO: add a flag to continue or abort on failure
+		}
+	}
+	for i, v := range extras {
+		if err := rec.WriteExtra(columns[i], v); err != nil {
+			logger.WithFields(log.Fields{
+				"error": err,
+			}).Error("Failed to write extra column")
+			exit(1) // TODO: add a flag to continue or abort on failure
 		}
 	}
 	if err := rec.Done(); err != nil {
 		logger.WithFields(log.Fields{
 			"error": err,
 		}).Error("Failed to complete record")
-		os.Exit(1) // TODO: add a flag to continue or abort on failure
+		exit(1) // TODO: add a flag to continue or abort on failure
 	}
 }
 
@@ -135,43 +385,333 @@ func main() {
 	}
 	r := io.MultiReader(readers...)
 
-	// Open the out-file for writing
+	// Open the out-file for writing, unless this is a dry run.
 	outFilename := flag.Args()[lastArg]
-	w, err := outfile.Open(outFilename)
-	if err != nil {
+	var w io.Writer
+	var err error
+	dryRunOut := &countingWriter{}
+	if *dryRunFlag {
 		logger.WithFields(log.Fields{
-			"error":    err,
 			"filename": outFilename,
-		}).Error("Failed to open file for output")
-		os.Exit(2)
+		}).Warn("Dry run: output will not be written")
+		w = dryRunOut
+	} else {
+		var f *os.File
+		f, err = outfile.Open(outFilename)
+		if err != nil {
+			logger.WithFields(log.Fields{
+				"error":    err,
+				"filename": outFilename,
+			}).Error("Failed to open file for output")
+			os.Exit(2)
+		}
+		defer f.Close()
+		w = f
 	}
-	defer w.Close()
 
 	ctx := context.Background()
 
+	if err := registerMetricsViews(); err != nil {
+		logger.WithFields(log.Fields{
+			"error": err,
+		}).Error("Failed to register metrics views")
+		os.Exit(2)
+	}
+
 	// Bump the # idle conns per host
 	http.DefaultTransport.(*http.Transport).MaxIdleConnsPerHost = *workersFlag * 5
 
 	// Prepare a client for communicating with GitHub's GraphQLv4 API and Restv3 API
 	rt := githubapi.NewRoundTripper(roundtripper.NewTransport(ctx, scLogger), logger)
+	if *githubCacheDirFlag != "" {
+		logger.WithFields(log.Fields{
+			"dir": *githubCacheDirFlag,
+			"ttl": *githubCacheTTLFlag,
+		}).Info("Caching GitHub responses")
+		rt = githubapi.NewCachingRoundTripper(rt, *githubCacheDirFlag, *githubCacheTTLFlag)
+	}
 	httpClient := &http.Client{
 		Transport: rt,
 	}
 	ghClient := githubapi.NewClient(httpClient)
+	if *githubEnterpriseFlag != "" {
+		ghClient, err = githubapi.NewEnterpriseClient(*githubEnterpriseFlag, httpClient)
+		if err != nil {
+			logger.WithFields(log.Fields{
+				"error": err,
+				"url":   *githubEnterpriseFlag,
+			}).Error("Failed to create GitHub Enterprise client")
+			os.Exit(2)
+		}
+		logger.WithField("host", ghClient.Host()).Info("Using GitHub Enterprise Server")
+	}
+
+	// Prepare a client for communicating with GitLab's REST API. A token is
+	// optional, but allows private projects to be accessed.
+	glClient := gitlab.NewClient(&http.Client{}, os.Getenv("GITLAB_TOKEN"))
+
+	// Prepare a client for communicating with Bitbucket Cloud's REST API. A
+	// token is optional, but allows private repositories to be accessed.
+	bbClient := bitbucket.NewClient(&http.Client{}, os.Getenv("BITBUCKET_TOKEN"))
 
 	// Register all the Repo factories.
 	projectrepo.Register(github.NewRepoFactory(ghClient, logger))
+	projectrepo.Register(gitlab.NewRepoFactory(glClient, logger, strings.Split(*gitlabHostsFlag, ",")))
+	projectrepo.Register(bitbucket.NewRepoFactory(bbClient, logger))
+	if *gitCloneFallbackFlag {
+		// The fallback matches any https URL, so it must be registered last.
+		projectrepo.Register(gitclone.NewRepoFactory(logger))
+	}
+
+	// Third-party services are queried with a client that retries transient
+	// failures, and their collectors leave signals unset if the failure persists.
+	thirdPartyClient := httpjson.NewRetryClient()
 
 	// Register all the collectors that are supported.
-	collector.Register(&github.RepoCollector{})
+	collector.Register(&github.RepoCollector{
+		CommitHistory:    *githubCommitHistoryFlag,
+		FileTree:         *githubFileTreeFlag,
+		SecurityPolicy:   *githubSecurityFlag,
+		PullRequests:     *githubPullsFlag,
+		BranchProtection: *githubProtectionFlag,
+	})
 	collector.Register(&github.IssuesCollector{})
-	collector.Register(githubmentions.NewCollector(ghClient))
+	collector.Register(&github.WorkflowCollector{IncludeRuns: !*workflowRunsDisableFlag})
+	collector.Register(&github.ProvenanceCollector{})
+	if *githubPackagesFlag {
+		collector.Register(&github.PackagesCollector{})
+	}
+	if *githubFundingFlag {
+		collector.Register(&github.FundingCollector{})
+	}
+	if *githubDiscussionsFlag {
+		collector.Register(&github.DiscussionsCollector{})
+	}
+	if *githubActionUsageFlag {
+		collector.Register(&github.ActionUsageCollector{})
+	}
+	if *githubSigstoreFlag {
+		collector.Register(collector.TolerateTransient(&github.SigstoreCollector{Client: thirdPartyClient}, logger))
+	}
+	if *githubTrafficFlag {
+		collector.Register(&github.TrafficCollector{})
+	}
+	if *githubTriageFlag {
+		collector.Register(&github.TriageCollector{})
+	}
+	collector.Register(&gitlab.RepoCollector{})
+	collector.Register(&bitbucket.RepoCollector{})
+	collector.Register(&gitclone.RepoCollector{})
+	collector.Register(githubmentions.NewCollector(ghClient, logger))
+	if *githubDependentsFlag {
+		collector.Register(collector.TolerateTransient(githubdependents.NewCollector(thirdPartyClient, logger), logger))
+	}
+	if *npmDisableFlag {
+		logger.Warn("npm signal collection is disabled.")
+	} else {
+		collector.Register(collector.TolerateTransient(npm.NewCollector(ghClient, thirdPartyClient, logger), logger))
+	}
+	if *cratesioDisableFlag {
+		logger.Warn("crates.io signal collection is disabled.")
+	} else {
+		collector.Register(collector.TolerateTransient(cratesio.NewCollector(thirdPartyClient, logger), logger))
+	}
+	depsdevClient := depsdevapi.NewClient(thirdPartyClient)
+	if *goImportersDisableFlag {
+		logger.Warn("Go module importer collection is disabled.")
+	} else {
+		collector.Register(collector.TolerateTransient(goimporters.NewCollector(depsdevClient, logger), logger))
+	}
+	if *nugetDisableFlag {
+		logger.Warn("NuGet signal collection is disabled.")
+	} else {
+		collector.Register(collector.TolerateTransient(nuget.NewCollector(thirdPartyClient, logger), logger))
+	}
+	if *rubygemsDisableFlag {
+		logger.Warn("rubygems.org signal collection is disabled.")
+	} else {
+		collector.Register(collector.TolerateTransient(rubygems.NewCollector(thirdPartyClient, logger), logger))
+	}
+	if *packagistDisableFlag {
+		logger.Warn("Packagist signal collection is disabled.")
+	} else {
+		collector.Register(collector.TolerateTransient(packagist.NewCollector(thirdPartyClient, logger), logger))
+	}
+	if *pubdevDisableFlag {
+		logger.Warn("pub.dev signal collection is disabled.")
+	} else {
+		collector.Register(collector.TolerateTransient(pubdev.NewCollector(thirdPartyClient, logger), logger))
+	}
+	if *hexpmDisableFlag {
+		logger.Warn("hex.pm signal collection is disabled.")
+	} else {
+		collector.Register(collector.TolerateTransient(hexpm.NewCollector(thirdPartyClient, logger), logger))
+	}
+	if *cocoapodsDisableFlag {
+		logger.Warn("CocoaPods signal collection is disabled.")
+	} else {
+		collector.Register(collector.TolerateTransient(cocoapods.NewCollector(thirdPartyClient, logger), logger))
+	}
+	if *terraformDisableFlag {
+		logger.Warn("Terraform Registry signal collection is disabled.")
+	} else {
+		collector.Register(collector.TolerateTransient(terraform.NewCollector(thirdPartyClient, logger), logger))
+	}
+	if *osvDisableFlag {
+		logger.Warn("OSV vulnerability collection is disabled.")
+	} else {
+		collector.Register(collector.TolerateTransient(osv.NewCollector(depsdevClient, thirdPartyClient, logger), logger))
+	}
+	if *registryDisableFlag {
+		logger.Warn("Package registry release collection is disabled.")
+	} else {
+		collector.Register(collector.TolerateTransient(registryreleases.NewCollector(depsdevClient, logger), logger))
+	}
+	if *depFreshnessFlag {
+		collector.Register(collector.TolerateTransient(depfreshness.NewCollector(depsdevClient, logger), logger))
+	}
+	if *scorecardDisableFlag {
+		logger.Warn("Scorecard signal collection is disabled.")
+	} else {
+		collector.Register(collector.TolerateTransient(scorecard.NewCollector(thirdPartyClient, logger), logger))
+	}
+	if *bestPracticesDisableFlag {
+		logger.Warn("Best Practices badge collection is disabled.")
+	} else {
+		collector.Register(collector.TolerateTransient(bestpractices.NewCollector(thirdPartyClient, logger), logger))
+	}
+	if *librariesIOFlag {
+		key := os.Getenv("LIBRARIES_IO_API_KEY")
+		if key == "" {
+			logger.Warn("LIBRARIES_IO_API_KEY is not set, libraries.io may reject requests.")
+		}
+		collector.Register(collector.TolerateTransient(librariesio.NewCollector(thirdPartyClient, key, logger), logger))
+	}
+	if *openHubFlag {
+		key := os.Getenv("OPENHUB_API_KEY")
+		if key == "" {
+			logger.Error("OPENHUB_API_KEY must be set to collect signals from Open Hub")
+			os.Exit(2)
+		}
+		collector.Register(collector.TolerateTransient(openhub.NewCollector(thirdPartyClient, key, logger), logger))
+	}
+	if *stackOverflowFlag {
+		var tags repomap.Map
+		if *stackOverflowTagsFlag != "" {
+			tags, err = repomap.Open(*stackOverflowTagsFlag)
+			if err != nil {
+				logger.WithFields(log.Fields{
+					"error":    err,
+					"filename": *stackOverflowTagsFlag,
+				}).Error("Failed to load Stack Overflow tags")
+				os.Exit(2)
+			}
+		}
+		collector.Register(collector.TolerateTransient(stackoverflow.NewCollector(thirdPartyClient, os.Getenv("STACK_EXCHANGE_KEY"), tags, logger), logger))
+	}
+	if *dockerHubFlag {
+		var images repomap.Map
+		if *dockerHubImagesFlag != "" {
+			images, err = repomap.Open(*dockerHubImagesFlag)
+			if err != nil {
+				logger.WithFields(log.Fields{
+					"error":    err,
+					"filename": *dockerHubImagesFlag,
+				}).Error("Failed to load Docker Hub images")
+				os.Exit(2)
+			}
+		}
+		collector.Register(collector.TolerateTransient(dockerhub.NewCollector(thirdPartyClient, images, logger), logger))
+	}
+	if *helmFlag {
+		collector.Register(collector.TolerateTransient(helm.NewCollector(thirdPartyClient, logger), logger))
+	}
+	if *cranFlag {
+		var packages repomap.Map
+		if *cranPackagesFlag != "" {
+			packages, err = repomap.Open(*cranPackagesFlag)
+			if err != nil {
+				logger.WithFields(log.Fields{
+					"error":    err,
+					"filename": *cranPackagesFlag,
+				}).Error("Failed to load CRAN packages")
+				os.Exit(2)
+			}
+		}
+		cc, err := cran.NewCollector(ctx, thirdPartyClient, packages, logger)
+		if err != nil {
+			logger.WithFields(log.Fields{
+				"error": err,
+			}).Error("Failed to create CRAN collector")
+			os.Exit(2)
+		}
+		collector.Register(collector.TolerateTransient(cc, logger))
+	}
+	if *wikidataFlag {
+		collector.Register(collector.TolerateTransient(wikidata.NewCollector(thirdPartyClient, logger), logger))
+	}
+	if *swiftPMFlag {
+		collector.Register(collector.TolerateTransient(swiftpm.NewCollector(thirdPartyClient, logger), logger))
+	}
+	if *mailingListsFlag != "" {
+		lists, err := repomap.Open(*mailingListsFlag)
+		if err != nil {
+			logger.WithFields(log.Fields{
+				"error":    err,
+				"filename": *mailingListsFlag,
+			}).Error("Failed to load mailing lists")
+			os.Exit(2)
+		}
+		collector.Register(collector.TolerateTransient(mailinglist.NewCollector(thirdPartyClient, lists, logger), logger))
+	}
+	// Repology limits the rate of requests, so a single client is shared.
+	repologyClient := repology.NewClient(thirdPartyClient)
+	if *repologyFlag {
+		collector.Register(collector.TolerateTransient(repology.NewCollector(repologyClient, logger), logger))
+	}
+	if *debianPopconFlag {
+		dc, err := debian.NewCollector(ctx, thirdPartyClient, repologyClient, logger, *debianCacheDirFlag)
+		if err != nil {
+			logger.WithFields(log.Fields{
+				"error": err,
+			}).Error("Failed to create Debian popularity-contest collector")
+			os.Exit(2)
+		}
+		logger.Info("Debian popularity-contest signal collector enabled")
+		collector.Register(collector.TolerateTransient(dc, logger))
+	}
+	if *cppFlag {
+		collector.Register(collector.TolerateTransient(cpp.NewCollector(thirdPartyClient, repologyClient, logger), logger))
+	}
+
+	if *ecosystemsFlag {
+		collector.Register(collector.TolerateTransient(ecosystems.NewCollector(thirdPartyClient, logger), logger))
+	}
 
 	if *depsdevDisableFlag {
 		// deps.dev collection has been disabled, so skip it.
 		logger.Warn("deps.dev signal collection is disabled.")
+	} else if *ecosystemsFlag {
+		// ecosyste.ms is used in place of deps.dev, so skip it.
+		logger.Info("deps.dev signal collection is replaced by ecosyste.ms.")
 	} else {
-		ddcollector, err := depsdev.NewCollector(ctx, logger, *gcpProjectFlag, *depsdevDatasetFlag)
+		ddcollectors, err := depsdev.NewCollectors(ctx, logger, depsdev.Config{
+			Backend:              depsdevBackend,
+			APIClient:            depsdevClient,
+			ProjectID:            *gcpProjectFlag,
+			DatasetName:          *depsdevDatasetFlag,
+			UpdateStrategy:       depsdevUpdateStrategy,
+			DestroyData:          *depsdevDestroyFlag,
+			Aggregation:          depsdevAggregation,
+			PackageDetail:        *depsdevDetailFlag,
+			EcosystemBreakdown:   *depsdevEcosystemFlag,
+			PyPIDownloads:        *depsdevPyPIFlag,
+			Maven:                *depsdevMavenFlag,
+			GHArchive:            *depsdevGHArchiveFlag,
+			InheritedScoresFile:  *depsdevScoresFileFlag,
+			InheritedScoresTable: *depsdevScoresTableFlag,
+			InheritedScoreColumn: *depsdevScoreColumnFlag,
+		})
 		if err != nil {
 			logger.WithFields(log.Fields{
 				"error": err,
@@ -179,52 +719,201 @@ func main() {
 			os.Exit(2)
 		}
 		logger.Info("deps.dev signal collector enabled")
-		collector.Register(ddcollector)
+		for _, c := range ddcollectors {
+			collector.Register(c)
+		}
+	}
+
+	// Load the extra columns to pass through to the output.
+	pt := &passthrough{}
+	if *passthroughFlag != "" {
+		pt, err = openPassthrough(*passthroughFlag, collector.EmptySets())
+		if err != nil {
+			logger.WithFields(log.Fields{
+				"error":    err,
+				"filename": *passthroughFlag,
+			}).Error("Failed to load passthrough file")
+			os.Exit(2)
+		}
 	}
 
 	// Prepare the output writer
-	out := result.NewCsvWriter(w, collector.EmptySets())
+	delimiter, err := parseDelimiter(*csvDelimiterFlag)
+	if err != nil {
+		logger.WithFields(log.Fields{
+			"error": err,
+		}).Error("Failed to parse csv delimiter")
+		os.Exit(2)
+	}
+	csvOpts := result.CsvOptions{
+		Delimiter:   delimiter,
+		AlwaysQuote: *csvAlwaysQuoteFlag,
+	}
+	out := formatType.NewWithOptions(w, collector.EmptySets(), csvOpts, pt.columns...)
+
+	// Stream the records into BigQuery as well, unless this is a dry run.
+	if *bigqueryTableFlag != "" && !*dryRunFlag {
+		bqOut, err := bq.NewWriter(ctx, *gcpProjectFlag, *bigqueryTableFlag, collector.EmptySets(), pt.columns...)
+		if err != nil {
+			logger.WithFields(log.Fields{
+				"error": err,
+				"table": *bigqueryTableFlag,
+			}).Error("Failed to create BigQuery writer")
+			os.Exit(2)
+		}
+		// os.Exit does not run deferred calls, so the stream is closed by exit,
+		// or at the end of the run.
+		onExit(func() {
+			if err := bqOut.Close(); err != nil {
+				logger.WithFields(log.Fields{
+					"error": err,
+				}).Warn("Failed to close BigQuery writer")
+			}
+		})
+		out = result.MultiWriter(out, bqOut)
+	}
+
+	// Prepare the status writer if a status file is being written.
+	var statusOut *statusWriter
+	if *statusFileFlag != "" {
+		f, err := outfile.Open(*statusFileFlag)
+		if err != nil {
+			logger.WithFields(log.Fields{
+				"error":    err,
+				"filename": *statusFileFlag,
+			}).Error("Failed to open status file")
+			exit(2)
+		}
+		defer f.Close()
+		statusOut = newStatusWriter(f)
+	}
+
+	// Track the repositories that have been collected if deduping is enabled.
+	var seen *repoSet
+	if *dedupeFlag {
+		seen = newRepoSet()
+	}
 
 	// Start the workers that process a channel of repo urls.
-	repos := make(chan *url.URL)
+	repos := make(chan repoJob)
+	results := make(chan repoResult)
 	wait := workerpool.WorkerPool(*workersFlag, func(worker int) {
 		innerLogger := logger.WithField("worker", worker)
-		for u := range repos {
-			handleRepo(ctx, innerLogger.WithField("url", u.String()), u, out)
+		for j := range repos {
+			l, ss, skip, err := collectRepoWithRetry(ctx, innerLogger.WithField("url", j.u.String()), j.u, seen, *repoTimeoutFlag, !*mirrorDisableFlag, *rateLimitWaitFlag)
+			results <- repoResult{index: j.index, input: j.input, logger: l, sets: ss, skip: skip, err: err}
 		}
 	})
 
+	// Write the results in the same order as the input. The first failure in
+	// input order aborts the run, ensuring the output is the same regardless
+	// of the order the workers finish in.
+	pending := make(chan empty, *workersFlag*pendingPerWorker)
+	writeDone := make(chan empty)
+	go func() {
+		defer close(writeDone)
+		q := newReorderer(func(res repoResult) {
+			<-pending
+			status := repoStatus{URL: res.input, Status: statusCollected}
+			switch {
+			case res.err != nil:
+				status.Status = statusError
+				status.Error = res.err.Error()
+			case res.sets == nil:
+				status.Status = statusSkipped
+				status.Reason = res.skip
+			}
+			if err := statusOut.Write(status); err != nil {
+				res.logger.WithFields(log.Fields{
+					"error": err,
+				}).Error("Failed to write status")
+				exit(1)
+			}
+			if res.err != nil {
+				res.logger.WithFields(log.Fields{
+					"error": res.err,
+				}).Error("Failed to collect signals for project")
+				// Keep the repositories collected so far, which all appear
+				// before this one in the input.
+				if err := out.Flush(); err != nil {
+					res.logger.WithFields(log.Fields{
+						"error": err,
+					}).Error("Failed to flush output")
+				}
+				if errors.Is(res.err, collector.ErrRateLimited) {
+					exit(exitRateLimited)
+				}
+				exit(1) // TODO: add a flag to continue or abort on failure
+			}
+			if res.sets != nil {
+				extras, _ := pt.lookup(res.input)
+				writeRepo(res.logger, out, res.sets, pt.columns, extras)
+				recordCollected(ctx)
+			}
+		})
+		for res := range results {
+			q.Add(res.index, res)
+		}
+	}()
+
 	// Read in each line from the input files
 	scanner := bufio.NewScanner(r)
+	index := 0
 	for scanner.Scan() {
 		line := scanner.Text()
+		if strings.TrimSpace(line) == "" {
+			logger.Debug("Skipping empty line")
+			recordSkipped(ctx, skipReasonEmptyURL)
+			continue
+		}
 
-		u, err := url.Parse(strings.TrimSpace(line))
+		u, err := projectrepo.ParseURL(line)
 		if err != nil {
 			logger.WithFields(log.Fields{
 				"error": err,
 				"url":   line,
 			}).Error("Failed to parse project url")
-			os.Exit(1) // TODO: add a flag to continue or abort on failure
+			recordSkipped(ctx, skipReasonParseError)
+			logMetrics(logger)
+			exit(1) // TODO: add a flag to continue or abort on failure
 		}
 		logger.WithFields(log.Fields{
 			"url": u.String(),
 		}).Debug("Parsed project url")
 
-		// Send the url to the workers
-		repos <- u
+		// Send the url to the workers, once there is room to hold its result.
+		pending <- empty{}
+		repos <- repoJob{index: index, input: line, u: u}
+		index++
 	}
 	if err := scanner.Err(); err != nil {
 		logger.WithFields(log.Fields{
 			"error": err,
 		}).Error("Failed while reading input")
-		os.Exit(2)
+		exit(2)
 	}
 	// Close the repos channel to indicate that there is no more input.
 	close(repos)
 
-	// Wait until all the workers have finished.
+	// Wait until all the workers have finished, and their results have been
+	// written.
 	wait()
+	close(results)
+	<-writeDone
+
+	if err := out.Flush(); err != nil {
+		logger.WithFields(log.Fields{
+			"error": err,
+		}).Error("Failed to flush output")
+		exit(1)
+	}
+	runExitHooks()
+	if *dryRunFlag {
+		logger.WithFields(log.Fields{
+			"filename": outFilename,
+			"bytes":    dryRunOut.Count(),
+		}).Info("Dry run: skipped writing output")
+	}
 
-	// TODO: track metrics as we are running to measure coverage of data
+	logMetrics(logger)
 }
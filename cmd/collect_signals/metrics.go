@@ -0,0 +1,101 @@
+package main
+
+import (
+	"context"
+
+	scstats "github.com/ossf/scorecard/v4/stats"
+	log "github.com/sirupsen/logrus"
+	"go.opencensus.io/stats"
+	"go.opencensus.io/stats/view"
+	"go.opencensus.io/tag"
+)
+
+// Reasons a repository may be skipped, used as the value of the skipReason
+// tag.
+const (
+	skipReasonEmptyURL      = "empty_url"
+	skipReasonUncollectable = "uncollectable"
+	skipReasonDuplicate     = "duplicate"
+	skipReasonTimeout       = "timeout"
+	skipReasonParseError    = "parse_error"
+)
+
+var (
+	// skipReason is the tag key for the reason a repository was skipped.
+	skipReason = tag.MustNewKey("reason")
+
+	// reposCollected measures the count of repositories collected.
+	reposCollected = stats.Int64("ReposCollected", "Measures the count of repositories collected", stats.UnitDimensionless)
+
+	// reposSkipped measures the count of repositories skipped.
+	reposSkipped = stats.Int64("ReposSkipped", "Measures the count of repositories skipped", stats.UnitDimensionless)
+
+	// collectedCount tracks the number of repositories collected.
+	collectedCount = view.View{
+		Name:        "ReposCollectedCount",
+		Description: "Count of repositories collected",
+		Measure:     reposCollected,
+		Aggregation: view.Count(),
+	}
+
+	// skippedCount tracks the number of repositories skipped per reason.
+	skippedCount = view.View{
+		Name:        "ReposSkippedCount",
+		Description: "Count of repositories skipped by reason",
+		Measure:     reposSkipped,
+		TagKeys:     []tag.Key{skipReason},
+		Aggregation: view.Count(),
+	}
+
+	// metricsViews holds all the views that are reported.
+	metricsViews = []*view.View{
+		&collectedCount,
+		&skippedCount,
+		&scstats.OutgoingHTTPRequests,
+	}
+)
+
+// registerMetricsViews registers the views used to report the metrics from a
+// run.
+func registerMetricsViews() error {
+	return view.Register(metricsViews...)
+}
+
+// recordCollected records that a repository has been collected.
+func recordCollected(ctx context.Context) {
+	stats.Record(ctx, reposCollected.M(1))
+}
+
+// recordSkipped records that a repository has been skipped for the given
+// reason.
+func recordSkipped(ctx context.Context, reason string) {
+	// The error can be ignored as the key and value are always valid.
+	_ = stats.RecordWithTags(ctx, []tag.Mutator{tag.Upsert(skipReason, reason)}, reposSkipped.M(1))
+}
+
+// logMetrics writes the current value of each view to logger, providing a
+// summary of the run.
+func logMetrics(logger *log.Logger) {
+	for _, v := range metricsViews {
+		rows, err := view.RetrieveData(v.Name)
+		if err != nil {
+			logger.WithFields(log.Fields{
+				"error": err,
+				"view":  v.Name,
+			}).Warn("Failed to retrieve metrics")
+			continue
+		}
+		for _, row := range rows {
+			fields := log.Fields{
+				"view": v.Name,
+			}
+			for _, t := range row.Tags {
+				fields[t.Key.Name()] = t.Value
+			}
+			if c, ok := row.Data.(*view.CountData); ok {
+				fields["count"] = c.Value
+			}
+			logger.WithFields(fields).Info("Metrics")
+		}
+	}
+}
@@ -0,0 +1,42 @@
+package main
+
+import (
+	"context"
+	"testing"
+
+	"go.opencensus.io/stats/view"
+)
+
+func TestRecordSkipped(t *testing.T) {
+	if err := registerMetricsViews(); err != nil {
+		t.Fatalf("registerMetricsViews() errored %v, want no error", err)
+	}
+	t.Cleanup(func() { view.Unregister(metricsViews...) })
+
+	ctx := context.Background()
+	recordSkipped(ctx, skipReasonEmptyURL)
+	recordSkipped(ctx, skipReasonUncollectable)
+	recordSkipped(ctx, skipReasonUncollectable)
+	recordSkipped(ctx, skipReasonParseError)
+
+	rows, err := view.RetrieveData(skippedCount.Name)
+	if err != nil {
+		t.Fatalf("RetrieveData() errored %v, want no error", err)
+	}
+	got := make(map[string]int64)
+	for _, row := range rows {
+		if len(row.Tags) != 1 {
+			t.Fatalf("len(Tags) == %d, want 1", len(row.Tags))
+		}
+		got[row.Tags[0].Value] = row.Data.(*view.CountData).Value
+	}
+	if c := got[skipReasonEmptyURL]; c != 1 {
+		t.Fatalf("%s count == %d, want 1", skipReasonEmptyURL, c)
+	}
+	if c := got[skipReasonUncollectable]; c != 2 {
+		t.Fatalf("%s count == %d, want 2", skipReasonUncollectable, c)
+	}
+	if c := got[skipReasonParseError]; c != 1 {
+		t.Fatalf("%s count == %d, want 1", skipReasonParseError, c)
+	}
+}
@@ -0,0 +1,57 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"net/url"
+
+	"github.com/ossf/criticality_score/cmd/collect_signals/collector"
+	"github.com/ossf/criticality_score/cmd/collect_signals/projectrepo"
+	"github.com/ossf/criticality_score/cmd/collect_signals/signal"
+	log "github.com/sirupsen/logrus"
+)
+
+// resolveFunc resolves a url to a Repo, such as projectrepo.Resolve.
+type resolveFunc func(context.Context, *url.URL) (projectrepo.Repo, error)
+
+// resolveUpstream returns the upstream repository of r, so that signals are
+// collected for it rather than for a read-only mirror.
+//
+// If r is not a mirror, r is returned along with a nil url. Otherwise the
+// upstream repository is returned along with the url of the mirror. If the
+// upstream repository can't be resolved, the mirror is collected instead.
+//
+// An error is only returned if resolving the upstream repository was rate
+// limited, as the upstream repository may exist.
+func resolveUpstream(ctx context.Context, logger *log.Entry, r projectrepo.Repo, resolve resolveFunc) (projectrepo.Repo, *url.URL, error) {
+	m, ok := r.(projectrepo.Mirror)
+	if !ok {
+		return r, nil, nil
+	}
+	up := m.UpstreamURL()
+	if up == nil {
+		return r, nil, nil
+	}
+	logger = logger.WithField("upstream_url", up.String())
+	upstream, err := resolve(ctx, up)
+	if err := collector.CheckRateLimit(err); errors.Is(err, collector.ErrRateLimited) {
+		return nil, nil, err
+	}
+	if err != nil {
+		logger.WithFields(log.Fields{
+			"error": err,
+		}).Warning("Failed to resolve upstream of mirror; collecting the mirror")
+		return r, nil, nil
+	}
+	logger.Info("Collecting the upstream repository of a mirror")
+	return upstream, r.URL(), nil
+}
+
+// setMirrorURL records the url of the mirror in the RepoSet in ss.
+func setMirrorURL(ss []signal.Set, mirror *url.URL) {
+	for _, s := range ss {
+		if rs, ok := s.(*signal.RepoSet); ok {
+			rs.MirrorURL.Set(mirror.String())
+		}
+	}
+}
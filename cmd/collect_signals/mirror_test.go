@@ -0,0 +1,115 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/url"
+	"testing"
+
+	"github.com/ossf/criticality_score/cmd/collect_signals/collector"
+	"github.com/ossf/criticality_score/cmd/collect_signals/projectrepo"
+	"github.com/ossf/criticality_score/cmd/collect_signals/signal"
+	log "github.com/sirupsen/logrus"
+)
+
+type testRepo struct {
+	u        *url.URL
+	upstream *url.URL
+}
+
+func (r *testRepo) URL() *url.URL {
+	return r.u
+}
+
+func (r *testRepo) UpstreamURL() *url.URL {
+	return r.upstream
+}
+
+func testEntry() *log.Entry {
+	l := log.New()
+	l.SetOutput(io.Discard)
+	return log.NewEntry(l)
+}
+
+func mustParse(raw string) *url.URL {
+	u, _ := url.Parse(raw)
+	return u
+}
+
+func TestResolveUpstream(t *testing.T) {
+	mirror := &testRepo{
+		u:        mustParse("https://github.com/example/mirror"),
+		upstream: mustParse("https://git.example.org/example"),
+	}
+	upstream := &testRepo{u: mustParse("https://git.example.org/example")}
+	resolve := func(ctx context.Context, u *url.URL) (projectrepo.Repo, error) {
+		if u.String() != upstream.u.String() {
+			t.Fatalf("resolve(%s), want resolve(%s)", u, upstream.u)
+		}
+		return upstream, nil
+	}
+	r, m, err := resolveUpstream(context.Background(), testEntry(), mirror, resolve)
+	if err != nil {
+		t.Fatalf("resolveUpstream() errored %v, want no error", err)
+	}
+	if r != upstream {
+		t.Fatalf("resolveUpstream() == %s, want %s", r.URL(), upstream.u)
+	}
+	if m == nil || m.String() != "https://github.com/example/mirror" {
+		t.Fatalf("mirror == %v, want https://github.com/example/mirror", m)
+	}
+}
+
+func TestResolveUpstream_NotMirror(t *testing.T) {
+	r := &testRepo{u: mustParse("https://github.com/example/example")}
+	resolve := func(ctx context.Context, u *url.URL) (projectrepo.Repo, error) {
+		t.Fatal("resolve called, want it not called")
+		return nil, nil
+	}
+	got, m, err := resolveUpstream(context.Background(), testEntry(), r, resolve)
+	if err != nil {
+		t.Fatalf("resolveUpstream() errored %v, want no error", err)
+	}
+	if got != r || m != nil {
+		t.Fatalf("resolveUpstream() == %v, %v; want the repository and nil", got, m)
+	}
+}
+
+func TestResolveUpstream_Unresolvable(t *testing.T) {
+	mirror := &testRepo{
+		u:        mustParse("https://github.com/example/mirror"),
+		upstream: mustParse("https://git.example.org/example"),
+	}
+	resolve := func(ctx context.Context, u *url.URL) (projectrepo.Repo, error) {
+		return nil, projectrepo.ErrorNotFound
+	}
+	got, m, err := resolveUpstream(context.Background(), testEntry(), mirror, resolve)
+	if err != nil {
+		t.Fatalf("resolveUpstream() errored %v, want no error", err)
+	}
+	if got != mirror || m != nil {
+		t.Fatalf("resolveUpstream() == %v, %v; want the mirror and nil", got, m)
+	}
+}
+
+func TestResolveUpstream_RateLimited(t *testing.T) {
+	mirror := &testRepo{
+		u:        mustParse("https://github.com/example/mirror"),
+		upstream: mustParse("https://gitlab.com/example/example"),
+	}
+	resolve := func(ctx context.Context, u *url.URL) (projectrepo.Repo, error) {
+		return nil, &collector.RateLimitError{}
+	}
+	if _, _, err := resolveUpstream(context.Background(), testEntry(), mirror, resolve); !errors.Is(err, collector.ErrRateLimited) {
+		t.Fatalf("resolveUpstream() errored %v, want %v", err, collector.ErrRateLimited)
+	}
+}
+
+func TestSetMirrorURL(t *testing.T) {
+	rs := &signal.RepoSet{}
+	setMirrorURL([]signal.Set{&signal.IssuesSet{}, rs}, mustParse("https://github.com/example/mirror"))
+	if got := rs.MirrorURL.Get(); got != "https://github.com/example/mirror" {
+		t.Fatalf("MirrorURL == %q, want %q", got, "https://github.com/example/mirror")
+	}
+}
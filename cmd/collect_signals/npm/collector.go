@@ -0,0 +1,194 @@
+// Package npm provides a Collector that returns a Set for the number of times
+// the npm package published from a repository has been downloaded.
+//
+// The package is found by reading the package.json file in the root of the
+// repository. Download counts are a much stronger signal of usage for
+// JavaScript projects than stars.
+package npm
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ossf/criticality_score/cmd/collect_signals/projectrepo"
+	"github.com/ossf/criticality_score/cmd/collect_signals/signal"
+	"github.com/ossf/criticality_score/internal/githubapi"
+	"github.com/ossf/criticality_score/internal/httpjson"
+	log "github.com/sirupsen/logrus"
+)
+
+// DefaultAPIURL is the base URL of the npm registry's download counts API.
+const DefaultAPIURL = "https://api.npmjs.org"
+
+type npmSet struct {
+	PackageName      signal.Field[string]
+	WeeklyDownloads  signal.Field[int]
+	MonthlyDownloads signal.Field[int]
+}
+
+func (s *npmSet) Namespace() signal.Namespace {
+	return signal.Namespace("npm")
+}
+
+// packageJSON contains the fields of a package.json file that are used.
+type packageJSON struct {
+	Name       string          `json:"name"`
+	Private    bool            `json:"private"`
+	Repository json.RawMessage `json:"repository"`
+}
+
+type downloads struct {
+	Downloads int `json:"downloads"`
+}
+
+type Collector struct {
+	client     *githubapi.Client
+	httpClient *http.Client
+	logger     *log.Logger
+	apiURL     string
+}
+
+// NewCollector returns a new Collector that uses the GitHub client c to read
+// package.json files, and httpClient to query the npm registry.
+func NewCollector(c *githubapi.Client, httpClient *http.Client, logger *log.Logger) *Collector {
+	return &Collector{
+		client:     c,
+		httpClient: httpClient,
+		logger:     logger,
+		apiURL:     DefaultAPIURL,
+	}
+}
+
+func (c *Collector) EmptySet() signal.Set {
+	return &npmSet{}
+}
+
+// IsSupported implements the collector.Collector interface.
+//
+// Only repositories hosted on the same GitHub instance as the client are
+// supported.
+func (c *Collector) IsSupported(r projectrepo.Repo) bool {
+	return strings.EqualFold(r.URL().Hostname(), c.client.Host())
+}
+
+// Collect implements the collector.Collector interface.
+//
+// If the repository does not have a package.json file, the package is
+// private, or the repository field of package.json does not refer to the
+// repository (e.g. the repository is a fork), the signals are left unset.
+func (c *Collector) Collect(ctx context.Context, r projectrepo.Repo) (signal.Set, error) {
+	s := &npmSet{}
+	repoName := strings.Trim(r.URL().Path, "/")
+	owner, name, ok := strings.Cut(repoName, "/")
+	if !ok {
+		return s, nil
+	}
+
+	logger := c.logger.WithField("url", r.URL().String())
+	logger.Debug("Fetching package.json")
+	pkg, err := c.queryPackageJSON(ctx, owner, name)
+	if err != nil {
+		return nil, err
+	}
+	if pkg == nil || pkg.Private || pkg.Name == "" {
+		return s, nil
+	}
+	if !repositoryMatches(pkg.Repository, repoName) {
+		logger.WithField("package", pkg.Name).Debug("package.json repository does not match; skipping")
+		return s, nil
+	}
+	s.PackageName.Set(pkg.Name)
+
+	logger.Debug("Fetching npm download counts")
+	if n, ok, err := c.queryDownloads(ctx, pkg.Name, "last-week"); err != nil {
+		return nil, err
+	} else if ok {
+		s.WeeklyDownloads.Set(n)
+	}
+	if n, ok, err := c.queryDownloads(ctx, pkg.Name, "last-month"); err != nil {
+		return nil, err
+	} else if ok {
+		s.MonthlyDownloads.Set(n)
+	}
+	return s, nil
+}
+
+// queryPackageJSON returns the package.json file in the root of the
+// repository.
+//
+// If the repository does not have a package.json file, or it is not valid,
+// nil will be returned.
+func (c *Collector) queryPackageJSON(ctx context.Context, owner, name string) (*packageJSON, error) {
+	fc, _, resp, err := c.client.Rest().Repositories.GetContents(ctx, owner, name, "package.json", nil)
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if fc == nil {
+		// package.json is a directory.
+		return nil, nil
+	}
+	content, err := fc.GetContent()
+	if err != nil {
+		return nil, err
+	}
+	pkg := &packageJSON{}
+	if err := json.Unmarshal([]byte(content), pkg); err != nil {
+		return nil, nil
+	}
+	return pkg, nil
+}
+
+// queryDownloads returns the number of downloads of the package pkg during
+// period (e.g. "last-week").
+//
+// If the package has not been published, false will be returned.
+func (c *Collector) queryDownloads(ctx context.Context, pkg, period string) (int, bool, error) {
+	var d downloads
+	_, err := httpjson.Get(ctx, c.httpClient, c.apiURL+"/downloads/point/"+period+"/"+escapePackageName(pkg), &d)
+	if httpjson.StatusCode(err) == http.StatusNotFound {
+		return 0, false, nil
+	}
+	if err != nil {
+		return 0, false, err
+	}
+	return d.Downloads, true, nil
+}
+
+// escapePackageName escapes a package name for use in a URL path. The "/"
+// in scoped package names (e.g. "@scope/name") is kept, as it is required by
+// the npm API.
+func escapePackageName(pkg string) string {
+	if scope, name, ok := strings.Cut(pkg, "/"); ok {
+		return url.PathEscape(scope) + "/" + url.PathEscape(name)
+	}
+	return url.PathEscape(pkg)
+}
+
+// repositoryMatches returns true if the repository field of a package.json
+// file refers to the GitHub repository repoName (e.g. "owner/name").
+//
+// The field may be a string, such as a URL or the "github:owner/name"
+// shorthand, or an object with a url.
+func repositoryMatches(raw json.RawMessage, repoName string) bool {
+	var repo string
+	if err := json.Unmarshal(raw, &repo); err != nil {
+		var obj struct {
+			URL string `json:"url"`
+		}
+		if err := json.Unmarshal(raw, &obj); err != nil {
+			return false
+		}
+		repo = obj.URL
+	}
+	repo = strings.ToLower(strings.TrimSpace(repo))
+	repo = strings.TrimPrefix(repo, "github:")
+	repo = strings.TrimSuffix(strings.TrimSuffix(repo, "/"), ".git")
+	repoName = strings.ToLower(repoName)
+	return repo == repoName || strings.HasSuffix(repo, "/"+repoName) || strings.HasSuffix(repo, ":"+repoName)
+}
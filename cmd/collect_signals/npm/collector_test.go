@@ -0,0 +1,127 @@
+package npm
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"net/http"
+	"testing"
+
+	"github.com/ossf/criticality_score/internal/collectortest"
+	"github.com/ossf/criticality_score/internal/githubapi"
+)
+
+// testRepoURL is the repository the signals are collected for.
+const testRepoURL = "https://github.com/example/example"
+
+// newTestCollector returns a Collector where all the requests to GitHub and
+// npm are handled by h.
+func newTestCollector(t *testing.T, h http.Handler) *Collector {
+	t.Helper()
+	s := collectortest.NewServer(t, h)
+	client, err := githubapi.NewEnterpriseClient(s.URL, &http.Client{})
+	if err != nil {
+		t.Fatalf("NewEnterpriseClient() errored %v, want no error", err)
+	}
+	c := NewCollector(client, &http.Client{}, collectortest.Logger())
+	c.apiURL = s.URL
+	return c
+}
+
+// testHandler serves the package.json file content, and the given number of
+// downloads for pkg.
+func testHandler(content, pkg string, weekly, monthly int) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/api/v3/repos/example/example/contents/package.json":
+			json.NewEncoder(w).Encode(map[string]string{
+				"type":     "file",
+				"encoding": "base64",
+				"content":  base64.StdEncoding.EncodeToString([]byte(content)),
+			})
+		case "/downloads/point/last-week/" + pkg:
+			json.NewEncoder(w).Encode(map[string]any{"downloads": weekly, "package": pkg})
+		case "/downloads/point/last-month/" + pkg:
+			json.NewEncoder(w).Encode(map[string]any{"downloads": monthly, "package": pkg})
+		default:
+			http.NotFound(w, r)
+		}
+	}
+}
+
+func TestCollect(t *testing.T) {
+	c := newTestCollector(t, testHandler(`{"name": "@example/pkg", "repository": "github:example/example"}`, "@example/pkg", 100, 450))
+	s := collectortest.Collect[*npmSet](t, c, testRepoURL)
+	if got := s.PackageName.Get(); got != "@example/pkg" {
+		t.Fatalf("PackageName == %q, want %q", got, "@example/pkg")
+	}
+	if got := s.WeeklyDownloads.Get(); got != 100 {
+		t.Fatalf("WeeklyDownloads == %d, want 100", got)
+	}
+	if got := s.MonthlyDownloads.Get(); got != 450 {
+		t.Fatalf("MonthlyDownloads == %d, want 450", got)
+	}
+}
+
+func TestCollect_NotPublished(t *testing.T) {
+	c := newTestCollector(t, testHandler(`{"name": "pkg", "repository": "github:example/example"}`, "other", 1, 1))
+	s := collectortest.Collect[*npmSet](t, c, testRepoURL)
+	if !s.PackageName.IsSet() {
+		t.Fatal("PackageName is not set, want set")
+	}
+	if s.WeeklyDownloads.IsSet() || s.MonthlyDownloads.IsSet() {
+		t.Fatal("download counts are set, want unset")
+	}
+}
+
+func TestCollect_Skipped(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+	}{
+		{name: "private", content: `{"name": "pkg", "private": true, "repository": "github:example/example"}`},
+		{name: "fork", content: `{"name": "pkg", "repository": "github:upstream/example"}`},
+		{name: "no repository", content: `{"name": "pkg"}`},
+		{name: "invalid json", content: `{"name": `},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := newTestCollector(t, testHandler(test.content, "pkg", 1, 1))
+			s := collectortest.Collect[*npmSet](t, c, testRepoURL)
+			if s.PackageName.IsSet() || s.WeeklyDownloads.IsSet() {
+				t.Fatalf("signals are set, want unset")
+			}
+		})
+	}
+}
+
+func TestCollect_NoPackageJSON(t *testing.T) {
+	c := newTestCollector(t, http.NotFoundHandler())
+	s := collectortest.Collect[*npmSet](t, c, testRepoURL)
+	if s.PackageName.IsSet() {
+		t.Fatal("PackageName is set, want unset")
+	}
+}
+
+func TestRepositoryMatches(t *testing.T) {
+	tests := []struct {
+		raw  string
+		want bool
+	}{
+		{raw: `"github:example/example"`, want: true},
+		{raw: `"example/example"`, want: true},
+		{raw: `"https://github.com/Example/Example"`, want: true},
+		{raw: `{"type": "git", "url": "git+https://github.com/example/example.git"}`, want: true},
+		{raw: `{"type": "git", "url": "git@github.com:example/example.git"}`, want: true},
+		{raw: `"https://github.com/example/example-fork"`, want: false},
+		{raw: `"https://github.com/other/example"`, want: false},
+		{raw: `42`, want: false},
+	}
+	for _, test := range tests {
+		t.Run(test.raw, func(t *testing.T) {
+			if got := repositoryMatches(json.RawMessage(test.raw), "example/example"); got != test.want {
+				t.Fatalf("repositoryMatches() == %v, want %v", got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,147 @@
+// Package nuget provides a Collector that returns a Set for the number of
+// times the NuGet packages published from a repository have been downloaded.
+//
+// The NuGet search API does not support looking up packages by repository,
+// so packages are found by searching for the name of the repository, and
+// keeping the packages whose project URL refers to the repository.
+package nuget
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/ossf/criticality_score/cmd/collect_signals/projectrepo"
+	"github.com/ossf/criticality_score/cmd/collect_signals/signal"
+	"github.com/ossf/criticality_score/internal/httpjson"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultSearchURL is the URL of the NuGet search API, as listed in the
+	// "SearchQueryService" resource of https://api.nuget.org/v3/index.json.
+	DefaultSearchURL = "https://azuresearch-usnc.nuget.org/query"
+
+	// searchTake is the number of search results examined for packages
+	// published from a repository.
+	searchTake = 100
+)
+
+type nugetSet struct {
+	PackageCount signal.Field[int]
+
+	// TotalDownloads is the number of downloads of all the versions of the
+	// packages.
+	TotalDownloads signal.Field[int]
+
+	// LatestVersionDownloads is the number of downloads of the latest
+	// version of each of the packages.
+	LatestVersionDownloads signal.Field[int]
+}
+
+func (s *nugetSet) Namespace() signal.Namespace {
+	return signal.Namespace("nuget")
+}
+
+type version struct {
+	Version   string `json:"version"`
+	Downloads int    `json:"downloads"`
+}
+
+type searchPackage struct {
+	ID             string    `json:"id"`
+	Version        string    `json:"version"`
+	ProjectURL     string    `json:"projectUrl"`
+	TotalDownloads int       `json:"totalDownloads"`
+	Versions       []version `json:"versions"`
+}
+
+// latestDownloads returns the number of downloads of the latest version of
+// the package.
+func (p *searchPackage) latestDownloads() int {
+	for _, v := range p.Versions {
+		if v.Version == p.Version {
+			return v.Downloads
+		}
+	}
+	return 0
+}
+
+type searchResult struct {
+	Data []searchPackage `json:"data"`
+}
+
+type Collector struct {
+	client    *http.Client
+	logger    *log.Logger
+	searchURL string
+}
+
+// NewCollector returns a new Collector that uses the http.Client c to query
+// NuGet.
+func NewCollector(c *http.Client, logger *log.Logger) *Collector {
+	return &Collector{
+		client:    c,
+		logger:    logger,
+		searchURL: DefaultSearchURL,
+	}
+}
+
+func (c *Collector) EmptySet() signal.Set {
+	return &nugetSet{}
+}
+
+func (c *Collector) IsSupported(r projectrepo.Repo) bool {
+	return true
+}
+
+// Collect implements the collector.Collector interface.
+//
+// If no packages are published from the repository the signals are left
+// unset.
+func (c *Collector) Collect(ctx context.Context, r projectrepo.Repo) (signal.Set, error) {
+	s := &nugetSet{}
+	c.logger.WithField("url", r.URL().String()).Debug("Searching NuGet")
+	pkgs, err := c.queryPackages(ctx, r.URL())
+	if err != nil {
+		return nil, err
+	}
+	if len(pkgs) == 0 {
+		return s, nil
+	}
+	total := 0
+	latest := 0
+	for _, p := range pkgs {
+		total += p.TotalDownloads
+		latest += p.latestDownloads()
+	}
+	s.PackageCount.Set(len(pkgs))
+	s.TotalDownloads.Set(total)
+	s.LatestVersionDownloads.Set(latest)
+	return s, nil
+}
+
+// queryPackages returns the packages published from the repository at u.
+func (c *Collector) queryPackages(ctx context.Context, u *url.URL) ([]searchPackage, error) {
+	query := url.Values{
+		"q":           {path.Base(strings.TrimSuffix(strings.Trim(u.Path, "/"), ".git"))},
+		"take":        {fmt.Sprint(searchTake)},
+		"prerelease":  {"false"},
+		"semVerLevel": {"2.0.0"},
+	}
+	var res searchResult
+	if _, err := httpjson.Get(ctx, c.client, c.searchURL+"?"+query.Encode(), &res); err != nil {
+		return nil, err
+	}
+	repo := projectrepo.NormalizeURL(u.String())
+	var pkgs []searchPackage
+	for _, p := range res.Data {
+		if projectrepo.RefersTo(repo, p.ProjectURL) {
+			pkgs = append(pkgs, p)
+		}
+	}
+	return pkgs, nil
+}
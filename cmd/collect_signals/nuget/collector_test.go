@@ -0,0 +1,57 @@
+package nuget
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/ossf/criticality_score/internal/collectortest"
+)
+
+// testRepoURL is the repository the signals are collected for.
+const testRepoURL = "https://github.com/example/json"
+
+// newTestCollector returns a Collector where all the search requests are
+// answered with body.
+func newTestCollector(t *testing.T, body string) *Collector {
+	t.Helper()
+	s := collectortest.NewServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("q") != "json" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	}))
+	c := NewCollector(&http.Client{}, collectortest.Logger())
+	c.searchURL = s.URL
+	return c
+}
+
+func TestCollect(t *testing.T) {
+	c := newTestCollector(t, `{"data": [
+		{"id": "Example.Json", "version": "2.0.0", "projectUrl": "https://github.com/example/json", "totalDownloads": 1000,
+		 "versions": [{"version": "1.0.0", "downloads": 700}, {"version": "2.0.0", "downloads": 300}]},
+		{"id": "Example.Json.Extras", "version": "1.0.0", "projectUrl": "https://github.com/Example/json/tree/main/extras", "totalDownloads": 50,
+		 "versions": [{"version": "1.0.0", "downloads": 50}]},
+		{"id": "Other.Json", "version": "1.0.0", "projectUrl": "https://github.com/other/json", "totalDownloads": 9999,
+		 "versions": [{"version": "1.0.0", "downloads": 9999}]}
+	]}`)
+	s := collectortest.Collect[*nugetSet](t, c, testRepoURL)
+	if got := s.PackageCount.Get(); got != 2 {
+		t.Fatalf("PackageCount == %d, want 2", got)
+	}
+	if got := s.TotalDownloads.Get(); got != 1050 {
+		t.Fatalf("TotalDownloads == %d, want 1050", got)
+	}
+	if got := s.LatestVersionDownloads.Get(); got != 350 {
+		t.Fatalf("LatestVersionDownloads == %d, want 350", got)
+	}
+}
+
+func TestCollect_NoPackages(t *testing.T) {
+	c := newTestCollector(t, `{"data": []}`)
+	s := collectortest.Collect[*nugetSet](t, c, testRepoURL)
+	if s.PackageCount.IsSet() || s.TotalDownloads.IsSet() || s.LatestVersionDownloads.IsSet() {
+		t.Fatal("signals are set, want unset")
+	}
+}
@@ -0,0 +1,218 @@
+// Package openhub provides a Collector that returns a Set for the activity and
+// size of a repository's project, as reported by Open Hub.
+//
+// Open Hub analyzes the full history of a project, including history kept in
+// CVS or Subversion before it was imported into Git, so it is useful for old
+// projects with sparse history on GitHub.
+//
+// Projects are found by searching Open Hub for the name of the repository,
+// and keeping the first project whose homepage, download page or code
+// locations refer to the repository.
+package openhub
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/ossf/criticality_score/cmd/collect_signals/projectrepo"
+	"github.com/ossf/criticality_score/cmd/collect_signals/signal"
+	"github.com/ossf/criticality_score/internal/httpjson"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultAPIURL is the base URL of the Open Hub API.
+	DefaultAPIURL = "https://www.openhub.net"
+
+	// maxCandidates limits the number of search results whose code
+	// locations are fetched.
+	maxCandidates = 5
+)
+
+type openHubSet struct {
+	// TwelveMonthContributorCount is the number of people who committed to
+	// the project in the last 12 months.
+	TwelveMonthContributorCount signal.Field[int]
+
+	TotalCommitCount signal.Field[int]
+	TotalCodeLines   signal.Field[int]
+
+	// HistoryMonths is the number of months between the first and last
+	// commits Open Hub has analyzed.
+	HistoryMonths signal.Field[int]
+}
+
+func (s *openHubSet) Namespace() signal.Namespace {
+	return signal.Namespace("openhub")
+}
+
+type analysis struct {
+	TwelveMonthContributorCount int    `xml:"twelve_month_contributor_count"`
+	TotalCommitCount            int    `xml:"total_commit_count"`
+	TotalCodeLines              int    `xml:"total_code_lines"`
+	MinMonth                    string `xml:"min_month"`
+	MaxMonth                    string `xml:"max_month"`
+}
+
+type project struct {
+	ID          string    `xml:"id"`
+	HomepageURL string    `xml:"homepage_url"`
+	DownloadURL string    `xml:"download_url"`
+	Analysis    *analysis `xml:"analysis"`
+}
+
+type projectsResponse struct {
+	Projects []project `xml:"result>project"`
+}
+
+// enlistmentsResponse holds the code locations of a project. Newer responses
+// nest the URL in code_location, while older ones use repository.
+type enlistmentsResponse struct {
+	Enlistments []struct {
+		CodeLocation struct {
+			URL string `xml:"url"`
+		} `xml:"code_location"`
+		Repository struct {
+			URL string `xml:"url"`
+		} `xml:"repository"`
+	} `xml:"result>enlistment"`
+}
+
+type Collector struct {
+	client *http.Client
+	logger *log.Logger
+	apiURL string
+	apiKey string
+}
+
+// NewCollector returns a new Collector that uses the http.Client c to query
+// Open Hub with the API key apiKey.
+func NewCollector(c *http.Client, apiKey string, logger *log.Logger) *Collector {
+	return &Collector{
+		client: c,
+		logger: logger,
+		apiURL: DefaultAPIURL,
+		apiKey: apiKey,
+	}
+}
+
+func (c *Collector) EmptySet() signal.Set {
+	return &openHubSet{}
+}
+
+func (c *Collector) IsSupported(r projectrepo.Repo) bool {
+	return true
+}
+
+// Collect implements the collector.Collector interface.
+//
+// If no project is found for the repository, or it has not been analyzed,
+// the signals are left unset.
+func (c *Collector) Collect(ctx context.Context, r projectrepo.Repo) (signal.Set, error) {
+	s := &openHubSet{}
+	logger := c.logger.WithField("url", r.URL().String())
+
+	logger.Debug("Searching Open Hub")
+	var res projectsResponse
+	query := url.Values{"query": {path.Base(strings.TrimSuffix(strings.Trim(r.URL().Path, "/"), ".git"))}}
+	if err := c.get(ctx, "/projects.xml", query, &res); err != nil {
+		return nil, err
+	}
+
+	repo := projectrepo.NormalizeURL(r.URL().String())
+	for i, p := range res.Projects {
+		if i >= maxCandidates {
+			break
+		}
+		ok := projectrepo.RefersTo(repo, p.HomepageURL, p.DownloadURL)
+		if !ok {
+			logger.WithField("project", p.ID).Debug("Fetching Open Hub enlistments")
+			var err error
+			ok, err = c.enlisted(ctx, p.ID, repo)
+			if err != nil {
+				return nil, err
+			}
+		}
+		if !ok {
+			continue
+		}
+		if p.Analysis != nil {
+			summarize(s, p.Analysis)
+		}
+		return s, nil
+	}
+	return s, nil
+}
+
+// enlisted returns true if one of the code locations of the project id is
+// the normalized repository URL repo.
+func (c *Collector) enlisted(ctx context.Context, id, repo string) (bool, error) {
+	var res enlistmentsResponse
+	if err := c.get(ctx, "/projects/"+url.PathEscape(id)+"/enlistments.xml", nil, &res); err != nil {
+		return false, err
+	}
+	for _, e := range res.Enlistments {
+		if projectrepo.RefersTo(repo, e.CodeLocation.URL, e.Repository.URL) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// summarize sets the signals in s from the project's analysis.
+func summarize(s *openHubSet, a *analysis) {
+	s.TwelveMonthContributorCount.Set(a.TwelveMonthContributorCount)
+	s.TotalCommitCount.Set(a.TotalCommitCount)
+	s.TotalCodeLines.Set(a.TotalCodeLines)
+	first, ferr := parseMonth(a.MinMonth)
+	last, lerr := parseMonth(a.MaxMonth)
+	if ferr == nil && lerr == nil {
+		s.HistoryMonths.Set((last.Year()-first.Year())*12 + int(last.Month()-first.Month()))
+	}
+}
+
+// parseMonth parses a month in the analysis, such as "2001-06-01" or
+// "2001-06-01T00:00:00Z".
+func parseMonth(v string) (time.Time, error) {
+	if len(v) > len("2006-01-02") {
+		v = v[:len("2006-01-02")]
+	}
+	return time.Parse("2006-01-02", v)
+}
+
+// get fetches the XML document at the API path p with the query parameters
+// query, and decodes it into result.
+func (c *Collector) get(ctx context.Context, p string, query url.Values, result any) error {
+	if query == nil {
+		query = url.Values{}
+	}
+	query.Set("api_key", c.apiKey)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.apiURL+p+"?"+query.Encode(), nil)
+	if err != nil {
+		return err
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		// The URL is not included, as it contains the API key.
+		return &httpjson.StatusError{URL: c.apiURL + p, StatusCode: resp.StatusCode}
+	}
+	b, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if err := xml.Unmarshal(b, result); err != nil {
+		return fmt.Errorf("invalid response from %s: %w", c.apiURL+p, err)
+	}
+	return nil
+}
@@ -0,0 +1,121 @@
+package openhub
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/ossf/criticality_score/internal/collectortest"
+)
+
+// newTestCollector returns a Collector where all the requests are answered
+// with the bodies in responses, keyed by path. Requests without the API key
+// are rejected.
+func newTestCollector(t *testing.T, responses map[string]string) *Collector {
+	t.Helper()
+	s := collectortest.NewServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("api_key") != "key" {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		body, ok := responses[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/xml")
+		w.Write([]byte(body))
+	}))
+	c := NewCollector(&http.Client{}, "key", collectortest.Logger())
+	c.apiURL = s.URL
+	return c
+}
+
+const testProjects = `<?xml version="1.0" encoding="UTF-8"?>
+<response>
+  <status>success</status>
+  <result>
+    <project>
+      <id>1</id>
+      <name>Other Make</name>
+      <homepage_url>https://example.com/make</homepage_url>
+      <analysis>
+        <twelve_month_contributor_count>1</twelve_month_contributor_count>
+        <total_code_lines>10</total_code_lines>
+      </analysis>
+    </project>
+    <project>
+      <id>2</id>
+      <name>GNU Make</name>
+      <homepage_url>https://www.gnu.org/software/make/</homepage_url>
+      <analysis>
+        <twelve_month_contributor_count>4</twelve_month_contributor_count>
+        <total_commit_count>5000</total_commit_count>
+        <total_code_lines>120000</total_code_lines>
+        <min_month>1991-10-01</min_month>
+        <max_month>2024-01-01</max_month>
+      </analysis>
+    </project>
+  </result>
+</response>`
+
+func TestCollect_Enlisted(t *testing.T) {
+	c := newTestCollector(t, map[string]string{
+		"/projects.xml": testProjects,
+		"/projects/1/enlistments.xml": `<response><result>
+			<enlistment><code_location><url>https://github.com/other/make.git</url></code_location></enlistment>
+		</result></response>`,
+		"/projects/2/enlistments.xml": `<response><result>
+			<enlistment><repository><url>git://git.savannah.gnu.org/make.git</url></repository></enlistment>
+			<enlistment><code_location><url>https://github.com/mirror/make.git</url></code_location></enlistment>
+		</result></response>`,
+	})
+	s := collectortest.Collect[*openHubSet](t, c, "https://github.com/mirror/make")
+	if got := s.TwelveMonthContributorCount.Get(); got != 4 {
+		t.Fatalf("TwelveMonthContributorCount == %d, want 4", got)
+	}
+	if got := s.TotalCommitCount.Get(); got != 5000 {
+		t.Fatalf("TotalCommitCount == %d, want 5000", got)
+	}
+	if got := s.TotalCodeLines.Get(); got != 120000 {
+		t.Fatalf("TotalCodeLines == %d, want 120000", got)
+	}
+	if got := s.HistoryMonths.Get(); got != 387 {
+		t.Fatalf("HistoryMonths == %d, want 387", got)
+	}
+}
+
+func TestCollect_Homepage(t *testing.T) {
+	c := newTestCollector(t, map[string]string{
+		"/projects.xml": `<response><result><project>
+			<id>3</id>
+			<homepage_url>https://github.com/example/example</homepage_url>
+			<analysis><total_code_lines>100</total_code_lines></analysis>
+		</project></result></response>`,
+	})
+	s := collectortest.Collect[*openHubSet](t, c, "https://github.com/example/example")
+	if got := s.TotalCodeLines.Get(); got != 100 {
+		t.Fatalf("TotalCodeLines == %d, want 100", got)
+	}
+	if s.HistoryMonths.IsSet() {
+		t.Fatal("HistoryMonths is set, want unset")
+	}
+}
+
+func TestCollect_NoProject(t *testing.T) {
+	c := newTestCollector(t, map[string]string{
+		"/projects.xml": `<response><status>success</status><items_returned>0</items_returned><result></result></response>`,
+	})
+	s := collectortest.Collect[*openHubSet](t, c, "https://github.com/example/example")
+	if s.TwelveMonthContributorCount.IsSet() || s.TotalCodeLines.IsSet() || s.HistoryMonths.IsSet() {
+		t.Fatal("signals are set, want unset")
+	}
+}
+
+func TestCollect_Unauthorized(t *testing.T) {
+	c := newTestCollector(t, nil)
+	c.apiKey = "wrong"
+	if _, err := c.Collect(context.Background(), collectortest.NewRepo(t, "https://github.com/example/example")); err == nil {
+		t.Fatal("Collect() returned no error, want an error")
+	}
+}
@@ -0,0 +1,133 @@
+package osv
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/ossf/criticality_score/internal/httpjson"
+)
+
+// DefaultAPIURL is the base URL of the OSV.dev API.
+const DefaultAPIURL = "https://api.osv.dev/v1"
+
+// maxQueryPages limits the number of pages of results read for a single
+// package, as some packages have thousands of vulnerabilities.
+const maxQueryPages = 10
+
+type event struct {
+	Introduced string `json:"introduced"`
+	Fixed      string `json:"fixed"`
+}
+
+type versionRange struct {
+	Type   string  `json:"type"`
+	Events []event `json:"events"`
+}
+
+type affected struct {
+	Package queryPackage   `json:"package"`
+	Ranges  []versionRange `json:"ranges"`
+}
+
+type vulnerability struct {
+	ID        string     `json:"id"`
+	Published time.Time  `json:"published"`
+	Affected  []affected `json:"affected"`
+}
+
+// fixedVersions returns the versions of the package in the ecosystem that
+// fix the vulnerability.
+//
+// Ranges of git commits are ignored, as only published versions have a
+// release date.
+func (v *vulnerability) fixedVersions(ecosystem, name string) []string {
+	var fixed []string
+	for _, a := range v.Affected {
+		if a.Package.Ecosystem != ecosystem || a.Package.Name != name {
+			continue
+		}
+		for _, r := range a.Ranges {
+			if r.Type == "GIT" {
+				continue
+			}
+			for _, e := range r.Events {
+				if e.Fixed != "" {
+					fixed = append(fixed, e.Fixed)
+				}
+			}
+		}
+	}
+	return fixed
+}
+
+type queryPackage struct {
+	Name      string `json:"name"`
+	Ecosystem string `json:"ecosystem"`
+}
+
+type queryRequest struct {
+	Package   queryPackage `json:"package"`
+	Version   string       `json:"version,omitempty"`
+	PageToken string       `json:"page_token,omitempty"`
+}
+
+type queryResult struct {
+	Vulns         []vulnerability `json:"vulns"`
+	NextPageToken string          `json:"next_page_token"`
+}
+
+type osvClient struct {
+	client *http.Client
+	apiURL string
+}
+
+func newOSVClient(apiURL string, c *http.Client) *osvClient {
+	return &osvClient{
+		client: c,
+		apiURL: strings.TrimSuffix(apiURL, "/"),
+	}
+}
+
+// Query returns the vulnerabilities for the package in the ecosystem. If
+// version is not empty only the vulnerabilities that affect that version
+// are returned.
+func (c *osvClient) Query(ctx context.Context, ecosystem, name, version string) ([]vulnerability, error) {
+	q := queryRequest{
+		Package: queryPackage{Name: name, Ecosystem: ecosystem},
+		Version: version,
+	}
+	var vulns []vulnerability
+	for i := 0; i < maxQueryPages; i++ {
+		var res queryResult
+		if err := c.post(ctx, "query", &q, &res); err != nil {
+			return nil, err
+		}
+		vulns = append(vulns, res.Vulns...)
+		if res.NextPageToken == "" {
+			break
+		}
+		q.PageToken = res.NextPageToken
+	}
+	return vulns, nil
+}
+
+// post sends body as JSON to the API endpoint path and decodes the JSON
+// response into result.
+func (c *osvClient) post(ctx context.Context, path string, body, result any) error {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.apiURL+"/"+path, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("Content-Type", "application/json")
+	_, err = httpjson.Do(c.client, req, result)
+	return err
+}
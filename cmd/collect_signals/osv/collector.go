@@ -0,0 +1,220 @@
+// Package osv provides a Collector that returns a Set for the known
+// vulnerabilities in the packages published from a repository.
+//
+// The packages published from a repository are found using the deps.dev
+// API, and their vulnerabilities are queried from the OSV.dev API. A
+// vulnerability is considered open if it affects the default version of a
+// package, which is usually the latest release.
+//
+// The time taken to fix a vulnerability is measured from when the advisory
+// was published until the first version that fixes it was released, using
+// the release dates recorded by deps.dev.
+package osv
+
+import (
+	"context"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/ossf/criticality_score/cmd/collect_signals/projectrepo"
+	"github.com/ossf/criticality_score/cmd/collect_signals/signal"
+	"github.com/ossf/criticality_score/internal/depsdevapi"
+	log "github.com/sirupsen/logrus"
+)
+
+type osvSet struct {
+	// VulnerabilityCount is the number of unique vulnerabilities that have
+	// affected any version of the packages.
+	VulnerabilityCount signal.Field[int]
+
+	// OpenVulnerabilityCount is the number of unique vulnerabilities that
+	// affect the default version of the packages.
+	OpenVulnerabilityCount signal.Field[int]
+
+	// DaysSinceNewestOpen is the number of days since the most recently
+	// published open vulnerability was published.
+	DaysSinceNewestOpen signal.Field[int]
+
+	// VulnFixLatencyDays is the median number of days between a
+	// vulnerability being published and a version that fixes it being
+	// released, for the vulnerabilities published in the last two years.
+	VulnFixLatencyDays signal.Field[int]
+}
+
+func (s *osvSet) Namespace() signal.Namespace {
+	return signal.Namespace("osv")
+}
+
+// ecosystems maps deps.dev package management systems to OSV ecosystems.
+var ecosystems = map[string]string{
+	"CARGO": "crates.io",
+	"GO":    "Go",
+	"MAVEN": "Maven",
+	"NPM":   "npm",
+	"NUGET": "NuGet",
+	"PYPI":  "PyPI",
+}
+
+// fixLatencyLookback is how far back to look for vulnerabilities when
+// measuring the time taken to fix them.
+const fixLatencyLookback = 2 * 365 * 24 * time.Hour
+
+type Collector struct {
+	client  *osvClient
+	depsdev *depsdevapi.Client
+	logger  *log.Logger
+}
+
+// NewCollector returns a new Collector that uses the Client dc to find the
+// packages for a repository, and the http.Client c to query OSV.dev.
+func NewCollector(dc *depsdevapi.Client, c *http.Client, logger *log.Logger) *Collector {
+	return &Collector{
+		client:  newOSVClient(DefaultAPIURL, c),
+		depsdev: dc,
+		logger:  logger,
+	}
+}
+
+func (c *Collector) EmptySet() signal.Set {
+	return &osvSet{}
+}
+
+func (c *Collector) IsSupported(r projectrepo.Repo) bool {
+	return true
+}
+
+// Collect implements the collector.Collector interface.
+//
+// If no packages in an ecosystem supported by OSV are published from the
+// repository the signals are left unset.
+func (c *Collector) Collect(ctx context.Context, r projectrepo.Repo) (signal.Set, error) {
+	s := &osvSet{}
+	logger := c.logger.WithField("url", r.URL().String())
+	now := time.Now()
+
+	logger.Debug("Fetching packages from deps.dev")
+	pkgs, err := c.depsdev.ProjectPackages(ctx, depsdevapi.ProjectKey(r.URL()), "")
+	if err != nil {
+		return nil, err
+	}
+
+	all := make(map[string]vulnerability)
+	open := make(map[string]vulnerability)
+	latencies := make(map[string]time.Duration)
+	found := false
+	for _, p := range pkgs {
+		ecosystem, ok := ecosystems[p.System]
+		if !ok {
+			continue
+		}
+		found = true
+		logger.WithFields(log.Fields{
+			"ecosystem": ecosystem,
+			"package":   p.Name,
+		}).Debug("Fetching vulnerabilities from OSV")
+		vulns, err := c.client.Query(ctx, ecosystem, p.Name, "")
+		if err != nil {
+			return nil, err
+		}
+		addVulns(all, vulns)
+		if len(vulns) == 0 {
+			continue
+		}
+
+		info, err := c.depsdev.Package(ctx, p.System, p.Name)
+		if err != nil {
+			return nil, err
+		}
+		if info == nil {
+			continue
+		}
+		addFixLatencies(latencies, vulns, ecosystem, p.Name, info, now.Add(-fixLatencyLookback))
+
+		version := info.DefaultVersion()
+		if version == "" {
+			continue
+		}
+		vulns, err = c.client.Query(ctx, ecosystem, p.Name, version)
+		if err != nil {
+			return nil, err
+		}
+		addVulns(open, vulns)
+	}
+	if !found {
+		return s, nil
+	}
+	summarize(s, all, open, now)
+	if len(latencies) > 0 {
+		s.VulnFixLatencyDays.Set(int(medianDuration(latencies).Hours()) / 24)
+	}
+	return s, nil
+}
+
+// addVulns adds each of the vulnerabilities to m, keyed by their ID.
+func addVulns(m map[string]vulnerability, vulns []vulnerability) {
+	for _, v := range vulns {
+		m[v.ID] = v
+	}
+}
+
+// summarize sets the signals in s for the vulnerabilities that have ever
+// affected the packages, and the open vulnerabilities that affect them now.
+//
+// DaysSinceNewestOpen is left unset if there are no open vulnerabilities.
+func summarize(s *osvSet, all, open map[string]vulnerability, now time.Time) {
+	s.VulnerabilityCount.Set(len(all))
+	s.OpenVulnerabilityCount.Set(len(open))
+	var newest time.Time
+	for _, v := range open {
+		if v.Published.After(newest) {
+			newest = v.Published
+		}
+	}
+	if !newest.IsZero() {
+		s.DaysSinceNewestOpen.Set(int(now.Sub(newest).Hours()) / 24)
+	}
+}
+
+// addFixLatencies adds the time taken to release a fix to latencies for each
+// of the vulnerabilities in the package that were published after since.
+//
+// If a vulnerability is fixed in several versions, or by several packages,
+// the earliest fix is used. Vulnerabilities without a fix that was released
+// are not added.
+func addFixLatencies(latencies map[string]time.Duration, vulns []vulnerability, ecosystem, name string, info *depsdevapi.Package, since time.Time) {
+	for _, v := range vulns {
+		if v.Published.Before(since) {
+			continue
+		}
+		for _, f := range v.fixedVersions(ecosystem, name) {
+			released := info.PublishedAt(f)
+			if released.IsZero() {
+				continue
+			}
+			// Fixes are often released before the advisory is published.
+			latency := released.Sub(v.Published)
+			if latency < 0 {
+				latency = 0
+			}
+			if l, ok := latencies[v.ID]; !ok || latency < l {
+				latencies[v.ID] = latency
+			}
+		}
+	}
+}
+
+// medianDuration returns the median of the durations in m, which must not be
+// empty.
+func medianDuration(m map[string]time.Duration) time.Duration {
+	ds := make([]time.Duration, 0, len(m))
+	for _, d := range m {
+		ds = append(ds, d)
+	}
+	sort.Slice(ds, func(i, j int) bool { return ds[i] < ds[j] })
+	mid := len(ds) / 2
+	if len(ds)%2 == 0 {
+		return (ds[mid-1] + ds[mid]) / 2
+	}
+	return ds[mid]
+}
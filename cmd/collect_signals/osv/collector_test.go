@@ -0,0 +1,196 @@
+package osv
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ossf/criticality_score/internal/collectortest"
+	"github.com/ossf/criticality_score/internal/depsdevapi"
+)
+
+// depsDevResponses holds the responses of a fake deps.dev API server, keyed
+// by the escaped request path.
+var depsDevResponses = map[string]string{
+	"/projects/github.com%2Fexample%2Fjson:packageversions": `{"versions": [
+		{"versionKey": {"system": "NPM", "name": "example-json", "version": "1.0.0"}},
+		{"versionKey": {"system": "NPM", "name": "example-json", "version": "2.0.0"}},
+		{"versionKey": {"system": "PYPI", "name": "example-json", "version": "2.0.0"}},
+		{"versionKey": {"system": "CONDA", "name": "example-json", "version": "2.0.0"}}
+	]}`,
+	"/systems/NPM/packages/example-json": `{"versions": [
+		{"versionKey": {"version": "1.0.0"}},
+		{"versionKey": {"version": "2.0.0"}, "isDefault": true}
+	]}`,
+	"/systems/PYPI/packages/example-json": `{"versions": [
+		{"versionKey": {"version": "2.0.0"}, "isDefault": true}
+	]}`,
+	"/projects/github.com%2Fexample%2Fconda:packageversions": `{"versions": [
+		{"versionKey": {"system": "CONDA", "name": "example-conda", "version": "1.0.0"}}
+	]}`,
+}
+
+// osvResponses holds the vulnerabilities returned by a fake OSV.dev API
+// server, keyed by "ecosystem/name@version".
+var osvResponses = map[string]string{
+	"npm/example-json@": `{"vulns": [
+		{"id": "GHSA-1", "published": "2021-01-01T00:00:00Z"},
+		{"id": "GHSA-2", "published": "2022-01-01T00:00:00Z"}
+	], "next_page_token": "page2"}`,
+	"npm/example-json@page2": `{"vulns": [
+		{"id": "GHSA-3", "published": "2022-05-01T00:00:00Z"}
+	]}`,
+	"npm/example-json@2.0.0": `{"vulns": [
+		{"id": "GHSA-2", "published": "2022-01-01T00:00:00Z"}
+	]}`,
+	"PyPI/example-json@": `{"vulns": [
+		{"id": "GHSA-2", "published": "2022-01-01T00:00:00Z"},
+		{"id": "PYSEC-1", "published": "2022-03-01T00:00:00Z"}
+	]}`,
+	"PyPI/example-json@2.0.0": `{"vulns": [
+		{"id": "PYSEC-1", "published": "2022-03-01T00:00:00Z"}
+	]}`,
+}
+
+func newTestCollector(t *testing.T) *Collector {
+	t.Helper()
+	dd := collectortest.NewServer(t, collectortest.PathHandler(depsDevResponses))
+	o := collectortest.NewServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		var q queryRequest
+		if r.Method != http.MethodPost || r.URL.Path != "/query" {
+			http.NotFound(w, r)
+			return
+		}
+		if err := json.NewDecoder(r.Body).Decode(&q); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		key := q.Package.Ecosystem + "/" + q.Package.Name + "@" + q.Version + q.PageToken
+		w.Header().Set("Content-Type", "application/json")
+		if body, ok := osvResponses[key]; ok {
+			w.Write([]byte(body))
+		} else {
+			w.Write([]byte(`{}`))
+		}
+	}))
+	c := NewCollector(depsdevapi.NewCustomClient(dd.URL, &http.Client{}), &http.Client{}, collectortest.Logger())
+	c.client = newOSVClient(o.URL, &http.Client{})
+	return c
+}
+
+func TestCollect(t *testing.T) {
+	s := collectortest.Collect[*osvSet](t, newTestCollector(t), "https://github.com/example/json")
+	if got := s.VulnerabilityCount.Get(); got != 4 {
+		t.Fatalf("VulnerabilityCount == %d, want 4", got)
+	}
+	if got := s.OpenVulnerabilityCount.Get(); got != 2 {
+		t.Fatalf("OpenVulnerabilityCount == %d, want 2", got)
+	}
+	if !s.DaysSinceNewestOpen.IsSet() {
+		t.Fatal("DaysSinceNewestOpen is unset, want set")
+	}
+}
+
+func TestCollect_UnsupportedEcosystem(t *testing.T) {
+	s := collectortest.Collect[*osvSet](t, newTestCollector(t), "https://github.com/example/conda")
+	if s.VulnerabilityCount.IsSet() {
+		t.Fatal("VulnerabilityCount is set, want unset")
+	}
+}
+
+func TestCollect_UnknownProject(t *testing.T) {
+	s := collectortest.Collect[*osvSet](t, newTestCollector(t), "https://github.com/example/missing")
+	if s.VulnerabilityCount.IsSet() {
+		t.Fatal("VulnerabilityCount is set, want unset")
+	}
+	if s.DaysSinceNewestOpen.IsSet() {
+		t.Fatal("DaysSinceNewestOpen is set, want unset")
+	}
+}
+
+func TestSummarize(t *testing.T) {
+	now := time.Date(2022, 3, 11, 0, 0, 0, 0, time.UTC)
+	all := map[string]vulnerability{
+		"A": {ID: "A", Published: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)},
+		"B": {ID: "B", Published: time.Date(2022, 3, 1, 0, 0, 0, 0, time.UTC)},
+		"C": {ID: "C", Published: time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)},
+	}
+	open := map[string]vulnerability{"B": all["B"], "C": all["C"]}
+	var s osvSet
+	summarize(&s, all, open, now)
+	if got := s.VulnerabilityCount.Get(); got != 3 {
+		t.Fatalf("VulnerabilityCount == %d, want 3", got)
+	}
+	if got := s.OpenVulnerabilityCount.Get(); got != 2 {
+		t.Fatalf("OpenVulnerabilityCount == %d, want 2", got)
+	}
+	if got := s.DaysSinceNewestOpen.Get(); got != 10 {
+		t.Fatalf("DaysSinceNewestOpen == %d, want 10", got)
+	}
+}
+
+func TestSummarize_NoOpen(t *testing.T) {
+	var s osvSet
+	summarize(&s, map[string]vulnerability{"A": {ID: "A"}}, map[string]vulnerability{}, time.Now())
+	if got := s.OpenVulnerabilityCount.Get(); got != 0 {
+		t.Fatalf("OpenVulnerabilityCount == %d, want 0", got)
+	}
+	if s.DaysSinceNewestOpen.IsSet() {
+		t.Fatal("DaysSinceNewestOpen is set, want unset")
+	}
+}
+
+func TestAddFixLatencies(t *testing.T) {
+	day := 24 * time.Hour
+	published := time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)
+	info := &depsdevapi.Package{Versions: []depsdevapi.Version{
+		{VersionKey: depsdevapi.VersionKey{Version: "1.0.1"}, PublishedAt: published.Add(-2 * day)},
+		{VersionKey: depsdevapi.VersionKey{Version: "1.2.3"}, PublishedAt: published.Add(10 * day)},
+		{VersionKey: depsdevapi.VersionKey{Version: "2.0.1"}, PublishedAt: published.Add(30 * day)},
+	}}
+	affects := func(fixed ...string) []affected {
+		r := versionRange{Type: "SEMVER"}
+		for _, f := range fixed {
+			r.Events = append(r.Events, event{Introduced: "0"}, event{Fixed: f})
+		}
+		return []affected{{
+			Package: queryPackage{Name: "example", Ecosystem: "npm"},
+			Ranges:  []versionRange{r},
+		}}
+	}
+	vulns := []vulnerability{
+		{ID: "EARLY", Published: published, Affected: affects("1.0.1")},
+		{ID: "MULTI", Published: published, Affected: affects("2.0.1", "1.2.3")},
+		{ID: "UNFIXED", Published: published, Affected: affects()},
+		{ID: "UNRELEASED", Published: published, Affected: affects("9.9.9")},
+		{ID: "OLD", Published: published.Add(-365 * day), Affected: affects("1.0.1")},
+	}
+	latencies := make(map[string]time.Duration)
+	addFixLatencies(latencies, vulns, "npm", "example", info, published.Add(-day))
+	want := map[string]time.Duration{"EARLY": 0, "MULTI": 10 * day}
+	if len(latencies) != len(want) {
+		t.Fatalf("addFixLatencies() added %v, want %v", latencies, want)
+	}
+	for id, w := range want {
+		if got, ok := latencies[id]; !ok || got != w {
+			t.Fatalf("latencies[%q] == %v, want %v", id, got, w)
+		}
+	}
+}
+
+func TestMedianDuration(t *testing.T) {
+	tests := []struct {
+		m    map[string]time.Duration
+		want time.Duration
+	}{
+		{map[string]time.Duration{"A": 3}, 3},
+		{map[string]time.Duration{"A": 5, "B": 1, "C": 3}, 3},
+		{map[string]time.Duration{"A": 4, "B": 1, "C": 2, "D": 8}, 3},
+	}
+	for _, test := range tests {
+		if got := medianDuration(test.m); got != test.want {
+			t.Fatalf("medianDuration(%v) == %v, want %v", test.m, got, test.want)
+		}
+	}
+}
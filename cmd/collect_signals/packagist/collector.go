@@ -0,0 +1,160 @@
+// Package packagist provides a Collector that returns a Set for the usage of
+// the Composer packages published from a repository, as reported by
+// Packagist.
+//
+// Packages are found by searching Packagist for the name of the repository,
+// and keeping the packages whose repository URL refers to the repository.
+package packagist
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/ossf/criticality_score/cmd/collect_signals/projectrepo"
+	"github.com/ossf/criticality_score/cmd/collect_signals/signal"
+	"github.com/ossf/criticality_score/internal/httpjson"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultAPIURL is the base URL of the Packagist API.
+	DefaultAPIURL = "https://packagist.org"
+
+	// searchPerPage is the number of search results examined for packages
+	// published from a repository.
+	searchPerPage = 100
+)
+
+type packagistSet struct {
+	PackageCount signal.Field[int]
+
+	// DependentCount is the total number of packages that depend on any of
+	// the packages published from the repository.
+	DependentCount signal.Field[int]
+
+	// MonthlyInstalls is the number of installs in the last 30 days.
+	MonthlyInstalls signal.Field[int]
+}
+
+func (s *packagistSet) Namespace() signal.Namespace {
+	return signal.Namespace("packagist")
+}
+
+type searchResult struct {
+	Results []struct {
+		Name       string `json:"name"`
+		Repository string `json:"repository"`
+	} `json:"results"`
+}
+
+type packageResult struct {
+	Package struct {
+		Dependents int `json:"dependents"`
+		Downloads  struct {
+			Monthly int `json:"monthly"`
+		} `json:"downloads"`
+	} `json:"package"`
+}
+
+type Collector struct {
+	client *http.Client
+	logger *log.Logger
+	apiURL string
+}
+
+// NewCollector returns a new Collector that uses the http.Client c to query
+// Packagist.
+func NewCollector(c *http.Client, logger *log.Logger) *Collector {
+	return &Collector{
+		client: c,
+		logger: logger,
+		apiURL: DefaultAPIURL,
+	}
+}
+
+func (c *Collector) EmptySet() signal.Set {
+	return &packagistSet{}
+}
+
+func (c *Collector) IsSupported(r projectrepo.Repo) bool {
+	return true
+}
+
+// Collect implements the collector.Collector interface.
+//
+// If no packages are published from the repository the signals are left
+// unset.
+func (c *Collector) Collect(ctx context.Context, r projectrepo.Repo) (signal.Set, error) {
+	s := &packagistSet{}
+	logger := c.logger.WithField("url", r.URL().String())
+
+	logger.Debug("Searching Packagist")
+	names, err := c.queryPackages(ctx, r.URL())
+	if err != nil {
+		return nil, err
+	}
+	if len(names) == 0 {
+		return s, nil
+	}
+
+	dependents := 0
+	installs := 0
+	for _, name := range names {
+		logger.WithField("package", name).Debug("Fetching package stats")
+		p, err := c.queryPackage(ctx, name)
+		if err != nil {
+			return nil, err
+		}
+		if p == nil {
+			continue
+		}
+		dependents += p.Package.Dependents
+		installs += p.Package.Downloads.Monthly
+	}
+	s.PackageCount.Set(len(names))
+	s.DependentCount.Set(dependents)
+	s.MonthlyInstalls.Set(installs)
+	return s, nil
+}
+
+// queryPackages returns the names of the packages published from the
+// repository at u.
+func (c *Collector) queryPackages(ctx context.Context, u *url.URL) ([]string, error) {
+	query := url.Values{
+		"q":        {path.Base(strings.TrimSuffix(strings.Trim(u.Path, "/"), ".git"))},
+		"per_page": {fmt.Sprint(searchPerPage)},
+	}
+	var res searchResult
+	if _, err := httpjson.Get(ctx, c.client, c.apiURL+"/search.json?"+query.Encode(), &res); err != nil {
+		return nil, err
+	}
+	repo := projectrepo.NormalizeURL(u.String())
+	var names []string
+	for _, p := range res.Results {
+		if p.Repository != "" && projectrepo.NormalizeURL(p.Repository) == repo {
+			names = append(names, p.Name)
+		}
+	}
+	return names, nil
+}
+
+// queryPackage returns the stats for the package name, which is in the form
+// "vendor/package".
+//
+// If the package does not exist, nil is returned.
+func (c *Collector) queryPackage(ctx context.Context, name string) (*packageResult, error) {
+	vendor, pkg, _ := strings.Cut(name, "/")
+	res := &packageResult{}
+	_, err := httpjson.Get(ctx, c.client, c.apiURL+"/packages/"+url.PathEscape(vendor)+"/"+url.PathEscape(pkg)+".json", res)
+	if httpjson.StatusCode(err) == http.StatusNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
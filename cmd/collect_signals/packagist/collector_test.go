@@ -0,0 +1,59 @@
+package packagist
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/ossf/criticality_score/internal/collectortest"
+)
+
+// testRepoURL is the repository the signals are collected for.
+const testRepoURL = "https://github.com/symfony/console"
+
+// newTestCollector returns a Collector where all the requests are handled by
+// h.
+func newTestCollector(t *testing.T, h http.Handler) *Collector {
+	t.Helper()
+	s := collectortest.NewServer(t, h)
+	c := NewCollector(&http.Client{}, collectortest.Logger())
+	c.apiURL = s.URL
+	return c
+}
+
+func TestCollect(t *testing.T) {
+	c := newTestCollector(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/search.json":
+			w.Write([]byte(`{"results": [
+				{"name": "symfony/console", "repository": "https://github.com/symfony/console"},
+				{"name": "other/console", "repository": "https://github.com/other/console"}
+			]}`))
+		case "/packages/symfony/console.json":
+			w.Write([]byte(`{"package": {"name": "symfony/console", "dependents": 12000, "downloads": {"total": 1000000, "monthly": 5000, "daily": 100}}}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	s := collectortest.Collect[*packagistSet](t, c, testRepoURL)
+	if got := s.PackageCount.Get(); got != 1 {
+		t.Fatalf("PackageCount == %d, want 1", got)
+	}
+	if got := s.DependentCount.Get(); got != 12000 {
+		t.Fatalf("DependentCount == %d, want 12000", got)
+	}
+	if got := s.MonthlyInstalls.Get(); got != 5000 {
+		t.Fatalf("MonthlyInstalls == %d, want 5000", got)
+	}
+}
+
+func TestCollect_NoPackages(t *testing.T) {
+	c := newTestCollector(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"results": []}`))
+	}))
+	s := collectortest.Collect[*packagistSet](t, c, testRepoURL)
+	if s.PackageCount.IsSet() || s.DependentCount.IsSet() || s.MonthlyInstalls.IsSet() {
+		t.Fatal("signals are set, want unset")
+	}
+}
@@ -0,0 +1,100 @@
+package main
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/ossf/criticality_score/cmd/collect_signals/signal"
+)
+
+// passthrough holds extra columns for each repository that are copied to the
+// output unchanged.
+type passthrough struct {
+	// columns holds the names of the extra columns.
+	columns []string
+
+	// values maps a repository url to the value for each column.
+	values map[string][]string
+}
+
+// passthroughKey returns the key used to look up the values for the raw
+// repository url.
+func passthroughKey(raw string) string {
+	return strings.ToLower(strings.TrimSpace(raw))
+}
+
+// loadPassthrough reads the extra columns for each repository from r.
+//
+// r must be a CSV file with a header row. The first column must contain the
+// repository url, as it appears in the input. The remaining columns are the
+// extra columns.
+func loadPassthrough(r io.Reader) (*passthrough, error) {
+	cr := csv.NewReader(r)
+	header, err := cr.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, errors.New("passthrough file is empty")
+	} else if err != nil {
+		return nil, err
+	}
+	if len(header) < 2 {
+		return nil, errors.New("passthrough file must have a url column and at least one other column")
+	}
+	p := &passthrough{
+		columns: header[1:],
+		values:  make(map[string][]string),
+	}
+	for {
+		row, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return p, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		key := passthroughKey(row[0])
+		if _, exists := p.values[key]; exists {
+			return nil, fmt.Errorf("passthrough file has duplicate url: %s", row[0])
+		}
+		p.values[key] = row[1:]
+	}
+}
+
+// lookup returns the values of the extra columns for the repository url raw.
+//
+// If there are no values for raw, false is returned.
+func (p *passthrough) lookup(raw string) ([]string, bool) {
+	vs, ok := p.values[passthroughKey(raw)]
+	return vs, ok
+}
+
+// openPassthrough loads the extra columns from the file named filename.
+//
+// An error is returned if an extra column has the same name as a signal in
+// emptySets.
+func openPassthrough(filename string, emptySets []signal.Set) (*passthrough, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	p, err := loadPassthrough(f)
+	if err != nil {
+		return nil, err
+	}
+	signals := make(map[string]bool)
+	for _, s := range emptySets {
+		for _, name := range signal.SetFields(s, true) {
+			signals[name] = true
+		}
+	}
+	for _, c := range p.columns {
+		if signals[c] {
+			return nil, fmt.Errorf("passthrough column %s is already used by a signal", c)
+		}
+	}
+	return p, nil
+}
@@ -0,0 +1,49 @@
+package main
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoadPassthrough(t *testing.T) {
+	p, err := loadPassthrough(strings.NewReader("" +
+		"url,team,ecosystem\n" +
+		"https://github.com/ossf/criticality_score,scoring,go\n" +
+		"https://github.com/ossf/scorecard,checks,go\n"))
+	if err != nil {
+		t.Fatalf("loadPassthrough() errored %v, want no error", err)
+	}
+	if want := []string{"team", "ecosystem"}; !reflect.DeepEqual(p.columns, want) {
+		t.Fatalf("columns == %v, want %v", p.columns, want)
+	}
+	vs, ok := p.lookup("https://github.com/OSSF/Criticality_Score ")
+	if !ok {
+		t.Fatal("lookup() returned false, want true")
+	}
+	if want := []string{"scoring", "go"}; !reflect.DeepEqual(vs, want) {
+		t.Fatalf("lookup() == %v, want %v", vs, want)
+	}
+	if _, ok := p.lookup("https://github.com/ossf/missing"); ok {
+		t.Fatal("lookup() returned true, want false")
+	}
+}
+
+func TestLoadPassthrough_Invalid(t *testing.T) {
+	tests := []struct {
+		name string
+		data string
+	}{
+		{"empty", ""},
+		{"no extra columns", "url\nhttps://github.com/ossf/scorecard\n"},
+		{"wrong column count", "url,team\nhttps://github.com/ossf/scorecard,checks,go\n"},
+		{"duplicate url", "url,team\nhttps://github.com/a/b,x\nhttps://github.com/A/B,y\n"},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := loadPassthrough(strings.NewReader(test.data)); err == nil {
+				t.Fatal("loadPassthrough() returned no error, want an error")
+			}
+		})
+	}
+}
@@ -10,6 +10,16 @@ type Repo interface {
 	URL() *url.URL
 }
 
+// Mirror is implemented by a Repo that may be a read-only mirror of another
+// repository.
+type Mirror interface {
+	// UpstreamURL returns the URL of the repository being mirrored.
+	//
+	// If the Repo is not a mirror, or the upstream repository is unknown,
+	// nil is returned.
+	UpstreamURL() *url.URL
+}
+
 // Factory is used to obtain new instances of Repo.
 type Factory interface {
 	// New returns a new instance of Repo for the supplied URL.
@@ -0,0 +1,69 @@
+package projectrepo
+
+import (
+	"net/url"
+	"regexp"
+	"strings"
+)
+
+// scpLikeURL matches the scp-like syntax supported by git for ssh
+// repositories (e.g. "git@github.com:owner/repo.git").
+var scpLikeURL = regexp.MustCompile(`^(?:([\w.~-]+)@)?([\w.-]+):([^/].*)$`)
+
+// ParseURL parses the raw repository location into a URL.
+//
+// In addition to the URLs supported by url.Parse, the scp-like syntax used by
+// git is supported. These are returned as an "ssh" URL, so that
+// "git@github.com:owner/repo.git" becomes
+// "ssh://git@github.com/owner/repo.git".
+func ParseURL(raw string) (*url.URL, error) {
+	raw = strings.TrimSpace(raw)
+	if !strings.Contains(raw, "://") {
+		if m := scpLikeURL.FindStringSubmatch(raw); m != nil {
+			u := &url.URL{
+				Scheme: "ssh",
+				Host:   m[2],
+				Path:   "/" + m[3],
+			}
+			if m[1] != "" {
+				u.User = url.User(m[1])
+			}
+			return u, nil
+		}
+	}
+	return url.Parse(raw)
+}
+
+// NormalizeURL returns the repository URL u in a form that can be compared,
+// without the scheme, "www.", case or a trailing "/" or ".git".
+//
+// It is used to match the repository URLs recorded by package registries and
+// other services, which vary in how they refer to the same repository.
+func NormalizeURL(u string) string {
+	u = strings.ToLower(strings.TrimSpace(u))
+	if _, rest, ok := strings.Cut(u, "://"); ok {
+		u = rest
+	}
+	u = strings.TrimPrefix(u, "www.")
+	return strings.TrimSuffix(strings.TrimSuffix(u, "/"), ".git")
+}
+
+// RefersTo returns true if any of the urls refers to the normalized repository
+// URL repo, or a page inside it.
+//
+// Pages inside a repository match, as registries often link to a directory in
+// a monorepo (e.g. "https://github.com/owner/repo/tree/main/pkg"), the
+// repository's issue tracker or a fragment of its home page. Empty urls never
+// match.
+func RefersTo(repo string, urls ...string) bool {
+	for _, u := range urls {
+		if u == "" {
+			continue
+		}
+		n := NormalizeURL(u)
+		if n == repo || strings.HasPrefix(n, repo+"/") || strings.HasPrefix(n, repo+"#") {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,99 @@
+package projectrepo
+
+import "testing"
+
+func TestParseURL(t *testing.T) {
+	tests := []struct {
+		raw      string
+		wantHost string
+		wantPath string
+	}{
+		{raw: "https://github.com/owner/repo", wantHost: "github.com", wantPath: "/owner/repo"},
+		{raw: "https://github.com/owner/repo.git", wantHost: "github.com", wantPath: "/owner/repo.git"},
+		{raw: "  https://github.com/owner/repo  ", wantHost: "github.com", wantPath: "/owner/repo"},
+		{raw: "ssh://git@github.com/owner/repo.git", wantHost: "github.com", wantPath: "/owner/repo.git"},
+		{raw: "git@github.com:owner/repo.git", wantHost: "github.com", wantPath: "/owner/repo.git"},
+		{raw: "git@github.com:owner/repo", wantHost: "github.com", wantPath: "/owner/repo"},
+		{raw: "github.com:owner/repo", wantHost: "github.com", wantPath: "/owner/repo"},
+	}
+	for _, test := range tests {
+		t.Run(test.raw, func(t *testing.T) {
+			u, err := ParseURL(test.raw)
+			if err != nil {
+				t.Fatalf("ParseURL() errored %v, want no error", err)
+			}
+			if h := u.Hostname(); h != test.wantHost {
+				t.Errorf("Hostname() == %q, want %q", h, test.wantHost)
+			}
+			if u.Path != test.wantPath {
+				t.Errorf("Path == %q, want %q", u.Path, test.wantPath)
+			}
+		})
+	}
+}
+
+func TestParseURL_SCPUser(t *testing.T) {
+	u, err := ParseURL("git@github.com:owner/repo.git")
+	if err != nil {
+		t.Fatalf("ParseURL() errored %v, want no error", err)
+	}
+	if u.Scheme != "ssh" {
+		t.Errorf("Scheme == %q, want %q", u.Scheme, "ssh")
+	}
+	if n := u.User.Username(); n != "git" {
+		t.Errorf("User.Username() == %q, want %q", n, "git")
+	}
+}
+
+func TestParseURL_Invalid(t *testing.T) {
+	if _, err := ParseURL("https://github.com/%zz"); err == nil {
+		t.Fatal("ParseURL() returned no error, want an error")
+	}
+}
+
+func TestNormalizeURL(t *testing.T) {
+	tests := []struct {
+		url  string
+		want string
+	}{
+		{url: "https://github.com/owner/repo", want: "github.com/owner/repo"},
+		{url: "http://www.github.com/Owner/Repo/", want: "github.com/owner/repo"},
+		{url: "git://github.com/owner/repo.git", want: "github.com/owner/repo"},
+		{url: "  https://gitlab.com/group/project  ", want: "gitlab.com/group/project"},
+		{url: "github.com/owner/repo", want: "github.com/owner/repo"},
+		{url: "", want: ""},
+	}
+	for _, test := range tests {
+		t.Run(test.url, func(t *testing.T) {
+			if got := NormalizeURL(test.url); got != test.want {
+				t.Fatalf("NormalizeURL() == %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestRefersTo(t *testing.T) {
+	repo := NormalizeURL("https://github.com/example/serde")
+	tests := []struct {
+		name string
+		urls []string
+		want bool
+	}{
+		{name: "same", urls: []string{"https://github.com/example/serde"}, want: true},
+		{name: "git suffix", urls: []string{"https://github.com/example/serde.git"}, want: true},
+		{name: "case and www", urls: []string{"http://www.github.com/Example/Serde/"}, want: true},
+		{name: "directory", urls: []string{"https://github.com/example/serde/tree/master/serde_json"}, want: true},
+		{name: "fragment", urls: []string{"https://github.com/example/serde#readme"}, want: true},
+		{name: "other repo", urls: []string{"https://github.com/example/serde-json"}, want: false},
+		{name: "any", urls: []string{"https://example.com", "", "https://github.com/example/serde/issues"}, want: true},
+		{name: "empty", urls: []string{""}, want: false},
+		{name: "none", want: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := RefersTo(repo, test.urls...); got != test.want {
+				t.Fatalf("RefersTo(%q) == %v, want %v", test.urls, got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+// Package pubdev provides a Collector that returns a Set for the popularity
+// of the Dart and Flutter packages published from a repository, as reported
+// by pub.dev.
+//
+// Packages are found by searching pub.dev for the name of the repository,
+// and keeping the packages whose pubspec repository or homepage refers to
+// the repository.
+package pubdev
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/ossf/criticality_score/cmd/collect_signals/projectrepo"
+	"github.com/ossf/criticality_score/cmd/collect_signals/signal"
+	"github.com/ossf/criticality_score/internal/httpjson"
+	log "github.com/sirupsen/logrus"
+)
+
+// DefaultAPIURL is the base URL of the pub.dev API.
+const DefaultAPIURL = "https://pub.dev/api"
+
+type pubdevSet struct {
+	PackageCount signal.Field[int]
+
+	// LikeCount is the total number of likes given to the packages.
+	LikeCount signal.Field[int]
+
+	// MonthlyDownloads is the number of downloads in the last 30 days. It
+	// replaced the popularity score on pub.dev.
+	MonthlyDownloads signal.Field[int]
+}
+
+func (s *pubdevSet) Namespace() signal.Namespace {
+	return signal.Namespace("pubdev")
+}
+
+type searchResult struct {
+	Packages []struct {
+		Package string `json:"package"`
+	} `json:"packages"`
+}
+
+type packageResult struct {
+	Latest struct {
+		Pubspec struct {
+			Repository string `json:"repository"`
+			Homepage   string `json:"homepage"`
+		} `json:"pubspec"`
+	} `json:"latest"`
+}
+
+type scoreResult struct {
+	LikeCount           int `json:"likeCount"`
+	DownloadCount30Days int `json:"downloadCount30Days"`
+}
+
+type Collector struct {
+	client *http.Client
+	logger *log.Logger
+	apiURL string
+}
+
+// NewCollector returns a new Collector that uses the http.Client c to query
+// pub.dev.
+func NewCollector(c *http.Client, logger *log.Logger) *Collector {
+	return &Collector{
+		client: c,
+		logger: logger,
+		apiURL: DefaultAPIURL,
+	}
+}
+
+func (c *Collector) EmptySet() signal.Set {
+	return &pubdevSet{}
+}
+
+func (c *Collector) IsSupported(r projectrepo.Repo) bool {
+	return true
+}
+
+// Collect implements the collector.Collector interface.
+//
+// If no packages are published from the repository the signals are left
+// unset.
+func (c *Collector) Collect(ctx context.Context, r projectrepo.Repo) (signal.Set, error) {
+	s := &pubdevSet{}
+	logger := c.logger.WithField("url", r.URL().String())
+
+	logger.Debug("Searching pub.dev")
+	names, err := c.queryPackages(ctx, r.URL())
+	if err != nil {
+		return nil, err
+	}
+	if len(names) == 0 {
+		return s, nil
+	}
+
+	likes := 0
+	downloads := 0
+	for _, name := range names {
+		logger.WithField("package", name).Debug("Fetching package score")
+		score := &scoreResult{}
+		if _, err := httpjson.Get(ctx, c.client, c.apiURL+"/packages/"+url.PathEscape(name)+"/score", score); err != nil {
+			return nil, err
+		}
+		likes += score.LikeCount
+		downloads += score.DownloadCount30Days
+	}
+	s.PackageCount.Set(len(names))
+	s.LikeCount.Set(likes)
+	s.MonthlyDownloads.Set(downloads)
+	return s, nil
+}
+
+// queryPackages returns the names of the packages published from the
+// repository at u.
+//
+// Only the first page of search results is examined, as pub.dev does not
+// include the repository in its search results and each package must be
+// fetched to find it.
+func (c *Collector) queryPackages(ctx context.Context, u *url.URL) ([]string, error) {
+	query := url.Values{
+		"q": {path.Base(strings.TrimSuffix(strings.Trim(u.Path, "/"), ".git"))},
+	}
+	var res searchResult
+	if _, err := httpjson.Get(ctx, c.client, c.apiURL+"/search?"+query.Encode(), &res); err != nil {
+		return nil, err
+	}
+	repo := projectrepo.NormalizeURL(u.String())
+	var names []string
+	for _, p := range res.Packages {
+		pkg, err := c.queryPackage(ctx, p.Package)
+		if err != nil {
+			return nil, err
+		}
+		if pkg == nil {
+			continue
+		}
+		pubspec := pkg.Latest.Pubspec
+		if projectrepo.RefersTo(repo, pubspec.Repository, pubspec.Homepage) {
+			names = append(names, p.Package)
+		}
+	}
+	return names, nil
+}
+
+// queryPackage returns the details of the latest version of the package
+// name.
+//
+// If the package does not exist, nil is returned.
+func (c *Collector) queryPackage(ctx context.Context, name string) (*packageResult, error) {
+	res := &packageResult{}
+	_, err := httpjson.Get(ctx, c.client, c.apiURL+"/packages/"+url.PathEscape(name), res)
+	if httpjson.StatusCode(err) == http.StatusNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
@@ -0,0 +1,64 @@
+package pubdev
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/ossf/criticality_score/internal/collectortest"
+)
+
+// testRepoURL is the repository the signals are collected for.
+const testRepoURL = "https://github.com/flutter/packages"
+
+// newTestCollector returns a Collector where all the requests are handled by
+// h.
+func newTestCollector(t *testing.T, h http.Handler) *Collector {
+	t.Helper()
+	s := collectortest.NewServer(t, h)
+	c := NewCollector(&http.Client{}, collectortest.Logger())
+	c.apiURL = s.URL
+	return c
+}
+
+func TestCollect(t *testing.T) {
+	c := newTestCollector(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/search":
+			w.Write([]byte(`{"packages": [{"package": "url_launcher"}, {"package": "go_router"}, {"package": "other"}, {"package": "missing"}]}`))
+		case "/packages/url_launcher":
+			w.Write([]byte(`{"name": "url_launcher", "latest": {"pubspec": {"repository": "https://github.com/flutter/packages/tree/main/packages/url_launcher/url_launcher"}}}`))
+		case "/packages/go_router":
+			w.Write([]byte(`{"name": "go_router", "latest": {"pubspec": {"homepage": "https://github.com/flutter/packages"}}}`))
+		case "/packages/other":
+			w.Write([]byte(`{"name": "other", "latest": {"pubspec": {"repository": "https://github.com/flutter/packages-other"}}}`))
+		case "/packages/url_launcher/score":
+			w.Write([]byte(`{"grantedPoints": 160, "maxPoints": 160, "likeCount": 5000, "downloadCount30Days": 2000000}`))
+		case "/packages/go_router/score":
+			w.Write([]byte(`{"grantedPoints": 150, "maxPoints": 160, "likeCount": 4000, "downloadCount30Days": 1000000}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	s := collectortest.Collect[*pubdevSet](t, c, testRepoURL)
+	if got := s.PackageCount.Get(); got != 2 {
+		t.Fatalf("PackageCount == %d, want 2", got)
+	}
+	if got := s.LikeCount.Get(); got != 9000 {
+		t.Fatalf("LikeCount == %d, want 9000", got)
+	}
+	if got := s.MonthlyDownloads.Get(); got != 3000000 {
+		t.Fatalf("MonthlyDownloads == %d, want 3000000", got)
+	}
+}
+
+func TestCollect_NoPackages(t *testing.T) {
+	c := newTestCollector(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{"packages": []}`))
+	}))
+	s := collectortest.Collect[*pubdevSet](t, c, testRepoURL)
+	if s.PackageCount.IsSet() || s.LikeCount.IsSet() || s.MonthlyDownloads.IsSet() {
+		t.Fatal("signals are set, want unset")
+	}
+}
@@ -0,0 +1,120 @@
+// Package registryreleases provides a Collector that returns a Set for the
+// release cadence of the packages published from a repository.
+//
+// Many projects only publish releases to a package registry, without
+// creating GitHub releases or tags. The packages published from a repository,
+// and the time each of their versions was published, are found using the
+// deps.dev API.
+package registryreleases
+
+import (
+	"context"
+	"time"
+
+	"github.com/ossf/criticality_score/cmd/collect_signals/projectrepo"
+	"github.com/ossf/criticality_score/cmd/collect_signals/signal"
+	"github.com/ossf/criticality_score/internal/depsdevapi"
+	log "github.com/sirupsen/logrus"
+)
+
+// releaseLookback is how far back versions are counted as recent releases.
+const releaseLookback = 365 * 24 * time.Hour
+
+type registrySet struct {
+	PackageCount signal.Field[int]
+
+	// ReleasesLastYear is the number of versions of any of the packages
+	// published in the last year.
+	ReleasesLastYear signal.Field[int]
+
+	// DaysSinceLastRelease is the number of days since a version of any of
+	// the packages was last published.
+	DaysSinceLastRelease signal.Field[int]
+}
+
+func (s *registrySet) Namespace() signal.Namespace {
+	return signal.Namespace("registry")
+}
+
+type Collector struct {
+	client *depsdevapi.Client
+	logger *log.Logger
+}
+
+// NewCollector returns a new Collector that uses the Client c to query
+// deps.dev.
+func NewCollector(c *depsdevapi.Client, logger *log.Logger) *Collector {
+	return &Collector{
+		client: c,
+		logger: logger,
+	}
+}
+
+func (c *Collector) EmptySet() signal.Set {
+	return &registrySet{}
+}
+
+func (c *Collector) IsSupported(r projectrepo.Repo) bool {
+	return true
+}
+
+// Collect implements the collector.Collector interface.
+//
+// If the repository is not known to deps.dev, or no packages are published
+// from it, the signals are left unset. DaysSinceLastRelease is also left
+// unset if deps.dev does not know when any of the versions were published.
+func (c *Collector) Collect(ctx context.Context, r projectrepo.Repo) (signal.Set, error) {
+	s := &registrySet{}
+	logger := c.logger.WithField("url", r.URL().String())
+	now := time.Now()
+
+	logger.Debug("Fetching packages from deps.dev")
+	pkgs, err := c.client.ProjectPackages(ctx, depsdevapi.ProjectKey(r.URL()), "")
+	if err != nil {
+		return nil, err
+	}
+	if len(pkgs) == 0 {
+		return s, nil
+	}
+
+	var versions []depsdevapi.Version
+	for _, p := range pkgs {
+		logger.WithFields(log.Fields{
+			"system":  p.System,
+			"package": p.Name,
+		}).Debug("Fetching package versions")
+		info, err := c.client.Package(ctx, p.System, p.Name)
+		if err != nil {
+			return nil, err
+		}
+		if info != nil {
+			versions = append(versions, info.Versions...)
+		}
+	}
+	s.PackageCount.Set(len(pkgs))
+	summarize(s, versions, now)
+	return s, nil
+}
+
+// summarize sets the release signals in s from the versions of the packages,
+// relative to now. Versions with an unknown publish time are ignored.
+func summarize(s *registrySet, versions []depsdevapi.Version, now time.Time) {
+	since := now.Add(-releaseLookback)
+	recent := 0
+	var latest time.Time
+	for _, v := range versions {
+		if v.PublishedAt.IsZero() {
+			continue
+		}
+		if v.PublishedAt.After(since) {
+			recent++
+		}
+		if v.PublishedAt.After(latest) {
+			latest = v.PublishedAt
+		}
+	}
+	s.ReleasesLastYear.Set(recent)
+	if !latest.IsZero() {
+		s.DaysSinceLastRelease.Set(int(now.Sub(latest).Hours()) / 24)
+	}
+}
@@ -0,0 +1,68 @@
+package registryreleases
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ossf/criticality_score/internal/collectortest"
+	"github.com/ossf/criticality_score/internal/depsdevapi"
+)
+
+// testRepoURL is the repository the signals are collected for.
+const testRepoURL = "https://github.com/Example/Repo"
+
+// newTestCollector returns a Collector where all the requests are handled by
+// h.
+func newTestCollector(t *testing.T, h http.Handler) *Collector {
+	t.Helper()
+	s := collectortest.NewServer(t, h)
+	return NewCollector(depsdevapi.NewCustomClient(s.URL, &http.Client{}), collectortest.Logger())
+}
+
+func TestCollect(t *testing.T) {
+	recent := time.Now().Add(-10 * 24 * time.Hour).UTC().Format(time.RFC3339)
+	c := newTestCollector(t, collectortest.PathHandler(map[string]string{
+		"/projects/github.com%2Fexample%2Frepo:packageversions": `{"versions": [
+			{"versionKey": {"system": "NPM", "name": "repo", "version": "1.0.0"}},
+			{"versionKey": {"system": "PYPI", "name": "repo", "version": "1.0.0"}}
+		]}`,
+		"/systems/NPM/packages/repo": `{"versions": [
+			{"versionKey": {"version": "0.1.0"}, "publishedAt": "2015-01-01T00:00:00Z"},
+			{"versionKey": {"version": "1.0.0"}, "publishedAt": "` + recent + `"}
+		]}`,
+		"/systems/PYPI/packages/repo": `{"versions": [
+			{"versionKey": {"version": "1.0.0"}, "publishedAt": "` + recent + `"},
+			{"versionKey": {"version": "1.0.1"}}
+		]}`,
+	}))
+	s := collectortest.Collect[*registrySet](t, c, testRepoURL)
+	if got := s.PackageCount.Get(); got != 2 {
+		t.Fatalf("PackageCount == %d, want 2", got)
+	}
+	if got := s.ReleasesLastYear.Get(); got != 2 {
+		t.Fatalf("ReleasesLastYear == %d, want 2", got)
+	}
+	if got := s.DaysSinceLastRelease.Get(); got != 10 {
+		t.Fatalf("DaysSinceLastRelease == %d, want 10", got)
+	}
+}
+
+func TestCollect_UnknownProject(t *testing.T) {
+	c := newTestCollector(t, http.NotFoundHandler())
+	s := collectortest.Collect[*registrySet](t, c, testRepoURL)
+	if s.PackageCount.IsSet() || s.ReleasesLastYear.IsSet() || s.DaysSinceLastRelease.IsSet() {
+		t.Fatal("signals are set, want unset")
+	}
+}
+
+func TestSummarize_UnknownPublishTimes(t *testing.T) {
+	s := &registrySet{}
+	summarize(s, []depsdevapi.Version{{VersionKey: depsdevapi.VersionKey{Version: "1.0.0"}}}, time.Now())
+	if got := s.ReleasesLastYear.Get(); got != 0 {
+		t.Fatalf("ReleasesLastYear == %d, want 0", got)
+	}
+	if s.DaysSinceLastRelease.IsSet() {
+		t.Fatal("DaysSinceLastRelease is set, want unset")
+	}
+}
@@ -0,0 +1,34 @@
+package main
+
+// reorderer passes values to emit in the order of their index, buffering any
+// values that are added before the values that precede them.
+//
+// Indexes must start from 0 and must not be repeated. A reorderer is not safe
+// for concurrent use.
+type reorderer[T any] struct {
+	next    int
+	pending map[int]T
+	emit    func(T)
+}
+
+func newReorderer[T any](emit func(T)) *reorderer[T] {
+	return &reorderer[T]{
+		pending: make(map[int]T),
+		emit:    emit,
+	}
+}
+
+// Add adds the value v at index i, and emits it along with any buffered values
+// that follow it if all the values before i have been emitted.
+func (r *reorderer[T]) Add(i int, v T) {
+	r.pending[i] = v
+	for {
+		v, ok := r.pending[r.next]
+		if !ok {
+			return
+		}
+		delete(r.pending, r.next)
+		r.next++
+		r.emit(v)
+	}
+}
@@ -0,0 +1,24 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestReorderer(t *testing.T) {
+	var got []string
+	r := newReorderer(func(v string) {
+		got = append(got, v)
+	})
+	r.Add(2, "c")
+	r.Add(1, "b")
+	if len(got) != 0 {
+		t.Fatalf("emitted %v, want nothing", got)
+	}
+	r.Add(0, "a")
+	r.Add(4, "e")
+	r.Add(3, "d")
+	if want := []string{"a", "b", "c", "d", "e"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("emitted %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,107 @@
+package repology
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/ossf/criticality_score/internal/httpjson"
+)
+
+const (
+	// DefaultAPIURL is the base URL of the Repology API.
+	DefaultAPIURL = "https://repology.org/api/v1"
+
+	// requestInterval is the minimum time between requests to Repology, as
+	// required by its API policy.
+	requestInterval = time.Second
+
+	// userAgent identifies requests to Repology, as required by its API
+	// policy.
+	userAgent = "criticality_score (https://github.com/ossf/criticality_score)"
+)
+
+// Package is a single package of a project in a repository tracked by
+// Repology.
+type Package struct {
+	// Repo is the name of the repository, such as "debian_unstable".
+	Repo string `json:"repo"`
+
+	// SrcName is the name of the source package, if the repository has
+	// source packages.
+	SrcName string `json:"srcname"`
+
+	// BinName is the name of the binary package, if the repository has
+	// binary packages.
+	BinName string `json:"binname"`
+}
+
+// Client is used to query the Repology API. Requests are sent at most once
+// per second, as required by Repology. It is safe for concurrent use.
+type Client struct {
+	client  *http.Client
+	baseURL string
+
+	mu       sync.Mutex
+	interval time.Duration
+	next     time.Time
+}
+
+// NewClient returns a new Client that uses the http.Client c to send
+// requests.
+func NewClient(c *http.Client) *Client {
+	return &Client{
+		client:   c,
+		baseURL:  DefaultAPIURL,
+		interval: requestInterval,
+	}
+}
+
+// Project returns the packages of the project name in every repository.
+//
+// If Repology does not know about the project, nil is returned.
+func (c *Client) Project(ctx context.Context, name string) ([]Package, error) {
+	if err := c.wait(ctx); err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+"/project/"+url.PathEscape(name), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	req.Header.Set("User-Agent", userAgent)
+	var pkgs []Package
+	_, err = httpjson.Do(c.client, req, &pkgs)
+	if httpjson.StatusCode(err) == http.StatusNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return pkgs, nil
+}
+
+// wait blocks until the next request can be sent, or ctx is done.
+func (c *Client) wait(ctx context.Context) error {
+	c.mu.Lock()
+	now := time.Now()
+	wait := c.next.Sub(now)
+	if wait < 0 {
+		wait = 0
+	}
+	c.next = now.Add(wait + c.interval)
+	c.mu.Unlock()
+	if wait == 0 {
+		return nil
+	}
+	t := time.NewTimer(wait)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,82 @@
+// Package repology provides a Collector that returns a Set for the number of
+// distribution repositories a project is packaged in, as reported by
+// Repology.
+//
+// Repology does not support looking up projects by repository, so the
+// project with the same name as the repository is used. Projects with a
+// common name may include packages of unrelated software.
+package repology
+
+import (
+	"context"
+	"path"
+	"strings"
+
+	"github.com/ossf/criticality_score/cmd/collect_signals/projectrepo"
+	"github.com/ossf/criticality_score/cmd/collect_signals/signal"
+	log "github.com/sirupsen/logrus"
+)
+
+type repologySet struct {
+	// RepositoryCount is the number of distribution repositories with a
+	// package of the project.
+	RepositoryCount signal.Field[int]
+}
+
+func (s *repologySet) Namespace() signal.Namespace {
+	return signal.Namespace("repology")
+}
+
+type Collector struct {
+	client *Client
+	logger *log.Logger
+}
+
+// NewCollector returns a new Collector that uses the Client c to query
+// Repology.
+func NewCollector(c *Client, logger *log.Logger) *Collector {
+	return &Collector{
+		client: c,
+		logger: logger,
+	}
+}
+
+func (c *Collector) EmptySet() signal.Set {
+	return &repologySet{}
+}
+
+func (c *Collector) IsSupported(r projectrepo.Repo) bool {
+	return true
+}
+
+// Collect implements the collector.Collector interface.
+//
+// If Repology does not know about the project, the repository count is left
+// unset.
+func (c *Collector) Collect(ctx context.Context, r projectrepo.Repo) (signal.Set, error) {
+	s := &repologySet{}
+	name := ProjectName(r.URL().Path)
+	c.logger.WithFields(log.Fields{
+		"url":     r.URL().String(),
+		"project": name,
+	}).Debug("Fetching Repology project")
+	pkgs, err := c.client.Project(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if len(pkgs) == 0 {
+		return s, nil
+	}
+	repos := make(map[string]struct{})
+	for _, p := range pkgs {
+		repos[p.Repo] = struct{}{}
+	}
+	s.RepositoryCount.Set(len(repos))
+	return s, nil
+}
+
+// ProjectName returns the name of the Repology project for a repository with
+// the URL path p. Repology project names are lowercase.
+func ProjectName(p string) string {
+	return strings.ToLower(path.Base(strings.TrimSuffix(strings.Trim(p, "/"), ".git")))
+}
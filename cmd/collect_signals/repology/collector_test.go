@@ -0,0 +1,86 @@
+package repology
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/ossf/criticality_score/internal/collectortest"
+)
+
+// newTestClient returns a Client where all the requests are handled by h and
+// are not rate limited.
+func newTestClient(t *testing.T, h http.Handler) *Client {
+	t.Helper()
+	s := collectortest.NewServer(t, h)
+	c := NewClient(&http.Client{})
+	c.baseURL = s.URL
+	c.interval = 0
+	return c
+}
+
+func collect(t *testing.T, c *Client) *repologySet {
+	t.Helper()
+	s, err := NewCollector(c, collectortest.Logger()).Collect(context.Background(), collectortest.NewRepo(t, "https://github.com/curl/Curl.git"))
+	if err != nil {
+		t.Fatalf("Collect() errored %v, want no error", err)
+	}
+	return s.(*repologySet)
+}
+
+func TestCollect(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/project/curl" || r.Header.Get("User-Agent") == "" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[
+			{"repo": "debian_unstable", "srcname": "curl", "binname": "curl"},
+			{"repo": "debian_unstable", "srcname": "curl", "binname": "libcurl4"},
+			{"repo": "fedora_rawhide", "srcname": "curl"},
+			{"repo": "homebrew", "binname": "curl"}
+		]`))
+	}))
+	s := collect(t, c)
+	if got := s.RepositoryCount.Get(); got != 3 {
+		t.Fatalf("RepositoryCount == %d, want 3", got)
+	}
+}
+
+func TestCollect_UnknownProject(t *testing.T) {
+	c := newTestClient(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[]`))
+	}))
+	s := collect(t, c)
+	if s.RepositoryCount.IsSet() {
+		t.Fatal("RepositoryCount is set, want unset")
+	}
+}
+
+func TestClientWait(t *testing.T) {
+	c := NewClient(&http.Client{})
+	c.interval = 50 * time.Millisecond
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := c.wait(context.Background()); err != nil {
+			t.Fatalf("wait() errored %v, want no error", err)
+		}
+	}
+	if d := time.Since(start); d < 100*time.Millisecond {
+		t.Fatalf("3 requests took %v, want at least %v", d, 100*time.Millisecond)
+	}
+}
+
+func TestClientWait_Canceled(t *testing.T) {
+	c := NewClient(&http.Client{})
+	c.interval = time.Hour
+	c.wait(context.Background())
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := c.wait(ctx); err != context.Canceled {
+		t.Fatalf("wait() errored %v, want %v", err, context.Canceled)
+	}
+}
@@ -0,0 +1,100 @@
+package bq
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+	"github.com/ossf/criticality_score/cmd/collect_signals/signal"
+)
+
+// validColumn matches the names BigQuery accepts for a column.
+var validColumn = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// fieldType returns the BigQuery type used to store values of the type t.
+func fieldType(t reflect.Type) (bigquery.FieldType, error) {
+	if t == timeType {
+		return bigquery.TimestampFieldType, nil
+	}
+	switch t.Kind() {
+	case reflect.String:
+		return bigquery.StringFieldType, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return bigquery.IntegerFieldType, nil
+	case reflect.Float32, reflect.Float64:
+		return bigquery.FloatFieldType, nil
+	case reflect.Bool:
+		return bigquery.BooleanFieldType, nil
+	default:
+		return "", fmt.Errorf("unsupported field type: %s", t)
+	}
+}
+
+// schemaFromSignalSets returns the schema of a table for the fields in sets,
+// followed by the extra columns.
+//
+// Each namespace is stored as a RECORD column containing its fields, so a
+// signal has the same name in a query as it does in the CSV header, such as
+// "repo.star_count". Namespaces and fields are sorted so that the schema is
+// identical for the same sets. The extras are STRING columns, and keep the
+// order they are provided in.
+func schemaFromSignalSets(sets []signal.Set, extras []string) (bigquery.Schema, error) {
+	namespaces := make(map[string]map[string]bigquery.FieldType)
+	for _, s := range sets {
+		if err := signal.ValidateSet(s); err != nil {
+			return nil, err
+		}
+		names := signal.SetFields(s, true)
+		types := signal.SetTypes(s)
+		for i, name := range names {
+			ns, field, _ := strings.Cut(name, ".")
+			ft, err := fieldType(types[i])
+			if err != nil {
+				return nil, fmt.Errorf("field %s: %w", name, err)
+			}
+			if namespaces[ns] == nil {
+				namespaces[ns] = make(map[string]bigquery.FieldType)
+			}
+			namespaces[ns][field] = ft
+		}
+	}
+
+	var schema bigquery.Schema
+	for _, ns := range sortedKeys(namespaces) {
+		var fields bigquery.Schema
+		for _, name := range sortedKeys(namespaces[ns]) {
+			fields = append(fields, &bigquery.FieldSchema{Name: name, Type: namespaces[ns][name]})
+		}
+		schema = append(schema, &bigquery.FieldSchema{
+			Name:   ns,
+			Type:   bigquery.RecordFieldType,
+			Schema: fields,
+		})
+	}
+	for _, e := range extras {
+		if !validColumn.MatchString(e) {
+			return nil, fmt.Errorf("extra column %q is not a valid BigQuery column name", e)
+		}
+		if _, exists := namespaces[e]; exists {
+			return nil, fmt.Errorf("extra column %q has the same name as a namespace", e)
+		}
+		schema = append(schema, &bigquery.FieldSchema{Name: e, Type: bigquery.StringFieldType})
+	}
+	return schema, nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
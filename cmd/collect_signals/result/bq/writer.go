@@ -0,0 +1,358 @@
+// Package bq provides a result.Writer that streams records directly into a
+// BigQuery table using the BigQuery Storage Write API.
+//
+// This avoids writing a CSV file and loading it into BigQuery as a separate
+// step.
+package bq
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+	"cloud.google.com/go/bigquery/storage/managedwriter"
+	"cloud.google.com/go/bigquery/storage/managedwriter/adapt"
+	"github.com/ossf/criticality_score/cmd/collect_signals/result"
+	"github.com/ossf/criticality_score/cmd/collect_signals/signal"
+	"google.golang.org/api/googleapi"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+// maxPendingAppends is the number of appends that are waited for at once. Once
+// this many appends are pending, the Writer waits for them to finish before
+// appending more, so the results do not accumulate over a long run.
+const maxPendingAppends = 1000
+
+// rowAppender appends encoded rows to a table.
+//
+// It allows the Writer to be tested without BigQuery.
+type rowAppender interface {
+	appendRows(ctx context.Context, rows [][]byte) (appendResult, error)
+}
+
+// appendResult waits for the outcome of appending rows.
+type appendResult interface {
+	GetResult(ctx context.Context) (int64, error)
+}
+
+// managedStream is a rowAppender that appends rows to a managed stream.
+type managedStream struct {
+	*managedwriter.ManagedStream
+}
+
+func (s managedStream) appendRows(ctx context.Context, rows [][]byte) (appendResult, error) {
+	return s.AppendRows(ctx, rows)
+}
+
+// Writer streams records into a BigQuery table.
+//
+// Each record is appended to the table's default stream as soon as it is
+// done, so records are visible in the table straight away. Appends happen in
+// the background, and Flush waits for them to finish. Every
+// maxPendingAppends records, the Writer also waits for the pending appends
+// to finish.
+type Writer struct {
+	ctx    context.Context
+	md     protoreflect.MessageDescriptor
+	extras map[string]bool
+	stream rowAppender
+	closer func() error
+
+	// Prevents concurrent access to pending.
+	mu      sync.Mutex
+	pending []appendResult
+}
+
+// ParseTable splits table, in the form "project.dataset.table" or
+// "dataset.table", into its parts.
+//
+// If the project is omitted, defaultProject is returned as the project.
+func ParseTable(table, defaultProject string) (project, dataset, name string, err error) {
+	parts := strings.Split(table, ".")
+	switch len(parts) {
+	case 2:
+		project, dataset, name = defaultProject, parts[0], parts[1]
+	case 3:
+		project, dataset, name = parts[0], parts[1], parts[2]
+	default:
+		return "", "", "", fmt.Errorf("invalid table %q: must be in the form project.dataset.table", table)
+	}
+	if project == "" || dataset == "" || name == "" {
+		return "", "", "", fmt.Errorf("invalid table %q: must be in the form project.dataset.table", table)
+	}
+	return project, dataset, name, nil
+}
+
+// NewWriter returns a Writer that streams records into table, in the form
+// "project.dataset.table". If the project is omitted, projectID is used. If
+// projectID is also empty, the project is detected from the environment.
+//
+// The schema of the table is derived from emptySets and extras. If the table
+// does not exist it is created. An existing table must have a compatible
+// schema, such as one created by a previous run with the same flags.
+func NewWriter(ctx context.Context, projectID, table string, emptySets []signal.Set, extras ...string) (*Writer, error) {
+	schema, err := schemaFromSignalSets(emptySets, extras)
+	if err != nil {
+		return nil, err
+	}
+	md, err := messageDescriptor(schema)
+	if err != nil {
+		return nil, err
+	}
+	dp, err := adapt.NormalizeDescriptor(md)
+	if err != nil {
+		return nil, err
+	}
+
+	if projectID == "" {
+		projectID = bigquery.DetectProjectID
+	}
+	project, dataset, name, err := ParseTable(table, projectID)
+	if err != nil {
+		return nil, err
+	}
+	bqClient, err := bigquery.NewClient(ctx, project)
+	if err != nil {
+		return nil, err
+	}
+	defer bqClient.Close()
+	// Resolve the project if it was detected from the environment.
+	project = bqClient.Project()
+	if err := ensureTable(ctx, bqClient.Dataset(dataset).Table(name), schema); err != nil {
+		return nil, err
+	}
+
+	mwClient, err := managedwriter.NewClient(ctx, project)
+	if err != nil {
+		return nil, err
+	}
+	stream, err := mwClient.NewManagedStream(ctx,
+		managedwriter.WithDestinationTable(managedwriter.TableParentFromParts(project, dataset, name)),
+		managedwriter.WithType(managedwriter.DefaultStream),
+		managedwriter.WithSchemaDescriptor(dp))
+	if err != nil {
+		mwClient.Close()
+		return nil, err
+	}
+	w := newWriter(ctx, md, managedStream{stream}, extras)
+	w.closer = func() error {
+		err := stream.Close()
+		if cerr := mwClient.Close(); err == nil {
+			err = cerr
+		}
+		return err
+	}
+	return w, nil
+}
+
+func newWriter(ctx context.Context, md protoreflect.MessageDescriptor, stream rowAppender, extras []string) *Writer {
+	m := make(map[string]bool)
+	for _, e := range extras {
+		m[e] = true
+	}
+	return &Writer{
+		ctx:    ctx,
+		md:     md,
+		extras: m,
+		stream: stream,
+	}
+}
+
+// messageDescriptor returns the descriptor of the protocol buffer message used
+// to encode a row of a table with schema.
+func messageDescriptor(schema bigquery.Schema) (protoreflect.MessageDescriptor, error) {
+	ts, err := adapt.BQSchemaToStorageTableSchema(schema)
+	if err != nil {
+		return nil, err
+	}
+	d, err := adapt.StorageSchemaToProto2Descriptor(ts, "root")
+	if err != nil {
+		return nil, err
+	}
+	md, ok := d.(protoreflect.MessageDescriptor)
+	if !ok {
+		return nil, errors.New("schema did not convert to a message descriptor")
+	}
+	return md, nil
+}
+
+// ensureTable creates the table t with schema if it does not exist.
+func ensureTable(ctx context.Context, t *bigquery.Table, schema bigquery.Schema) error {
+	_, err := t.Metadata(ctx)
+	if err == nil {
+		return nil
+	}
+	var apiErr *googleapi.Error
+	if !errors.As(err, &apiErr) || apiErr.Code != 404 {
+		return err
+	}
+	return t.Create(ctx, &bigquery.TableMetadata{Schema: schema})
+}
+
+func (w *Writer) Record() result.RecordWriter {
+	return &record{
+		values: make(map[string]any),
+		extras: make(map[string]string),
+		sink:   w,
+	}
+}
+
+// Flush implements the result.Writer interface.
+//
+// Flush waits for every record appended so far to be written to the table,
+// returning the first error.
+func (w *Writer) Flush() error {
+	w.mu.Lock()
+	pending := w.pending
+	w.pending = nil
+	w.mu.Unlock()
+	return w.wait(pending)
+}
+
+// wait waits for each of pending to finish, returning the first error.
+func (w *Writer) wait(pending []appendResult) error {
+	var first error
+	for _, r := range pending {
+		if _, err := r.GetResult(w.ctx); err != nil && first == nil {
+			first = err
+		}
+	}
+	return first
+}
+
+// Close closes the connection to BigQuery. Flush should be called first to
+// ensure all the records have been written.
+func (w *Writer) Close() error {
+	if w.closer == nil {
+		return nil
+	}
+	return w.closer()
+}
+
+func (w *Writer) appendRecord(r *record) error {
+	row, err := encodeRow(w.md, r.values, r.extras)
+	if err != nil {
+		return err
+	}
+	res, err := w.stream.appendRows(w.ctx, [][]byte{row})
+	if err != nil {
+		return err
+	}
+	w.mu.Lock()
+	w.pending = append(w.pending, res)
+	var pending []appendResult
+	if len(w.pending) >= maxPendingAppends {
+		pending = w.pending
+		w.pending = nil
+	}
+	w.mu.Unlock()
+	return w.wait(pending)
+}
+
+type record struct {
+	values map[string]any
+	extras map[string]string
+	sink   *Writer
+}
+
+func (r *record) WriteSignalSet(s signal.Set) error {
+	for k, v := range signal.SetAsMap(s, true) {
+		if v == nil {
+			continue
+		}
+		r.values[k] = v
+	}
+	return nil
+}
+
+func (r *record) WriteExtra(name, value string) error {
+	if !r.sink.extras[name] {
+		return fmt.Errorf("%w: %s", result.UnknownExtraError, name)
+	}
+	r.extras[name] = value
+	return nil
+}
+
+func (r *record) Done() error {
+	return r.sink.appendRecord(r)
+}
+
+// encodeRow encodes the signal values and the extras as a row matching the
+// message descriptor md.
+//
+// Signals are set in the RECORD column for their namespace. Signals that are
+// unset are left NULL.
+func encodeRow(md protoreflect.MessageDescriptor, values map[string]any, extras map[string]string) ([]byte, error) {
+	msg := dynamicpb.NewMessage(md)
+	for name, v := range values {
+		ns, field, _ := strings.Cut(name, ".")
+		nsFd := md.Fields().ByName(protoreflect.Name(strings.ToLower(ns)))
+		if nsFd == nil || nsFd.Message() == nil {
+			return nil, fmt.Errorf("failed to write field %s: unknown namespace", name)
+		}
+		fd := nsFd.Message().Fields().ByName(protoreflect.Name(strings.ToLower(field)))
+		if fd == nil {
+			return nil, fmt.Errorf("failed to write field %s: unknown field", name)
+		}
+		pv, err := protoValue(v)
+		if err != nil {
+			return nil, fmt.Errorf("failed to write field %s: %w", name, err)
+		}
+		msg.Mutable(nsFd).Message().Set(fd, pv)
+	}
+	for name, v := range extras {
+		fd := md.Fields().ByName(protoreflect.Name(strings.ToLower(name)))
+		if fd == nil {
+			return nil, fmt.Errorf("%w: %s", result.UnknownExtraError, name)
+		}
+		msg.Set(fd, protoreflect.ValueOfString(v))
+	}
+	return proto.Marshal(msg)
+}
+
+// protoValue converts the value of a signal into the value used to encode it.
+//
+// Timestamps are encoded as microseconds since the epoch, as expected by the
+// Storage Write API.
+func protoValue(value any) (protoreflect.Value, error) {
+	switch v := value.(type) {
+	case string:
+		return protoreflect.ValueOfString(v), nil
+	case int:
+		return protoreflect.ValueOfInt64(int64(v)), nil
+	case int8:
+		return protoreflect.ValueOfInt64(int64(v)), nil
+	case int16:
+		return protoreflect.ValueOfInt64(int64(v)), nil
+	case int32:
+		return protoreflect.ValueOfInt64(int64(v)), nil
+	case int64:
+		return protoreflect.ValueOfInt64(v), nil
+	case uint:
+		return protoreflect.ValueOfInt64(int64(v)), nil
+	case uint8:
+		return protoreflect.ValueOfInt64(int64(v)), nil
+	case uint16:
+		return protoreflect.ValueOfInt64(int64(v)), nil
+	case uint32:
+		return protoreflect.ValueOfInt64(int64(v)), nil
+	case uint64:
+		return protoreflect.ValueOfInt64(int64(v)), nil
+	case float32:
+		return protoreflect.ValueOfFloat64(float64(v)), nil
+	case float64:
+		return protoreflect.ValueOfFloat64(v), nil
+	case bool:
+		return protoreflect.ValueOfBool(v), nil
+	case time.Time:
+		return protoreflect.ValueOfInt64(v.UnixMicro()), nil
+	default:
+		return protoreflect.Value{}, fmt.Errorf("%w: %T", result.MarshalError, value)
+	}
+}
@@ -0,0 +1,247 @@
+package bq
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"cloud.google.com/go/bigquery"
+	"github.com/ossf/criticality_score/cmd/collect_signals/result"
+	"github.com/ossf/criticality_score/cmd/collect_signals/signal"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/types/dynamicpb"
+)
+
+type testSet struct {
+	Name      signal.Field[string]
+	Count     signal.Field[int]
+	Ratio     signal.Field[float64]
+	Archived  signal.Field[bool]
+	CreatedAt signal.Field[time.Time]
+}
+
+func (s *testSet) Namespace() signal.Namespace {
+	return signal.Namespace("test")
+}
+
+type otherSet struct {
+	Stars signal.Field[int] `signal:"star_count"`
+}
+
+func (s *otherSet) Namespace() signal.Namespace {
+	return signal.Namespace("other")
+}
+
+type fakeResult struct {
+	err error
+}
+
+func (r fakeResult) GetResult(ctx context.Context) (int64, error) {
+	return 0, r.err
+}
+
+type fakeAppender struct {
+	rows [][]byte
+	err  error
+}
+
+func (a *fakeAppender) appendRows(ctx context.Context, rows [][]byte) (appendResult, error) {
+	a.rows = append(a.rows, rows...)
+	return fakeResult{err: a.err}, nil
+}
+
+func TestSchemaFromSignalSets(t *testing.T) {
+	schema, err := schemaFromSignalSets([]signal.Set{&testSet{}, &otherSet{}}, []string{"team"})
+	if err != nil {
+		t.Fatalf("schemaFromSignalSets() errored %v, want no error", err)
+	}
+	var names []string
+	for _, f := range schema {
+		names = append(names, f.Name)
+	}
+	wantNames := []string{"other", "test", "team"}
+	if len(names) != len(wantNames) {
+		t.Fatalf("columns == %v, want %v", names, wantNames)
+	}
+	for i, n := range wantNames {
+		if names[i] != n {
+			t.Fatalf("columns == %v, want %v", names, wantNames)
+		}
+	}
+	if got := schema[0].Schema[0].Name; got != "star_count" {
+		t.Fatalf("other field == %q, want %q", got, "star_count")
+	}
+	want := map[string]bigquery.FieldType{
+		"archived":   bigquery.BooleanFieldType,
+		"count":      bigquery.IntegerFieldType,
+		"created_at": bigquery.TimestampFieldType,
+		"name":       bigquery.StringFieldType,
+		"ratio":      bigquery.FloatFieldType,
+	}
+	for _, f := range schema[1].Schema {
+		if f.Type != want[f.Name] {
+			t.Fatalf("test.%s type == %v, want %v", f.Name, f.Type, want[f.Name])
+		}
+	}
+	if got := schema[2].Type; got != bigquery.StringFieldType {
+		t.Fatalf("team type == %v, want %v", got, bigquery.StringFieldType)
+	}
+}
+
+func TestSchemaFromSignalSets_InvalidExtra(t *testing.T) {
+	for _, extra := range []string{"not-valid", "test"} {
+		if _, err := schemaFromSignalSets([]signal.Set{&testSet{}}, []string{extra}); err == nil {
+			t.Fatalf("schemaFromSignalSets(%q) returned no error, want an error", extra)
+		}
+	}
+}
+
+func TestParseTable(t *testing.T) {
+	tests := []struct {
+		table                  string
+		project, dataset, name string
+		wantErr                bool
+	}{
+		{table: "p.d.t", project: "p", dataset: "d", name: "t"},
+		{table: "d.t", project: "default", dataset: "d", name: "t"},
+		{table: "t", wantErr: true},
+		{table: "a.b.c.d", wantErr: true},
+		{table: "p..t", wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.table, func(t *testing.T) {
+			project, dataset, name, err := ParseTable(test.table, "default")
+			if test.wantErr {
+				if err == nil {
+					t.Fatalf("ParseTable() returned no error, want an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseTable() errored %v, want no error", err)
+			}
+			if project != test.project || dataset != test.dataset || name != test.name {
+				t.Fatalf("ParseTable() == %q, %q, %q, want %q, %q, %q", project, dataset, name, test.project, test.dataset, test.name)
+			}
+		})
+	}
+}
+
+func newTestWriter(t *testing.T, a rowAppender, extras ...string) *Writer {
+	t.Helper()
+	schema, err := schemaFromSignalSets([]signal.Set{&testSet{}, &otherSet{}}, extras)
+	if err != nil {
+		t.Fatalf("schemaFromSignalSets() errored %v, want no error", err)
+	}
+	md, err := messageDescriptor(schema)
+	if err != nil {
+		t.Fatalf("messageDescriptor() errored %v, want no error", err)
+	}
+	return newWriter(context.Background(), md, a, extras)
+}
+
+func TestWriter(t *testing.T) {
+	a := &fakeAppender{}
+	w := newTestWriter(t, a, "team")
+
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 6000, time.UTC)
+	rec := w.Record()
+	if err := rec.WriteSignalSet(&testSet{
+		Name:      signal.Val("example"),
+		Count:     signal.Val(-3),
+		Ratio:     signal.Val(0.25),
+		CreatedAt: signal.Val(createdAt),
+	}); err != nil {
+		t.Fatalf("WriteSignalSet() errored %v, want no error", err)
+	}
+	if err := rec.WriteSignalSet(&otherSet{}); err != nil {
+		t.Fatalf("WriteSignalSet() errored %v, want no error", err)
+	}
+	if err := rec.WriteExtra("team", "infra"); err != nil {
+		t.Fatalf("WriteExtra() errored %v, want no error", err)
+	}
+	if err := rec.Done(); err != nil {
+		t.Fatalf("Done() errored %v, want no error", err)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush() errored %v, want no error", err)
+	}
+
+	if len(a.rows) != 1 {
+		t.Fatalf("len(rows) == %d, want 1", len(a.rows))
+	}
+	msg := dynamicpb.NewMessage(w.md)
+	if err := proto.Unmarshal(a.rows[0], msg); err != nil {
+		t.Fatalf("Unmarshal() errored %v, want no error", err)
+	}
+	get := func(m protoreflect.Message, name string) (protoreflect.Value, bool) {
+		fd := m.Descriptor().Fields().ByName(protoreflect.Name(name))
+		return m.Get(fd), m.Has(fd)
+	}
+	ns, _ := get(msg, "test")
+	test := ns.Message()
+	if v, _ := get(test, "name"); v.String() != "example" {
+		t.Fatalf("test.name == %q, want %q", v.String(), "example")
+	}
+	if v, _ := get(test, "count"); v.Int() != -3 {
+		t.Fatalf("test.count == %d, want -3", v.Int())
+	}
+	if v, _ := get(test, "ratio"); v.Float() != 0.25 {
+		t.Fatalf("test.ratio == %v, want 0.25", v.Float())
+	}
+	if v, _ := get(test, "created_at"); v.Int() != createdAt.UnixMicro() {
+		t.Fatalf("test.created_at == %d, want %d", v.Int(), createdAt.UnixMicro())
+	}
+	if _, ok := get(test, "archived"); ok {
+		t.Fatalf("test.archived is set, want NULL")
+	}
+	if _, ok := get(msg, "other"); ok {
+		t.Fatalf("other is set, want NULL")
+	}
+	if v, _ := get(msg, "team"); v.String() != "infra" {
+		t.Fatalf("team == %q, want %q", v.String(), "infra")
+	}
+}
+
+func TestWriter_FlushError(t *testing.T) {
+	wantErr := errors.New("append failed")
+	w := newTestWriter(t, &fakeAppender{err: wantErr})
+	if err := w.Record().Done(); err != nil {
+		t.Fatalf("Done() errored %v, want no error", err)
+	}
+	if err := w.Flush(); !errors.Is(err, wantErr) {
+		t.Fatalf("Flush() errored %v, want %v", err, wantErr)
+	}
+}
+
+func TestWriter_DrainsPendingAppends(t *testing.T) {
+	wantErr := errors.New("append failed")
+	a := &fakeAppender{}
+	w := newTestWriter(t, a)
+	for i := 0; i < maxPendingAppends-1; i++ {
+		if err := w.Record().Done(); err != nil {
+			t.Fatalf("Done() errored %v, want no error", err)
+		}
+	}
+	if got := len(w.pending); got != maxPendingAppends-1 {
+		t.Fatalf("len(pending) == %d, want %d", got, maxPendingAppends-1)
+	}
+	// The append that reaches the limit waits for all the pending appends,
+	// and returns their first error.
+	a.err = wantErr
+	if err := w.Record().Done(); !errors.Is(err, wantErr) {
+		t.Fatalf("Done() errored %v, want %v", err, wantErr)
+	}
+	if got := len(w.pending); got != 0 {
+		t.Fatalf("len(pending) == %d, want 0", got)
+	}
+}
+
+func TestWriter_UnknownExtra(t *testing.T) {
+	w := newTestWriter(t, &fakeAppender{})
+	if err := w.Record().WriteExtra("missing", "value"); !errors.Is(err, result.UnknownExtraError) {
+		t.Fatalf("WriteExtra() errored %v, want %v", err, result.UnknownExtraError)
+	}
+}
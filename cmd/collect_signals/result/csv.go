@@ -4,22 +4,45 @@ import (
 	"encoding/csv"
 	"fmt"
 	"io"
+	"sort"
+	"strings"
 	"sync"
 	"time"
 
 	"github.com/ossf/criticality_score/cmd/collect_signals/signal"
 )
 
+// CsvOptions controls the format of the CSV output.
+type CsvOptions struct {
+	// Delimiter separates each field. If zero, a comma is used.
+	Delimiter rune
+
+	// AlwaysQuote quotes every field, rather than only those that need it.
+	AlwaysQuote bool
+}
+
 type csvWriter struct {
 	header        []string
+	extras        map[string]bool
 	w             *csv.Writer
 	headerWritten bool
 
+	// raw and quote are used to write fields when every field is quoted, as
+	// csv.Writer only quotes fields when needed.
+	raw   io.Writer
+	quote bool
+
 	// Prevents concurrent writes to w, and headerWritten.
 	mu sync.Mutex
 }
 
-func headerFromSignalSets(sets []signal.Set) []string {
+// headerFromSignalSets returns the columns for the fields in sets, followed by
+// the extra columns.
+//
+// The signal columns are sorted so that the header is identical for the same
+// sets, regardless of the order the sets are registered in. The extras keep
+// the order they are provided in.
+func headerFromSignalSets(sets []signal.Set, extras []string) []string {
 	var hs []string
 	for _, s := range sets {
 		if err := signal.ValidateSet(s); err != nil {
@@ -27,14 +50,61 @@ func headerFromSignalSets(sets []signal.Set) []string {
 		}
 		hs = append(hs, signal.SetFields(s, true)...)
 	}
-	return hs
+	sort.Strings(hs)
+	return append(hs, extras...)
+}
+
+func extraSet(extras []string) map[string]bool {
+	m := make(map[string]bool)
+	for _, e := range extras {
+		m[e] = true
+	}
+	return m
 }
 
-func NewCsvWriter(w io.Writer, emptySets []signal.Set) Writer {
+// NewCsvWriter returns a Writer that outputs records as CSV.
+//
+// The header contains a column for each field in emptySets, followed by each
+// of the extras. Extras that are not written for a record are left empty.
+func NewCsvWriter(w io.Writer, emptySets []signal.Set, extras ...string) Writer {
+	return NewCsvWriterWithOptions(w, emptySets, CsvOptions{}, extras...)
+}
+
+// NewCsvWriterWithOptions returns a Writer that outputs records as CSV, using
+// opts to control the format.
+//
+// See NewCsvWriter.
+func NewCsvWriterWithOptions(w io.Writer, emptySets []signal.Set, opts CsvOptions, extras ...string) Writer {
+	cw := csv.NewWriter(w)
+	if opts.Delimiter != 0 {
+		cw.Comma = opts.Delimiter
+	}
 	return &csvWriter{
-		header: headerFromSignalSets(emptySets),
-		w:      csv.NewWriter(w),
+		header: headerFromSignalSets(emptySets, extras),
+		extras: extraSet(extras),
+		w:      cw,
+		raw:    w,
+		quote:  opts.AlwaysQuote,
+	}
+}
+
+// write outputs a single row of fields. It must be called while holding mu.
+func (s *csvWriter) write(fields []string) error {
+	if !s.quote {
+		return s.w.Write(fields)
+	}
+	var b strings.Builder
+	for i, f := range fields {
+		if i > 0 {
+			b.WriteRune(s.w.Comma)
+		}
+		b.WriteByte('"')
+		b.WriteString(strings.ReplaceAll(f, `"`, `""`))
+		b.WriteByte('"')
 	}
+	b.WriteByte('\n')
+	_, err := io.WriteString(s.raw, b.String())
+	return err
 }
 
 func (w *csvWriter) Record() RecordWriter {
@@ -58,7 +128,7 @@ func (s *csvWriter) maybeWriteHeader() error {
 		return nil
 	}
 	s.headerWritten = true
-	return s.w.Write(s.header)
+	return s.write(s.header)
 }
 
 func (s *csvWriter) writeRecord(c *csvRecord) error {
@@ -73,9 +143,22 @@ func (s *csvWriter) writeRecord(c *csvRecord) error {
 	// concurrent writes to w.
 	s.mu.Lock()
 	defer s.mu.Unlock()
-	if err := s.w.Write(rec); err != nil {
+	if err := s.write(rec); err != nil {
+		return err
+	}
+	s.w.Flush()
+	return s.w.Error()
+}
+
+// Flush implements the Writer interface.
+//
+// Records are not buffered, so this only ensures the header is written.
+func (s *csvWriter) Flush() error {
+	if err := s.maybeWriteHeader(); err != nil {
 		return err
 	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
 	s.w.Flush()
 	return s.w.Error()
 }
@@ -97,6 +180,14 @@ func (r *csvRecord) WriteSignalSet(s signal.Set) error {
 	return nil
 }
 
+func (r *csvRecord) WriteExtra(name, value string) error {
+	if !r.sink.extras[name] {
+		return fmt.Errorf("%w: %s", UnknownExtraError, name)
+	}
+	r.values[name] = value
+	return nil
+}
+
 func (r *csvRecord) Done() error {
 	return r.sink.writeRecord(r)
 }
@@ -0,0 +1,136 @@
+package result
+
+import (
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/ossf/criticality_score/cmd/collect_signals/signal"
+)
+
+func TestCsvWriterExtras(t *testing.T) {
+	var b bytes.Buffer
+	w := NewCsvWriter(&b, []signal.Set{&testSet{}}, "team", "ecosystem")
+
+	rec := w.Record()
+	if err := rec.WriteSignalSet(&testSet{Name: signal.Val("a"), Count: signal.Val(1)}); err != nil {
+		t.Fatalf("WriteSignalSet() errored %v, want no error", err)
+	}
+	if err := rec.WriteExtra("team", "infra"); err != nil {
+		t.Fatalf("WriteExtra() errored %v, want no error", err)
+	}
+	if err := rec.WriteExtra("ecosystem", "go"); err != nil {
+		t.Fatalf("WriteExtra() errored %v, want no error", err)
+	}
+	if err := rec.Done(); err != nil {
+		t.Fatalf("Done() errored %v, want no error", err)
+	}
+
+	// The second record has no extras, so the columns are left empty.
+	rec = w.Record()
+	if err := rec.WriteSignalSet(&testSet{Name: signal.Val("b")}); err != nil {
+		t.Fatalf("WriteSignalSet() errored %v, want no error", err)
+	}
+	if err := rec.Done(); err != nil {
+		t.Fatalf("Done() errored %v, want no error", err)
+	}
+
+	want := "" +
+		"test.count,test.name,team,ecosystem\n" +
+		"1,a,infra,go\n" +
+		",b,,\n"
+	if got := b.String(); got != want {
+		t.Fatalf("output == %q, want %q", got, want)
+	}
+}
+
+func TestCsvWriterExtras_Unknown(t *testing.T) {
+	w := NewCsvWriter(&bytes.Buffer{}, []signal.Set{&testSet{}}, "team")
+	err := w.Record().WriteExtra("owner", "someone")
+	if !errors.Is(err, UnknownExtraError) {
+		t.Fatalf("WriteExtra() errored %v, want %v", err, UnknownExtraError)
+	}
+}
+
+// orderedSet has fields declared out of lexical order.
+type orderedSet struct {
+	Zebra signal.Field[int]
+	Apple signal.Field[int]
+}
+
+func (s *orderedSet) Namespace() signal.Namespace {
+	return signal.Namespace("ordered")
+}
+
+func TestCsvWriterHeader_Sorted(t *testing.T) {
+	header := func(sets ...signal.Set) string {
+		var b bytes.Buffer
+		w := NewCsvWriter(&b, sets, "extra_b", "extra_a")
+		if err := w.Flush(); err != nil {
+			t.Fatalf("Flush() errored %v, want no error", err)
+		}
+		return b.String()
+	}
+	want := "ordered.apple,ordered.zebra,test.count,test.name,extra_b,extra_a\n"
+	if got := header(&testSet{}, &orderedSet{}); got != want {
+		t.Fatalf("header == %q, want %q", got, want)
+	}
+	if got := header(&orderedSet{}, &testSet{}); got != want {
+		t.Fatalf("header == %q, want %q", got, want)
+	}
+}
+
+func TestCsvWriterWithOptions_Delimiter(t *testing.T) {
+	var b bytes.Buffer
+	w := NewCsvWriterWithOptions(&b, []signal.Set{&testSet{}}, CsvOptions{Delimiter: '\t'})
+	rec := w.Record()
+	if err := rec.WriteSignalSet(&testSet{Name: signal.Val("a\tb \"c\""), Count: signal.Val(1)}); err != nil {
+		t.Fatalf("WriteSignalSet() errored %v, want no error", err)
+	}
+	if err := rec.Done(); err != nil {
+		t.Fatalf("Done() errored %v, want no error", err)
+	}
+	want := "" +
+		"test.count\ttest.name\n" +
+		"1\t\"a\tb \"\"c\"\"\"\n"
+	if got := b.String(); got != want {
+		t.Fatalf("output == %q, want %q", got, want)
+	}
+	assertCsvRoundTrip(t, b.String(), '\t', []string{"1", "a\tb \"c\""})
+}
+
+func TestCsvWriterWithOptions_AlwaysQuote(t *testing.T) {
+	var b bytes.Buffer
+	w := NewCsvWriterWithOptions(&b, []signal.Set{&testSet{}}, CsvOptions{Delimiter: ';', AlwaysQuote: true})
+	rec := w.Record()
+	if err := rec.WriteSignalSet(&testSet{Name: signal.Val("a;b \"c\"")}); err != nil {
+		t.Fatalf("WriteSignalSet() errored %v, want no error", err)
+	}
+	if err := rec.Done(); err != nil {
+		t.Fatalf("Done() errored %v, want no error", err)
+	}
+	want := "" +
+		"\"test.count\";\"test.name\"\n" +
+		"\"\";\"a;b \"\"c\"\"\"\n"
+	if got := b.String(); got != want {
+		t.Fatalf("output == %q, want %q", got, want)
+	}
+	assertCsvRoundTrip(t, b.String(), ';', []string{"", "a;b \"c\""})
+}
+
+// assertCsvRoundTrip checks that the second row of output is parsed as want.
+func assertCsvRoundTrip(t *testing.T, output string, delimiter rune, want []string) {
+	t.Helper()
+	r := csv.NewReader(strings.NewReader(output))
+	r.Comma = delimiter
+	rows, err := r.ReadAll()
+	if err != nil {
+		t.Fatalf("ReadAll() errored %v, want no error", err)
+	}
+	if len(rows) != 2 || !reflect.DeepEqual(rows[1], want) {
+		t.Fatalf("ReadAll() == %q, want second row %q", rows, want)
+	}
+}
@@ -7,13 +7,18 @@ import (
 )
 
 var (
-	MarshalError = errors.New("failed to marshal value")
+	MarshalError      = errors.New("failed to marshal value")
+	UnknownExtraError = errors.New("unknown extra column")
 )
 
 type RecordWriter interface {
 	// WriteSignalSet is used to output the value for a signal.Set for a record.
 	WriteSignalSet(signal.Set) error
 
+	// WriteExtra is used to output the value of an extra column for a record.
+	// The column must be one of the extras the Writer was created with.
+	WriteExtra(name, value string) error
+
 	// Done indicates that all the fields for the record have been written and
 	// record is complete.
 	Done() error
@@ -24,4 +29,7 @@ type Writer interface {
 
 	// Record returns a RecordWriter that can be used to write a new record.
 	Record() RecordWriter
+
+	// Flush ensures any buffered records have been written.
+	Flush() error
 }
@@ -0,0 +1,73 @@
+package result
+
+import (
+	"fmt"
+
+	"github.com/ossf/criticality_score/cmd/collect_signals/signal"
+)
+
+type multiWriter struct {
+	writers []Writer
+}
+
+// MultiWriter returns a Writer that duplicates each record to all the writers.
+//
+// Every call is passed to all of the writers, even if an earlier writer fails,
+// so that a failure in one writer does not leave a record half written in the
+// others. The first error is returned and identifies the position of the
+// writer that failed in the list of writers.
+func MultiWriter(writers ...Writer) Writer {
+	return &multiWriter{writers: append([]Writer(nil), writers...)}
+}
+
+func (w *multiWriter) Record() RecordWriter {
+	rs := make([]RecordWriter, len(w.writers))
+	for i, w := range w.writers {
+		rs[i] = w.Record()
+	}
+	return &multiRecord{records: rs}
+}
+
+// Flush implements the Writer interface.
+func (w *multiWriter) Flush() error {
+	var first error
+	for i, w := range w.writers {
+		if err := w.Flush(); err != nil && first == nil {
+			first = fmt.Errorf("writer %d: %w", i, err)
+		}
+	}
+	return first
+}
+
+type multiRecord struct {
+	records []RecordWriter
+}
+
+// each calls fn for every record, returning the first error.
+func (r *multiRecord) each(fn func(RecordWriter) error) error {
+	var first error
+	for i, rec := range r.records {
+		if err := fn(rec); err != nil && first == nil {
+			first = fmt.Errorf("writer %d: %w", i, err)
+		}
+	}
+	return first
+}
+
+func (r *multiRecord) WriteSignalSet(s signal.Set) error {
+	return r.each(func(rec RecordWriter) error {
+		return rec.WriteSignalSet(s)
+	})
+}
+
+func (r *multiRecord) WriteExtra(name, value string) error {
+	return r.each(func(rec RecordWriter) error {
+		return rec.WriteExtra(name, value)
+	})
+}
+
+func (r *multiRecord) Done() error {
+	return r.each(func(rec RecordWriter) error {
+		return rec.Done()
+	})
+}
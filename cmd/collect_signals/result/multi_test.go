@@ -0,0 +1,59 @@
+package result
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ossf/criticality_score/cmd/collect_signals/signal"
+)
+
+func TestMultiWriter(t *testing.T) {
+	var csvOut, textOut bytes.Buffer
+	sets := []signal.Set{&testSet{}}
+	w := MultiWriter(NewCsvWriter(&csvOut, sets), NewTextWriter(&textOut, sets))
+
+	rec := w.Record()
+	if err := rec.WriteSignalSet(&testSet{Name: signal.Val("a"), Count: signal.Val(1)}); err != nil {
+		t.Fatalf("WriteSignalSet() errored %v, want no error", err)
+	}
+	if err := rec.Done(); err != nil {
+		t.Fatalf("Done() errored %v, want no error", err)
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush() errored %v, want no error", err)
+	}
+
+	if want := "test.count,test.name\n1,a\n"; csvOut.String() != want {
+		t.Fatalf("csv output == %q, want %q", csvOut.String(), want)
+	}
+	if !strings.Contains(textOut.String(), "test.name") {
+		t.Fatalf("text output == %q, want it to contain the header", textOut.String())
+	}
+}
+
+func TestMultiWriter_Error(t *testing.T) {
+	var first, second bytes.Buffer
+	w := MultiWriter(
+		NewCsvWriter(&first, []signal.Set{&testSet{}}, "team"),
+		NewCsvWriter(&second, []signal.Set{&testSet{}}),
+	)
+
+	rec := w.Record()
+	err := rec.WriteExtra("team", "infra")
+	if !errors.Is(err, UnknownExtraError) {
+		t.Fatalf("WriteExtra() errored %v, want %v", err, UnknownExtraError)
+	}
+	if !strings.HasPrefix(err.Error(), "writer 1: ") {
+		t.Fatalf("WriteExtra() errored %q, want it to identify writer 1", err)
+	}
+
+	// The record is still completed by the writer that did not fail.
+	if err := rec.Done(); err != nil {
+		t.Fatalf("Done() errored %v, want no error", err)
+	}
+	if want := "test.count,test.name,team\n,,infra\n"; first.String() != want {
+		t.Fatalf("output == %q, want %q", first.String(), want)
+	}
+}
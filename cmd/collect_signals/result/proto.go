@@ -0,0 +1,174 @@
+package result
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ossf/criticality_score/cmd/collect_signals/result/recordpb"
+	"github.com/ossf/criticality_score/cmd/collect_signals/signal"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// protoSchemaVersion is the CollectionMetadata.schema_version written with
+// each record. See recordpb/record.proto.
+const protoSchemaVersion = 1
+
+// protoWriter outputs each record as a length-delimited SignalRecord
+// protocol buffer message, as defined in recordpb/record.proto.
+type protoWriter struct {
+	extras map[string]bool
+	w      io.Writer
+
+	// now returns the time recorded in each record's metadata.
+	now func() time.Time
+
+	// Prevents concurrent writes to w.
+	mu sync.Mutex
+}
+
+// NewProtoWriter returns a Writer that outputs records as length-delimited
+// SignalRecord messages.
+//
+// Only the signals that are set are included in each record, so emptySets
+// are only used to validate the sets. Extras that are not written for a
+// record are omitted.
+func NewProtoWriter(w io.Writer, emptySets []signal.Set, extras ...string) Writer {
+	for _, s := range emptySets {
+		if err := signal.ValidateSet(s); err != nil {
+			panic(err)
+		}
+	}
+	return &protoWriter{
+		extras: extraSet(extras),
+		w:      w,
+		now:    time.Now,
+	}
+}
+
+func (w *protoWriter) Record() RecordWriter {
+	return &protoRecord{
+		values: make(map[string]any),
+		extras: make(map[string]string),
+		sink:   w,
+	}
+}
+
+// Flush implements the Writer interface.
+//
+// Records are not buffered, so there is nothing to flush.
+func (w *protoWriter) Flush() error {
+	return nil
+}
+
+func (w *protoWriter) writeRecord(r *protoRecord) error {
+	msg, err := r.message(w.now())
+	if err != nil {
+		return err
+	}
+	// Deterministic ensures the extras map is always encoded in the same
+	// order.
+	b, err := proto.MarshalOptions{Deterministic: true}.Marshal(msg)
+	if err != nil {
+		return err
+	}
+	b = append(protowire.AppendVarint(nil, uint64(len(b))), b...)
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	_, err = w.w.Write(b)
+	return err
+}
+
+type protoRecord struct {
+	values map[string]any
+	extras map[string]string
+	sink   *protoWriter
+}
+
+func (r *protoRecord) WriteSignalSet(s signal.Set) error {
+	for k, v := range signal.SetAsMap(s, true) {
+		if v == nil {
+			continue
+		}
+		r.values[k] = v
+	}
+	return nil
+}
+
+func (r *protoRecord) WriteExtra(name, value string) error {
+	if !r.sink.extras[name] {
+		return fmt.Errorf("%w: %s", UnknownExtraError, name)
+	}
+	r.extras[name] = value
+	return nil
+}
+
+func (r *protoRecord) Done() error {
+	return r.sink.writeRecord(r)
+}
+
+// message returns the record as a SignalRecord message, with collectedAt as
+// the time it was collected.
+//
+// Fields are sorted by name so the encoding is deterministic.
+func (r *protoRecord) message(collectedAt time.Time) (*recordpb.SignalRecord, error) {
+	msg := &recordpb.SignalRecord{
+		Metadata: &recordpb.CollectionMetadata{
+			SchemaVersion: protoSchemaVersion,
+			CollectedAt:   timestamppb.New(collectedAt),
+		},
+	}
+	for _, k := range sortedKeys(r.values) {
+		f, err := protoField(k, r.values[k])
+		if err != nil {
+			return nil, fmt.Errorf("failed to write field %s: %w", k, err)
+		}
+		msg.Fields = append(msg.Fields, f)
+	}
+	if len(r.extras) > 0 {
+		msg.Extras = make(map[string]string, len(r.extras))
+		for k, v := range r.extras {
+			msg.Extras[k] = v
+		}
+	}
+	return msg, nil
+}
+
+// protoField returns a single signal as a Field message.
+func protoField(name string, value any) (*recordpb.Field, error) {
+	f := &recordpb.Field{Name: name}
+	if t, ok := value.(time.Time); ok {
+		f.Value = &recordpb.Field_TimeValue{TimeValue: timestamppb.New(t)}
+		return f, nil
+	}
+	v := reflect.ValueOf(value)
+	switch v.Kind() {
+	case reflect.String:
+		f.Value = &recordpb.Field_StringValue{StringValue: v.String()}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		f.Value = &recordpb.Field_IntValue{IntValue: v.Int()}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		f.Value = &recordpb.Field_IntValue{IntValue: int64(v.Uint())}
+	case reflect.Float32, reflect.Float64:
+		f.Value = &recordpb.Field_FloatValue{FloatValue: v.Float()}
+	case reflect.Bool:
+		f.Value = &recordpb.Field_BoolValue{BoolValue: v.Bool()}
+	default:
+		return nil, fmt.Errorf("%w: %T", MarshalError, value)
+	}
+	return f, nil
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
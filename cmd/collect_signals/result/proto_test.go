@@ -0,0 +1,111 @@
+package result
+
+import (
+	"bytes"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/ossf/criticality_score/cmd/collect_signals/result/recordpb"
+	"github.com/ossf/criticality_score/cmd/collect_signals/signal"
+	"google.golang.org/protobuf/encoding/protowire"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+type protoTestSet struct {
+	Ratio     signal.Field[float64]
+	Archived  signal.Field[bool]
+	CreatedAt signal.Field[time.Time]
+}
+
+func (s *protoTestSet) Namespace() signal.Namespace {
+	return signal.Namespace("proto")
+}
+
+// readRecords decodes each length-delimited SignalRecord in b.
+func readRecords(t *testing.T, b []byte) []*recordpb.SignalRecord {
+	t.Helper()
+	var recs []*recordpb.SignalRecord
+	for len(b) > 0 {
+		size, n := protowire.ConsumeVarint(b)
+		if n < 0 {
+			t.Fatalf("ConsumeVarint() errored %v", protowire.ParseError(n))
+		}
+		b = b[n:]
+		rec := &recordpb.SignalRecord{}
+		if err := proto.Unmarshal(b[:size], rec); err != nil {
+			t.Fatalf("Unmarshal() errored %v, want no error", err)
+		}
+		recs = append(recs, rec)
+		b = b[size:]
+	}
+	return recs
+}
+
+func TestProtoWriter(t *testing.T) {
+	var b bytes.Buffer
+	collectedAt := time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC)
+	w := NewProtoWriter(&b, []signal.Set{&testSet{}, &protoTestSet{}}, "extra")
+	w.(*protoWriter).now = func() time.Time { return collectedAt }
+
+	for _, ss := range [][]signal.Set{
+		{
+			&testSet{Name: signal.Val("example"), Count: signal.Val(-3)},
+			&protoTestSet{Ratio: signal.Val(0.25), Archived: signal.Val(false), CreatedAt: signal.Val(time.Unix(1000, 0))},
+		},
+		{&testSet{}},
+	} {
+		rec := w.Record()
+		for _, s := range ss {
+			if err := rec.WriteSignalSet(s); err != nil {
+				t.Fatalf("WriteSignalSet() errored %v, want no error", err)
+			}
+		}
+		if err := rec.WriteExtra("extra", "value"); err != nil {
+			t.Fatalf("WriteExtra() errored %v, want no error", err)
+		}
+		if err := rec.Done(); err != nil {
+			t.Fatalf("Done() errored %v, want no error", err)
+		}
+	}
+
+	recs := readRecords(t, b.Bytes())
+	if len(recs) != 2 {
+		t.Fatalf("len(records) == %d, want 2", len(recs))
+	}
+
+	want := &recordpb.SignalRecord{
+		Fields: []*recordpb.Field{
+			{Name: "proto.archived", Value: &recordpb.Field_BoolValue{BoolValue: false}},
+			{Name: "proto.created_at", Value: &recordpb.Field_TimeValue{TimeValue: timestamppb.New(time.Unix(1000, 0))}},
+			{Name: "proto.ratio", Value: &recordpb.Field_FloatValue{FloatValue: 0.25}},
+			{Name: "test.count", Value: &recordpb.Field_IntValue{IntValue: -3}},
+			{Name: "test.name", Value: &recordpb.Field_StringValue{StringValue: "example"}},
+		},
+		Extras: map[string]string{"extra": "value"},
+		Metadata: &recordpb.CollectionMetadata{
+			SchemaVersion: protoSchemaVersion,
+			CollectedAt:   timestamppb.New(collectedAt),
+		},
+	}
+	if got := recs[0]; !proto.Equal(got, want) {
+		t.Fatalf("record == %v, want %v", got, want)
+	}
+	// The score is not set by collect_signals.
+	if recs[0].Score != nil {
+		t.Fatalf("score == %v, want unset", recs[0].GetScore())
+	}
+
+	// Unset signals are omitted.
+	if got := len(recs[1].Fields); got != 0 {
+		t.Fatalf("len(fields) == %d, want 0", got)
+	}
+}
+
+func TestProtoWriter_UnknownExtra(t *testing.T) {
+	w := NewProtoWriter(&bytes.Buffer{}, []signal.Set{&testSet{}})
+	if err := w.Record().WriteExtra("missing", "value"); !errors.Is(err, UnknownExtraError) {
+		t.Fatalf("WriteExtra() errored %v, want %v", err, UnknownExtraError)
+	}
+}
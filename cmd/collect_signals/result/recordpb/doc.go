@@ -0,0 +1,5 @@
+// Package recordpb contains the Go types for the SignalRecord protocol buffer
+// message written by collect_signals with "-format proto".
+package recordpb
+
+//go:generate protoc --go_out=. --go_opt=paths=source_relative record.proto
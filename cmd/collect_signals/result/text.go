@@ -0,0 +1,125 @@
+package result
+
+import (
+	"fmt"
+	"io"
+	"strings"
+	"sync"
+	"text/tabwriter"
+
+	"github.com/ossf/criticality_score/cmd/collect_signals/signal"
+)
+
+const (
+	textMinWidth = 0
+	textTabWidth = 8
+	textPadding  = 2
+	textPadChar  = ' '
+)
+
+// textCellReplacer replaces tabs and newlines in a cell with spaces so that
+// they do not break the alignment of the table.
+var textCellReplacer = strings.NewReplacer("\t", " ", "\n", " ")
+
+// textWriter outputs records as a table, with each column aligned.
+//
+// The width of each column depends on every record, so records are buffered
+// in memory until Flush is called.
+type textWriter struct {
+	header []string
+	extras map[string]bool
+	w      io.Writer
+	rows   [][]string
+
+	// Prevents concurrent access to rows.
+	mu sync.Mutex
+}
+
+// NewTextWriter returns a Writer that outputs the records as an aligned
+// table of text when Flush is called.
+//
+// The columns are the same as those used by NewCsvWriter.
+func NewTextWriter(w io.Writer, emptySets []signal.Set, extras ...string) Writer {
+	return &textWriter{
+		header: headerFromSignalSets(emptySets, extras),
+		extras: extraSet(extras),
+		w:      w,
+	}
+}
+
+func (w *textWriter) Record() RecordWriter {
+	return &textRecord{
+		values: make(map[string]string),
+		sink:   w,
+	}
+}
+
+// Flush implements the Writer interface.
+//
+// The header and all the records written so far are rendered as a table.
+func (w *textWriter) Flush() error {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	tw := tabwriter.NewWriter(w.w, textMinWidth, textTabWidth, textPadding, textPadChar, 0)
+	if err := writeTextRow(tw, w.header); err != nil {
+		return err
+	}
+	for _, row := range w.rows {
+		if err := writeTextRow(tw, row); err != nil {
+			return err
+		}
+	}
+	w.rows = nil
+	return tw.Flush()
+}
+
+func (w *textWriter) addRecord(r *textRecord) {
+	var row []string
+	for _, k := range w.header {
+		row = append(row, r.values[k])
+	}
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.rows = append(w.rows, row)
+}
+
+// writeTextRow writes the cells of row to w as a tab-terminated line.
+func writeTextRow(w io.Writer, row []string) error {
+	var b strings.Builder
+	for _, cell := range row {
+		b.WriteString(textCellReplacer.Replace(cell))
+		b.WriteByte('\t')
+	}
+	_, err := fmt.Fprintln(w, b.String())
+	return err
+}
+
+type textRecord struct {
+	values map[string]string
+	sink   *textWriter
+}
+
+func (r *textRecord) WriteSignalSet(s signal.Set) error {
+	data := signal.SetAsMap(s, true)
+	for k, v := range data {
+		if s, err := marshalValue(v); err != nil {
+			return fmt.Errorf("failed to write field %s: %w", k, err)
+		} else {
+			r.values[k] = s
+		}
+	}
+	return nil
+}
+
+func (r *textRecord) WriteExtra(name, value string) error {
+	if !r.sink.extras[name] {
+		return fmt.Errorf("%w: %s", UnknownExtraError, name)
+	}
+	r.values[name] = value
+	return nil
+}
+
+func (r *textRecord) Done() error {
+	r.sink.addRecord(r)
+	return nil
+}
@@ -0,0 +1,49 @@
+package result
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/ossf/criticality_score/cmd/collect_signals/signal"
+)
+
+type testSet struct {
+	Name  signal.Field[string]
+	Count signal.Field[int]
+}
+
+func (s *testSet) Namespace() signal.Namespace {
+	return signal.Namespace("test")
+}
+
+func TestTextWriter(t *testing.T) {
+	var b bytes.Buffer
+	w := NewTextWriter(&b, []signal.Set{&testSet{}})
+	for _, s := range []*testSet{
+		{Name: signal.Val("a-long-name"), Count: signal.Val(1)},
+		{Name: signal.Val("short"), Count: signal.Val(12345)},
+		{Name: signal.Val("unset")},
+	} {
+		rec := w.Record()
+		if err := rec.WriteSignalSet(s); err != nil {
+			t.Fatalf("WriteSignalSet() errored %v, want no error", err)
+		}
+		if err := rec.Done(); err != nil {
+			t.Fatalf("Done() errored %v, want no error", err)
+		}
+	}
+	if b.Len() != 0 {
+		t.Fatalf("output before Flush() == %q, want empty", b.String())
+	}
+	if err := w.Flush(); err != nil {
+		t.Fatalf("Flush() errored %v, want no error", err)
+	}
+	want := "" +
+		"test.count  test.name    \n" +
+		"1           a-long-name  \n" +
+		"12345       short        \n" +
+		"            unset        \n"
+	if got := b.String(); got != want {
+		t.Fatalf("output == %q, want %q", got, want)
+	}
+}
@@ -0,0 +1,84 @@
+package result
+
+import (
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/ossf/criticality_score/cmd/collect_signals/signal"
+)
+
+var ErrInvalidWriterType = errors.New("invalid writer type")
+
+// WriterType identifies the format of the output produced by a Writer.
+type WriterType int
+
+const (
+	// WriterTypeCSV outputs records as comma separated values.
+	WriterTypeCSV WriterType = iota
+
+	// WriterTypeText outputs records as an aligned table of text.
+	WriterTypeText
+
+	// WriterTypeProto outputs records as length-delimited protocol buffer
+	// messages.
+	WriterTypeProto
+)
+
+// String implements the fmt.Stringer interface.
+func (t WriterType) String() string {
+	text, err := t.MarshalText()
+	if err != nil {
+		return ""
+	}
+	return string(text)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (t WriterType) MarshalText() ([]byte, error) {
+	switch t {
+	case WriterTypeCSV:
+		return []byte("csv"), nil
+	case WriterTypeText:
+		return []byte("text"), nil
+	case WriterTypeProto:
+		return []byte("proto"), nil
+	default:
+		return []byte{}, fmt.Errorf("%w: %d", ErrInvalidWriterType, t)
+	}
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (t *WriterType) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "csv":
+		*t = WriterTypeCSV
+	case "text":
+		*t = WriterTypeText
+	case "proto":
+		*t = WriterTypeProto
+	default:
+		return fmt.Errorf("%w: %q", ErrInvalidWriterType, string(text))
+	}
+	return nil
+}
+
+// New returns a new Writer of the given type.
+func (t WriterType) New(w io.Writer, emptySets []signal.Set, extras ...string) Writer {
+	return t.NewWithOptions(w, emptySets, CsvOptions{}, extras...)
+}
+
+// NewWithOptions returns a new Writer of the given type. The csvOpts are only
+// used by WriterTypeCSV.
+func (t WriterType) NewWithOptions(w io.Writer, emptySets []signal.Set, csvOpts CsvOptions, extras ...string) Writer {
+	switch t {
+	case WriterTypeCSV:
+		return NewCsvWriterWithOptions(w, emptySets, csvOpts, extras...)
+	case WriterTypeText:
+		return NewTextWriter(w, emptySets, extras...)
+	case WriterTypeProto:
+		return NewProtoWriter(w, emptySets, extras...)
+	default:
+		panic(fmt.Sprintf("invalid writer type: %d", t))
+	}
+}
@@ -0,0 +1,143 @@
+// Package rubygems provides a Collector that returns a Set for the usage of
+// the gems published from a repository, as reported by rubygems.org.
+//
+// rubygems.org does not support looking up gems by repository, so gems are
+// found by searching for the name of the repository, and keeping the gems
+// whose source code or homepage URL refers to the repository.
+package rubygems
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/ossf/criticality_score/cmd/collect_signals/projectrepo"
+	"github.com/ossf/criticality_score/cmd/collect_signals/signal"
+	"github.com/ossf/criticality_score/internal/httpjson"
+	log "github.com/sirupsen/logrus"
+)
+
+// DefaultAPIURL is the base URL of the rubygems.org API.
+const DefaultAPIURL = "https://rubygems.org/api/v1"
+
+type gemsSet struct {
+	GemCount signal.Field[int]
+
+	// TotalDownloads is the number of downloads of all the versions of the
+	// gems.
+	TotalDownloads signal.Field[int]
+
+	// ReverseDependencyCount is the number of gems that depend on any of the
+	// gems published from the repository.
+	ReverseDependencyCount signal.Field[int] `signal:"reverse_dependencies"`
+}
+
+func (s *gemsSet) Namespace() signal.Namespace {
+	return signal.Namespace("rubygems")
+}
+
+type gem struct {
+	Name          string `json:"name"`
+	Downloads     int    `json:"downloads"`
+	SourceCodeURI string `json:"source_code_uri"`
+	HomepageURI   string `json:"homepage_uri"`
+}
+
+type Collector struct {
+	client *http.Client
+	logger *log.Logger
+	apiURL string
+}
+
+// NewCollector returns a new Collector that uses the http.Client c to query
+// rubygems.org.
+func NewCollector(c *http.Client, logger *log.Logger) *Collector {
+	return &Collector{
+		client: c,
+		logger: logger,
+		apiURL: DefaultAPIURL,
+	}
+}
+
+func (c *Collector) EmptySet() signal.Set {
+	return &gemsSet{}
+}
+
+func (c *Collector) IsSupported(r projectrepo.Repo) bool {
+	return true
+}
+
+// Collect implements the collector.Collector interface.
+//
+// If no gems are published from the repository the signals are left unset.
+func (c *Collector) Collect(ctx context.Context, r projectrepo.Repo) (signal.Set, error) {
+	s := &gemsSet{}
+	logger := c.logger.WithField("url", r.URL().String())
+
+	logger.Debug("Searching rubygems.org")
+	gems, err := c.queryGems(ctx, r.URL())
+	if err != nil {
+		return nil, err
+	}
+	if len(gems) == 0 {
+		return s, nil
+	}
+
+	downloads := 0
+	dependents := make(map[string]struct{})
+	for _, g := range gems {
+		downloads += g.Downloads
+		logger.WithField("gem", g.Name).Debug("Fetching reverse dependencies")
+		names, err := c.queryReverseDependencies(ctx, g.Name)
+		if err != nil {
+			return nil, err
+		}
+		for _, n := range names {
+			dependents[n] = struct{}{}
+		}
+	}
+	// Gems in the same repository often depend on each other, so they are
+	// not counted as dependents.
+	for _, g := range gems {
+		delete(dependents, g.Name)
+	}
+	s.GemCount.Set(len(gems))
+	s.TotalDownloads.Set(downloads)
+	s.ReverseDependencyCount.Set(len(dependents))
+	return s, nil
+}
+
+// queryGems returns the gems published from the repository at u.
+func (c *Collector) queryGems(ctx context.Context, u *url.URL) ([]gem, error) {
+	query := url.Values{
+		"query": {path.Base(strings.TrimSuffix(strings.Trim(u.Path, "/"), ".git"))},
+	}
+	var res []gem
+	if _, err := httpjson.Get(ctx, c.client, c.apiURL+"/search.json?"+query.Encode(), &res); err != nil {
+		return nil, err
+	}
+	repo := projectrepo.NormalizeURL(u.String())
+	var gems []gem
+	for _, g := range res {
+		if projectrepo.RefersTo(repo, g.SourceCodeURI, g.HomepageURI) {
+			gems = append(gems, g)
+		}
+	}
+	return gems, nil
+}
+
+// queryReverseDependencies returns the names of the gems that depend on the
+// gem name.
+func (c *Collector) queryReverseDependencies(ctx context.Context, name string) ([]string, error) {
+	var names []string
+	_, err := httpjson.Get(ctx, c.client, c.apiURL+"/gems/"+url.PathEscape(name)+"/reverse_dependencies.json", &names)
+	if httpjson.StatusCode(err) == http.StatusNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return names, nil
+}
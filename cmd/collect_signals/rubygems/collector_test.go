@@ -0,0 +1,62 @@
+package rubygems
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/ossf/criticality_score/internal/collectortest"
+)
+
+// testRepoURL is the repository the signals are collected for.
+const testRepoURL = "https://github.com/example/rails"
+
+// newTestCollector returns a Collector where all the requests are handled by
+// h.
+func newTestCollector(t *testing.T, h http.Handler) *Collector {
+	t.Helper()
+	s := collectortest.NewServer(t, h)
+	c := NewCollector(&http.Client{}, collectortest.Logger())
+	c.apiURL = s.URL
+	return c
+}
+
+func TestCollect(t *testing.T) {
+	c := newTestCollector(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/search.json":
+			w.Write([]byte(`[
+				{"name": "rails", "downloads": 1000, "source_code_uri": "https://github.com/example/rails/tree/v1.0.0"},
+				{"name": "railties", "downloads": 500, "homepage_uri": "https://github.com/example/rails"},
+				{"name": "rails-other", "downloads": 9999, "source_code_uri": "https://github.com/other/rails"}
+			]`))
+		case "/gems/rails/reverse_dependencies.json":
+			w.Write([]byte(`["railties", "devise", "sprockets"]`))
+		case "/gems/railties/reverse_dependencies.json":
+			w.Write([]byte(`["devise", "rspec-rails"]`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	s := collectortest.Collect[*gemsSet](t, c, testRepoURL)
+	if got := s.GemCount.Get(); got != 2 {
+		t.Fatalf("GemCount == %d, want 2", got)
+	}
+	if got := s.TotalDownloads.Get(); got != 1500 {
+		t.Fatalf("TotalDownloads == %d, want 1500", got)
+	}
+	if got := s.ReverseDependencyCount.Get(); got != 3 {
+		t.Fatalf("ReverseDependencyCount == %d, want 3", got)
+	}
+}
+
+func TestCollect_NoGems(t *testing.T) {
+	c := newTestCollector(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`[]`))
+	}))
+	s := collectortest.Collect[*gemsSet](t, c, testRepoURL)
+	if s.GemCount.IsSet() || s.TotalDownloads.IsSet() || s.ReverseDependencyCount.IsSet() {
+		t.Fatal("signals are set, want unset")
+	}
+}
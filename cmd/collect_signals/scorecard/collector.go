@@ -0,0 +1,120 @@
+// Package scorecard provides a Collector that returns a Set for the OpenSSF
+// Scorecard result of a repository.
+//
+// Results are read from the public Scorecard API, which serves the results
+// of the weekly Scorecard scan. Only repositories included in the scan have
+// a result.
+package scorecard
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ossf/criticality_score/cmd/collect_signals/projectrepo"
+	"github.com/ossf/criticality_score/cmd/collect_signals/signal"
+	"github.com/ossf/criticality_score/internal/httpjson"
+	log "github.com/sirupsen/logrus"
+)
+
+// DefaultAPIURL is the base URL of the public Scorecard API.
+const DefaultAPIURL = "https://api.securityscorecards.dev"
+
+type scorecardSet struct {
+	// Score is the aggregate Scorecard score, between 0 and 10.
+	Score signal.Field[float64]
+
+	// Maintained, CodeReview and SecurityPolicy are the scores of the checks
+	// with the same name, between 0 and 10. They are left unset if the check
+	// was inconclusive.
+	Maintained     signal.Field[int]
+	CodeReview     signal.Field[int]
+	SecurityPolicy signal.Field[int]
+}
+
+func (s *scorecardSet) Namespace() signal.Namespace {
+	return signal.Namespace("scorecard")
+}
+
+type check struct {
+	Name  string `json:"name"`
+	Score int    `json:"score"`
+}
+
+type result struct {
+	Score  float64 `json:"score"`
+	Checks []check `json:"checks"`
+}
+
+type Collector struct {
+	client *http.Client
+	logger *log.Logger
+	apiURL string
+}
+
+// NewCollector returns a new Collector that uses the http.Client c to query
+// the Scorecard API.
+func NewCollector(c *http.Client, logger *log.Logger) *Collector {
+	return &Collector{
+		client: c,
+		logger: logger,
+		apiURL: DefaultAPIURL,
+	}
+}
+
+func (c *Collector) EmptySet() signal.Set {
+	return &scorecardSet{}
+}
+
+func (c *Collector) IsSupported(r projectrepo.Repo) bool {
+	return true
+}
+
+// Collect implements the collector.Collector interface.
+//
+// If the repository does not have a Scorecard result the signals are left
+// unset.
+func (c *Collector) Collect(ctx context.Context, r projectrepo.Repo) (signal.Set, error) {
+	s := &scorecardSet{}
+	c.logger.WithField("url", r.URL().String()).Debug("Fetching Scorecard result")
+	res, err := c.queryResult(ctx, r.URL())
+	if err != nil {
+		return nil, err
+	}
+	if res == nil {
+		return s, nil
+	}
+	s.Score.Set(res.Score)
+	for _, ch := range res.Checks {
+		// A score of -1 means the check was inconclusive.
+		if ch.Score < 0 {
+			continue
+		}
+		switch ch.Name {
+		case "Maintained":
+			s.Maintained.Set(ch.Score)
+		case "Code-Review":
+			s.CodeReview.Set(ch.Score)
+		case "Security-Policy":
+			s.SecurityPolicy.Set(ch.Score)
+		}
+	}
+	return s, nil
+}
+
+// queryResult returns the Scorecard result for the repository at u.
+//
+// If the repository does not have a result, nil is returned.
+func (c *Collector) queryResult(ctx context.Context, u *url.URL) (*result, error) {
+	project := strings.ToLower(u.Hostname()) + "/" + strings.TrimSuffix(strings.Trim(u.Path, "/"), ".git")
+	res := &result{}
+	_, err := httpjson.Get(ctx, c.client, c.apiURL+"/projects/"+project, res)
+	if httpjson.StatusCode(err) == http.StatusNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return res, nil
+}
@@ -0,0 +1,58 @@
+package scorecard
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/ossf/criticality_score/internal/collectortest"
+)
+
+func newTestCollector(t *testing.T) *Collector {
+	t.Helper()
+	s := collectortest.NewServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/projects/github.com/example/json" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(`{
+			"repo": {"name": "github.com/example/json"},
+			"score": 6.4,
+			"checks": [
+				{"name": "Maintained", "score": 10},
+				{"name": "Code-Review", "score": 0},
+				{"name": "Security-Policy", "score": -1},
+				{"name": "Fuzzing", "score": 7}
+			]
+		}`))
+	}))
+	c := NewCollector(&http.Client{}, collectortest.Logger())
+	c.apiURL = s.URL
+	return c
+}
+
+func TestCollect(t *testing.T) {
+	s := collectortest.Collect[*scorecardSet](t, newTestCollector(t), "https://GitHub.com/example/json.git")
+	if got := s.Score.Get(); got != 6.4 {
+		t.Fatalf("Score == %v, want 6.4", got)
+	}
+	if got := s.Maintained.Get(); got != 10 {
+		t.Fatalf("Maintained == %d, want 10", got)
+	}
+	if !s.CodeReview.IsSet() || s.CodeReview.Get() != 0 {
+		t.Fatalf("CodeReview == %d, want 0", s.CodeReview.Get())
+	}
+	if s.SecurityPolicy.IsSet() {
+		t.Fatal("SecurityPolicy is set, want unset")
+	}
+}
+
+func TestCollect_NoResult(t *testing.T) {
+	s := collectortest.Collect[*scorecardSet](t, newTestCollector(t), "https://github.com/example/missing")
+	if s.Score.IsSet() {
+		t.Fatal("Score is set, want unset")
+	}
+	if s.Maintained.IsSet() {
+		t.Fatal("Maintained is set, want unset")
+	}
+}
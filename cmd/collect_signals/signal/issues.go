@@ -4,6 +4,14 @@ type IssuesSet struct {
 	UpdatedCount     Field[int]     `signal:"updated_issues_count,legacy"`
 	ClosedCount      Field[int]     `signal:"closed_issues_count,legacy"`
 	CommentFrequency Field[float64] `signal:"issue_comment_frequency,legacy"`
+
+	OpenCount           Field[int] `signal:"open_issues_count"`
+	AssignedOpenCount   Field[int] `signal:"assigned_open_issue_count"`
+	UnassignedOpenCount Field[int] `signal:"unassigned_open_issue_count"`
+
+	// FirstResponseHours is the median number of hours between an issue
+	// being opened and a maintainer first commenting on it.
+	FirstResponseHours Field[int] `signal:"issue_first_response_hours"`
 }
 
 func (r *IssuesSet) Namespace() Namespace {
@@ -7,7 +7,21 @@ type RepoSet struct {
 	Language Field[string]
 	License  Field[string]
 
+	// MirrorURL is the URL of the read-only mirror that URL was resolved
+	// from, if the input was a mirror.
+	MirrorURL Field[string]
+
+	// RepoSizeKB is the size of the repository in kilobytes.
+	RepoSizeKB Field[int] `signal:"repo_size_kb"`
+
+	// PrimaryLanguageShare is the fraction of the code written in the
+	// largest language, and LanguageCount is the number of languages used.
+	PrimaryLanguageShare Field[float64]
+	LanguageCount        Field[int]
+
 	StarCount Field[int]
+	ForkCount Field[int]
+	Archived  Field[bool]
 	CreatedAt Field[time.Time]
 	UpdatedAt Field[time.Time]
 
@@ -17,10 +31,100 @@ type RepoSet struct {
 	ContributorCount Field[int] `signal:"legacy"`
 	OrgCount         Field[int] `signal:"legacy"`
 
+	// RecentAuthorCount is the number of distinct authors of the last 90
+	// days' commits.
+	RecentAuthorCount Field[int] `signal:"recent_author_count"`
+
+	// DistinctOrgCount is the number of distinct organizations that the
+	// authors of the last year's commits work for.
+	DistinctOrgCount Field[int]
+
+	// Top1CommitShare and Top3CommitShare are the fractions of the last
+	// year's commits made by the most active author, and by the three most
+	// active authors. High values indicate a low bus factor.
+	Top1CommitShare Field[float64] `signal:"top1_commit_share"`
+	Top3CommitShare Field[float64] `signal:"top3_commit_share"`
+
+	// SignedCommitRatio is the fraction of the last year's commits that are
+	// signed.
+	SignedCommitRatio Field[float64]
+
+	// PullMergeHours is the median number of hours between a recent pull
+	// request being opened and merged.
+	PullMergeHours Field[int] `signal:"pr_merge_hours"`
+
+	// ReviewedPullPercent is the percentage of recent pull requests that
+	// have at least one review.
+	ReviewedPullPercent Field[float64] `signal:"pr_reviewed_percent"`
+
+	// ExternalPullAcceptancePercent is the percentage of recently closed
+	// pull requests from authors outside the project that were merged.
+	ExternalPullAcceptancePercent Field[float64] `signal:"external_pr_acceptance_percent"`
+
 	CommitFrequency    Field[float64] `signal:"legacy"`
 	RecentReleaseCount Field[int]     `signal:"legacy"`
+
+	// HasCIConfig is true if the repository configures a CI system, such as
+	// GitHub Actions or Travis CI. TestFileRatio is the fraction of files
+	// near the root of the repository that look like tests.
+	HasCIConfig   Field[bool]
+	TestFileRatio Field[float64]
+
+	// SecurityContactPresent is true if the security policy includes an
+	// email address or link for reporting vulnerabilities.
+	HasSecurityPolicy      Field[bool]
+	SecurityContactPresent Field[bool]
+
+	// The default branch signals require admin access to the repository,
+	// and are left unset if the token does not have it.
+	DefaultBranchProtected             Field[bool]
+	DefaultBranchRequiredReviews       Field[int]
+	DefaultBranchRequiresLinearHistory Field[bool]
+	DefaultBranchAllowsForcePush       Field[bool]
+
+	// ReleaseCount is the total number of releases. If a project has no
+	// releases, collectors may count its tags instead.
+	ReleaseCount   Field[int]
+	LastReleasedAt Field[time.Time]
+
+	// DaysSinceLastRelease is the number of days since LastReleasedAt. See
+	// SetDaysSinceLastRelease().
+	DaysSinceLastRelease Field[int]
+
+	// ReleaseLagDays is the number of days between the last release and the
+	// last commit. A large value indicates there is work that has not been
+	// released. See SetReleaseLag().
+	ReleaseLagDays Field[int]
 }
 
 func (r *RepoSet) Namespace() Namespace {
 	return NamespaceRepo
 }
+
+// SetReleaseLag derives ReleaseLagDays from LastReleasedAt and UpdatedAt, the
+// time of the last commit.
+//
+// ReleaseLagDays is the days since the last release minus the days since the
+// last commit, relative to now. It is left unset if either LastReleasedAt or
+// UpdatedAt is unset.
+func (r *RepoSet) SetReleaseLag(now time.Time) {
+	if !r.LastReleasedAt.IsSet() || !r.UpdatedAt.IsSet() {
+		r.ReleaseLagDays.Unset()
+		return
+	}
+	r.ReleaseLagDays.Set(daysSince(now, r.LastReleasedAt.Get()) - daysSince(now, r.UpdatedAt.Get()))
+}
+
+// SetDaysSinceLastRelease derives DaysSinceLastRelease from LastReleasedAt,
+// relative to now. It is left unset if LastReleasedAt is unset.
+func (r *RepoSet) SetDaysSinceLastRelease(now time.Time) {
+	if !r.LastReleasedAt.IsSet() {
+		r.DaysSinceLastRelease.Unset()
+		return
+	}
+	r.DaysSinceLastRelease.Set(daysSince(now, r.LastReleasedAt.Get()))
+}
+
+func daysSince(now, t time.Time) int {
+	return int(now.Sub(t).Hours()) / 24
+}
@@ -0,0 +1,66 @@
+package signal
+
+import (
+	"testing"
+	"time"
+)
+
+var now = time.Date(2022, 6, 1, 12, 0, 0, 0, time.UTC)
+
+func TestRepoSetSetReleaseLag_Unreleased(t *testing.T) {
+	s := &RepoSet{
+		// Committing actively, but the last release was months ago.
+		UpdatedAt:      Val(now.AddDate(0, 0, -1)),
+		LastReleasedAt: Val(now.AddDate(0, 0, -91)),
+	}
+	s.SetReleaseLag(now)
+	if got := s.ReleaseLagDays.Get(); got != 90 {
+		t.Fatalf("ReleaseLagDays == %d, want 90", got)
+	}
+}
+
+func TestRepoSetSetReleaseLag_UpToDate(t *testing.T) {
+	s := &RepoSet{
+		UpdatedAt:      Val(now.AddDate(0, 0, -10)),
+		LastReleasedAt: Val(now.AddDate(0, 0, -10)),
+	}
+	s.SetReleaseLag(now)
+	if got := s.ReleaseLagDays.Get(); got != 0 {
+		t.Fatalf("ReleaseLagDays == %d, want 0", got)
+	}
+}
+
+func TestRepoSetSetReleaseLag_Missing(t *testing.T) {
+	tests := []struct {
+		name string
+		set  *RepoSet
+	}{
+		{"no release", &RepoSet{UpdatedAt: Val(now)}},
+		{"no commit", &RepoSet{LastReleasedAt: Val(now)}},
+		{"neither", &RepoSet{}},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.set.SetReleaseLag(now)
+			if test.set.ReleaseLagDays.IsSet() {
+				t.Fatal("ReleaseLagDays is set, want unset")
+			}
+		})
+	}
+}
+
+func TestRepoSetSetDaysSinceLastRelease(t *testing.T) {
+	s := &RepoSet{LastReleasedAt: Val(now.AddDate(0, 0, -30))}
+	s.SetDaysSinceLastRelease(now)
+	if got := s.DaysSinceLastRelease.Get(); got != 30 {
+		t.Fatalf("DaysSinceLastRelease == %d, want 30", got)
+	}
+}
+
+func TestRepoSetSetDaysSinceLastRelease_Missing(t *testing.T) {
+	s := &RepoSet{}
+	s.SetDaysSinceLastRelease(now)
+	if s.DaysSinceLastRelease.IsSet() {
+		t.Fatal("DaysSinceLastRelease is set, want unset")
+	}
+}
@@ -50,7 +50,7 @@ type SupportedType interface {
 	~int | ~int8 | ~int16 | ~int32 | ~int64 |
 		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
 		~float32 | ~float64 |
-		~string | time.Time
+		~string | ~bool | time.Time
 }
 
 // valuer is provides access to the field's value without needing to use
@@ -239,3 +239,17 @@ func SetAsMap(s Set, namespace bool) map[string]any {
 	}
 	return m
 }
+
+// SetTypes returns a slice containing the type of the value of each field for
+// s, in the same order as SetFields.
+func SetTypes(s Set) []reflect.Type {
+	var ts []reflect.Type
+	for _, sf := range reflect.VisibleFields(reflect.TypeOf(s).Elem()) {
+		if parseStructField(sf) == nil {
+			continue
+		}
+		// The first field of Field is its value.
+		ts = append(ts, sf.Type.Field(0).Type)
+	}
+	return ts
+}
@@ -0,0 +1,181 @@
+// Package stackoverflow provides a Collector that returns a Set for the
+// number of Stack Overflow questions about a repository's project.
+//
+// Questions are counted using the Stack Overflow tags for the project. The
+// tags are read from a mapping file if the repository is listed in it.
+// Otherwise the tag is guessed from the repository's name, which may match an
+// unrelated tag for projects with a common name.
+package stackoverflow
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/ossf/criticality_score/cmd/collect_signals/projectrepo"
+	"github.com/ossf/criticality_score/cmd/collect_signals/signal"
+	"github.com/ossf/criticality_score/internal/httpjson"
+	"github.com/ossf/criticality_score/internal/repomap"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultAPIURL is the base URL of the Stack Exchange API.
+	DefaultAPIURL = "https://api.stackexchange.com/2.3"
+
+	// site is the Stack Exchange site questions are counted on.
+	site = "stackoverflow"
+
+	// recentLookback is the period recent questions are counted over.
+	recentLookback = 90 * 24 * time.Hour
+)
+
+type stackOverflowSet struct {
+	TagCount signal.Field[int]
+
+	// QuestionCount is the total number of questions with any of the tags.
+	QuestionCount signal.Field[int]
+
+	// RecentQuestionCount is the number of questions with any of the tags
+	// asked in the last 90 days.
+	RecentQuestionCount signal.Field[int]
+}
+
+func (s *stackOverflowSet) Namespace() signal.Namespace {
+	return signal.Namespace("stackoverflow")
+}
+
+type tagInfo struct {
+	Items []struct {
+		Name  string `json:"name"`
+		Count int    `json:"count"`
+	} `json:"items"`
+}
+
+type total struct {
+	Total int `json:"total"`
+}
+
+type Collector struct {
+	client *http.Client
+	logger *log.Logger
+	apiURL string
+	apiKey string
+	tags   repomap.Map
+}
+
+// NewCollector returns a new Collector that uses the http.Client c to query
+// the Stack Exchange API.
+//
+// tags maps repositories to their Stack Overflow tags, and may be nil. If
+// apiKey is not empty it is sent with each request for a higher quota.
+func NewCollector(c *http.Client, apiKey string, tags repomap.Map, logger *log.Logger) *Collector {
+	return &Collector{
+		client: c,
+		logger: logger,
+		apiURL: DefaultAPIURL,
+		apiKey: apiKey,
+		tags:   tags,
+	}
+}
+
+func (c *Collector) EmptySet() signal.Set {
+	return &stackOverflowSet{}
+}
+
+func (c *Collector) IsSupported(r projectrepo.Repo) bool {
+	return true
+}
+
+// Collect implements the collector.Collector interface.
+//
+// If none of the tags exist on Stack Overflow the signals are left unset.
+func (c *Collector) Collect(ctx context.Context, r projectrepo.Repo) (signal.Set, error) {
+	s := &stackOverflowSet{}
+	tags, ok := c.tags.Lookup(r.URL())
+	if !ok {
+		tags = []string{guessTag(r.URL().Path)}
+	}
+	c.logger.WithFields(log.Fields{
+		"url":  r.URL().String(),
+		"tags": tags,
+	}).Debug("Fetching Stack Overflow tags")
+	counts, err := c.queryTagCounts(ctx, tags)
+	if err != nil {
+		return nil, err
+	}
+	if len(counts) == 0 {
+		return s, nil
+	}
+	questions := 0
+	recent := 0
+	since := time.Now().Add(-recentLookback)
+	for tag, count := range counts {
+		questions += count
+		n, err := c.queryRecentCount(ctx, tag, since)
+		if err != nil {
+			return nil, err
+		}
+		recent += n
+	}
+	s.TagCount.Set(len(counts))
+	s.QuestionCount.Set(questions)
+	s.RecentQuestionCount.Set(recent)
+	return s, nil
+}
+
+// guessTag returns the Stack Overflow tag that a project with the repository
+// path p is likely to use. Tags are lowercase, and use "-" between words.
+func guessTag(p string) string {
+	name := path.Base(strings.TrimSuffix(strings.Trim(p, "/"), ".git"))
+	return strings.ReplaceAll(strings.ToLower(name), "_", "-")
+}
+
+// queryTagCounts returns the number of questions for each of the tags that
+// exist.
+func (c *Collector) queryTagCounts(ctx context.Context, tags []string) (map[string]int, error) {
+	var res tagInfo
+	if err := c.get(ctx, "tags/"+url.PathEscape(strings.Join(tags, ";"))+"/info", nil, &res); err != nil {
+		return nil, err
+	}
+	counts := make(map[string]int)
+	for _, t := range res.Items {
+		counts[t.Name] = t.Count
+	}
+	return counts, nil
+}
+
+// queryRecentCount returns the number of questions with the tag asked since
+// the given time.
+func (c *Collector) queryRecentCount(ctx context.Context, tag string, since time.Time) (int, error) {
+	query := url.Values{
+		"tagged":   {tag},
+		"fromdate": {fmt.Sprint(since.Unix())},
+		"filter":   {"total"},
+	}
+	var res total
+	if err := c.get(ctx, "questions", query, &res); err != nil {
+		return 0, err
+	}
+	return res.Total, nil
+}
+
+// get queries the API endpoint path with the query parameters, and decodes
+// the JSON response into result.
+//
+// path must already be escaped.
+func (c *Collector) get(ctx context.Context, path string, query url.Values, result any) error {
+	if query == nil {
+		query = url.Values{}
+	}
+	query.Set("site", site)
+	if c.apiKey != "" {
+		query.Set("key", c.apiKey)
+	}
+	_, err := httpjson.Get(ctx, c.client, c.apiURL+"/"+path+"?"+query.Encode(), result)
+	return err
+}
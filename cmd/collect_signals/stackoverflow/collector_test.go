@@ -0,0 +1,97 @@
+package stackoverflow
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/ossf/criticality_score/internal/collectortest"
+	"github.com/ossf/criticality_score/internal/repomap"
+)
+
+// tagCounts holds the tags known to the fake Stack Exchange API server, and
+// their total and recent question counts.
+var tagCounts = map[string][2]int{
+	"example-json": {1200, 30},
+	"jsonlib":      {300, 5},
+}
+
+func newTestCollector(t *testing.T, tags repomap.Map) *Collector {
+	t.Helper()
+	s := collectortest.NewServer(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("site") != site {
+			http.Error(w, "missing site", http.StatusBadRequest)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/tags/") && strings.HasSuffix(r.URL.Path, "/info"):
+			var items []string
+			for _, tag := range strings.Split(strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/tags/"), "/info"), ";") {
+				if c, ok := tagCounts[tag]; ok {
+					items = append(items, `{"name": "`+tag+`", "count": `+strconv.Itoa(c[0])+`}`)
+				}
+			}
+			w.Write([]byte(`{"items": [` + strings.Join(items, ",") + `]}`))
+		case r.URL.Path == "/questions" && r.URL.Query().Get("fromdate") != "":
+			c := tagCounts[r.URL.Query().Get("tagged")]
+			w.Write([]byte(`{"total": ` + strconv.Itoa(c[1]) + `}`))
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	c := NewCollector(&http.Client{}, "", tags, collectortest.Logger())
+	c.apiURL = s.URL
+	return c
+}
+
+func TestCollect_GuessedTag(t *testing.T) {
+	s := collectortest.Collect[*stackOverflowSet](t, newTestCollector(t, nil), "https://github.com/example/Example_JSON")
+	if got := s.TagCount.Get(); got != 1 {
+		t.Fatalf("TagCount == %d, want 1", got)
+	}
+	if got := s.QuestionCount.Get(); got != 1200 {
+		t.Fatalf("QuestionCount == %d, want 1200", got)
+	}
+	if got := s.RecentQuestionCount.Get(); got != 30 {
+		t.Fatalf("RecentQuestionCount == %d, want 30", got)
+	}
+}
+
+func TestCollect_MappedTags(t *testing.T) {
+	tags := repomap.Map{"github.com/example/json": {"example-json", "jsonlib", "missing"}}
+	s := collectortest.Collect[*stackOverflowSet](t, newTestCollector(t, tags), "https://github.com/example/json")
+	if got := s.TagCount.Get(); got != 2 {
+		t.Fatalf("TagCount == %d, want 2", got)
+	}
+	if got := s.QuestionCount.Get(); got != 1500 {
+		t.Fatalf("QuestionCount == %d, want 1500", got)
+	}
+	if got := s.RecentQuestionCount.Get(); got != 35 {
+		t.Fatalf("RecentQuestionCount == %d, want 35", got)
+	}
+}
+
+func TestCollect_UnknownTag(t *testing.T) {
+	s := collectortest.Collect[*stackOverflowSet](t, newTestCollector(t, nil), "https://github.com/example/unknown")
+	if s.QuestionCount.IsSet() {
+		t.Fatal("QuestionCount is set, want unset")
+	}
+}
+
+func TestGuessTag(t *testing.T) {
+	tests := []struct {
+		path string
+		want string
+	}{
+		{"/example/json", "json"},
+		{"/example/Node_Redis.git", "node-redis"},
+		{"/example/socket.io/", "socket.io"},
+	}
+	for _, test := range tests {
+		if got := guessTag(test.path); got != test.want {
+			t.Fatalf("guessTag(%q) == %q, want %q", test.path, got, test.want)
+		}
+	}
+}
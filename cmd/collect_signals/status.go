@@ -0,0 +1,40 @@
+package main
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// Outcomes recorded for each repository in the status file.
+const (
+	statusCollected = "collected"
+	statusSkipped   = "skipped"
+	statusError     = "error"
+)
+
+// repoStatus records the outcome of collecting a single repository.
+type repoStatus struct {
+	URL    string `json:"url"`
+	Status string `json:"status"`
+	Reason string `json:"reason,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+// statusWriter writes a repoStatus for each repository as a line of JSON.
+//
+// A nil statusWriter discards all the statuses written to it.
+type statusWriter struct {
+	enc *json.Encoder
+}
+
+func newStatusWriter(w io.Writer) *statusWriter {
+	return &statusWriter{enc: json.NewEncoder(w)}
+}
+
+// Write outputs the status s.
+func (w *statusWriter) Write(s repoStatus) error {
+	if w == nil {
+		return nil
+	}
+	return w.enc.Encode(s)
+}
@@ -0,0 +1,35 @@
+package main
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestStatusWriter(t *testing.T) {
+	var b bytes.Buffer
+	w := newStatusWriter(&b)
+	statuses := []repoStatus{
+		{URL: "https://github.com/a/a", Status: statusCollected},
+		{URL: "https://github.com/b/b", Status: statusSkipped, Reason: skipReasonDuplicate},
+		{URL: "https://github.com/c/c", Status: statusError, Error: "failed"},
+	}
+	for _, s := range statuses {
+		if err := w.Write(s); err != nil {
+			t.Fatalf("Write() errored %v, want no error", err)
+		}
+	}
+	want := "" +
+		`{"url":"https://github.com/a/a","status":"collected"}` + "\n" +
+		`{"url":"https://github.com/b/b","status":"skipped","reason":"duplicate"}` + "\n" +
+		`{"url":"https://github.com/c/c","status":"error","error":"failed"}` + "\n"
+	if got := b.String(); got != want {
+		t.Fatalf("output == %q, want %q", got, want)
+	}
+}
+
+func TestStatusWriter_Nil(t *testing.T) {
+	var w *statusWriter
+	if err := w.Write(repoStatus{URL: "https://github.com/a/a", Status: statusCollected}); err != nil {
+		t.Fatalf("Write() errored %v, want no error", err)
+	}
+}
@@ -0,0 +1,147 @@
+// Package swiftpm provides a Collector that returns a Set for the platform
+// compatibility of a Swift package, as reported by the Swift Package Index.
+//
+// The Swift Package Index builds each package it indexes against every
+// platform and Swift version, and publishes the results as badges. The
+// badges are read from its public badge endpoint, which does not need an API
+// token. The index does not publish the number of packages that depend on a
+// package, so dependent counts are not collected.
+package swiftpm
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/ossf/criticality_score/cmd/collect_signals/projectrepo"
+	"github.com/ossf/criticality_score/cmd/collect_signals/signal"
+	"github.com/ossf/criticality_score/internal/httpjson"
+	log "github.com/sirupsen/logrus"
+)
+
+// DefaultAPIURL is the base URL of the Swift Package Index API.
+const DefaultAPIURL = "https://swiftpackageindex.com/api"
+
+const (
+	platformsBadge     = "platforms"
+	swiftVersionsBadge = "swift-versions"
+)
+
+type swiftSet struct {
+	// PlatformCount is the number of platforms the package builds for, such
+	// as iOS, macOS and Linux.
+	PlatformCount signal.Field[int]
+	SupportsLinux signal.Field[bool]
+
+	// SwiftVersionCount is the number of recent Swift versions the package
+	// builds with.
+	SwiftVersionCount signal.Field[int]
+}
+
+func (s *swiftSet) Namespace() signal.Namespace {
+	return signal.Namespace("swiftpm")
+}
+
+// badge is a shields.io endpoint badge. Message lists the compatible
+// platforms or Swift versions, separated by "|".
+type badge struct {
+	Message string `json:"message"`
+	IsError bool   `json:"isError"`
+}
+
+// values returns the items listed in the badge's message.
+func (b *badge) values() []string {
+	var vs []string
+	for _, v := range strings.Split(b.Message, "|") {
+		if v = strings.TrimSpace(v); v != "" {
+			vs = append(vs, v)
+		}
+	}
+	return vs
+}
+
+type Collector struct {
+	client *http.Client
+	logger *log.Logger
+	apiURL string
+}
+
+// NewCollector returns a new Collector that uses the http.Client c to query
+// the Swift Package Index.
+func NewCollector(c *http.Client, logger *log.Logger) *Collector {
+	return &Collector{
+		client: c,
+		logger: logger,
+		apiURL: DefaultAPIURL,
+	}
+}
+
+func (c *Collector) EmptySet() signal.Set {
+	return &swiftSet{}
+}
+
+// IsSupported returns true for github.com repositories, as the Swift Package
+// Index only indexes packages hosted there.
+func (c *Collector) IsSupported(r projectrepo.Repo) bool {
+	return strings.EqualFold(r.URL().Hostname(), "github.com")
+}
+
+// Collect implements the collector.Collector interface.
+//
+// If the repository is not an indexed Swift package the signals are left
+// unset.
+func (c *Collector) Collect(ctx context.Context, r projectrepo.Repo) (signal.Set, error) {
+	s := &swiftSet{}
+	logger := c.logger.WithField("url", r.URL().String())
+
+	logger.Debug("Fetching Swift Package Index platforms")
+	platforms, err := c.queryBadge(ctx, r.URL(), platformsBadge)
+	if err != nil {
+		return nil, err
+	}
+	if platforms == nil {
+		return s, nil
+	}
+	logger.Debug("Fetching Swift Package Index Swift versions")
+	versions, err := c.queryBadge(ctx, r.URL(), swiftVersionsBadge)
+	if err != nil {
+		return nil, err
+	}
+
+	linux := false
+	for _, p := range platforms {
+		if strings.EqualFold(p, "Linux") {
+			linux = true
+		}
+	}
+	s.PlatformCount.Set(len(platforms))
+	s.SupportsLinux.Set(linux)
+	s.SwiftVersionCount.Set(len(versions))
+	return s, nil
+}
+
+// queryBadge returns the items listed in the badge of type badgeType for the
+// repository at u.
+//
+// If the package is not indexed, or has no build results yet, nil is
+// returned.
+func (c *Collector) queryBadge(ctx context.Context, u *url.URL, badgeType string) ([]string, error) {
+	owner, name, ok := strings.Cut(strings.TrimSuffix(strings.Trim(u.Path, "/"), ".git"), "/")
+	if !ok || owner == "" || name == "" || strings.Contains(name, "/") {
+		return nil, nil
+	}
+	badgeURL := c.apiURL + "/packages/" + url.PathEscape(owner) + "/" + url.PathEscape(name) + "/badge?type=" + url.QueryEscape(badgeType)
+	var b badge
+	_, err := httpjson.Get(ctx, c.client, badgeURL, &b)
+	if httpjson.StatusCode(err) == http.StatusNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if b.IsError {
+		return nil, nil
+	}
+	return b.values(), nil
+}
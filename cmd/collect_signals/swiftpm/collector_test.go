@@ -0,0 +1,80 @@
+package swiftpm
+
+import (
+	"context"
+	"net/http"
+	"testing"
+
+	"github.com/ossf/criticality_score/internal/collectortest"
+)
+
+// testRepoURL is the repository the signals are collected for.
+const testRepoURL = "https://github.com/example/example.git"
+
+// newTestCollector returns a Collector where all the requests are handled by
+// h.
+func newTestCollector(t *testing.T, h http.Handler) *Collector {
+	t.Helper()
+	s := collectortest.NewServer(t, h)
+	c := NewCollector(&http.Client{}, collectortest.Logger())
+	c.apiURL = s.URL
+	return c
+}
+
+// badgeHandler responds to badge requests for example/example with the
+// body for the badge type, and 404 to all other requests.
+func badgeHandler(badges map[string]string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		body, ok := badges[r.URL.Query().Get("type")]
+		if r.URL.Path != "/packages/example/example/badge" || !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	}
+}
+
+func TestCollect(t *testing.T) {
+	c := newTestCollector(t, badgeHandler(map[string]string{
+		"platforms":      `{"schemaVersion": 1, "label": "Platforms", "message": "iOS | macOS | visionOS | Linux | tvOS | watchOS"}`,
+		"swift-versions": `{"schemaVersion": 1, "label": "Swift", "message": "6.0 | 5.10 | 5.9"}`,
+	}))
+	s := collectortest.Collect[*swiftSet](t, c, testRepoURL)
+	if got := s.PlatformCount.Get(); got != 6 {
+		t.Fatalf("PlatformCount == %d, want 6", got)
+	}
+	if !s.SupportsLinux.Get() {
+		t.Fatal("SupportsLinux == false, want true")
+	}
+	if got := s.SwiftVersionCount.Get(); got != 3 {
+		t.Fatalf("SwiftVersionCount == %d, want 3", got)
+	}
+}
+
+func TestCollect_NoBuildResults(t *testing.T) {
+	c := newTestCollector(t, badgeHandler(map[string]string{
+		"platforms": `{"schemaVersion": 1, "label": "Platforms", "message": "pending", "isError": true}`,
+	}))
+	s := collectortest.Collect[*swiftSet](t, c, testRepoURL)
+	if s.PlatformCount.IsSet() || s.SupportsLinux.IsSet() || s.SwiftVersionCount.IsSet() {
+		t.Fatal("signals are set, want unset")
+	}
+}
+
+func TestCollect_NotIndexed(t *testing.T) {
+	c := newTestCollector(t, http.NotFoundHandler())
+	s := collectortest.Collect[*swiftSet](t, c, testRepoURL)
+	if s.PlatformCount.IsSet() || s.SupportsLinux.IsSet() || s.SwiftVersionCount.IsSet() {
+		t.Fatal("signals are set, want unset")
+	}
+}
+
+func TestCollect_ServerError(t *testing.T) {
+	c := newTestCollector(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	if _, err := c.Collect(context.Background(), collectortest.NewRepo(t, "https://github.com/example/example")); err == nil {
+		t.Fatal("Collect() returned no error, want an error")
+	}
+}
@@ -0,0 +1,131 @@
+// Package terraform provides a Collector that returns a Set for the number of
+// downloads of a Terraform provider or module from the Terraform Registry.
+//
+// The Terraform Registry only publishes providers and modules from GitHub
+// repositories that follow its naming conventions. The provider "owner/name"
+// is published from the repository "owner/terraform-provider-name", and the
+// module "owner/name/system" from "owner/terraform-system-name".
+package terraform
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+
+	"github.com/ossf/criticality_score/cmd/collect_signals/projectrepo"
+	"github.com/ossf/criticality_score/cmd/collect_signals/signal"
+	"github.com/ossf/criticality_score/internal/httpjson"
+	log "github.com/sirupsen/logrus"
+)
+
+// DefaultAPIURL is the base URL of the Terraform Registry API.
+const DefaultAPIURL = "https://registry.terraform.io/v1"
+
+const (
+	repoPrefix     = "terraform-"
+	providerPrefix = "terraform-provider-"
+)
+
+type terraformSet struct {
+	ProviderDownloadCount signal.Field[int]
+	ModuleDownloadCount   signal.Field[int]
+}
+
+func (s *terraformSet) Namespace() signal.Namespace {
+	return signal.Namespace("terraform")
+}
+
+// registryEntry is a provider or module in the Terraform Registry.
+type registryEntry struct {
+	Source    string `json:"source"`
+	Downloads int    `json:"downloads"`
+}
+
+type Collector struct {
+	client *http.Client
+	logger *log.Logger
+	apiURL string
+}
+
+// NewCollector returns a new Collector that uses the http.Client c to query
+// the Terraform Registry.
+func NewCollector(c *http.Client, logger *log.Logger) *Collector {
+	return &Collector{
+		client: c,
+		logger: logger,
+		apiURL: DefaultAPIURL,
+	}
+}
+
+func (c *Collector) EmptySet() signal.Set {
+	return &terraformSet{}
+}
+
+// IsSupported returns true for github.com repositories named like a
+// Terraform provider or module.
+func (c *Collector) IsSupported(r projectrepo.Repo) bool {
+	u := r.URL()
+	return strings.EqualFold(u.Hostname(), "github.com") && registryPath(u) != ""
+}
+
+// Collect implements the collector.Collector interface.
+//
+// If the repository is not published to the Terraform Registry the signals
+// are left unset.
+func (c *Collector) Collect(ctx context.Context, r projectrepo.Repo) (signal.Set, error) {
+	s := &terraformSet{}
+	p := registryPath(r.URL())
+	if p == "" {
+		return s, nil
+	}
+	c.logger.WithFields(log.Fields{
+		"url":  r.URL().String(),
+		"path": p,
+	}).Debug("Fetching Terraform Registry entry")
+	e := &registryEntry{}
+	_, err := httpjson.Get(ctx, c.client, c.apiURL+p, e)
+	if httpjson.StatusCode(err) == http.StatusNotFound {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	// The conventions allow a repository to be taken for another with the
+	// same owner and name, so the entry must be published from it.
+	if projectrepo.NormalizeURL(e.Source) != projectrepo.NormalizeURL(r.URL().String()) {
+		return s, nil
+	}
+	if strings.HasPrefix(p, "/providers/") {
+		s.ProviderDownloadCount.Set(e.Downloads)
+	} else {
+		s.ModuleDownloadCount.Set(e.Downloads)
+	}
+	return s, nil
+}
+
+// registryPath returns the path of the Terraform Registry API for the
+// provider or module published from the repository at u, such as
+// "/providers/hashicorp/aws" or "/modules/terraform-aws-modules/vpc/aws".
+//
+// If the repository is not named like a provider or module, "" is returned.
+func registryPath(u *url.URL) string {
+	owner, repo := path.Split(strings.TrimSuffix(strings.Trim(u.Path, "/"), ".git"))
+	owner = strings.ToLower(strings.Trim(owner, "/"))
+	repo = strings.ToLower(repo)
+	if owner == "" || strings.Contains(owner, "/") || !strings.HasPrefix(repo, repoPrefix) {
+		return ""
+	}
+	if name := strings.TrimPrefix(repo, providerPrefix); name != repo {
+		if name == "" {
+			return ""
+		}
+		return "/providers/" + url.PathEscape(owner) + "/" + url.PathEscape(name)
+	}
+	system, name, ok := strings.Cut(strings.TrimPrefix(repo, repoPrefix), "-")
+	if !ok || system == "" || name == "" {
+		return ""
+	}
+	return "/modules/" + url.PathEscape(owner) + "/" + url.PathEscape(name) + "/" + url.PathEscape(system)
+}
@@ -0,0 +1,81 @@
+package terraform
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/ossf/criticality_score/internal/collectortest"
+)
+
+// newTestCollector returns a Collector where all the requests are answered
+// with the bodies in responses, keyed by path.
+func newTestCollector(t *testing.T, responses map[string]string) *Collector {
+	t.Helper()
+	s := collectortest.NewServer(t, collectortest.PathHandler(responses))
+	c := NewCollector(&http.Client{}, collectortest.Logger())
+	c.apiURL = s.URL
+	return c
+}
+
+func TestRegistryPath(t *testing.T) {
+	tests := map[string]string{
+		"https://github.com/hashicorp/terraform-provider-aws":                 "/providers/hashicorp/aws",
+		"https://github.com/terraform-aws-modules/terraform-aws-vpc.git":      "/modules/terraform-aws-modules/vpc/aws",
+		"https://github.com/Azure/terraform-azurerm-caf-enterprise-scale":     "/modules/azure/caf-enterprise-scale/azurerm",
+		"https://github.com/hashicorp/terraform":                              "",
+		"https://github.com/hashicorp/terraform-provider-":                    "",
+		"https://github.com/example/vpc":                                      "",
+		"https://github.com/gruntwork-io/terraform-aws-vpc/tree/main/modules": "",
+	}
+	for raw, want := range tests {
+		u, _ := url.Parse(raw)
+		if got := registryPath(u); got != want {
+			t.Errorf("registryPath(%q) == %q, want %q", raw, got, want)
+		}
+	}
+}
+
+func TestCollect_Provider(t *testing.T) {
+	c := newTestCollector(t, map[string]string{
+		"/providers/hashicorp/aws": `{"id": "hashicorp/aws/5.40.0", "namespace": "hashicorp", "name": "aws", "source": "https://github.com/hashicorp/terraform-provider-aws", "downloads": 2500000000}`,
+	})
+	s := collectortest.Collect[*terraformSet](t, c, "https://github.com/hashicorp/terraform-provider-aws")
+	if got := s.ProviderDownloadCount.Get(); got != 2500000000 {
+		t.Fatalf("ProviderDownloadCount == %d, want 2500000000", got)
+	}
+	if s.ModuleDownloadCount.IsSet() {
+		t.Fatal("ModuleDownloadCount is set, want unset")
+	}
+}
+
+func TestCollect_Module(t *testing.T) {
+	c := newTestCollector(t, map[string]string{
+		"/modules/terraform-aws-modules/vpc/aws": `{"id": "terraform-aws-modules/vpc/aws/5.5.0", "source": "https://github.com/terraform-aws-modules/terraform-aws-vpc", "downloads": 90000000}`,
+	})
+	s := collectortest.Collect[*terraformSet](t, c, "https://github.com/terraform-aws-modules/terraform-aws-vpc")
+	if got := s.ModuleDownloadCount.Get(); got != 90000000 {
+		t.Fatalf("ModuleDownloadCount == %d, want 90000000", got)
+	}
+	if s.ProviderDownloadCount.IsSet() {
+		t.Fatal("ProviderDownloadCount is set, want unset")
+	}
+}
+
+func TestCollect_OtherSource(t *testing.T) {
+	c := newTestCollector(t, map[string]string{
+		"/providers/example/foo": `{"source": "https://github.com/example/terraform-provider-foo-fork", "downloads": 10}`,
+	})
+	s := collectortest.Collect[*terraformSet](t, c, "https://github.com/example/terraform-provider-foo")
+	if s.ProviderDownloadCount.IsSet() || s.ModuleDownloadCount.IsSet() {
+		t.Fatal("signals are set, want unset")
+	}
+}
+
+func TestCollect_NotPublished(t *testing.T) {
+	c := newTestCollector(t, nil)
+	s := collectortest.Collect[*terraformSet](t, c, "https://github.com/example/terraform-provider-foo")
+	if s.ProviderDownloadCount.IsSet() || s.ModuleDownloadCount.IsSet() {
+		t.Fatal("signals are set, want unset")
+	}
+}
@@ -0,0 +1,187 @@
+// Package wikidata provides a Collector that returns a Set for how notable a
+// repository's project is, based on its Wikidata entity.
+//
+// The entities are those whose source code repository (P1324) or official
+// website (P856) is the repository. The number of sitelinks an entity has is
+// the number of Wikimedia pages, such as Wikipedia articles in each language,
+// that are about it.
+package wikidata
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/ossf/criticality_score/cmd/collect_signals/projectrepo"
+	"github.com/ossf/criticality_score/cmd/collect_signals/signal"
+	"github.com/ossf/criticality_score/internal/httpjson"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	// DefaultAPIURL is the URL of the Wikidata SPARQL query service.
+	DefaultAPIURL = "https://query.wikidata.org/sparql"
+
+	// userAgent identifies requests to the query service, which blocks
+	// clients that do not identify themselves.
+	userAgent = "criticality_score (https://github.com/ossf/criticality_score)"
+)
+
+// entityQuery finds the entities for any of the repository URLs in the
+// VALUES clause, with their number of sitelinks and Wikipedia articles.
+const entityQuery = `SELECT ?item ?sitelinks (COUNT(DISTINCT ?article) AS ?articles) WHERE {
+  VALUES ?repo { %s }
+  { ?item wdt:P1324 ?repo . } UNION { ?item wdt:P856 ?repo . }
+  ?item wikibase:sitelinks ?sitelinks .
+  OPTIONAL {
+    ?article schema:about ?item ;
+             schema:isPartOf ?wiki .
+    ?wiki wikibase:wikiGroup "wikipedia" .
+  }
+}
+GROUP BY ?item ?sitelinks`
+
+type wikidataSet struct {
+	// HasWikipediaArticle is true if a Wikipedia, in any language, has an
+	// article about the project.
+	HasWikipediaArticle signal.Field[bool]
+
+	// SitelinkCount is the highest number of sitelinks of any entity for the
+	// repository.
+	SitelinkCount signal.Field[int]
+}
+
+func (s *wikidataSet) Namespace() signal.Namespace {
+	return signal.Namespace("wikidata")
+}
+
+type binding struct {
+	Value string `json:"value"`
+}
+
+type sparqlResult struct {
+	Results struct {
+		Bindings []map[string]binding `json:"bindings"`
+	} `json:"results"`
+}
+
+// entity is a Wikidata entity for a repository.
+type entity struct {
+	Sitelinks int
+	Articles  int
+}
+
+type Collector struct {
+	client *http.Client
+	logger *log.Logger
+	apiURL string
+}
+
+// NewCollector returns a new Collector that uses the http.Client c to query
+// Wikidata.
+func NewCollector(c *http.Client, logger *log.Logger) *Collector {
+	return &Collector{
+		client: c,
+		logger: logger,
+		apiURL: DefaultAPIURL,
+	}
+}
+
+func (c *Collector) EmptySet() signal.Set {
+	return &wikidataSet{}
+}
+
+func (c *Collector) IsSupported(r projectrepo.Repo) bool {
+	return true
+}
+
+// Collect implements the collector.Collector interface.
+//
+// If Wikidata has no entity for the repository the signals are left unset.
+func (c *Collector) Collect(ctx context.Context, r projectrepo.Repo) (signal.Set, error) {
+	s := &wikidataSet{}
+	c.logger.WithField("url", r.URL().String()).Debug("Fetching Wikidata entities")
+	entities, err := c.queryEntities(ctx, r.URL())
+	if err != nil {
+		return nil, err
+	}
+	if len(entities) == 0 {
+		return s, nil
+	}
+	sitelinks := 0
+	articles := 0
+	for _, e := range entities {
+		if e.Sitelinks > sitelinks {
+			sitelinks = e.Sitelinks
+		}
+		articles += e.Articles
+	}
+	s.HasWikipediaArticle.Set(articles > 0)
+	s.SitelinkCount.Set(sitelinks)
+	return s, nil
+}
+
+// queryEntities returns the Wikidata entities for the repository at u.
+func (c *Collector) queryEntities(ctx context.Context, u *url.URL) ([]entity, error) {
+	urls := repoURLs(u)
+	if len(urls) == 0 {
+		return nil, nil
+	}
+	var iris []string
+	for _, v := range urls {
+		iris = append(iris, "<"+v+">")
+	}
+	query := url.Values{
+		"query":  {fmt.Sprintf(entityQuery, strings.Join(iris, " "))},
+		"format": {"json"},
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.apiURL+"?"+query.Encode(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/sparql-results+json")
+	req.Header.Set("User-Agent", userAgent)
+	var res sparqlResult
+	if _, err := httpjson.Do(c.client, req, &res); err != nil {
+		return nil, err
+	}
+	var entities []entity
+	for _, b := range res.Results.Bindings {
+		// Both values are integers, so parse errors are ignored.
+		sitelinks, _ := strconv.Atoi(b["sitelinks"].Value)
+		articles, _ := strconv.Atoi(b["articles"].Value)
+		entities = append(entities, entity{Sitelinks: sitelinks, Articles: articles})
+	}
+	return entities, nil
+}
+
+// repoURLs returns the forms of the repository URL u that may be used in
+// Wikidata: with either the http or https scheme, with or without a
+// trailing "/" or ".git", and in both the original and lower case.
+//
+// If u cannot be safely used in a SPARQL query, nil is returned.
+func repoURLs(u *url.URL) []string {
+	host := strings.TrimPrefix(strings.ToLower(u.Hostname()), "www.")
+	p := strings.TrimSuffix(strings.Trim(u.Path, "/"), ".git")
+	if host == "" || p == "" || strings.ContainsAny(host+p, "<>\"{}|^`\\ ") {
+		return nil
+	}
+	seen := make(map[string]struct{})
+	var urls []string
+	for _, scheme := range []string{"https", "http"} {
+		for _, path := range []string{p, strings.ToLower(p)} {
+			for _, suffix := range []string{"", "/", ".git"} {
+				v := scheme + "://" + host + "/" + path + suffix
+				if _, ok := seen[v]; ok {
+					continue
+				}
+				seen[v] = struct{}{}
+				urls = append(urls, v)
+			}
+		}
+	}
+	return urls
+}
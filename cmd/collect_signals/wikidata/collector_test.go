@@ -0,0 +1,96 @@
+package wikidata
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/ossf/criticality_score/internal/collectortest"
+)
+
+// testRepoURL is the repository the signals are collected for.
+const testRepoURL = "https://github.com/curl/curl"
+
+// newTestCollector returns a Collector where all the requests are handled by
+// h.
+func newTestCollector(t *testing.T, h http.Handler) *Collector {
+	t.Helper()
+	s := collectortest.NewServer(t, h)
+	c := NewCollector(&http.Client{}, collectortest.Logger())
+	c.apiURL = s.URL
+	return c
+}
+
+func jsonHandler(t *testing.T, body string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Header.Get("User-Agent") != userAgent {
+			t.Errorf("User-Agent == %q, want %q", r.Header.Get("User-Agent"), userAgent)
+		}
+		if q := r.URL.Query().Get("query"); !strings.Contains(q, "<https://github.com/curl/curl>") {
+			t.Errorf("query %q does not contain the repository URL", q)
+		}
+		w.Header().Set("Content-Type", "application/sparql-results+json")
+		w.Write([]byte(body))
+	}
+}
+
+func TestCollect(t *testing.T) {
+	c := newTestCollector(t, jsonHandler(t, `{"results": {"bindings": [
+		{"item": {"type": "uri", "value": "http://www.wikidata.org/entity/Q286306"}, "sitelinks": {"value": "38"}, "articles": {"value": "31"}},
+		{"item": {"type": "uri", "value": "http://www.wikidata.org/entity/Q1"}, "sitelinks": {"value": "2"}, "articles": {"value": "0"}}
+	]}}`))
+	s := collectortest.Collect[*wikidataSet](t, c, testRepoURL)
+	if !s.HasWikipediaArticle.Get() {
+		t.Fatal("HasWikipediaArticle == false, want true")
+	}
+	if got := s.SitelinkCount.Get(); got != 38 {
+		t.Fatalf("SitelinkCount == %d, want 38", got)
+	}
+}
+
+func TestCollect_NoArticles(t *testing.T) {
+	c := newTestCollector(t, jsonHandler(t, `{"results": {"bindings": [
+		{"item": {"type": "uri", "value": "http://www.wikidata.org/entity/Q1"}, "sitelinks": {"value": "1"}, "articles": {"value": "0"}}
+	]}}`))
+	s := collectortest.Collect[*wikidataSet](t, c, testRepoURL)
+	if s.HasWikipediaArticle.Get() {
+		t.Fatal("HasWikipediaArticle == true, want false")
+	}
+}
+
+func TestCollect_NoEntity(t *testing.T) {
+	c := newTestCollector(t, jsonHandler(t, `{"results": {"bindings": []}}`))
+	s := collectortest.Collect[*wikidataSet](t, c, testRepoURL)
+	if s.HasWikipediaArticle.IsSet() || s.SitelinkCount.IsSet() {
+		t.Fatal("signals are set, want unset")
+	}
+}
+
+func TestCollect_ServerError(t *testing.T) {
+	c := newTestCollector(t, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusTooManyRequests)
+	}))
+	if _, err := c.Collect(context.Background(), collectortest.NewRepo(t, "https://github.com/curl/curl")); err == nil {
+		t.Fatal("Collect() returned no error, want an error")
+	}
+}
+
+func TestRepoURLs(t *testing.T) {
+	u, _ := url.Parse("https://www.GitHub.com/Example/Repo.git")
+	urls := repoURLs(u)
+	if len(urls) != 12 {
+		t.Fatalf("repoURLs() returned %d urls, want 12", len(urls))
+	}
+	if urls[0] != "https://github.com/Example/Repo" {
+		t.Fatalf("repoURLs()[0] == %q, want %q", urls[0], "https://github.com/Example/Repo")
+	}
+}
+
+func TestRepoURLs_Unsafe(t *testing.T) {
+	u, _ := url.Parse("https://github.com/example/repo%3E")
+	if urls := repoURLs(u); urls != nil {
+		t.Fatalf("repoURLs() == %v, want nil", urls)
+	}
+}
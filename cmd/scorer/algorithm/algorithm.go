@@ -4,4 +4,18 @@ type Algorithm interface {
 	Score(record map[string]float64) float64
 }
 
+// BreakdownAlgorithm is an Algorithm that can also report how much each Input
+// contributed to the score.
+type BreakdownAlgorithm interface {
+	Algorithm
+
+	// ScoreWithBreakdown returns the score for the record, along with a map
+	// from each Input's Name to the Input's contribution to the score.
+	//
+	// Inputs that are not present in the record are not included in the
+	// map. This allows a missing Input to be distinguished from an Input
+	// that contributed zero.
+	ScoreWithBreakdown(record map[string]float64) (float64, map[string]float64)
+}
+
 type Factory func(inputs []*Input) (Algorithm, error)
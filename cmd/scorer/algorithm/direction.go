@@ -0,0 +1,55 @@
+package algorithm
+
+import (
+	"errors"
+	"fmt"
+)
+
+var ErrInvalidDirection = errors.New("invalid direction")
+
+// Direction indicates whether an Input's value makes a project more or less
+// critical as it increases.
+type Direction int
+
+const (
+	// DirectionPositive means larger values increase the score.
+	DirectionPositive Direction = iota
+
+	// DirectionNegative means larger values decrease the score. The
+	// normalized value is inverted, so it becomes 1 - normalized.
+	DirectionNegative
+)
+
+// String implements the fmt.Stringer interface.
+func (d Direction) String() string {
+	text, err := d.MarshalText()
+	if err != nil {
+		return ""
+	}
+	return string(text)
+}
+
+// MarshalText implements the encoding.TextMarshaler interface.
+func (d Direction) MarshalText() ([]byte, error) {
+	switch d {
+	case DirectionPositive:
+		return []byte("positive"), nil
+	case DirectionNegative:
+		return []byte("negative"), nil
+	default:
+		return []byte{}, fmt.Errorf("%w: %d", ErrInvalidDirection, d)
+	}
+}
+
+// UnmarshalText implements the encoding.TextUnmarshaler interface.
+func (d *Direction) UnmarshalText(text []byte) error {
+	switch string(text) {
+	case "positive":
+		*d = DirectionPositive
+	case "negative":
+		*d = DirectionNegative
+	default:
+		return fmt.Errorf("%w: %q", ErrInvalidDirection, string(text))
+	}
+	return nil
+}
@@ -0,0 +1,26 @@
+package algorithm
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestDirectionUnmarshalText(t *testing.T) {
+	var d Direction
+	if err := d.UnmarshalText([]byte("negative")); err != nil {
+		t.Fatalf("UnmarshalText() errored %v, want no error", err)
+	}
+	if d != DirectionNegative {
+		t.Fatalf("UnmarshalText() == %v, want %v", d, DirectionNegative)
+	}
+	if got := d.String(); got != "negative" {
+		t.Fatalf("String() == %q, want %q", got, "negative")
+	}
+}
+
+func TestDirectionUnmarshalText_Invalid(t *testing.T) {
+	var d Direction
+	if err := d.UnmarshalText([]byte("up")); !errors.Is(err, ErrInvalidDirection) {
+		t.Fatalf("UnmarshalText() errored %v, want %v", err, ErrInvalidDirection)
+	}
+}
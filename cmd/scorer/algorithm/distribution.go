@@ -1,7 +1,19 @@
 package algorithm
 
 import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
 	"math"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+var (
+	ErrUnknownDistribution      = errors.New("unknown distribution")
+	ErrInvalidDistributionParam = errors.New("invalid distribution parameter")
 )
 
 type Distribution struct {
@@ -17,21 +29,196 @@ func (d *Distribution) Normalize(v float64) float64 {
 	return d.normalizeFn(v)
 }
 
+// distributionParams holds the parameters passed to a distribution.
+//
+// Parameters are removed as they are read, so that any unknown parameters
+// remain once the distribution has been created.
+type distributionParams map[string]float64
+
+// optional returns the value of the parameter key, or def if it is not set.
+func (p distributionParams) optional(key string, def float64) float64 {
+	v, ok := p[key]
+	if !ok {
+		return def
+	}
+	delete(p, key)
+	return v
+}
+
+// required returns the value of the parameter key, or an error if it is not
+// set.
+func (p distributionParams) required(key string) (float64, error) {
+	v, ok := p[key]
+	if !ok {
+		return 0, fmt.Errorf("%w: missing %s", ErrInvalidDistributionParam, key)
+	}
+	delete(p, key)
+	return v, nil
+}
+
+// A distributionFactory returns the normalization function for a distribution
+// using the supplied parameters.
+type distributionFactory func(distributionParams) (func(float64) float64, error)
+
 var (
-	normalizationFuncs = map[string]func(float64) float64{
-		"linear":  func(v float64) float64 { return v },
-		"zapfian": func(v float64) float64 { return math.Log(1 + v) },
+	distributionFactories = map[string]distributionFactory{
+		"linear": func(_ distributionParams) (func(float64) float64, error) {
+			return func(v float64) float64 { return v }, nil
+		},
+		"zapfian": func(p distributionParams) (func(float64) float64, error) {
+			base := p.optional("base", math.E)
+			if base <= 1 {
+				return nil, fmt.Errorf("%w: base must be greater than 1", ErrInvalidDistributionParam)
+			}
+			logBase := math.Log(base)
+			return func(v float64) float64 { return math.Log(1+v) / logBase }, nil
+		},
+		"log10": func(_ distributionParams) (func(float64) float64, error) {
+			return func(v float64) float64 { return math.Log10(1 + v) }, nil
+		},
+		"sqrt": func(_ distributionParams) (func(float64) float64, error) {
+			return func(v float64) float64 { return math.Sqrt(math.Max(v, 0)) }, nil
+		},
+		"clamp": func(p distributionParams) (func(float64) float64, error) {
+			max, err := p.required("max")
+			if err != nil {
+				return nil, err
+			}
+			return func(v float64) float64 { return math.Min(v, max) }, nil
+		},
 	}
 	DefaultDistributionName = "linear"
+
+	// PercentileDistributionName is the name of the distribution returned by
+	// NewPercentileDistribution. It requires reference data, so it can't be
+	// created by ParseDistribution.
+	PercentileDistributionName = "percentile"
 )
 
-func LookupDistribution(name string) *Distribution {
-	fn, ok := normalizationFuncs[name]
+// parseDistributionSpec splits a distribution spec of the form
+// "name:key=value,key=value" into the name and its parameters.
+func parseDistributionSpec(spec string) (string, distributionParams, error) {
+	name, rawParams, hasParams := strings.Cut(spec, ":")
+	params := make(distributionParams)
+	if !hasParams {
+		return name, params, nil
+	}
+	for _, kv := range strings.Split(rawParams, ",") {
+		k, v, ok := strings.Cut(kv, "=")
+		k = strings.TrimSpace(k)
+		if !ok || k == "" {
+			return "", nil, fmt.Errorf("%w: %q", ErrInvalidDistributionParam, kv)
+		}
+		f, err := strconv.ParseFloat(strings.TrimSpace(v), 64)
+		if err != nil {
+			return "", nil, fmt.Errorf("%w: %s: %v", ErrInvalidDistributionParam, k, err)
+		}
+		if _, exists := params[k]; exists {
+			return "", nil, fmt.Errorf("%w: %s set more than once", ErrInvalidDistributionParam, k)
+		}
+		params[k] = f
+	}
+	return name, params, nil
+}
+
+// ParseDistribution returns the Distribution described by spec.
+//
+// The spec is the name of the distribution, optionally followed by a colon
+// and a comma separated list of parameters. For example "linear",
+// "zapfian:base=10" or "clamp:max=5000".
+//
+// An error is returned if the distribution is unknown, or if a parameter is
+// invalid, missing or not supported by the distribution.
+func ParseDistribution(spec string) (*Distribution, error) {
+	name, params, err := parseDistributionSpec(spec)
+	if err != nil {
+		return nil, err
+	}
+	factory, ok := distributionFactories[name]
 	if !ok {
-		return nil
+		return nil, fmt.Errorf("%w: %s", ErrUnknownDistribution, name)
+	}
+	fn, err := factory(params)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", name, err)
+	}
+	if len(params) > 0 {
+		var unknown []string
+		for k := range params {
+			unknown = append(unknown, k)
+		}
+		sort.Strings(unknown)
+		return nil, fmt.Errorf("%w: %s: unknown %s", ErrInvalidDistributionParam, name, strings.Join(unknown, ", "))
 	}
 	return &Distribution{
-		name:        name,
+		name:        spec,
 		normalizeFn: fn,
+	}, nil
+}
+
+// LookupDistribution returns the Distribution described by spec, or nil if
+// spec is not valid. See ParseDistribution().
+func LookupDistribution(spec string) *Distribution {
+	d, err := ParseDistribution(spec)
+	if err != nil {
+		return nil
+	}
+	return d
+}
+
+// NewPercentileDistribution returns a Distribution that normalizes a value to
+// its percentile rank, between 0 and 1, within the reference samples.
+//
+// Values between two samples are linearly interpolated. Values at or below the
+// smallest sample are normalized to 0, and values at or above the largest
+// sample are normalized to 1.
+//
+// An error is returned if there are no samples.
+func NewPercentileDistribution(samples []float64) (*Distribution, error) {
+	if len(samples) == 0 {
+		return nil, errors.New("percentile distribution requires at least one sample")
+	}
+	sorted := append([]float64(nil), samples...)
+	sort.Float64s(sorted)
+	last := len(sorted) - 1
+	return &Distribution{
+		name: PercentileDistributionName,
+		normalizeFn: func(v float64) float64 {
+			if v <= sorted[0] {
+				return 0
+			}
+			if v >= sorted[last] {
+				return 1
+			}
+			// i is the last sample <= v. As v is between the smallest and
+			// largest samples, sorted[i+1] must be > v.
+			i := sort.Search(len(sorted), func(j int) bool { return sorted[j] > v }) - 1
+			frac := (v - sorted[i]) / (sorted[i+1] - sorted[i])
+			return (float64(i) + frac) / float64(last)
+		},
+	}, nil
+}
+
+// LoadPercentileDistribution reads the reference samples from r and returns a
+// percentile Distribution. See NewPercentileDistribution().
+//
+// r must contain a single value on each line. Empty lines are ignored.
+func LoadPercentileDistribution(r io.Reader) (*Distribution, error) {
+	var samples []float64
+	scanner := bufio.NewScanner(r)
+	for n := 1; scanner.Scan(); n++ {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" {
+			continue
+		}
+		v, err := strconv.ParseFloat(line, 64)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", n, err)
+		}
+		samples = append(samples, v)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
 	}
+	return NewPercentileDistribution(samples)
 }
@@ -0,0 +1,133 @@
+package algorithm
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+)
+
+const tolerance = 1e-9
+
+func TestParseDistribution(t *testing.T) {
+	tests := []struct {
+		spec  string
+		value float64
+		want  float64
+	}{
+		{"linear", 9, 9},
+		{"zapfian", math.E - 1, 1},
+		{"zapfian:base=10", 99, 2},
+		{"zapfian: base = 2", 7, 3},
+		{"log10", 999, 3},
+		{"sqrt", 16, 4},
+		{"sqrt", -4, 0},
+		{"clamp:max=5000", 4000, 4000},
+		{"clamp:max=5000", 6000, 5000},
+	}
+	for _, test := range tests {
+		t.Run(test.spec, func(t *testing.T) {
+			d, err := ParseDistribution(test.spec)
+			if err != nil {
+				t.Fatalf("ParseDistribution() errored %v, want no error", err)
+			}
+			if got := d.Normalize(test.value); math.Abs(got-test.want) > tolerance {
+				t.Fatalf("Normalize(%v) == %v, want %v", test.value, got, test.want)
+			}
+			if s := d.String(); s != test.spec {
+				t.Fatalf("String() == %q, want %q", s, test.spec)
+			}
+		})
+	}
+}
+
+func TestParseDistribution_Errors(t *testing.T) {
+	tests := []struct {
+		spec string
+		want error
+	}{
+		{"unknown", ErrUnknownDistribution},
+		{"unknown:max=1", ErrUnknownDistribution},
+		{"linear:max=1", ErrInvalidDistributionParam},
+		{"zapfian:bsae=10", ErrInvalidDistributionParam},
+		{"zapfian:base=1", ErrInvalidDistributionParam},
+		{"zapfian:base", ErrInvalidDistributionParam},
+		{"zapfian:base=ten", ErrInvalidDistributionParam},
+		{"clamp", ErrInvalidDistributionParam},
+		{"clamp:max=1,max=2", ErrInvalidDistributionParam},
+	}
+	for _, test := range tests {
+		t.Run(test.spec, func(t *testing.T) {
+			_, err := ParseDistribution(test.spec)
+			if !errors.Is(err, test.want) {
+				t.Fatalf("ParseDistribution() errored %v, want %v", err, test.want)
+			}
+		})
+	}
+}
+
+func TestLookupDistribution_Invalid(t *testing.T) {
+	if d := LookupDistribution("clamp"); d != nil {
+		t.Fatalf("LookupDistribution() == %v, want nil", d)
+	}
+}
+
+func TestNewPercentileDistribution(t *testing.T) {
+	d, err := NewPercentileDistribution([]float64{100, 0, 10, 1000, 10})
+	if err != nil {
+		t.Fatalf("NewPercentileDistribution() errored %v, want no error", err)
+	}
+	tests := []struct {
+		value float64
+		want  float64
+	}{
+		{-5, 0},
+		{0, 0},
+		{5, 0.125},
+		{10, 0.5},
+		{55, 0.625},
+		{100, 0.75},
+		{1000, 1},
+		{1e9, 1},
+	}
+	for _, test := range tests {
+		if got := d.Normalize(test.value); math.Abs(got-test.want) > tolerance {
+			t.Fatalf("Normalize(%v) == %v, want %v", test.value, got, test.want)
+		}
+	}
+}
+
+func TestNewPercentileDistribution_SingleSample(t *testing.T) {
+	d, err := NewPercentileDistribution([]float64{5})
+	if err != nil {
+		t.Fatalf("NewPercentileDistribution() errored %v, want no error", err)
+	}
+	if got := d.Normalize(4); got != 0 {
+		t.Fatalf("Normalize(4) == %v, want 0", got)
+	}
+	if got := d.Normalize(6); got != 1 {
+		t.Fatalf("Normalize(6) == %v, want 1", got)
+	}
+}
+
+func TestNewPercentileDistribution_NoSamples(t *testing.T) {
+	if _, err := NewPercentileDistribution(nil); err == nil {
+		t.Fatal("NewPercentileDistribution() returned no error, want an error")
+	}
+}
+
+func TestLoadPercentileDistribution(t *testing.T) {
+	d, err := LoadPercentileDistribution(strings.NewReader("30\n\n10\n 20 \n"))
+	if err != nil {
+		t.Fatalf("LoadPercentileDistribution() errored %v, want no error", err)
+	}
+	if got := d.Normalize(20); got != 0.5 {
+		t.Fatalf("Normalize(20) == %v, want 0.5", got)
+	}
+}
+
+func TestLoadPercentileDistribution_Invalid(t *testing.T) {
+	if _, err := LoadPercentileDistribution(strings.NewReader("10\nabc\n")); err == nil {
+		t.Fatal("LoadPercentileDistribution() returned no error, want an error")
+	}
+}
@@ -33,13 +33,31 @@ func (b Bounds) Threshold() float64 {
 }
 
 type Input struct {
+	// Name is used to identify the Input. The name does not need to be
+	// unique, allowing the same field to be used by multiple Inputs.
+	Name string
+
 	Bounds       *Bounds
 	Weight       float64
 	Distribution *Distribution
 	Source       Value
 	Tags         []string
+
+	// MaxContribution, if set, is the largest contribution the Input can make
+	// to a score. See Contribution() and CappedValue().
+	MaxContribution *float64
+
+	// Direction, if DirectionNegative, inverts the normalized value so that
+	// larger raw values contribute less. Bounds must be set, so that the
+	// normalized value is between 0 and 1.
+	Direction Direction
 }
 
+// Value returns the normalized value of the Input for the given fields.
+//
+// If the Input's Direction is DirectionNegative the value is inverted after
+// the Distribution has normalized it, so the inversion does not change the
+// shape of the Distribution.
 func (i *Input) Value(fields map[string]float64) (float64, bool) {
 	v, ok := i.Source.Value(fields)
 	if !ok {
@@ -50,5 +68,49 @@ func (i *Input) Value(fields map[string]float64) (float64, bool) {
 		v = i.Bounds.Apply(v)
 		den = i.Distribution.Normalize(i.Bounds.Threshold())
 	}
-	return i.Distribution.Normalize(v) / den, true
+	v = i.Distribution.Normalize(v) / den
+	if i.Direction == DirectionNegative {
+		v = 1 - v
+	}
+	return v, true
+}
+
+// Contribution returns the weighted value of the Input for the given fields.
+//
+// The raw value is processed in the following order:
+//  1. the Bounds are applied to the raw value.
+//  2. the Distribution normalizes the bounded value.
+//  3. the normalized value is inverted, if the Direction is negative.
+//  4. the normalized value is multiplied by the Weight.
+//  5. the weighted value is capped to MaxContribution, if set.
+//
+// The MaxContribution is applied after normalization, so it limits how much a
+// single Input can contribute to a score, regardless of the Input's raw value.
+func (i *Input) Contribution(fields map[string]float64) (float64, bool) {
+	v, ok := i.Value(fields)
+	if !ok {
+		return 0, false
+	}
+	c := i.Weight * v
+	if i.MaxContribution != nil && c > *i.MaxContribution {
+		c = *i.MaxContribution
+	}
+	return c, true
+}
+
+// CappedValue returns the normalized value of the Input for the given fields,
+// capped so that the weighted value does not exceed MaxContribution.
+//
+// It is used by algorithms that combine the value and the Weight themselves,
+// rather than summing each Input's Contribution. The value is only capped
+// when the Weight is positive.
+func (i *Input) CappedValue(fields map[string]float64) (float64, bool) {
+	v, ok := i.Value(fields)
+	if !ok {
+		return 0, false
+	}
+	if i.MaxContribution != nil && i.Weight > 0 && i.Weight*v > *i.MaxContribution {
+		v = *i.MaxContribution / i.Weight
+	}
+	return v, true
 }
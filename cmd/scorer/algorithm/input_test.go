@@ -0,0 +1,109 @@
+package algorithm
+
+import (
+	"math"
+	"testing"
+)
+
+func testInput(field string, weight float64) *Input {
+	return &Input{
+		Weight:       weight,
+		Distribution: LookupDistribution("linear"),
+		Source:       Field(field),
+	}
+}
+
+func TestContribution(t *testing.T) {
+	i := testInput("a", 2)
+	c, ok := i.Contribution(map[string]float64{"a": 3})
+	if !ok {
+		t.Fatal("Contribution() returned false, want true")
+	}
+	if c != 6 {
+		t.Fatalf("Contribution() == %v, want 6", c)
+	}
+}
+
+func TestContribution_Missing(t *testing.T) {
+	i := testInput("a", 2)
+	if _, ok := i.Contribution(map[string]float64{"b": 3}); ok {
+		t.Fatal("Contribution() returned true, want false")
+	}
+}
+
+func TestContribution_MaxContribution(t *testing.T) {
+	max := 5.0
+	runaway := testInput("runaway", 2)
+	runaway.MaxContribution = &max
+	other := testInput("other", 2)
+	other.MaxContribution = &max
+
+	record := map[string]float64{"runaway": 1000, "other": 1.5}
+	if c, _ := runaway.Contribution(record); c != max {
+		t.Errorf("Contribution() == %v, want %v", c, max)
+	}
+	if c, _ := other.Contribution(record); c != 3 {
+		t.Errorf("Contribution() == %v, want 3", c)
+	}
+}
+
+func TestCappedValue(t *testing.T) {
+	max := 5.0
+	runaway := testInput("runaway", 2)
+	runaway.MaxContribution = &max
+	other := testInput("other", 2)
+	other.MaxContribution = &max
+
+	record := map[string]float64{"runaway": 1000, "other": 1.5}
+	if v, _ := runaway.CappedValue(record); v != 2.5 {
+		t.Errorf("CappedValue() == %v, want 2.5", v)
+	}
+	if v, _ := other.CappedValue(record); v != 1.5 {
+		t.Errorf("CappedValue() == %v, want 1.5", v)
+	}
+	if _, ok := runaway.CappedValue(map[string]float64{}); ok {
+		t.Error("CappedValue() returned true, want false")
+	}
+}
+
+func TestContribution_MaxContributionAfterNormalization(t *testing.T) {
+	// With bounds and a zapfian distribution the normalized value is always
+	// in the range [0, 1], so the weighted value is at most the weight.
+	max := 0.5
+	i := &Input{
+		Bounds:          &Bounds{Upper: 100},
+		Weight:          1,
+		Distribution:    LookupDistribution("zapfian"),
+		Source:          Field("a"),
+		MaxContribution: &max,
+	}
+	c, _ := i.Contribution(map[string]float64{"a": 1})
+	want := math.Log(2) / math.Log(101)
+	if c != want {
+		t.Errorf("Contribution() == %v, want %v", c, want)
+	}
+	if c, _ := i.Contribution(map[string]float64{"a": 1000}); c != max {
+		t.Errorf("Contribution() == %v, want %v", c, max)
+	}
+}
+
+func TestValue_NegativeDirection(t *testing.T) {
+	i := &Input{
+		Bounds:       &Bounds{Upper: 100},
+		Weight:       1,
+		Distribution: LookupDistribution("zapfian"),
+		Source:       Field("a"),
+		Direction:    DirectionNegative,
+	}
+	// The inversion happens after normalization.
+	v, _ := i.Value(map[string]float64{"a": 1})
+	if want := 1 - math.Log(2)/math.Log(101); v != want {
+		t.Errorf("Value() == %v, want %v", v, want)
+	}
+	if v, _ := i.Value(map[string]float64{"a": 0}); v != 1 {
+		t.Errorf("Value() == %v, want 1", v)
+	}
+	if v, _ := i.Value(map[string]float64{"a": 1000}); v != 0 {
+		t.Errorf("Value() == %v, want 0", v)
+	}
+}
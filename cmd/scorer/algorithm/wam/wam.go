@@ -19,18 +19,35 @@ func New(inputs []*algorithm.Input) (algorithm.Algorithm, error) {
 	}, nil
 }
 
+// Score implements the algorithm.Algorithm interface.
 func (p *WeighetedArithmeticMean) Score(record map[string]float64) float64 {
+	s, _ := p.ScoreWithBreakdown(record)
+	return s
+}
+
+// ScoreWithBreakdown implements the algorithm.BreakdownAlgorithm interface.
+//
+// The contribution of each Input is the weighted value of the Input, before
+// it is divided by the total weight.
+//
+// If none of the Inputs are present in the record the score is 0.
+func (p *WeighetedArithmeticMean) ScoreWithBreakdown(record map[string]float64) (float64, map[string]float64) {
 	var totalWeight float64
 	var s float64
+	breakdown := make(map[string]float64)
 	for _, i := range p.inputs {
-		v, ok := i.Value(record)
+		c, ok := i.Contribution(record)
 		if !ok {
 			continue
 		}
 		totalWeight += i.Weight
-		s += i.Weight * v
+		s += c
+		breakdown[i.Name] += c
+	}
+	if totalWeight == 0 {
+		return 0, breakdown
 	}
-	return s / totalWeight
+	return s / totalWeight, breakdown
 }
 
 func init() {
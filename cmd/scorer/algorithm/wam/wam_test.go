@@ -0,0 +1,73 @@
+package wam
+
+import (
+	"testing"
+
+	"github.com/ossf/criticality_score/cmd/scorer/algorithm"
+)
+
+func testInput(field string, weight float64) *algorithm.Input {
+	return &algorithm.Input{
+		Name:         field,
+		Weight:       weight,
+		Distribution: algorithm.LookupDistribution("linear"),
+		Source:       algorithm.Field(field),
+	}
+}
+
+func TestScore(t *testing.T) {
+	a, _ := New([]*algorithm.Input{testInput("a", 1), testInput("b", 3)})
+	// (1*2 + 3*6) / 4 = 5
+	if s := a.Score(map[string]float64{"a": 2, "b": 6}); s != 5 {
+		t.Fatalf("Score() == %v, want 5", s)
+	}
+}
+
+func TestScoreWithBreakdown(t *testing.T) {
+	a, _ := New([]*algorithm.Input{testInput("a", 1), testInput("b", 3), testInput("c", 1), testInput("d", 1)})
+	s, breakdown := a.(algorithm.BreakdownAlgorithm).ScoreWithBreakdown(map[string]float64{"a": 2, "b": 6, "c": 0})
+	// (1*2 + 3*6 + 1*0) / 5 = 4
+	if s != 4 {
+		t.Fatalf("ScoreWithBreakdown() score == %v, want 4", s)
+	}
+	want := map[string]float64{"a": 2, "b": 18, "c": 0}
+	if len(breakdown) != len(want) {
+		t.Fatalf("ScoreWithBreakdown() breakdown == %v, want %v", breakdown, want)
+	}
+	for k, v := range want {
+		if got, ok := breakdown[k]; !ok || got != v {
+			t.Errorf("breakdown[%q] == %v, %v; want %v, true", k, got, ok, v)
+		}
+	}
+	if _, ok := breakdown["d"]; ok {
+		t.Errorf("breakdown contains missing input d, want it excluded")
+	}
+}
+
+func TestScoreWithBreakdown_SharedName(t *testing.T) {
+	a, _ := New([]*algorithm.Input{testInput("a", 1), testInput("a", 2)})
+	_, breakdown := a.(algorithm.BreakdownAlgorithm).ScoreWithBreakdown(map[string]float64{"a": 2})
+	if c := breakdown["a"]; c != 6 {
+		t.Fatalf("breakdown[a] == %v, want 6", c)
+	}
+}
+
+func TestScore_EmptyRecord(t *testing.T) {
+	a, _ := New([]*algorithm.Input{testInput("a", 1), testInput("b", 3)})
+	if s := a.Score(map[string]float64{}); s != 0 {
+		t.Fatalf("Score() == %v, want 0", s)
+	}
+}
+
+func TestScore_NoInputValues(t *testing.T) {
+	a, _ := New([]*algorithm.Input{testInput("a", 1), testInput("b", 3)})
+	// None of the record's fields are used by the inputs, so every input's
+	// Value() returns false.
+	s, breakdown := a.(algorithm.BreakdownAlgorithm).ScoreWithBreakdown(map[string]float64{"c": 2, "d": 6})
+	if s != 0 {
+		t.Fatalf("ScoreWithBreakdown() score == %v, want 0", s)
+	}
+	if len(breakdown) != 0 {
+		t.Fatalf("ScoreWithBreakdown() breakdown == %v, want empty", breakdown)
+	}
+}
@@ -0,0 +1,57 @@
+// The package wgm implements the Weighted Geometric Mean.
+//
+// Compared to the Weighted Arithmetic Mean, the score is less dominated by a
+// single Input with a very large value.
+package wgm
+
+import (
+	"math"
+
+	"github.com/ossf/criticality_score/cmd/scorer/algorithm"
+)
+
+// MinValue is used in place of any Input value that is zero or negative, as
+// the logarithm of these values is undefined.
+const MinValue = 1e-6
+
+type WeightedGeometricMean struct {
+	inputs []*algorithm.Input
+}
+
+// New returns a new instance of the Weighted Geometric Mean algorithm.
+func New(inputs []*algorithm.Input) (algorithm.Algorithm, error) {
+	return &WeightedGeometricMean{
+		inputs: inputs,
+	}, nil
+}
+
+// Score implements the algorithm.Algorithm interface.
+//
+// The score is calculated as exp(sum(weight*ln(value)) / sum(weight)) over the
+// Inputs present in the record. Each value is capped by the Input's
+// MaxContribution, if set. Values that are zero or negative are replaced with
+// MinValue. If none of the Inputs are present in the record the score is
+// 0.
+func (p *WeightedGeometricMean) Score(record map[string]float64) float64 {
+	var totalWeight float64
+	var s float64
+	for _, i := range p.inputs {
+		v, ok := i.CappedValue(record)
+		if !ok {
+			continue
+		}
+		if v <= 0 {
+			v = MinValue
+		}
+		totalWeight += i.Weight
+		s += i.Weight * math.Log(v)
+	}
+	if totalWeight == 0 {
+		return 0
+	}
+	return math.Exp(s / totalWeight)
+}
+
+func init() {
+	algorithm.Register("weighted_geometric_mean", New)
+}
@@ -0,0 +1,75 @@
+package wgm
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ossf/criticality_score/cmd/scorer/algorithm"
+)
+
+const tolerance = 1e-9
+
+func testInput(field string, weight float64) *algorithm.Input {
+	return &algorithm.Input{
+		Name:         field,
+		Weight:       weight,
+		Distribution: algorithm.LookupDistribution("linear"),
+		Source:       algorithm.Field(field),
+	}
+}
+
+func TestScore(t *testing.T) {
+	a, _ := New([]*algorithm.Input{testInput("a", 1), testInput("b", 3)})
+	// exp((1*ln(2) + 3*ln(16)) / 4) = (2 * 16^3)^(1/4) = 8192^(1/4)
+	want := math.Pow(8192, 0.25)
+	if s := a.Score(map[string]float64{"a": 2, "b": 16}); math.Abs(s-want) > tolerance {
+		t.Fatalf("Score() == %v, want %v", s, want)
+	}
+}
+
+func TestScore_EqualWeights(t *testing.T) {
+	a, _ := New([]*algorithm.Input{testInput("a", 1), testInput("b", 1)})
+	// sqrt(4 * 9) = 6
+	if s := a.Score(map[string]float64{"a": 4, "b": 9}); math.Abs(s-6) > tolerance {
+		t.Fatalf("Score() == %v, want 6", s)
+	}
+}
+
+func TestScore_SingleInput(t *testing.T) {
+	a, _ := New([]*algorithm.Input{testInput("a", 2), testInput("b", 3)})
+	// Only "a" is present, so the score is the value of "a".
+	if s := a.Score(map[string]float64{"a": 5}); math.Abs(s-5) > tolerance {
+		t.Fatalf("Score() == %v, want 5", s)
+	}
+}
+
+func TestScore_ZeroValue(t *testing.T) {
+	a, _ := New([]*algorithm.Input{testInput("a", 1), testInput("b", 1)})
+	// sqrt(MinValue * 4)
+	want := math.Sqrt(MinValue * 4)
+	s := a.Score(map[string]float64{"a": 0, "b": 4})
+	if math.IsNaN(s) || math.IsInf(s, 0) {
+		t.Fatalf("Score() == %v, want a finite value", s)
+	}
+	if math.Abs(s-want) > tolerance {
+		t.Fatalf("Score() == %v, want %v", s, want)
+	}
+}
+
+func TestScore_MaxContribution(t *testing.T) {
+	max := 8.0
+	capped := testInput("a", 2)
+	capped.MaxContribution = &max
+	a, _ := New([]*algorithm.Input{capped, testInput("b", 2)})
+	// "a" is capped to 8/2 = 4, so the score is sqrt(4 * 9) = 6.
+	if s := a.Score(map[string]float64{"a": 1000, "b": 9}); math.Abs(s-6) > tolerance {
+		t.Fatalf("Score() == %v, want 6", s)
+	}
+}
+
+func TestScore_NoInputValues(t *testing.T) {
+	a, _ := New([]*algorithm.Input{testInput("a", 1), testInput("b", 3)})
+	if s := a.Score(map[string]float64{"c": 2}); s != 0 {
+		t.Fatalf("Score() == %v, want 0", s)
+	}
+}
@@ -0,0 +1,80 @@
+// The package wmedian implements the Weighted Median.
+//
+// Compared to the Weighted Arithmetic Mean, the score is not affected by a
+// single Input with an outlying value.
+package wmedian
+
+import (
+	"sort"
+
+	"github.com/ossf/criticality_score/cmd/scorer/algorithm"
+)
+
+type WeightedMedian struct {
+	inputs []*algorithm.Input
+}
+
+// New returns a new instance of the Weighted Median algorithm.
+func New(inputs []*algorithm.Input) (algorithm.Algorithm, error) {
+	return &WeightedMedian{
+		inputs: inputs,
+	}, nil
+}
+
+type weightedValue struct {
+	value  float64
+	weight float64
+}
+
+// Score implements the algorithm.Algorithm interface.
+//
+// The values of the Inputs present in the record, capped by each Input's
+// MaxContribution if set, are sorted, and each value is placed at the midpoint
+// of its share of the cumulative weight. The score is found where the
+// cumulative weight reaches half of the total weight, interpolating linearly
+// between the two values either side of it.
+//
+// This means that when the weight is split evenly between two values the
+// score is their mean, and when only one Input is present the score is its
+// value. Inputs with a weight of zero are ignored. If none of the Inputs are
+// present in the record the score is 0.
+func (p *WeightedMedian) Score(record map[string]float64) float64 {
+	var values []weightedValue
+	var totalWeight float64
+	for _, i := range p.inputs {
+		v, ok := i.CappedValue(record)
+		if !ok || i.Weight <= 0 {
+			continue
+		}
+		values = append(values, weightedValue{value: v, weight: i.Weight})
+		totalWeight += i.Weight
+	}
+	if totalWeight == 0 {
+		return 0
+	}
+	sort.SliceStable(values, func(i, j int) bool {
+		return values[i].value < values[j].value
+	})
+
+	half := totalWeight / 2
+	var cumulative float64
+	var prevPos, prevValue float64
+	for n, v := range values {
+		pos := cumulative + v.weight/2
+		cumulative += v.weight
+		if pos < half {
+			prevPos, prevValue = pos, v.value
+			continue
+		}
+		if n == 0 || pos == half {
+			return v.value
+		}
+		return prevValue + (v.value-prevValue)*(half-prevPos)/(pos-prevPos)
+	}
+	// Unreachable: the last value's position is always at or above half.
+	return values[len(values)-1].value
+}
+
+func init() {
+	algorithm.Register("weighted_median", New)
+}
@@ -0,0 +1,109 @@
+package wmedian
+
+import (
+	"math"
+	"testing"
+
+	"github.com/ossf/criticality_score/cmd/scorer/algorithm"
+)
+
+const tolerance = 1e-9
+
+func testInput(field string, weight float64) *algorithm.Input {
+	return &algorithm.Input{
+		Name:         field,
+		Weight:       weight,
+		Distribution: algorithm.LookupDistribution("linear"),
+		Source:       algorithm.Field(field),
+	}
+}
+
+func TestScore(t *testing.T) {
+	tests := []struct {
+		name    string
+		weights []float64
+		values  []float64
+		want    float64
+	}{
+		{
+			name:    "odd number of equal weights",
+			weights: []float64{1, 1, 1},
+			values:  []float64{3, 1, 2},
+			want:    2,
+		},
+		{
+			name:    "even split",
+			weights: []float64{1, 1},
+			values:  []float64{2, 6},
+			want:    4,
+		},
+		{
+			// Positions are 0.05, 0.2, 0.45 and 0.8 of the total weight, so
+			// the median is 1/7th of the way from 3 to 4.
+			name:    "unequal weights",
+			weights: []float64{0.1, 0.2, 0.3, 0.4},
+			values:  []float64{1, 2, 3, 4},
+			want:    3 + 0.05/0.35,
+		},
+		{
+			name:    "dominant weight",
+			weights: []float64{1, 10, 1},
+			values:  []float64{1, 5, 100},
+			want:    5,
+		},
+		{
+			name:    "outlier",
+			weights: []float64{1, 1, 1},
+			values:  []float64{1, 2, 1000000},
+			want:    2,
+		},
+	}
+	fields := []string{"a", "b", "c", "d"}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			var inputs []*algorithm.Input
+			record := make(map[string]float64)
+			for i, w := range test.weights {
+				inputs = append(inputs, testInput(fields[i], w))
+				record[fields[i]] = test.values[i]
+			}
+			a, _ := New(inputs)
+			if s := a.Score(record); math.Abs(s-test.want) > tolerance {
+				t.Fatalf("Score() == %v, want %v", s, test.want)
+			}
+		})
+	}
+}
+
+func TestScore_SingleInput(t *testing.T) {
+	a, _ := New([]*algorithm.Input{testInput("a", 2), testInput("b", 3)})
+	// Only "a" is present, so the score is the value of "a".
+	if s := a.Score(map[string]float64{"a": 5}); math.Abs(s-5) > tolerance {
+		t.Fatalf("Score() == %v, want 5", s)
+	}
+}
+
+func TestScore_ZeroWeight(t *testing.T) {
+	a, _ := New([]*algorithm.Input{testInput("a", 0), testInput("b", 1)})
+	if s := a.Score(map[string]float64{"a": 100, "b": 5}); math.Abs(s-5) > tolerance {
+		t.Fatalf("Score() == %v, want 5", s)
+	}
+}
+
+func TestScore_MaxContribution(t *testing.T) {
+	max := 4.0
+	capped := testInput("a", 2)
+	capped.MaxContribution = &max
+	a, _ := New([]*algorithm.Input{capped, testInput("b", 2)})
+	// "a" is capped to 4/2 = 2, so the score is the mean of 2 and 3.
+	if s := a.Score(map[string]float64{"a": 100, "b": 3}); math.Abs(s-2.5) > tolerance {
+		t.Fatalf("Score() == %v, want 2.5", s)
+	}
+}
+
+func TestScore_NoInputValues(t *testing.T) {
+	a, _ := New([]*algorithm.Input{testInput("a", 1), testInput("b", 3)})
+	if s := a.Score(map[string]float64{}); s != 0 {
+		t.Fatalf("Score() == %v, want 0", s)
+	}
+}
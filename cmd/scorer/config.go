@@ -5,23 +5,37 @@ import (
 	"fmt"
 	"io"
 	"io/ioutil"
+	"os"
+	"strings"
 
 	"github.com/ossf/criticality_score/cmd/scorer/algorithm"
 	"gopkg.in/yaml.v3"
 )
 
+var ErrUnknownFields = errors.New("unknown fields")
+
 type Condition struct {
 	Not         *Condition `yaml:"not"`
 	FieldExists string     `yaml:"field_exists"`
 }
 
 type Input struct {
-	Field        string            `yaml:"field"`
-	Weight       float64           `yaml:"weight"`
-	Bounds       *algorithm.Bounds `yaml:"bounds"`
-	Distribution string            `yaml:"distribution"`
-	Condition    *Condition        `yaml:"condition"`
-	Tags         []string          `yaml:"tags"`
+	Field           string            `yaml:"field"`
+	Weight          float64           `yaml:"weight"`
+	Bounds          *algorithm.Bounds `yaml:"bounds"`
+	Distribution    string            `yaml:"distribution"`
+	Condition       *Condition        `yaml:"condition"`
+	Tags            []string          `yaml:"tags"`
+	MaxContribution *float64          `yaml:"max_contribution"`
+
+	// Reference is the filename of the sample values used by the "percentile"
+	// distribution. It must contain one value per line. Bounds may not be
+	// used with the "percentile" distribution.
+	Reference string `yaml:"reference"`
+
+	// Direction is "negative" if larger values should make a project less
+	// critical. The value is inverted after it has been normalized.
+	Direction algorithm.Direction `yaml:"direction"`
 }
 
 // Implements yaml.Unmarshaler interface
@@ -37,6 +51,29 @@ func (i *Input) UnmarshalYAML(value *yaml.Node) error {
 	if raw.Field == "" {
 		return errors.New("field must be set")
 	}
+	if raw.MaxContribution != nil && *raw.MaxContribution < 0 {
+		return errors.New("max_contribution must not be negative")
+	}
+	isPercentile := raw.Distribution == algorithm.PercentileDistributionName
+	if isPercentile && raw.Reference == "" {
+		return errors.New("reference must be set for the percentile distribution")
+	}
+	if !isPercentile && raw.Reference != "" {
+		return errors.New("reference is only supported by the percentile distribution")
+	}
+	if isPercentile && raw.Bounds != nil {
+		// The reference samples are raw values, so they can't rank a value
+		// that the Bounds have scaled.
+		return errors.New("bounds are not supported by the percentile distribution")
+	}
+	if raw.Direction == algorithm.DirectionNegative {
+		if raw.Bounds == nil {
+			return errors.New("bounds must be set for the negative direction")
+		}
+		if raw.Bounds.SmallerIsBetter {
+			return errors.New("smaller_is_better must not be set for the negative direction")
+		}
+	}
 	*i = Input(*raw)
 	return nil
 }
@@ -58,6 +95,24 @@ func buildCondition(c *Condition) (algorithm.Condition, error) {
 	return nil, errors.New("one condition field must be set")
 }
 
+// distribution returns the Distribution for the Input. The percentile
+// distribution is loaded from the Reference file.
+func (i *Input) distribution() (*algorithm.Distribution, error) {
+	if i.Distribution != algorithm.PercentileDistributionName {
+		return algorithm.ParseDistribution(i.Distribution)
+	}
+	f, err := os.Open(i.Reference)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	d, err := algorithm.LoadPercentileDistribution(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", i.Reference, err)
+	}
+	return d, nil
+}
+
 func (i *Input) ToAlgorithmInput() (*algorithm.Input, error) {
 	var v algorithm.Value
 	v = algorithm.Field(i.Field)
@@ -71,16 +126,19 @@ func (i *Input) ToAlgorithmInput() (*algorithm.Input, error) {
 			Inner:     v,
 		}
 	}
-	d := algorithm.LookupDistribution(i.Distribution)
-	if d == nil {
-		return nil, fmt.Errorf("unknown distribution %s", i.Distribution)
+	d, err := i.distribution()
+	if err != nil {
+		return nil, fmt.Errorf("field %s: %w", i.Field, err)
 	}
 	return &algorithm.Input{
-		Bounds:       i.Bounds,
-		Weight:       i.Weight,
-		Distribution: d,
-		Source:       v,
-		Tags:         i.Tags,
+		Name:            i.Field,
+		Bounds:          i.Bounds,
+		Weight:          i.Weight,
+		Distribution:    d,
+		Source:          v,
+		Tags:            i.Tags,
+		MaxContribution: i.MaxContribution,
+		Direction:       i.Direction,
 	}, nil
 }
 
@@ -125,3 +183,56 @@ func (c *Config) Algorithm() (algorithm.Algorithm, error) {
 	}
 	return algorithm.NewAlgorithm(c.Name, inputs)
 }
+
+// InputNames returns the unique names of each of the Config's Inputs, in the
+// order they first appear.
+func (c *Config) InputNames() []string {
+	var names []string
+	seen := make(map[string]bool)
+	for _, i := range c.Inputs {
+		if seen[i.Field] {
+			continue
+		}
+		seen[i.Field] = true
+		names = append(names, i.Field)
+	}
+	return names
+}
+
+// fields returns the names of all the fields referenced by the Condition.
+func (c *Condition) fields() []string {
+	if c == nil {
+		return nil
+	}
+	if c.FieldExists != "" {
+		return []string{c.FieldExists}
+	}
+	return c.Not.fields()
+}
+
+// Validate checks that every field referenced by the Config's Inputs,
+// including those used in conditions, is one of the knownFields.
+//
+// If any fields are unknown an error wrapping ErrUnknownFields is returned
+// that lists each of them.
+func (c *Config) Validate(knownFields []string) error {
+	known := make(map[string]bool)
+	for _, f := range knownFields {
+		known[f] = true
+	}
+	var unknown []string
+	seen := make(map[string]bool)
+	for _, i := range c.Inputs {
+		for _, f := range append([]string{i.Field}, i.Condition.fields()...) {
+			if known[f] || seen[f] {
+				continue
+			}
+			seen[f] = true
+			unknown = append(unknown, f)
+		}
+	}
+	if len(unknown) > 0 {
+		return fmt.Errorf("%w: %s", ErrUnknownFields, strings.Join(unknown, ", "))
+	}
+	return nil
+}
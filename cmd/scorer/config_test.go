@@ -0,0 +1,86 @@
+package main
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/ossf/criticality_score/cmd/scorer/algorithm"
+)
+
+const validateTestConfig = `algorithm: weighted_arithmetic_mean
+inputs:
+  - field: legacy.created_since
+  - field: legacy.commit_frequency
+    condition:
+      not:
+        field_exists: legacy.archived
+  - field: legacy.created_since
+    weight: 2
+`
+
+func TestConfigValidate(t *testing.T) {
+	c, err := LoadConfig(strings.NewReader(validateTestConfig))
+	if err != nil {
+		t.Fatalf("LoadConfig() errored %v, want no error", err)
+	}
+	known := []string{"repo.url", "legacy.created_since", "legacy.commit_frequency", "legacy.archived"}
+	if err := c.Validate(known); err != nil {
+		t.Fatalf("Validate() errored %v, want no error", err)
+	}
+}
+
+func TestConfigValidate_Unknown(t *testing.T) {
+	c, err := LoadConfig(strings.NewReader(validateTestConfig))
+	if err != nil {
+		t.Fatalf("LoadConfig() errored %v, want no error", err)
+	}
+	err = c.Validate([]string{"legacy.commit_frequency"})
+	if !errors.Is(err, ErrUnknownFields) {
+		t.Fatalf("Validate() errored %v, want %v", err, ErrUnknownFields)
+	}
+	if want := "unknown fields: legacy.created_since, legacy.archived"; err.Error() != want {
+		t.Fatalf("Validate() errored %q, want %q", err, want)
+	}
+}
+
+func TestLoadConfig_NegativeDirection(t *testing.T) {
+	c, err := LoadConfig(strings.NewReader(`algorithm: weighted_arithmetic_mean
+inputs:
+  - field: legacy.updated_since
+    bounds:
+      upper: 120
+    direction: negative
+`))
+	if err != nil {
+		t.Fatalf("LoadConfig() errored %v, want no error", err)
+	}
+	if d := c.Inputs[0].Direction; d != algorithm.DirectionNegative {
+		t.Fatalf("Direction == %v, want %v", d, algorithm.DirectionNegative)
+	}
+}
+
+func TestLoadConfig_NegativeDirectionWithoutBounds(t *testing.T) {
+	_, err := LoadConfig(strings.NewReader(`algorithm: weighted_arithmetic_mean
+inputs:
+  - field: legacy.updated_since
+    direction: negative
+`))
+	if err == nil {
+		t.Fatal("LoadConfig() returned no error, want an error")
+	}
+}
+
+func TestLoadConfig_PercentileWithBounds(t *testing.T) {
+	_, err := LoadConfig(strings.NewReader(`algorithm: weighted_arithmetic_mean
+inputs:
+  - field: legacy.contributor_count
+    distribution: percentile
+    reference: contributors.txt
+    bounds:
+      upper: 5000
+`))
+	if err == nil || !strings.Contains(err.Error(), "bounds") {
+		t.Fatalf("LoadConfig() errored %v, want a bounds error", err)
+	}
+}
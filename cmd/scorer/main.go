@@ -30,7 +30,10 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/ossf/criticality_score/cmd/scorer/algorithm"
 	_ "github.com/ossf/criticality_score/cmd/scorer/algorithm/wam"
+	_ "github.com/ossf/criticality_score/cmd/scorer/algorithm/wgm"
+	_ "github.com/ossf/criticality_score/cmd/scorer/algorithm/wmedian"
 	"github.com/ossf/criticality_score/internal/outfile"
 	"github.com/ossf/criticality_score/internal/textvarflag"
 	log "github.com/sirupsen/logrus"
@@ -41,6 +44,8 @@ const defaultLogLevel = log.InfoLevel
 var (
 	configFlag     = flag.String("config", "", "the filename of the config")
 	columnNameFlag = flag.String("column", "", "the name of the output column")
+	breakdownFlag  = flag.Bool("score-breakdown", false, "adds a column with the contribution of each input to the score.")
+	validateFlag   = flag.Bool("validate", false, "fail if the config references a field that is not in the header of IN_CSV.")
 	logLevel       log.Level
 )
 
@@ -75,13 +80,21 @@ func generateColumnName() string {
 	return f + "_score"
 }
 
-func makeOutHeader(header []string, resultColumn string) ([]string, error) {
+// breakdownColumnName returns the name of the column used for the
+// contribution of the named input to the score in resultColumn.
+func breakdownColumnName(resultColumn, input string) string {
+	return resultColumn + "." + input
+}
+
+func makeOutHeader(header []string, resultColumns []string) ([]string, error) {
 	for _, h := range header {
-		if h == resultColumn {
-			return nil, fmt.Errorf("header already contains field %s", resultColumn)
+		for _, c := range resultColumns {
+			if h == c {
+				return nil, fmt.Errorf("header already contains field %s", c)
+			}
 		}
 	}
-	return append(header, resultColumn), nil
+	return append(header, resultColumns...), nil
 }
 
 func makeRecord(header []string, row []string) map[string]float64 {
@@ -184,8 +197,38 @@ func main() {
 		os.Exit(2)
 	}
 
+	if *validateFlag {
+		if err := c.Validate(inHeader); err != nil {
+			logger.WithFields(log.Fields{
+				"error":    err,
+				"filename": *configFlag,
+			}).Error("Config references fields missing from the input")
+			os.Exit(2)
+		}
+	}
+
+	// Determine the columns to add to the output.
+	resultColumn := generateColumnName()
+	resultColumns := []string{resultColumn}
+	var ba algorithm.BreakdownAlgorithm
+	var breakdownInputs []string
+	if *breakdownFlag {
+		var ok bool
+		ba, ok = a.(algorithm.BreakdownAlgorithm)
+		if !ok {
+			logger.WithFields(log.Fields{
+				"algorithm": c.Name,
+			}).Error("Algorithm does not support a score breakdown")
+			os.Exit(2)
+		}
+		breakdownInputs = c.InputNames()
+		for _, name := range breakdownInputs {
+			resultColumns = append(resultColumns, breakdownColumnName(resultColumn, name))
+		}
+	}
+
 	// Generate and output the CSV header row
-	outHeader, err := makeOutHeader(inHeader, generateColumnName())
+	outHeader, err := makeOutHeader(inHeader, resultColumns)
 	if err != nil {
 		logger.WithFields(log.Fields{
 			"error": err,
@@ -212,8 +255,23 @@ func main() {
 			os.Exit(2)
 		}
 		record := makeRecord(inHeader, row)
-		score := a.Score(record)
+		if ba == nil {
+			score := a.Score(record)
+			row = append(row, fmt.Sprintf("%.5f", score))
+			pq.PushRow(row, score)
+			continue
+		}
+		score, breakdown := ba.ScoreWithBreakdown(record)
 		row = append(row, fmt.Sprintf("%.5f", score))
+		for _, name := range breakdownInputs {
+			// Inputs missing from the record are left empty so they can be
+			// distinguished from an input that contributed zero.
+			if c, ok := breakdown[name]; ok {
+				row = append(row, fmt.Sprintf("%.5f", c))
+			} else {
+				row = append(row, "")
+			}
+		}
 		pq.PushRow(row, score)
 	}
 
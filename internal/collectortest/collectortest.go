@@ -0,0 +1,82 @@
+// Package collectortest provides helpers for testing Collectors against fake
+// services.
+package collectortest
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/ossf/criticality_score/cmd/collect_signals/collector"
+	"github.com/ossf/criticality_score/cmd/collect_signals/signal"
+	log "github.com/sirupsen/logrus"
+)
+
+// Repo is a projectrepo.Repo for a repository URL.
+type Repo struct {
+	u *url.URL
+}
+
+// NewRepo returns a Repo for the repository at rawURL.
+func NewRepo(t testing.TB, rawURL string) *Repo {
+	t.Helper()
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		t.Fatalf("url.Parse(%q) errored %v, want no error", rawURL, err)
+	}
+	return &Repo{u: u}
+}
+
+func (r *Repo) URL() *url.URL {
+	return r.u
+}
+
+// Logger returns a logger that discards everything logged to it.
+func Logger() *log.Logger {
+	logger := log.New()
+	logger.SetOutput(io.Discard)
+	return logger
+}
+
+// NewServer starts a server that handles requests with h. The server is
+// closed when the test finishes.
+func NewServer(t testing.TB, h http.Handler) *httptest.Server {
+	t.Helper()
+	s := httptest.NewServer(h)
+	t.Cleanup(s.Close)
+	return s
+}
+
+// PathHandler returns an http.Handler that answers requests with the JSON
+// bodies in responses, keyed by escaped path. Requests for any other path are
+// answered with a 404.
+func PathHandler(responses map[string]string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := responses[r.URL.EscapedPath()]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Write([]byte(body))
+	})
+}
+
+// Collect returns the signals c collects for the repository at rawURL.
+//
+// The test fails if c returns an error, or a Set that is not an S.
+func Collect[S signal.Set](t testing.TB, c collector.Collector, rawURL string) S {
+	t.Helper()
+	s, err := c.Collect(context.Background(), NewRepo(t, rawURL))
+	if err != nil {
+		t.Fatalf("Collect() errored %v, want no error", err)
+	}
+	res, ok := s.(S)
+	if !ok {
+		t.Fatalf("Collect() returned %T, want %T", s, res)
+	}
+	return res
+}
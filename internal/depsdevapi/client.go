@@ -0,0 +1,225 @@
+// Package depsdevapi provides a client for the deps.dev REST API.
+package depsdevapi
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/ossf/criticality_score/internal/httpjson"
+)
+
+// DefaultBaseURL is the base URL of the deps.dev API.
+const DefaultBaseURL = "https://api.deps.dev/v3alpha"
+
+// VersionKey identifies a version of a package.
+type VersionKey struct {
+	System  string `json:"system"`
+	Name    string `json:"name"`
+	Version string `json:"version"`
+}
+
+// Dependents holds the number of packages that depend on a version of a
+// package.
+type Dependents struct {
+	DependentCount         int `json:"dependentCount"`
+	DirectDependentCount   int `json:"directDependentCount"`
+	IndirectDependentCount int `json:"indirectDependentCount"`
+}
+
+// Dependency is a node in the resolved dependency graph of a version of a
+// package.
+type Dependency struct {
+	VersionKey VersionKey `json:"versionKey"`
+
+	// Relation is "SELF" for the version itself, "DIRECT" for its direct
+	// dependencies, and "INDIRECT" for the rest.
+	Relation string `json:"relation"`
+}
+
+// Dependencies holds the resolved dependency graph of a version of a package.
+type Dependencies struct {
+	Nodes []Dependency `json:"nodes"`
+}
+
+// Direct returns the direct dependencies in the graph.
+func (d *Dependencies) Direct() []VersionKey {
+	var keys []VersionKey
+	for _, n := range d.Nodes {
+		if n.Relation == "DIRECT" {
+			keys = append(keys, n.VersionKey)
+		}
+	}
+	return keys
+}
+
+type projectPackageVersions struct {
+	Versions []struct {
+		VersionKey VersionKey `json:"versionKey"`
+	} `json:"versions"`
+}
+
+// Version describes a single version of a package.
+type Version struct {
+	VersionKey  VersionKey `json:"versionKey"`
+	IsDefault   bool       `json:"isDefault"`
+	PublishedAt time.Time  `json:"publishedAt"`
+}
+
+// Package describes a package and its versions.
+type Package struct {
+	Versions []Version `json:"versions"`
+}
+
+// PublishedAt returns the time that version was published. If the version is
+// unknown, or was published at an unknown time, the zero time is returned.
+func (p *Package) PublishedAt(version string) time.Time {
+	for _, v := range p.Versions {
+		if v.VersionKey.Version == version {
+			return v.PublishedAt
+		}
+	}
+	return time.Time{}
+}
+
+// DefaultVersion returns the default version of the package, or an empty
+// string if it does not have one.
+func (p *Package) DefaultVersion() string {
+	for _, v := range p.Versions {
+		if v.IsDefault {
+			return v.VersionKey.Version
+		}
+	}
+	return ""
+}
+
+// Client is used to query the deps.dev API.
+type Client struct {
+	client  *http.Client
+	baseURL string
+}
+
+// NewClient returns a new Client for the public deps.dev API that uses the
+// http.Client c to send requests.
+func NewClient(c *http.Client) *Client {
+	return NewCustomClient(DefaultBaseURL, c)
+}
+
+// NewCustomClient returns a new Client for the deps.dev API at baseURL.
+func NewCustomClient(baseURL string, c *http.Client) *Client {
+	return &Client{
+		client:  c,
+		baseURL: strings.TrimSuffix(baseURL, "/"),
+	}
+}
+
+// ProjectKey returns the deps.dev project key for the repository at u, such
+// as "github.com/owner/name". deps.dev uses lowercase project keys.
+func ProjectKey(u *url.URL) string {
+	return strings.ToLower(u.Hostname() + "/" + strings.TrimSuffix(strings.Trim(u.Path, "/"), ".git"))
+}
+
+// ProjectPackages returns the packages in the system that map to the
+// project key. Each package is only returned once. If system is empty,
+// packages from every system are returned.
+//
+// If the project is not known to deps.dev, nil is returned.
+func (c *Client) ProjectPackages(ctx context.Context, key, system string) ([]VersionKey, error) {
+	var res projectPackageVersions
+	err := c.get(ctx, "projects/"+url.PathEscape(key)+":packageversions", &res)
+	if httpjson.StatusCode(err) == http.StatusNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[VersionKey]struct{})
+	var pkgs []VersionKey
+	for _, v := range res.Versions {
+		if system != "" && v.VersionKey.System != system {
+			continue
+		}
+		k := VersionKey{System: v.VersionKey.System, Name: v.VersionKey.Name}
+		if _, ok := seen[k]; ok {
+			continue
+		}
+		seen[k] = struct{}{}
+		pkgs = append(pkgs, k)
+	}
+	return pkgs, nil
+}
+
+// Package returns the package and its versions.
+//
+// If the package does not exist, nil is returned.
+func (c *Client) Package(ctx context.Context, system, name string) (*Package, error) {
+	p := &Package{}
+	err := c.get(ctx, packagePath(system, name), p)
+	if httpjson.StatusCode(err) == http.StatusNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// DefaultVersion returns the default version of the package, which is
+// usually the latest release.
+//
+// If the package does not exist, or does not have a default version, an empty
+// string is returned.
+func (c *Client) DefaultVersion(ctx context.Context, system, name string) (string, error) {
+	p, err := c.Package(ctx, system, name)
+	if err != nil || p == nil {
+		return "", err
+	}
+	return p.DefaultVersion(), nil
+}
+
+// Dependents returns the number of packages that depend on the version of
+// the package identified by k.
+//
+// If the version does not exist, nil is returned.
+func (c *Client) Dependents(ctx context.Context, k VersionKey) (*Dependents, error) {
+	d := &Dependents{}
+	err := c.get(ctx, packagePath(k.System, k.Name)+"/versions/"+url.PathEscape(k.Version)+":dependents", d)
+	if httpjson.StatusCode(err) == http.StatusNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+// Dependencies returns the resolved dependency graph of the version of the
+// package identified by k.
+//
+// If the version does not exist, nil is returned.
+func (c *Client) Dependencies(ctx context.Context, k VersionKey) (*Dependencies, error) {
+	d := &Dependencies{}
+	err := c.get(ctx, packagePath(k.System, k.Name)+"/versions/"+url.PathEscape(k.Version)+":dependencies", d)
+	if httpjson.StatusCode(err) == http.StatusNotFound {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return d, nil
+}
+
+func packagePath(system, name string) string {
+	return "systems/" + url.PathEscape(system) + "/packages/" + url.PathEscape(name)
+}
+
+// get queries the API endpoint path and decodes the JSON response into
+// result.
+//
+// path must already be escaped.
+func (c *Client) get(ctx context.Context, path string, result any) error {
+	_, err := httpjson.Get(ctx, c.client, c.baseURL+"/"+path, result)
+	return err
+}
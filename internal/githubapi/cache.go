@@ -0,0 +1,164 @@
+package githubapi
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"net/http/httputil"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// Today returns the current time truncated to the start of the day in UTC.
+//
+// Queries that include a time relative to now should use Today rather than
+// time.Now, so that the same request is made throughout a day and can be
+// served by the cache returned by NewCachingRoundTripper.
+func Today() time.Time {
+	return time.Now().UTC().Truncate(24 * time.Hour)
+}
+
+type cachingRoundTripper struct {
+	inner http.RoundTripper
+	dir   string
+	ttl   time.Duration
+
+	// now returns the current time. It is replaced for testing.
+	now func() time.Time
+}
+
+// NewCachingRoundTripper returns an http.RoundTripper that stores successful
+// responses in the directory dir, and serves identical requests from the
+// directory for up to ttl after they were stored.
+//
+// Requests are identified by their method, URL and body, so both REST and
+// GraphQL requests are cached, including the variables sent with a GraphQL
+// query. Headers, such as the token used, are not part of a request's identity.
+// GraphQL responses that contain errors are not cached.
+//
+// On a cache hit no request is sent, so it should wrap all the other
+// RoundTrippers.
+func NewCachingRoundTripper(inner http.RoundTripper, dir string, ttl time.Duration) http.RoundTripper {
+	return &cachingRoundTripper{
+		inner: inner,
+		dir:   dir,
+		ttl:   ttl,
+		now:   time.Now,
+	}
+}
+
+// RoundTrip implements the http.RoundTripper interface
+func (rt *cachingRoundTripper) RoundTrip(r *http.Request) (*http.Response, error) {
+	if r.Method != http.MethodGet && r.Method != http.MethodPost {
+		return rt.inner.RoundTrip(r)
+	}
+	var body []byte
+	if r.Body != nil {
+		var err error
+		body, err = ioutil.ReadAll(r.Body)
+		r.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		r.Body = ioutil.NopCloser(bytes.NewReader(body))
+	}
+	filename := filepath.Join(rt.dir, cacheKey(r, body))
+	if resp, ok := rt.load(filename, r); ok {
+		return resp, nil
+	}
+	resp, err := rt.inner.RoundTrip(r)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		return resp, err
+	}
+	if r.Method == http.MethodPost {
+		// GraphQL errors, including rate limits, are returned with a 200 OK.
+		b, err := ioutil.ReadAll(resp.Body)
+		resp.Body.Close()
+		if err != nil {
+			return nil, err
+		}
+		resp.Body = ioutil.NopCloser(bytes.NewReader(b))
+		if hasGraphQLErrors(b) {
+			return resp, nil
+		}
+	}
+	return rt.store(filename, resp)
+}
+
+// hasGraphQLErrors returns true if body is a GraphQL response with a non-empty
+// "errors" array.
+func hasGraphQLErrors(body []byte) bool {
+	var res struct {
+		Errors []json.RawMessage `json:"errors"`
+	}
+	if err := json.Unmarshal(body, &res); err != nil {
+		return false
+	}
+	return len(res.Errors) > 0
+}
+
+// cacheKey returns the filename used to store the response to r.
+func cacheKey(r *http.Request, body []byte) string {
+	h := sha256.New()
+	io.WriteString(h, r.Method)
+	h.Write([]byte{0})
+	io.WriteString(h, r.URL.String())
+	h.Write([]byte{0})
+	h.Write(body)
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// load returns the cached response for r stored in filename, if it exists
+// and has not expired.
+func (rt *cachingRoundTripper) load(filename string, r *http.Request) (*http.Response, bool) {
+	info, err := os.Stat(filename)
+	if err != nil || rt.now().Sub(info.ModTime()) > rt.ttl {
+		return nil, false
+	}
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, false
+	}
+	resp, err := http.ReadResponse(bufio.NewReader(bytes.NewReader(data)), r)
+	if err != nil {
+		return nil, false
+	}
+	return resp, true
+}
+
+// store writes resp to filename, and returns an equivalent response that can
+// be used in its place.
+//
+// Failing to write the cache is not an error, as the response is still valid.
+func (rt *cachingRoundTripper) store(filename string, resp *http.Response) (*http.Response, error) {
+	data, err := httputil.DumpResponse(resp, true)
+	if err != nil {
+		return nil, err
+	}
+	// Write to a temporary file first so a partially written response is
+	// never read.
+	if err := os.MkdirAll(rt.dir, 0o755); err != nil {
+		return resp, nil
+	}
+	tmp, err := os.CreateTemp(rt.dir, "tmp-*")
+	if err != nil {
+		return resp, nil
+	}
+	_, err = tmp.Write(data)
+	if closeErr := tmp.Close(); err == nil {
+		err = closeErr
+	}
+	if err == nil {
+		err = os.Rename(tmp.Name(), filename)
+	}
+	if err != nil {
+		os.Remove(tmp.Name())
+	}
+	return resp, nil
+}
@@ -0,0 +1,105 @@
+package githubapi
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+// countingServer returns a server that responds with the request body, and a
+// pointer to the number of requests it has received.
+func countingServer(t *testing.T, status int) (*httptest.Server, *int) {
+	t.Helper()
+	count := 0
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count++
+		body, _ := ioutil.ReadAll(r.Body)
+		w.WriteHeader(status)
+		w.Write([]byte("response:"))
+		w.Write(body)
+	}))
+	t.Cleanup(s.Close)
+	return s, &count
+}
+
+func post(t *testing.T, c *http.Client, u, body string) string {
+	t.Helper()
+	resp, err := c.Post(u, "application/json", strings.NewReader(body))
+	if err != nil {
+		t.Fatalf("Post() errored %v, want no error", err)
+	}
+	defer resp.Body.Close()
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		t.Fatalf("ReadAll() errored %v, want no error", err)
+	}
+	return string(data)
+}
+
+func TestCachingRoundTripper(t *testing.T) {
+	s, count := countingServer(t, http.StatusOK)
+	c := &http.Client{Transport: NewCachingRoundTripper(http.DefaultTransport, t.TempDir(), time.Hour)}
+
+	for i := 0; i < 2; i++ {
+		if got := post(t, c, s.URL, `{"query": "a"}`); got != `response:{"query": "a"}` {
+			t.Fatalf("response == %q, want %q", got, `response:{"query": "a"}`)
+		}
+	}
+	if *count != 1 {
+		t.Fatalf("requests == %d, want 1", *count)
+	}
+
+	// A different body, such as different query variables, is not a hit.
+	if got := post(t, c, s.URL, `{"query": "b"}`); got != `response:{"query": "b"}` {
+		t.Fatalf("response == %q, want %q", got, `response:{"query": "b"}`)
+	}
+	if *count != 2 {
+		t.Fatalf("requests == %d, want 2", *count)
+	}
+}
+
+func TestCachingRoundTripper_Expired(t *testing.T) {
+	s, count := countingServer(t, http.StatusOK)
+	rt := NewCachingRoundTripper(http.DefaultTransport, t.TempDir(), time.Hour).(*cachingRoundTripper)
+	c := &http.Client{Transport: rt}
+
+	post(t, c, s.URL, "a")
+	rt.now = func() time.Time { return time.Now().Add(2 * time.Hour) }
+	post(t, c, s.URL, "a")
+	if *count != 2 {
+		t.Fatalf("requests == %d, want 2", *count)
+	}
+}
+
+func TestCachingRoundTripper_ErrorNotCached(t *testing.T) {
+	s, count := countingServer(t, http.StatusBadGateway)
+	c := &http.Client{Transport: NewCachingRoundTripper(http.DefaultTransport, t.TempDir(), time.Hour)}
+
+	post(t, c, s.URL, "a")
+	post(t, c, s.URL, "a")
+	if *count != 2 {
+		t.Fatalf("requests == %d, want 2", *count)
+	}
+}
+
+func TestCachingRoundTripper_GraphQLErrorNotCached(t *testing.T) {
+	count := 0
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		count++
+		w.Write([]byte(`{"data": null, "errors": [{"type": "RATE_LIMITED", "message": "API rate limit exceeded"}]}`))
+	}))
+	t.Cleanup(s.Close)
+	c := &http.Client{Transport: NewCachingRoundTripper(http.DefaultTransport, t.TempDir(), time.Hour)}
+
+	for i := 0; i < 2; i++ {
+		if got := post(t, c, s.URL, `{"query": "a"}`); !strings.Contains(got, "RATE_LIMITED") {
+			t.Fatalf("response == %q, want the GraphQL errors", got)
+		}
+	}
+	if count != 2 {
+		t.Fatalf("requests == %d, want 2", count)
+	}
+}
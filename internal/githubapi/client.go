@@ -1,26 +1,58 @@
 package githubapi
 
 import (
+	"fmt"
 	"net/http"
+	"net/url"
+	"strings"
 
 	"github.com/google/go-github/v44/github"
 	"github.com/shurcooL/githubv4"
 )
 
+// DefaultHost is the host for repositories hosted on github.com.
+const DefaultHost = "github.com"
+
 type Client struct {
 	restClient  *github.Client
 	graphClient *githubv4.Client
+	host        string
 }
 
 func NewClient(client *http.Client) *Client {
 	c := &Client{
 		restClient:  github.NewClient(client),
 		graphClient: githubv4.NewClient(client),
+		host:        DefaultHost,
 	}
 
 	return c
 }
 
+// NewEnterpriseClient returns a Client for the GitHub Enterprise Server
+// instance at baseURL (e.g. "https://github.example.com").
+//
+// Both the REST and GraphQL APIs are accessed through baseURL.
+func NewEnterpriseClient(baseURL string, client *http.Client) (*Client, error) {
+	u, err := url.Parse(baseURL)
+	if err != nil {
+		return nil, err
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return nil, fmt.Errorf("invalid GitHub Enterprise URL: %s", baseURL)
+	}
+	base := strings.TrimSuffix(u.String(), "/")
+	restClient, err := github.NewEnterpriseClient(base+"/api/v3/", base+"/api/uploads/", client)
+	if err != nil {
+		return nil, err
+	}
+	return &Client{
+		restClient:  restClient,
+		graphClient: githubv4.NewEnterpriseClient(base+"/api/graphql", client),
+		host:        strings.ToLower(u.Hostname()),
+	}, nil
+}
+
 func (c *Client) Rest() *github.Client {
 	return c.restClient
 }
@@ -28,3 +60,9 @@ func (c *Client) Rest() *github.Client {
 func (c *Client) GraphQL() *githubv4.Client {
 	return c.graphClient
 }
+
+// Host returns the hostname used for the URLs of repositories that are
+// accessed with the Client.
+func (c *Client) Host() string {
+	return c.host
+}
@@ -0,0 +1,34 @@
+package githubapi
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestNewClient(t *testing.T) {
+	c := NewClient(&http.Client{})
+	if h := c.Host(); h != DefaultHost {
+		t.Fatalf("Host() == %q, want %q", h, DefaultHost)
+	}
+}
+
+func TestNewEnterpriseClient(t *testing.T) {
+	c, err := NewEnterpriseClient("https://GitHub.example.com/", &http.Client{})
+	if err != nil {
+		t.Fatalf("NewEnterpriseClient() errored %v, want no error", err)
+	}
+	if h := c.Host(); h != "github.example.com" {
+		t.Fatalf("Host() == %q, want %q", h, "github.example.com")
+	}
+	if u := c.Rest().BaseURL.String(); u != "https://GitHub.example.com/api/v3/" {
+		t.Fatalf("Rest().BaseURL == %q, want %q", u, "https://GitHub.example.com/api/v3/")
+	}
+}
+
+func TestNewEnterpriseClient_Invalid(t *testing.T) {
+	for _, u := range []string{"", "github.example.com", "://"} {
+		if _, err := NewEnterpriseClient(u, &http.Client{}); err == nil {
+			t.Fatalf("NewEnterpriseClient(%q) returned no error, want an error", u)
+		}
+	}
+}
@@ -1,6 +1,12 @@
 package githubapi
 
-import "github.com/google/go-github/v44/github"
+import (
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/google/go-github/v44/github"
+)
 
 // ErrorResponseStatusCode will unwrap a github.ErrorResponse and return the
 // status code inside.
@@ -17,3 +23,30 @@ func ErrorResponseStatusCode(err error) int {
 	}
 	return e.Response.StatusCode
 }
+
+// graphQLRateLimitMessage is included in the message of GraphQL errors caused
+// by the rate limit being exceeded.
+const graphQLRateLimitMessage = "API rate limit exceeded"
+
+// RateLimitReset returns true if err was caused by exceeding one of GitHub's
+// rate limits, along with the time the limit resets.
+//
+// The reset time is zero if GitHub did not indicate when the limit resets,
+// such as for errors returned by the GraphQL API.
+func RateLimitReset(err error, now time.Time) (time.Time, bool) {
+	var rateErr *github.RateLimitError
+	if errors.As(err, &rateErr) {
+		return rateErr.Rate.Reset.Time, true
+	}
+	var abuseErr *github.AbuseRateLimitError
+	if errors.As(err, &abuseErr) {
+		if d := abuseErr.GetRetryAfter(); d > 0 {
+			return now.Add(d), true
+		}
+		return time.Time{}, true
+	}
+	if err != nil && strings.Contains(err.Error(), graphQLRateLimitMessage) {
+		return time.Time{}, true
+	}
+	return time.Time{}, false
+}
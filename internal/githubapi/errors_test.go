@@ -0,0 +1,60 @@
+package githubapi
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/google/go-github/v44/github"
+)
+
+func TestRateLimitReset(t *testing.T) {
+	now := time.Date(2022, 5, 4, 10, 0, 0, 0, time.UTC)
+	reset := now.Add(time.Hour)
+	retryAfter := time.Minute
+	tests := []struct {
+		name   string
+		err    error
+		want   time.Time
+		wantOk bool
+	}{
+		{
+			name:   "rate limit",
+			err:    fmt.Errorf("wrapped: %w", &github.RateLimitError{Rate: github.Rate{Reset: github.Timestamp{Time: reset}}}),
+			want:   reset,
+			wantOk: true,
+		},
+		{
+			name:   "secondary rate limit",
+			err:    &github.AbuseRateLimitError{RetryAfter: &retryAfter},
+			want:   now.Add(retryAfter),
+			wantOk: true,
+		},
+		{
+			name:   "secondary rate limit without retry after",
+			err:    &github.AbuseRateLimitError{},
+			wantOk: true,
+		},
+		{
+			name:   "graphql",
+			err:    errors.New("API rate limit exceeded for user ID 1."),
+			wantOk: true,
+		},
+		{
+			name: "other",
+			err:  errors.New("not found"),
+		},
+		{
+			name: "nil",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, ok := RateLimitReset(test.err, now)
+			if ok != test.wantOk || !got.Equal(test.want) {
+				t.Fatalf("RateLimitReset() == %v, %v, want %v, %v", got, ok, test.want, test.wantOk)
+			}
+		})
+	}
+}
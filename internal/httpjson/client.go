@@ -0,0 +1,84 @@
+package httpjson
+
+import (
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"time"
+
+	"github.com/ossf/criticality_score/internal/retry"
+)
+
+const (
+	// maxRetries is the number of times a request that failed with a
+	// transient status is retried.
+	maxRetries = 3
+
+	// responseHeaderTimeout is how long to wait for a server to start
+	// responding to a request.
+	responseHeaderTimeout = time.Minute
+)
+
+// NewRetryClient returns an http.Client for querying third-party APIs.
+//
+// Requests that fail with a 429 or 5xx status are retried up to maxRetries
+// times with an increasing delay, and requests time out if the server does not
+// start responding within responseHeaderTimeout.
+func NewRetryClient() *http.Client {
+	t := http.DefaultTransport.(*http.Transport).Clone()
+	t.ResponseHeaderTimeout = responseHeaderTimeout
+	return &http.Client{
+		Transport: newRetryTransport(t, retryBackoff),
+	}
+}
+
+func newRetryTransport(inner http.RoundTripper, backoff retry.BackoffFn) http.RoundTripper {
+	return retry.NewRoundTripper(inner,
+		retry.MaxRetries(maxRetries),
+		retry.Backoff(backoff),
+		retry.Strategy(retryTransientStatus),
+	)
+}
+
+// retryBackoff waits one second before the second retry, doubling the delay
+// for each retry after it.
+func retryBackoff(d time.Duration) time.Duration {
+	if d <= 0 {
+		return time.Second
+	}
+	return d * 2
+}
+
+// retryTransientStatus implements retry.RetryStrategyFn.
+func retryTransientStatus(r *http.Response) (retry.RetryStrategy, error) {
+	if isTransientStatus(r.StatusCode) {
+		return retry.RetryImmediate, nil
+	}
+	return retry.NoRetry, nil
+}
+
+func isTransientStatus(code int) bool {
+	return code == http.StatusTooManyRequests || (500 <= code && code < 600)
+}
+
+// IsTransient returns true if err is likely to be caused by a temporary
+// problem reaching a service, rather than by the request itself.
+//
+// Errors for a 429 or 5xx status, timeouts, failed connections and responses
+// that were cut short are transient. A cancelled or expired context also
+// appears as a timeout, so callers must check the context themselves.
+func IsTransient(err error) bool {
+	if isTransientStatus(StatusCode(err)) {
+		return true
+	}
+	var netErr net.Error
+	if errors.As(err, &netErr) && netErr.Timeout() {
+		return true
+	}
+	var opErr *net.OpError
+	if errors.As(err, &opErr) {
+		return true
+	}
+	return errors.Is(err, io.ErrUnexpectedEOF)
+}
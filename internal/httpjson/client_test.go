@@ -0,0 +1,78 @@
+package httpjson
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+)
+
+func TestRetryTransport(t *testing.T) {
+	attempts := 0
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.Write([]byte(`{"name": "example"}`))
+	}))
+	t.Cleanup(s.Close)
+	c := &http.Client{Transport: newRetryTransport(http.DefaultTransport, func(time.Duration) time.Duration { return time.Millisecond })}
+
+	var v struct{ Name string }
+	if _, err := Get(context.Background(), c, s.URL, &v); err != nil {
+		t.Fatalf("Get() errored %v, want no error", err)
+	}
+	if attempts != 3 {
+		t.Fatalf("attempts == %d, want 3", attempts)
+	}
+	if v.Name != "example" {
+		t.Fatalf("Get() decoded %+v, want {Name:example}", v)
+	}
+}
+
+func TestRetryTransport_NotFound(t *testing.T) {
+	attempts := 0
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusNotFound)
+	}))
+	t.Cleanup(s.Close)
+	c := &http.Client{Transport: newRetryTransport(http.DefaultTransport, func(time.Duration) time.Duration { return time.Millisecond })}
+
+	if _, err := Get(context.Background(), c, s.URL, nil); StatusCode(err) != http.StatusNotFound {
+		t.Fatalf("Get() errored %v, want status %d", err, http.StatusNotFound)
+	}
+	if attempts != 1 {
+		t.Fatalf("attempts == %d, want 1", attempts)
+	}
+}
+
+func TestIsTransient(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want bool
+	}{
+		{name: "server error", err: &StatusError{StatusCode: http.StatusBadGateway}, want: true},
+		{name: "too many requests", err: fmt.Errorf("wrapped: %w", &StatusError{StatusCode: http.StatusTooManyRequests}), want: true},
+		{name: "not found", err: &StatusError{StatusCode: http.StatusNotFound}, want: false},
+		{name: "connection refused", err: &net.OpError{Op: "dial", Err: errors.New("connection refused")}, want: true},
+		{name: "unexpected eof", err: fmt.Errorf("failed to decode: %w", io.ErrUnexpectedEOF), want: true},
+		{name: "other", err: errors.New("other"), want: false},
+		{name: "nil", err: nil, want: false},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := IsTransient(test.err); got != test.want {
+				t.Fatalf("IsTransient(%v) == %v, want %v", test.err, got, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,72 @@
+// Package httpjson provides helpers for querying HTTP APIs that respond with
+// JSON.
+package httpjson
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+)
+
+// StatusError is returned when a response has a status code that is not
+// in the 2xx range.
+type StatusError struct {
+	URL        string
+	StatusCode int
+}
+
+func (e *StatusError) Error() string {
+	return fmt.Sprintf("request to %s failed with status %d", e.URL, e.StatusCode)
+}
+
+// StatusCode will unwrap a StatusError and return the status code inside.
+//
+// If the error is nil, or not a StatusError it will return a status code of 0.
+func StatusCode(err error) int {
+	var e *StatusError
+	if !errors.As(err, &e) {
+		return 0
+	}
+	return e.StatusCode
+}
+
+// Do sends the request req using the client c and decodes the JSON response
+// body into v.
+//
+// If the response has a non-2xx status code a StatusError is returned. The
+// response is returned so that headers can be inspected, however the body
+// will have already been consumed and closed.
+func Do(c *http.Client, req *http.Request, v any) (*http.Response, error) {
+	resp, err := c.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || 300 <= resp.StatusCode {
+		// Drain the body so the connection can be reused.
+		io.Copy(ioutil.Discard, resp.Body)
+		return resp, &StatusError{URL: req.URL.String(), StatusCode: resp.StatusCode}
+	}
+	if v == nil {
+		io.Copy(ioutil.Discard, resp.Body)
+		return resp, nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+		return resp, fmt.Errorf("failed to decode response from %s: %w", req.URL, err)
+	}
+	return resp, nil
+}
+
+// Get is a convenience wrapper around Do for sending a GET request to u.
+func Get(ctx context.Context, c *http.Client, u string, v any) (*http.Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	return Do(c, req, v)
+}
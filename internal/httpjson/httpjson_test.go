@@ -0,0 +1,88 @@
+package httpjson
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func newTestServer(t *testing.T, status int, body string) *httptest.Server {
+	t.Helper()
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("X-Test", "value")
+		w.WriteHeader(status)
+		w.Write([]byte(body))
+	}))
+	t.Cleanup(s.Close)
+	return s
+}
+
+func TestGet(t *testing.T) {
+	s := newTestServer(t, http.StatusOK, `{"name": "example", "count": 42}`)
+	var v struct {
+		Name  string
+		Count int
+	}
+	resp, err := Get(context.Background(), s.Client(), s.URL, &v)
+	if err != nil {
+		t.Fatalf("Get() errored %v, want no error", err)
+	}
+	if v.Name != "example" || v.Count != 42 {
+		t.Fatalf("Get() decoded %+v, want {Name:example Count:42}", v)
+	}
+	if h := resp.Header.Get("X-Test"); h != "value" {
+		t.Fatalf("Header.Get() == %q, want %q", h, "value")
+	}
+}
+
+func TestGet_NilValue(t *testing.T) {
+	s := newTestServer(t, http.StatusOK, `{"name": "example"}`)
+	if _, err := Get(context.Background(), s.Client(), s.URL, nil); err != nil {
+		t.Fatalf("Get() errored %v, want no error", err)
+	}
+}
+
+func TestGet_StatusError(t *testing.T) {
+	s := newTestServer(t, http.StatusNotFound, `{"message": "not found"}`)
+	var v struct{}
+	_, err := Get(context.Background(), s.Client(), s.URL, &v)
+	if c := StatusCode(err); c != http.StatusNotFound {
+		t.Fatalf("StatusCode() == %d, want %d", c, http.StatusNotFound)
+	}
+}
+
+func TestGet_InvalidJSON(t *testing.T) {
+	s := newTestServer(t, http.StatusOK, `{"name": `)
+	var v struct{ Name string }
+	_, err := Get(context.Background(), s.Client(), s.URL, &v)
+	if err == nil {
+		t.Fatal("Get() returned no error, want an error")
+	}
+	if c := StatusCode(err); c != 0 {
+		t.Fatalf("StatusCode() == %d, want 0", c)
+	}
+}
+
+func TestStatusCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "nil", err: nil, want: 0},
+		{name: "other error", err: errors.New("error"), want: 0},
+		{name: "status error", err: &StatusError{StatusCode: 403}, want: 403},
+		{name: "wrapped", err: fmt.Errorf("wrapped: %w", &StatusError{StatusCode: 500}), want: 500},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if c := StatusCode(test.err); c != test.want {
+				t.Fatalf("StatusCode() == %d, want %d", c, test.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+// Package repomap loads files that map repositories to names in another
+// system, such as a Stack Overflow tag or a Docker Hub image.
+package repomap
+
+import (
+	"encoding/csv"
+	"errors"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+)
+
+// Map holds the names that each repository maps to.
+type Map map[string][]string
+
+// key returns the key used to look up the names for the repository url raw.
+//
+// The scheme, case, "www." and any trailing "/" or ".git" are ignored.
+func key(raw string) string {
+	k := strings.ToLower(strings.TrimSpace(raw))
+	if _, rest, ok := strings.Cut(k, "://"); ok {
+		k = rest
+	}
+	k = strings.TrimPrefix(k, "www.")
+	return strings.TrimSuffix(strings.TrimSuffix(k, "/"), ".git")
+}
+
+// Load reads a Map from r.
+//
+// r must be a CSV file with a header row and two columns: the repository url,
+// and the name it maps to. A repository may appear in more than one row to
+// map it to several names. Rows with an empty name are ignored.
+func Load(r io.Reader) (Map, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = 2
+	if _, err := cr.Read(); errors.Is(err, io.EOF) {
+		return nil, errors.New("mapping file is empty")
+	} else if err != nil {
+		return nil, err
+	}
+	m := make(Map)
+	for {
+		row, err := cr.Read()
+		if errors.Is(err, io.EOF) {
+			return m, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		name := strings.TrimSpace(row[1])
+		if name == "" {
+			continue
+		}
+		k := key(row[0])
+		m[k] = append(m[k], name)
+	}
+}
+
+// Open loads a Map from the file named filename.
+func Open(filename string) (Map, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return Load(f)
+}
+
+// Lookup returns the names the repository at u maps to.
+//
+// If the repository is not in the Map, false is returned.
+func (m Map) Lookup(u *url.URL) ([]string, bool) {
+	names, ok := m[key(u.String())]
+	return names, ok
+}
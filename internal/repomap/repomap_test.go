@@ -0,0 +1,46 @@
+package repomap
+
+import (
+	"net/url"
+	"strings"
+	"testing"
+)
+
+func TestLoad(t *testing.T) {
+	m, err := Load(strings.NewReader(`url,name
+https://github.com/example/json,json
+https://github.com/example/json,example-json
+https://www.GitHub.com/Example/Other.git/,other
+https://github.com/example/empty,
+`))
+	if err != nil {
+		t.Fatalf("Load() errored %v, want no error", err)
+	}
+	tests := []struct {
+		url  string
+		want []string
+	}{
+		{"https://github.com/example/json", []string{"json", "example-json"}},
+		{"http://github.com/example/other", []string{"other"}},
+		{"https://github.com/example/empty", nil},
+	}
+	for _, test := range tests {
+		u, _ := url.Parse(test.url)
+		got, _ := m.Lookup(u)
+		if strings.Join(got, ",") != strings.Join(test.want, ",") {
+			t.Fatalf("Lookup(%q) == %v, want %v", test.url, got, test.want)
+		}
+	}
+}
+
+func TestLoad_Empty(t *testing.T) {
+	if _, err := Load(strings.NewReader("")); err == nil {
+		t.Fatal("Load() returned no error, want an error")
+	}
+}
+
+func TestLoad_WrongColumns(t *testing.T) {
+	if _, err := Load(strings.NewReader("url,name\nhttps://github.com/example/json,json,extra\n")); err == nil {
+		t.Fatal("Load() returned no error, want an error")
+	}
+}
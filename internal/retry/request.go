@@ -1,7 +1,9 @@
 package retry
 
 import (
+	"context"
 	"errors"
+	"io"
 	"net/http"
 	"time"
 )
@@ -56,8 +58,25 @@ type BackoffFn func(time.Duration) time.Duration
 
 // SleepFn must cause the current goroutine to sleep for the allocated Duration.
 //
-// The usual implementation of this is time.Sleep. This is provided for testing.
-type sleepFn func(time.Duration)
+// If the context is done before the Duration has passed, the context's error
+// must be returned.
+//
+// The usual implementation of this is ContextSleep. This is provided for
+// testing.
+type sleepFn func(context.Context, time.Duration) error
+
+// ContextSleep sleeps for the Duration d, or until ctx is done, in which case
+// the context's error is returned.
+func ContextSleep(ctx context.Context, d time.Duration) error {
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-t.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
 
 // DefaultBackoff will double the duration d if it is greater than zero,
 // otherwise it returns 1 minute.
@@ -120,7 +139,7 @@ func MakeOptions(os ...Option) *Options {
 	opts := &Options{
 		maxRetries:   DefaultMaxRetries,
 		initialDelay: DefaultInitialDuration,
-		sleep:        time.Sleep,
+		sleep:        ContextSleep,
 		backoff:      DefaultBackoff,
 	}
 	for _, o := range os {
@@ -185,17 +204,31 @@ func (r *Request) Do() (*http.Response, error) {
 	if r.attempts > 0 {
 		// This is a retry!
 		if r.delay > 0 {
-			// Wait if we have a delay
-			r.o.sleep(r.delay)
+			// Wait if we have a delay, unless the request is cancelled.
+			if err := r.o.sleep(r.r.Context(), r.delay); err != nil {
+				return r.onError(err)
+			}
 		}
 		// Update the delay
 		r.delay = r.o.backoff(r.delay)
 	}
+	req := r.r
+	if r.attempts > 0 && req.GetBody != nil {
+		// The body was consumed by the previous attempt, so send a copy of
+		// the request with a new body.
+		body, err := req.GetBody()
+		if err != nil {
+			return r.onError(err)
+		}
+		req = req.Clone(req.Context())
+		req.Body = body
+	}
+
 	// Bump the number of attempts
 	r.attempts++
 
 	// Issue the request
-	resp, err := r.client(r.r)
+	resp, err := r.client(req)
 	if err != nil {
 		// Return the response and the error if the client returned an error
 		// TODO: pass err to the strategy funcs.
@@ -259,6 +292,12 @@ func (rt *roundTripper) RoundTrip(r *http.Request) (*http.Response, error) {
 	var err error
 	rr := NewRequest(r, rt.inner.RoundTrip, rt.o)
 	for !rr.Done() {
+		if resp != nil {
+			// The request is being retried, so discard the previous response
+			// to allow the connection to be reused.
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		}
 		resp, err = rr.Do()
 	}
 	return resp, err
@@ -1,7 +1,10 @@
 package retry
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"io"
 	"io/ioutil"
 	"net/http"
 	"strings"
@@ -103,8 +106,9 @@ func TestRetryAfterDuration(t *testing.T) {
 	opts := MakeOptions(RetryAfter(func(_ *http.Response) time.Duration {
 		return time.Minute
 	}))
-	opts.sleep = func(d time.Duration) {
+	opts.sleep = func(_ context.Context, d time.Duration) error {
 		slept += d
+		return nil
 	}
 	req := NewRequest(&http.Request{}, func(r *http.Request) (*http.Response, error) {
 		return &http.Response{
@@ -141,3 +145,103 @@ func TestZeroMaxRetriesOnlyTriesOnce(t *testing.T) {
 		t.Fatalf("Done() == false; want true")
 	}
 }
+
+func TestRetryDelayCancelled(t *testing.T) {
+	opts := MakeOptions(RetryAfter(func(_ *http.Response) time.Duration {
+		return time.Hour
+	}))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	r, _ := http.NewRequestWithContext(ctx, http.MethodGet, "https://example.com", nil)
+	req := NewRequest(r, func(r *http.Request) (*http.Response, error) {
+		return &http.Response{
+			StatusCode: http.StatusBadRequest,
+			Body:       ioutil.NopCloser(strings.NewReader("")),
+		}, nil
+	}, opts)
+	req.Do()
+	if req.Done() {
+		t.Fatalf("Done() == true; want false")
+	}
+	if _, err := req.Do(); !errors.Is(err, context.Canceled) {
+		t.Fatalf("Do() errored %v; want %v", err, context.Canceled)
+	}
+	if !req.Done() {
+		t.Fatalf("Done() == false; want true")
+	}
+}
+
+func TestRetryResendsBody(t *testing.T) {
+	r, _ := http.NewRequest(http.MethodPost, "https://example.com", strings.NewReader("body"))
+	var bodies []string
+	req := NewRequest(r, func(r *http.Request) (*http.Response, error) {
+		b, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Fatalf("ReadAll() errored %v; want no error", err)
+		}
+		bodies = append(bodies, string(b))
+		return &http.Response{
+			StatusCode: http.StatusInternalServerError,
+			Body:       ioutil.NopCloser(strings.NewReader("")),
+		}, nil
+	}, MakeOptions(MaxRetries(1), Strategy(func(_ *http.Response) (RetryStrategy, error) {
+		return RetryImmediate, nil
+	})))
+	for !req.Done() {
+		req.Do()
+	}
+	if len(bodies) != 2 {
+		t.Fatalf("len(attempts) == %d; want 2", len(bodies))
+	}
+	for i, b := range bodies {
+		if b != "body" {
+			t.Fatalf("attempt %d sent body %q; want %q", i, b, "body")
+		}
+	}
+}
+
+// closeTracker records whether a response body has been closed.
+type closeTracker struct {
+	io.Reader
+	closed bool
+}
+
+func (c *closeTracker) Close() error {
+	c.closed = true
+	return nil
+}
+
+type roundTripperFunc func(*http.Request) (*http.Response, error)
+
+func (f roundTripperFunc) RoundTrip(r *http.Request) (*http.Response, error) {
+	return f(r)
+}
+
+func TestRoundTripperClosesRetriedResponses(t *testing.T) {
+	var bodies []*closeTracker
+	rt := NewRoundTripper(roundTripperFunc(func(r *http.Request) (*http.Response, error) {
+		b := &closeTracker{Reader: strings.NewReader("")}
+		bodies = append(bodies, b)
+		status := http.StatusInternalServerError
+		if len(bodies) == 2 {
+			status = http.StatusOK
+		}
+		return &http.Response{StatusCode: status, Body: b}, nil
+	}), Strategy(func(_ *http.Response) (RetryStrategy, error) {
+		return RetryImmediate, nil
+	}))
+	r, _ := http.NewRequest(http.MethodGet, "https://example.com", nil)
+	resp, err := rt.RoundTrip(r)
+	if err != nil {
+		t.Fatalf("RoundTrip() errored %v; want no error", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		t.Fatalf("RoundTrip() returned status %d; want %d", resp.StatusCode, http.StatusOK)
+	}
+	if !bodies[0].closed {
+		t.Fatalf("retried response was not closed")
+	}
+	if bodies[1].closed {
+		t.Fatalf("returned response was closed")
+	}
+}